@@ -1,19 +1,32 @@
 package middleware
 
 import (
-	"fmt"
+	"context"
 	"net/http"
 
 	pb "github.com/barathsurya2004/expenses/proto"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
+type contextKey string
+
+const userIDKey contextKey = "userId"
+
+// UserIDFromContext returns the id of the user authenticated by
+// AuthorizationMiddleware for the current request.
+func UserIDFromContext(ctx context.Context) string {
+	userId, _ := ctx.Value(userIDKey).(string)
+	return userId
+}
+
 func CorsMiddleWare(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Next-Page-Token, X-Total-Count")
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)
 			return
@@ -34,8 +47,6 @@ func AuthorizationMiddleware(conn *grpc.ClientConn) func(http.Handler) http.Hand
 				return
 			}
 
-			// Check the token in the database
-			fmt.Println("Checking token in the database...") // Placeholder for actual token validation logic
 			ctx := r.Context()
 			res, err := pClient.CheckAuthToken(ctx, &pb.CheckAuthTokenRequest{
 				AuthToken: token,
@@ -45,13 +56,21 @@ func AuthorizationMiddleware(conn *grpc.ClientConn) func(http.Handler) http.Hand
 				return
 			}
 
-			fmt.Println("token:", token)
 			if !res.GetIsValid() {
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			// Read-only API keys may only fetch data.
+			if res.GetScope() != "read_write" && r.Method != http.MethodGet {
+				http.Error(w, "Forbidden: read-only credential", http.StatusForbidden)
+				return
+			}
+
+			// Forward the credential so the gRPC services can authorize the call.
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+			ctx = context.WithValue(ctx, userIDKey, res.GetUserId())
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
@@ -0,0 +1,102 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) ExportMyData(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	stream, err := pClient.ExportMyData(ctx, &pb.ExportMyDataRequest{})
+	if err != nil {
+		log.Printf("Error creating gRPC stream: %v", err)
+		http.Error(w, "Failed to export data", http.StatusInternalServerError)
+		return
+	}
+
+	// Wait for the first chunk so errors can still be reported with a status code.
+	first, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		log.Printf("Error receiving export: %v", err)
+		http.Error(w, "Failed to export data", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("expenses-export-%s.zip", time.Now().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	if first == nil {
+		return
+	}
+	if _, err := w.Write(first.GetChunks()); err != nil {
+		log.Printf("Error writing export: %v", err)
+		return
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("Error receiving export: %v", err)
+			return
+		}
+		if _, err := w.Write(chunk.GetChunks()); err != nil {
+			log.Printf("Error writing export: %v", err)
+			return
+		}
+	}
+	log.Printf("Data export sent successfully")
+}
+
+func (s *Server) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.DeleteAccount(ctx, &pb.DeleteAccountRequest{
+		Password: req.Password,
+		Provider: req.Provider,
+		IdToken:  req.IDToken,
+		Nonce:    req.Nonce,
+	})
+	if err != nil {
+		log.Printf("Error deleting account: %v", err)
+		http.Error(w, "Failed to delete account", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) CancelAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.CancelAccountDeletion(ctx, &pb.CancelAccountDeletionRequest{})
+	if err != nil {
+		log.Printf("Error cancelling account deletion: %v", err)
+		http.Error(w, "Failed to cancel account deletion", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,151 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) AdminListUsers(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewAdminServiceClient(s.Conn)
+	ctx := r.Context()
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	res, err := pClient.ListUsers(ctx, &pb.ListUsersRequest{
+		Query:  r.URL.Query().Get("query"),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		log.Printf("Error listing users: %v", err)
+		writeGRPCError(w, err, "Failed to list users")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) AdminGetUsageStats(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewAdminServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.GetUsageStats(ctx, &pb.GetUsageStatsRequest{})
+	if err != nil {
+		log.Printf("Error getting usage stats: %v", err)
+		writeGRPCError(w, err, "Failed to get usage stats")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) AdminDisableUser(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeAdminUserRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewAdminServiceClient(s.Conn).DisableUser(r.Context(), &pb.DisableUserRequest{
+		UserId: req.UserID,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		log.Printf("Error disabling user: %v", err)
+		writeGRPCError(w, err, "Failed to disable user")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) AdminEnableUser(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeAdminUserRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewAdminServiceClient(s.Conn).EnableUser(r.Context(), &pb.EnableUserRequest{
+		UserId: req.UserID,
+	})
+	if err != nil {
+		log.Printf("Error enabling user: %v", err)
+		writeGRPCError(w, err, "Failed to enable user")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) AdminSetUserRole(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeAdminUserRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewAdminServiceClient(s.Conn).SetUserRole(r.Context(), &pb.SetUserRoleRequest{
+		UserId: req.UserID,
+		Role:   req.Role,
+	})
+	if err != nil {
+		log.Printf("Error setting user role: %v", err)
+		writeGRPCError(w, err, "Failed to set user role")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) AdminImpersonate(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeAdminUserRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewAdminServiceClient(s.Conn).ImpersonateForSupport(r.Context(), &pb.ImpersonateForSupportRequest{
+		UserId: req.UserID,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		log.Printf("Error impersonating user: %v", err)
+		writeGRPCError(w, err, "Failed to impersonate user")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) AdminRevokeUserTokens(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeAdminUserRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewAdminServiceClient(s.Conn).RevokeUserTokens(r.Context(), &pb.RevokeUserTokensRequest{
+		UserId:         req.UserID,
+		IncludeApiKeys: req.IncludeApiKeys,
+	})
+	if err != nil {
+		log.Printf("Error revoking user tokens: %v", err)
+		writeGRPCError(w, err, "Failed to revoke user tokens")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func decodeAdminUserRequest(w http.ResponseWriter, r *http.Request) (models.AdminUserRequest, bool) {
+	var req models.AdminUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
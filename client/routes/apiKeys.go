@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) CreateApiKey(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.CreateApiKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.CreateApiKey(ctx, &pb.CreateApiKeyRequest{
+		Name:  req.Name,
+		Scope: req.Scope,
+	})
+	if err != nil {
+		log.Printf("Error creating API key: %v", err)
+		writeGRPCError(w, err, "Failed to create API key")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListApiKeys(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.ListApiKeys(ctx, &pb.ListApiKeysRequest{})
+	if err != nil {
+		log.Printf("Error listing API keys: %v", err)
+		writeGRPCError(w, err, "Failed to list API keys")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res.GetApiKeys())
+}
+
+func (s *Server) RevokeApiKey(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.RevokeApiKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.RevokeApiKey(ctx, &pb.RevokeApiKeyRequest{
+		Id: req.ID,
+	})
+	if err != nil {
+		log.Printf("Error revoking API key: %v", err)
+		writeGRPCError(w, err, "Failed to revoke API key")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,124 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func categoryFromRequest(req models.Category) *pb.Category {
+	return &pb.Category{
+		Id:       req.ID,
+		Name:     req.Name,
+		ParentId: req.ParentID,
+		Icon:     req.Icon,
+		Colour:   req.Colour,
+		Synonyms: req.Synonyms,
+	}
+}
+
+func (s *Server) ListCategories(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.ListCategories(ctx, &pb.ListCategoriesRequest{})
+	if err != nil {
+		log.Printf("Error listing categories: %v", err)
+		writeGRPCError(w, err, "Failed to list categories")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.Category
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.CreateCategory(ctx, &pb.CreateCategoryRequest{Category: categoryFromRequest(req)})
+	if err != nil {
+		log.Printf("Error creating category: %v", err)
+		writeGRPCError(w, err, "Failed to create category")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.Category
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.UpdateCategory(ctx, &pb.UpdateCategoryRequest{Category: categoryFromRequest(req)})
+	if err != nil {
+		log.Printf("Error updating category: %v", err)
+		writeGRPCError(w, err, "Failed to update category")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.Category
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.DeleteCategory(ctx, &pb.DeleteCategoryRequest{Id: req.ID})
+	if err != nil {
+		log.Printf("Error deleting category: %v", err)
+		writeGRPCError(w, err, "Failed to delete category")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) PredictCategory(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	amount, _ := strconv.ParseFloat(r.URL.Query().Get("amount"), 64)
+	res, err := pClient.PredictCategory(ctx, &pb.PredictCategoryRequest{
+		Place:         r.URL.Query().Get("place"),
+		Amount:        amount,
+		ModeOfPayment: r.URL.Query().Get("mode_of_payment"),
+		DateAndTime:   r.URL.Query().Get("date_and_time"),
+	})
+	if err != nil {
+		log.Printf("Error predicting category: %v", err)
+		writeGRPCError(w, err, "Failed to predict category")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,307 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) CreateExpenseReport(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ExpenseReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.CreateExpenseReport(ctx, &pb.CreateExpenseReportRequest{
+		Title:      req.Title,
+		Purpose:    req.Purpose,
+		ExpenseIds: req.ExpenseIDs,
+	})
+	if err != nil {
+		log.Printf("Error creating expense report: %v", err)
+		writeGRPCError(w, err, "Failed to create expense report")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) UpdateExpenseReport(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ExpenseReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.UpdateExpenseReport(ctx, &pb.UpdateExpenseReportRequest{
+		ReportId:         req.ReportID,
+		Title:            req.Title,
+		Purpose:          req.Purpose,
+		AddExpenseIds:    req.AddExpenseIDs,
+		RemoveExpenseIds: req.RemoveExpenseIDs,
+	})
+	if err != nil {
+		log.Printf("Error updating expense report: %v", err)
+		writeGRPCError(w, err, "Failed to update expense report")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) DeleteExpenseReport(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ExpenseReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.DeleteExpenseReport(ctx, &pb.DeleteExpenseReportRequest{ReportId: req.ReportID})
+	if err != nil {
+		log.Printf("Error deleting expense report: %v", err)
+		writeGRPCError(w, err, "Failed to delete expense report")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListExpenseReports(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	page := readPageParams(r)
+	res, err := pClient.ListExpenseReports(ctx, &pb.ListExpenseReportsRequest{
+		Role:              r.URL.Query().Get("role"),
+		Status:            r.URL.Query().Get("status"),
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
+	})
+	if err != nil {
+		log.Printf("Error listing expense reports: %v", err)
+		writeGRPCError(w, err, "Failed to list expense reports")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) GetExpenseReport(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.GetExpenseReport(ctx, &pb.GetExpenseReportRequest{ReportId: r.URL.Query().Get("report_id")})
+	if err != nil {
+		log.Printf("Error getting expense report: %v", err)
+		writeGRPCError(w, err, "Failed to get expense report")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) SubmitExpenseReport(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ExpenseReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.SubmitExpenseReport(ctx, &pb.SubmitExpenseReportRequest{
+		ReportId:         req.ReportID,
+		ApproverUsername: req.ApproverUsername,
+		ApproverEmail:    req.ApproverEmail,
+	})
+	if err != nil {
+		log.Printf("Error submitting expense report: %v", err)
+		writeGRPCError(w, err, "Failed to submit expense report")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ReviewExpenseReport(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ReviewExpenseReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	decisions := make([]*pb.LineDecision, len(req.Decisions))
+	for i, decision := range req.Decisions {
+		decisions[i] = &pb.LineDecision{
+			LineId:   decision.LineID,
+			Decision: decision.Decision,
+			Comment:  decision.Comment,
+		}
+	}
+	res, err := pClient.ReviewExpenseReport(ctx, &pb.ReviewExpenseReportRequest{
+		ReportId:  req.ReportID,
+		Decisions: decisions,
+		Decision:  req.Decision,
+		Comment:   req.Comment,
+	})
+	if err != nil {
+		log.Printf("Error reviewing expense report: %v", err)
+		writeGRPCError(w, err, "Failed to review expense report")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) CommentOnExpenseReport(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ExpenseReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.CommentOnExpenseReport(ctx, &pb.CommentOnExpenseReportRequest{
+		ReportId: req.ReportID,
+		LineId:   req.LineID,
+		Body:     req.Body,
+	})
+	if err != nil {
+		log.Printf("Error commenting on expense report: %v", err)
+		writeGRPCError(w, err, "Failed to comment on expense report")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) MarkReportReimbursed(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ExpenseReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.MarkReportReimbursed(ctx, &pb.MarkReportReimbursedRequest{
+		ReportId:     req.ReportID,
+		Reference:    req.Reference,
+		ReimbursedAt: req.ReimbursedAt,
+	})
+	if err != nil {
+		log.Printf("Error marking expense report reimbursed: %v", err)
+		writeGRPCError(w, err, "Failed to mark expense report reimbursed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) GetReportReceipt(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	stream, err := pClient.GetReportReceipt(ctx, &pb.GetReportReceiptRequest{
+		ReportId:  r.URL.Query().Get("report_id"),
+		ReceiptId: r.URL.Query().Get("receipt_id"),
+	})
+	if err != nil {
+		log.Printf("Error creating gRPC stream: %v", err)
+		http.Error(w, "Failed to get receipt", http.StatusInternalServerError)
+		return
+	}
+	writeReportFile(w, stream, "Failed to get receipt")
+}
+
+func (s *Server) ExportExpenseReport(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpenseReportsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	stream, err := pClient.ExportExpenseReport(ctx, &pb.ExportExpenseReportRequest{
+		ReportId: r.URL.Query().Get("report_id"),
+		Format:   r.URL.Query().Get("format"),
+	})
+	if err != nil {
+		log.Printf("Error creating gRPC stream: %v", err)
+		http.Error(w, "Failed to export expense report", http.StatusInternalServerError)
+		return
+	}
+	writeReportFile(w, stream, "Failed to export expense report")
+}
+
+// writeReportFile copies a streamed file to the response. The first message
+// names the file, and any error with the request arrives with it while a
+// status code can still be sent.
+func writeReportFile(w http.ResponseWriter, stream interface {
+	Recv() (*pb.ReportFile, error)
+}, fallback string) {
+	first, err := stream.Recv()
+	if err != nil {
+		log.Printf("Error receiving file: %v", err)
+		writeGRPCError(w, err, fallback)
+		return
+	}
+
+	w.Header().Set("Content-Type", first.GetContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, first.GetFilename()))
+	if _, err := w.Write(first.GetChunks()); err != nil {
+		log.Printf("Error writing file: %v", err)
+		return
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("Error receiving file: %v", err)
+			return
+		}
+		if _, err := w.Write(chunk.GetChunks()); err != nil {
+			log.Printf("Error writing file: %v", err)
+			return
+		}
+	}
+}
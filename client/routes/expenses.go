@@ -0,0 +1,400 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) ListExpenses(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	// Custom fields are filtered with custom_field=<name or id>=<value>;
+	// an empty value matches any.
+	var customFields []*pb.CustomFieldValue
+	for _, filter := range r.URL.Query()["custom_field"] {
+		field, value, _ := strings.Cut(filter, "=")
+		customFields = append(customFields, customFieldValue(models.CustomFieldValue{Name: field, Value: value}))
+	}
+	page := readPageParams(r)
+	res, err := pClient.ListExpenses(ctx, &pb.ListExpensesRequest{
+		GroupId:           r.URL.Query().Get("group_id"),
+		TripId:            r.URL.Query().Get("trip_id"),
+		Tags:              r.URL.Query()["tag"],
+		CustomFields:      customFields,
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
+	})
+	if err != nil {
+		log.Printf("Error listing expenses: %v", err)
+		writeGRPCError(w, err, "Failed to list expenses")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) SearchExpenses(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	page := readPageParams(r)
+	res, err := pClient.SearchExpenses(ctx, &pb.SearchExpensesRequest{
+		Query:     r.URL.Query().Get("q"),
+		GroupId:   r.URL.Query().Get("group_id"),
+		PageSize:  page.size,
+		PageToken: page.token,
+		OrderBy:   page.orderBy,
+	})
+	if err != nil {
+		log.Printf("Error searching expenses: %v", err)
+		writeGRPCError(w, err, "Failed to search expenses")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ExportExpenses(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	stream, err := pClient.ExportExpenses(ctx, &pb.ExportExpensesRequest{
+		Format:     query.Get("format"),
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+		CategoryId: query.Get("category_id"),
+		MerchantId: query.Get("merchant_id"),
+		GroupId:    query.Get("group_id"),
+	})
+	if err != nil {
+		log.Printf("Error creating gRPC stream: %v", err)
+		http.Error(w, "Failed to export expenses", http.StatusInternalServerError)
+		return
+	}
+
+	// The first message names the file, and any error with the request
+	// arrives with it while a status code can still be sent.
+	first, err := stream.Recv()
+	if err != nil {
+		log.Printf("Error receiving export: %v", err)
+		writeGRPCError(w, err, "Failed to export expenses")
+		return
+	}
+
+	w.Header().Set("Content-Type", first.GetContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, first.GetFilename()))
+	if _, err := w.Write(first.GetChunks()); err != nil {
+		log.Printf("Error writing export: %v", err)
+		return
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("Error receiving export: %v", err)
+			return
+		}
+		if _, err := w.Write(chunk.GetChunks()); err != nil {
+			log.Printf("Error writing export: %v", err)
+			return
+		}
+	}
+	log.Printf("Expense export sent successfully")
+}
+
+func (s *Server) SetExpenseGroup(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.SetExpenseGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.SetExpenseGroup(ctx, &pb.SetExpenseGroupRequest{
+		ExpenseId: req.ExpenseID,
+		GroupId:   req.GroupID,
+	})
+	if err != nil {
+		log.Printf("Error setting expense group: %v", err)
+		writeGRPCError(w, err, "Failed to set expense group")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) AddExpense(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.AddExpenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.AddExpense(ctx, &pb.AddExpenseRequest{
+		DateAndTime:   req.DateAndTime,
+		Place:         req.Place,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Category:      req.Category,
+		ModeOfPayment: req.ModeOfPayment,
+		GroupId:       req.GroupID,
+		Tags:          req.Tags,
+		Notes:         req.Notes,
+		CustomFields:  customFieldValues(req.CustomFields),
+	})
+	if err != nil {
+		log.Printf("Error adding expense: %v", err)
+		writeGRPCError(w, err, "Failed to add expense")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) UpdateExpense(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.UpdateExpenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.UpdateExpense(ctx, &pb.UpdateExpenseRequest{
+		ExpenseId:     req.ExpenseID,
+		DateAndTime:   req.DateAndTime,
+		Place:         req.Place,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Category:      req.Category,
+		ModeOfPayment: req.ModeOfPayment,
+		Notes:         req.Notes,
+		AddTags:       req.AddTags,
+		RemoveTags:    req.RemoveTags,
+		CustomFields:  customFieldValues(req.CustomFields),
+	})
+	if err != nil {
+		log.Printf("Error updating expense: %v", err)
+		writeGRPCError(w, err, "Failed to update expense")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListTags(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	page := readPageParams(r)
+	res, err := pClient.ListTags(ctx, &pb.ListTagsRequest{
+		GroupId:           r.URL.Query().Get("group_id"),
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
+	})
+	if err != nil {
+		log.Printf("Error listing tags: %v", err)
+		writeGRPCError(w, err, "Failed to list tags")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) CreateCustomField(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.CustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.CreateCustomField(ctx, &pb.CreateCustomFieldRequest{
+		Name:    req.Name,
+		Type:    req.Type,
+		Options: req.Options,
+	})
+	if err != nil {
+		log.Printf("Error creating custom field: %v", err)
+		writeGRPCError(w, err, "Failed to create custom field")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) UpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.CustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.UpdateCustomField(ctx, &pb.UpdateCustomFieldRequest{
+		Id:      req.ID,
+		Name:    req.Name,
+		Options: req.Options,
+	})
+	if err != nil {
+		log.Printf("Error updating custom field: %v", err)
+		writeGRPCError(w, err, "Failed to update custom field")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) DeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.CustomFieldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.DeleteCustomField(ctx, &pb.DeleteCustomFieldRequest{Id: req.ID})
+	if err != nil {
+		log.Printf("Error deleting custom field: %v", err)
+		writeGRPCError(w, err, "Failed to delete custom field")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListCustomFields(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	page := readPageParams(r)
+	res, err := pClient.ListCustomFields(ctx, &pb.ListCustomFieldsRequest{
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
+	})
+	if err != nil {
+		log.Printf("Error listing custom fields: %v", err)
+		writeGRPCError(w, err, "Failed to list custom fields")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) CompareSpending(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewExpensesServiceClient(s.Conn)
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	res, err := pClient.CompareSpending(ctx, &pb.CompareSpendingRequest{
+		CurrentFrom:  query.Get("current_from"),
+		CurrentTo:    query.Get("current_to"),
+		PreviousFrom: query.Get("previous_from"),
+		PreviousTo:   query.Get("previous_to"),
+		Period:       query.Get("period"),
+		GroupId:      query.Get("group_id"),
+		Currency:     query.Get("currency"),
+		ByParent:     query.Get("by_parent") == "true",
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		log.Printf("Error comparing spending: %v", err)
+		writeGRPCError(w, err, "Failed to compare spending")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// pageParams are the pagination query parameters shared by list endpoints.
+type pageParams struct {
+	size         int32
+	token        string
+	orderBy      string
+	includeTotal bool
+}
+
+// readPageParams reads page_size, page_token, order_by and
+// include_total_count. limit is still accepted for page_size.
+func readPageParams(r *http.Request) pageParams {
+	query := r.URL.Query()
+	size, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil {
+		size, _ = strconv.Atoi(query.Get("limit"))
+	}
+	return pageParams{
+		size:         int32(size),
+		token:        query.Get("page_token"),
+		orderBy:      query.Get("order_by"),
+		includeTotal: query.Get("include_total_count") == "true",
+	}
+}
+
+// customFieldValue treats a name that parses as a UUID as the field's id.
+func customFieldValue(value models.CustomFieldValue) *pb.CustomFieldValue {
+	if value.FieldID == "" {
+		if _, err := uuid.Parse(value.Name); err == nil {
+			value.FieldID, value.Name = value.Name, ""
+		}
+	}
+	return &pb.CustomFieldValue{FieldId: value.FieldID, Name: value.Name, Value: value.Value}
+}
+
+func customFieldValues(values []models.CustomFieldValue) []*pb.CustomFieldValue {
+	converted := make([]*pb.CustomFieldValue, len(values))
+	for i, value := range values {
+		converted[i] = customFieldValue(value)
+	}
+	return converted
+}
@@ -0,0 +1,169 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeGroupRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewGroupsServiceClient(s.Conn).CreateGroup(r.Context(), &pb.CreateGroupRequest{
+		Name: req.Name,
+	})
+	if err != nil {
+		log.Printf("Error creating group: %v", err)
+		writeGRPCError(w, err, "Failed to create group")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListGroups(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewGroupsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	page := readPageParams(r)
+	res, err := pClient.ListGroups(ctx, &pb.ListGroupsRequest{
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
+	})
+	if err != nil {
+		log.Printf("Error listing groups: %v", err)
+		writeGRPCError(w, err, "Failed to list groups")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) GetGroup(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewGroupsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.GetGroup(ctx, &pb.GetGroupRequest{
+		GroupId: r.URL.Query().Get("group_id"),
+	})
+	if err != nil {
+		log.Printf("Error getting group: %v", err)
+		writeGRPCError(w, err, "Failed to get group")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) InviteToGroup(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeGroupRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewGroupsServiceClient(s.Conn).InviteToGroup(r.Context(), &pb.InviteToGroupRequest{
+		GroupId:  req.GroupID,
+		Username: req.Username,
+		Email:    req.Email,
+		Role:     req.Role,
+	})
+	if err != nil {
+		log.Printf("Error inviting to group: %v", err)
+		writeGRPCError(w, err, "Failed to invite to group")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListGroupInvitations(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewGroupsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.ListInvitations(ctx, &pb.ListInvitationsRequest{})
+	if err != nil {
+		log.Printf("Error listing group invitations: %v", err)
+		writeGRPCError(w, err, "Failed to list group invitations")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) AcceptGroupInvitation(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeGroupRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewGroupsServiceClient(s.Conn).AcceptInvitation(r.Context(), &pb.AcceptInvitationRequest{
+		InvitationId: req.InvitationID,
+	})
+	if err != nil {
+		log.Printf("Error accepting group invitation: %v", err)
+		writeGRPCError(w, err, "Failed to accept group invitation")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) SetGroupMemberRole(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeGroupRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewGroupsServiceClient(s.Conn).SetMemberRole(r.Context(), &pb.SetMemberRoleRequest{
+		GroupId: req.GroupID,
+		UserId:  req.UserID,
+		Role:    req.Role,
+	})
+	if err != nil {
+		log.Printf("Error setting group member role: %v", err)
+		writeGRPCError(w, err, "Failed to set group member role")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) RemoveGroupMember(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeGroupRequest(w, r)
+	if !ok {
+		return
+	}
+	res, err := pb.NewGroupsServiceClient(s.Conn).RemoveMember(r.Context(), &pb.RemoveMemberRequest{
+		GroupId: req.GroupID,
+		UserId:  req.UserID,
+	})
+	if err != nil {
+		log.Printf("Error removing group member: %v", err)
+		writeGRPCError(w, err, "Failed to remove group member")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func decodeGroupRequest(w http.ResponseWriter, r *http.Request) (models.GroupRequest, bool) {
+	var req models.GroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
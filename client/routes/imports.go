@@ -0,0 +1,183 @@
+package routes
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func csvMappingProto(mapping models.CsvMapping) *pb.CsvMapping {
+	return &pb.CsvMapping{
+		Delimiter:         mapping.Delimiter,
+		SkipRows:          mapping.SkipRows,
+		NoHeader:          mapping.NoHeader,
+		DateColumn:        mapping.DateColumn,
+		DateFormat:        mapping.DateFormat,
+		DescriptionColumn: mapping.DescriptionColumn,
+		AmountColumn:      mapping.AmountColumn,
+		DebitColumn:       mapping.DebitColumn,
+		CreditColumn:      mapping.CreditColumn,
+		CurrencyColumn:    mapping.CurrencyColumn,
+		ReferenceColumn:   mapping.ReferenceColumn,
+		DebitsPositive:    mapping.DebitsPositive,
+		DecimalComma:      mapping.DecimalComma,
+	}
+}
+
+// ImportStatement streams an uploaded statement to the import service. The
+// options are form fields next to the file; mapping is a JSON object.
+func (s *Server) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 11<<20)
+
+	if err := r.ParseMultipartForm(11 << 20); err != nil {
+		log.Printf("Error parsing multipart form: %v", err)
+		http.Error(w, "Invalid multipart form", http.StatusBadRequest)
+		return
+	}
+	file, handler, err := r.FormFile("file")
+	if err != nil {
+		log.Printf("Error retrieving file: %v", err)
+		http.Error(w, "Failed to retrieve file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	options := &pb.ImportStatementRequest{
+		Format:                    r.FormValue("format"),
+		Filename:                  handler.Filename,
+		ProfileId:                 r.FormValue("profile_id"),
+		Currency:                  r.FormValue("currency"),
+		GroupId:                   r.FormValue("group_id"),
+		DateOrder:                 r.FormValue("date_order"),
+		DryRun:                    formBool(r, "dry_run"),
+		SkipCredits:               formBool(r, "skip_credits"),
+		IncludePossibleDuplicates: formBool(r, "include_possible_duplicates"),
+	}
+	if value := r.FormValue("mapping"); value != "" {
+		var mapping models.CsvMapping
+		if err := json.Unmarshal([]byte(value), &mapping); err != nil {
+			http.Error(w, "Invalid mapping", http.StatusBadRequest)
+			return
+		}
+		options.Mapping = csvMappingProto(mapping)
+	}
+
+	pClient := pb.NewImportServiceClient(s.Conn)
+	ctx := r.Context()
+	stream, err := pClient.ImportStatement(ctx)
+	if err != nil {
+		log.Printf("Error creating gRPC stream: %v", err)
+		http.Error(w, "Failed to create gRPC stream", http.StatusInternalServerError)
+		return
+	}
+
+	buffer := make([]byte, 64*1024)
+	for {
+		n, err := file.Read(buffer)
+		if n > 0 {
+			options.Chunks = buffer[:n]
+			if err := stream.Send(options); err != nil {
+				log.Printf("Error sending chunk: %v", err)
+				http.Error(w, "Failed to send file chunk", http.StatusInternalServerError)
+				return
+			}
+			options = &pb.ImportStatementRequest{}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("Error reading file: %v", err)
+			http.Error(w, "Failed to read file", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Printf("Error importing statement: %v", err)
+		writeGRPCError(w, err, "Failed to import statement")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func formBool(r *http.Request, key string) bool {
+	value, _ := strconv.ParseBool(r.FormValue(key))
+	return value
+}
+
+func (s *Server) SaveImportProfile(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewImportServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ImportProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.SaveImportProfile(ctx, &pb.SaveImportProfileRequest{
+		Name:    req.Name,
+		Mapping: csvMappingProto(req.Mapping),
+	})
+	if err != nil {
+		log.Printf("Error saving import profile: %v", err)
+		writeGRPCError(w, err, "Failed to save import profile")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListImportProfiles(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewImportServiceClient(s.Conn)
+	ctx := r.Context()
+
+	page := readPageParams(r)
+	res, err := pClient.ListImportProfiles(ctx, &pb.ListImportProfilesRequest{
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
+	})
+	if err != nil {
+		log.Printf("Error listing import profiles: %v", err)
+		writeGRPCError(w, err, "Failed to list import profiles")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) DeleteImportProfile(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewImportServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ImportProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.DeleteImportProfile(ctx, &pb.DeleteImportProfileRequest{Id: req.ID})
+	if err != nil {
+		log.Printf("Error deleting import profile: %v", err)
+		writeGRPCError(w, err, "Failed to delete import profile")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,154 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) SetIngestionConsent(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewIngestionServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.IngestionConsentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.SetIngestionConsent(ctx, &pb.SetIngestionConsentRequest{
+		Channel: req.Channel,
+		Granted: req.Granted,
+	})
+	if err != nil {
+		log.Printf("Error setting ingestion consent: %v", err)
+		writeGRPCError(w, err, "Failed to set ingestion consent")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListIngestionConsents(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewIngestionServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.ListIngestionConsents(ctx, &pb.ListIngestionConsentsRequest{})
+	if err != nil {
+		log.Printf("Error listing ingestion consents: %v", err)
+		writeGRPCError(w, err, "Failed to list ingestion consents")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) IngestMessages(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewIngestionServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.IngestMessagesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	messages := make([]*pb.SmsMessage, 0, len(req.Messages))
+	for _, message := range req.Messages {
+		messages = append(messages, &pb.SmsMessage{
+			Sender:     message.Sender,
+			Body:       message.Body,
+			ReceivedAt: message.ReceivedAt,
+		})
+	}
+	res, err := pClient.IngestMessages(ctx, &pb.IngestMessagesRequest{Messages: messages})
+	if err != nil {
+		log.Printf("Error ingesting messages: %v", err)
+		writeGRPCError(w, err, "Failed to ingest messages")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ConnectMailbox(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewIngestionServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ConnectMailboxRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.ConnectMailbox(ctx, &pb.ConnectMailboxRequest{
+		Host:     req.Host,
+		Port:     req.Port,
+		Username: req.Username,
+		Password: req.Password,
+		Mailbox:  req.Mailbox,
+		Insecure: req.Insecure,
+	})
+	if err != nil {
+		log.Printf("Error connecting mailbox: %v", err)
+		writeGRPCError(w, err, "Failed to connect mailbox")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) DisconnectMailbox(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewIngestionServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.DisconnectMailbox(ctx, &pb.DisconnectMailboxRequest{})
+	if err != nil {
+		log.Printf("Error disconnecting mailbox: %v", err)
+		writeGRPCError(w, err, "Failed to disconnect mailbox")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) GetEmailIngestion(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewIngestionServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.GetEmailIngestion(ctx, &pb.GetEmailIngestionRequest{})
+	if err != nil {
+		log.Printf("Error getting email ingestion: %v", err)
+		writeGRPCError(w, err, "Failed to get email ingestion")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) PollMailbox(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewIngestionServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.PollMailbox(ctx, &pb.PollMailboxRequest{})
+	if err != nil {
+		log.Printf("Error polling mailbox: %v", err)
+		writeGRPCError(w, err, "Failed to poll mailbox")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,131 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) ListMerchants(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewMerchantsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	page := readPageParams(r)
+	res, err := pClient.ListMerchants(ctx, &pb.ListMerchantsRequest{
+		Query:             r.URL.Query().Get("query"),
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
+	})
+	if err != nil {
+		log.Printf("Error listing merchants: %v", err)
+		writeGRPCError(w, err, "Failed to list merchants")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) UpdateMerchant(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewMerchantsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.MerchantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.UpdateMerchant(ctx, &pb.UpdateMerchantRequest{Merchant: &pb.Merchant{
+		Id:              req.ID,
+		Name:            req.Name,
+		Location:        req.Location,
+		DefaultCategory: req.DefaultCategory,
+	}})
+	if err != nil {
+		log.Printf("Error updating merchant: %v", err)
+		writeGRPCError(w, err, "Failed to update merchant")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) MergeMerchants(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewMerchantsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.MergeMerchantsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.MergeMerchants(ctx, &pb.MergeMerchantsRequest{
+		TargetId:  req.TargetID,
+		SourceIds: req.SourceIDs,
+	})
+	if err != nil {
+		log.Printf("Error merging merchants: %v", err)
+		writeGRPCError(w, err, "Failed to merge merchants")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) SplitMerchant(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewMerchantsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.SplitMerchantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.SplitMerchant(ctx, &pb.SplitMerchantRequest{
+		MerchantId: req.MerchantID,
+		Aliases:    req.Aliases,
+		Name:       req.Name,
+	})
+	if err != nil {
+		log.Printf("Error splitting merchant: %v", err)
+		writeGRPCError(w, err, "Failed to split merchant")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) GetTopMerchants(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewMerchantsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	res, err := pClient.GetTopMerchants(ctx, &pb.GetTopMerchantsRequest{
+		Since: r.URL.Query().Get("since"),
+		Until: r.URL.Query().Get("until"),
+		Limit: int32(limit),
+	})
+	if err != nil {
+		log.Printf("Error getting top merchants: %v", err)
+		writeGRPCError(w, err, "Failed to get top merchants")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
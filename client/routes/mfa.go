@@ -0,0 +1,143 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) VerifyMfa(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.VerifyMfaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, clientIPMetadata, clientIP(r))
+	res, err := pClient.VerifyMfa(ctx, &pb.VerifyMfaRequest{
+		MfaToken: req.MfaToken,
+		Code:     req.Code,
+	})
+	if err != nil {
+		log.Printf("Error verifying MFA: %v", err)
+		writeGRPCError(w, err, "Failed to verify code")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"auth_token": "%s", "user_id": "%s"}`,
+		res.GetAuthToken(), res.GetUserId())
+}
+
+func (s *Server) EnrollTotp(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.TotpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.EnrollTotp(ctx, &pb.EnrollTotpRequest{
+		Password: req.Password,
+		Provider: req.Provider,
+		IdToken:  req.IDToken,
+		Nonce:    req.Nonce,
+	})
+	if err != nil {
+		log.Printf("Error enrolling TOTP: %v", err)
+		writeGRPCError(w, err, "Failed to enroll two-factor authentication")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ConfirmTotp(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.TotpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.ConfirmTotp(ctx, &pb.ConfirmTotpRequest{
+		Code: req.Code,
+	})
+	if err != nil {
+		log.Printf("Error confirming TOTP: %v", err)
+		writeGRPCError(w, err, "Failed to confirm two-factor authentication")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) DisableTotp(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.TotpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.DisableTotp(ctx, &pb.DisableTotpRequest{
+		Password: req.Password,
+		Code:     req.Code,
+		Provider: req.Provider,
+		IdToken:  req.IDToken,
+		Nonce:    req.Nonce,
+	})
+	if err != nil {
+		log.Printf("Error disabling TOTP: %v", err)
+		writeGRPCError(w, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.TotpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.RegenerateRecoveryCodes(ctx, &pb.RegenerateRecoveryCodesRequest{
+		Code: req.Code,
+	})
+	if err != nil {
+		log.Printf("Error regenerating recovery codes: %v", err)
+		writeGRPCError(w, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,283 @@
+package routes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gorilla/mux"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+	"github.com/barathsurya2004/expenses/services/sso"
+)
+
+const (
+	oidcFlowCookie = "oidc_flow"
+	oidcFlowTTL    = 10 * time.Minute
+)
+
+// oidcFlow is the per-login state kept in a short-lived cookie between the
+// redirect to the provider and the callback.
+type oidcFlow struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+	Mode     string `json:"mode"`
+}
+
+// oidcGateway resolves the OAuth2 endpoints of each provider through OIDC
+// discovery the first time it is used.
+type oidcGateway struct {
+	mu        sync.Mutex
+	providers map[string]sso.ProviderConfig
+	configs   map[string]*oauth2.Config
+}
+
+func newOidcGateway(providers map[string]sso.ProviderConfig) *oidcGateway {
+	return &oidcGateway{
+		providers: providers,
+		configs:   map[string]*oauth2.Config{},
+	}
+}
+
+func (g *oidcGateway) config(ctx context.Context, name string) (*oauth2.Config, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if cfg, ok := g.configs[name]; ok {
+		return cfg, nil
+	}
+	provider, ok := g.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown identity provider %q", name)
+	}
+	discovered, err := gooidc.NewProvider(ctx, provider.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &oauth2.Config{
+		ClientID:     provider.ClientID,
+		ClientSecret: provider.ClientSecret,
+		RedirectURL:  provider.RedirectURL,
+		Endpoint:     discovered.Endpoint(),
+		Scopes:       provider.Scopes,
+	}
+	g.configs[name] = cfg
+	return cfg, nil
+}
+
+// OidcLogin redirects the browser to the provider using the authorization
+// code flow with PKCE. Passing ?mode=link starts a flow whose callback hands
+// the ID token back for /link-identity instead of signing in. ?mode=reauth
+// does the same but makes the provider ask for the credentials again, so the
+// token can confirm a sensitive change on an account without a password.
+func (s *Server) OidcLogin(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["provider"]
+	cfg, err := s.oidc.config(r.Context(), name)
+	if err != nil {
+		log.Printf("Error loading identity provider %s: %v", name, err)
+		http.Error(w, "Unknown identity provider", http.StatusNotFound)
+		return
+	}
+
+	flow := oidcFlow{
+		Provider: name,
+		State:    randomToken(),
+		Verifier: oauth2.GenerateVerifier(),
+		Nonce:    randomToken(),
+		Mode:     r.URL.Query().Get("mode"),
+	}
+	if flow.Mode != "" && flow.Mode != "link" && flow.Mode != "reauth" {
+		http.Error(w, "mode must be link or reauth", http.StatusBadRequest)
+		return
+	}
+	encoded, err := json.Marshal(flow)
+	if err != nil {
+		http.Error(w, "Failed to start sign-in", http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcFlowCookie,
+		Value:    base64.RawURLEncoding.EncodeToString(encoded),
+		Path:     "/auth/oidc/",
+		MaxAge:   int(oidcFlowTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	opts := []oauth2.AuthCodeOption{
+		oauth2.S256ChallengeOption(flow.Verifier),
+		gooidc.Nonce(flow.Nonce),
+	}
+	if flow.Mode == "reauth" {
+		opts = append(opts,
+			oauth2.SetAuthURLParam("prompt", "login"),
+			oauth2.SetAuthURLParam("max_age", "0"),
+		)
+	}
+	url := cfg.AuthCodeURL(flow.State, opts...)
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+func (s *Server) OidcCallback(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["provider"]
+	ctx := r.Context()
+
+	cookie, err := r.Cookie(oidcFlowCookie)
+	if err != nil {
+		http.Error(w, "Sign-in session expired", http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: oidcFlowCookie, Path: "/auth/oidc/", MaxAge: -1})
+
+	var flow oidcFlow
+	raw, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil || json.Unmarshal(raw, &flow) != nil {
+		http.Error(w, "Invalid sign-in session", http.StatusBadRequest)
+		return
+	}
+	if flow.Provider != name || flow.State == "" || r.URL.Query().Get("state") != flow.State {
+		http.Error(w, "Invalid sign-in state", http.StatusBadRequest)
+		return
+	}
+	if errCode := r.URL.Query().Get("error"); errCode != "" {
+		log.Printf("Identity provider %s returned error: %s", name, errCode)
+		http.Error(w, "Sign-in was not completed", http.StatusUnauthorized)
+		return
+	}
+
+	cfg, err := s.oidc.config(ctx, name)
+	if err != nil {
+		log.Printf("Error loading identity provider %s: %v", name, err)
+		http.Error(w, "Unknown identity provider", http.StatusNotFound)
+		return
+	}
+	token, err := cfg.Exchange(ctx, r.URL.Query().Get("code"), oauth2.VerifierOption(flow.Verifier))
+	if err != nil {
+		log.Printf("Error exchanging authorization code: %v", err)
+		http.Error(w, "Failed to complete sign-in", http.StatusUnauthorized)
+		return
+	}
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		http.Error(w, "Identity provider did not return an id token", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if flow.Mode != "" {
+		json.NewEncoder(w).Encode(models.LinkIdentityRequest{
+			Provider: name,
+			IDToken:  idToken,
+			Nonce:    flow.Nonce,
+		})
+		return
+	}
+
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, clientIPMetadata, clientIP(r))
+	res, err := pClient.OidcLogin(ctx, &pb.OidcLoginRequest{
+		Provider: name,
+		IdToken:  idToken,
+		Nonce:    flow.Nonce,
+	})
+	if err != nil {
+		log.Printf("Error signing in with %s: %v", name, err)
+		w.Header().Del("Content-Type")
+		writeGRPCError(w, err, "Failed to sign in")
+		return
+	}
+
+	if res.GetMfaRequired() {
+		fmt.Fprintf(w, `{"mfa_required": true, "mfa_token": "%s"}`, res.GetMfaToken())
+		return
+	}
+	fmt.Fprintf(w, `{"auth_token": "%s", "user_id": "%s"}`,
+		res.GetAuthToken(), res.GetUserId())
+}
+
+func (s *Server) LinkIdentity(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.LinkIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.LinkIdentity(ctx, &pb.LinkIdentityRequest{
+		Password: req.Password,
+		Provider: req.Provider,
+		IdToken:  req.IDToken,
+		Nonce:    req.Nonce,
+	})
+	if err != nil {
+		log.Printf("Error linking identity: %v", err)
+		writeGRPCError(w, err, "Failed to link identity")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.LinkIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.UnlinkIdentity(ctx, &pb.UnlinkIdentityRequest{
+		Provider: req.Provider,
+	})
+	if err != nil {
+		log.Printf("Error unlinking identity: %v", err)
+		writeGRPCError(w, err, "Failed to unlink identity")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListIdentities(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.ListIdentities(ctx, &pb.ListIdentitiesRequest{})
+	if err != nil {
+		log.Printf("Error listing identities: %v", err)
+		writeGRPCError(w, err, "Failed to list identities")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res.GetIdentities())
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
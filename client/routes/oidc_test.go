@@ -0,0 +1,180 @@
+package routes
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/barathsurya2004/expenses/services/models"
+	"github.com/barathsurya2004/expenses/services/sso"
+)
+
+// fakeOidcProvider serves discovery and a token endpoint that only hands out
+// the ID token for the code it issued and the PKCE verifier of its
+// challenge.
+type fakeOidcProvider struct {
+	*httptest.Server
+	challenge string
+}
+
+func newFakeOidcProvider(t *testing.T) *fakeOidcProvider {
+	t.Helper()
+	p := &fakeOidcProvider{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 p.URL,
+			"authorization_endpoint": p.URL + "/authorize",
+			"token_endpoint":         p.URL + "/token",
+			"jwks_uri":               p.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != "code-1" || base64.RawURLEncoding.EncodeToString(sum[:]) != p.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access-1",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     "id-token-1",
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func newOidcTestServer(provider *fakeOidcProvider) *Server {
+	return &Server{oidc: newOidcGateway(map[string]sso.ProviderConfig{
+		"corp": {
+			Name:        "corp",
+			Issuer:      provider.URL,
+			ClientID:    "expenses",
+			RedirectURL: "http://localhost:8080/auth/oidc/corp/callback",
+			Scopes:      []string{"openid", "email"},
+		},
+	})}
+}
+
+// startOidcFlow calls the login route and returns the flow cookie and the
+// query of the redirect to the provider.
+func startOidcFlow(t *testing.T, s *Server, mode string) (*http.Cookie, url.Values) {
+	t.Helper()
+	r := httptest.NewRequest("GET", "/auth/oidc/corp/login?mode="+mode, nil)
+	r = mux.SetURLVars(r, map[string]string{"provider": "corp"})
+	w := httptest.NewRecorder()
+	s.OidcLogin(w, r)
+	if w.Code != http.StatusFound {
+		t.Fatalf("login status = %d: %s", w.Code, w.Body)
+	}
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != oidcFlowCookie {
+		t.Fatalf("cookies = %v", cookies)
+	}
+	return cookies[0], location.Query()
+}
+
+func oidcCallback(s *Server, cookie *http.Cookie, query url.Values) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", "/auth/oidc/corp/callback?"+query.Encode(), nil)
+	r = mux.SetURLVars(r, map[string]string{"provider": "corp"})
+	if cookie != nil {
+		r.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	s.OidcCallback(w, r)
+	return w
+}
+
+func TestOidcPkceCallback(t *testing.T) {
+	provider := newFakeOidcProvider(t)
+	s := newOidcTestServer(provider)
+
+	cookie, auth := startOidcFlow(t, s, "link")
+	if auth.Get("code_challenge_method") != "S256" || auth.Get("nonce") == "" || auth.Get("state") == "" {
+		t.Fatalf("authorization request = %v", auth)
+	}
+	if auth.Get("prompt") != "" {
+		t.Errorf("link mode asked for prompt=%s", auth.Get("prompt"))
+	}
+	provider.challenge = auth.Get("code_challenge")
+
+	w := oidcCallback(s, cookie, url.Values{"state": {auth.Get("state")}, "code": {"code-1"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("callback status = %d: %s", w.Code, w.Body)
+	}
+	var res models.LinkIdentityRequest
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Provider != "corp" || res.IDToken != "id-token-1" || res.Nonce != auth.Get("nonce") {
+		t.Errorf("callback returned %+v", res)
+	}
+}
+
+func TestOidcReauthForcesSignIn(t *testing.T) {
+	s := newOidcTestServer(newFakeOidcProvider(t))
+	_, auth := startOidcFlow(t, s, "reauth")
+	if auth.Get("prompt") != "login" || auth.Get("max_age") != "0" {
+		t.Errorf("reauth request = %v, want prompt=login and max_age=0", auth)
+	}
+
+	r := httptest.NewRequest("GET", "/auth/oidc/corp/login?mode=other", nil)
+	r = mux.SetURLVars(r, map[string]string{"provider": "corp"})
+	w := httptest.NewRecorder()
+	s.OidcLogin(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown mode status = %d, want 400", w.Code)
+	}
+}
+
+func TestOidcCallbackRejects(t *testing.T) {
+	provider := newFakeOidcProvider(t)
+	s := newOidcTestServer(provider)
+	cookie, auth := startOidcFlow(t, s, "link")
+	provider.challenge = auth.Get("code_challenge")
+
+	tests := []struct {
+		name   string
+		cookie *http.Cookie
+		query  url.Values
+		want   int
+	}{
+		{"no flow cookie", nil, url.Values{"state": {auth.Get("state")}, "code": {"code-1"}}, http.StatusBadRequest},
+		{"state mismatch", cookie, url.Values{"state": {"forged"}, "code": {"code-1"}}, http.StatusBadRequest},
+		{"provider error", cookie, url.Values{"state": {auth.Get("state")}, "error": {"access_denied"}}, http.StatusUnauthorized},
+		{"wrong code", cookie, url.Values{"state": {auth.Get("state")}, "code": {"code-2"}}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := oidcCallback(s, tt.cookie, tt.query); w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+
+	// The verifier in the cookie does not match a challenge from another
+	// flow, so the provider refuses the code.
+	_, other := startOidcFlow(t, s, "link")
+	provider.challenge = other.Get("code_challenge")
+	w := oidcCallback(s, cookie, url.Values{"state": {auth.Get("state")}, "code": {"code-1"}})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("wrong verifier status = %d, want 401: %s", w.Code, w.Body)
+	}
+}
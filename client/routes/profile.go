@@ -0,0 +1,86 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) GetProfile(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.GetProfile(ctx, &pb.GetProfileRequest{})
+	if err != nil {
+		log.Printf("Error getting profile: %v", err)
+		http.Error(w, "Failed to get profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res.GetProfile()); err != nil {
+		log.Printf("Error encoding profile: %v", err)
+		http.Error(w, "Failed to encode profile", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.UpdateProfile(ctx, &pb.UpdateProfileRequest{
+		Username:        req.Username,
+		Email:           req.Email,
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		AvatarUrl:       req.AvatarURL,
+		DefaultCurrency: req.DefaultCurrency,
+		TimeZone:        req.TimeZone,
+		Locale:          req.Locale,
+		WeekStartDay:    req.WeekStartDay,
+	})
+	if err != nil {
+		log.Printf("Error updating profile: %v", err)
+		http.Error(w, "Failed to update profile", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		log.Printf("Error encoding profile: %v", err)
+		http.Error(w, "Failed to encode profile", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewUsersServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.VerifyEmail(ctx, &pb.VerifyEmailRequest{
+		Token: r.URL.Query().Get("token"),
+	})
+	if err != nil {
+		log.Printf("Error verifying email: %v", err)
+		http.Error(w, "Failed to verify email", http.StatusInternalServerError)
+		return
+	}
+	if !res.GetVerified() {
+		http.Error(w, res.GetMessage(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
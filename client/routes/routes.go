@@ -6,26 +6,128 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"sync"
 
 	"github.com/gorilla/mux"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/barathsurya2004/expenses/client/middleware"
 	pb "github.com/barathsurya2004/expenses/proto"
 	"github.com/barathsurya2004/expenses/services/models"
+	"github.com/barathsurya2004/expenses/services/sso"
+	"github.com/barathsurya2004/expenses/services/trustedproxy"
 )
 
 type Server struct {
 	Conn *grpc.ClientConn
+	oidc *oidcGateway
 }
 
 func RegisterRoutes(r *mux.Router, conn *grpc.ClientConn) {
-	server := &Server{Conn: conn}
+	server := &Server{Conn: conn, oidc: newOidcGateway(sso.LoadProviders())}
 	r.HandleFunc("/create-user", server.CreateUser).Methods("POST")
 	r.HandleFunc("/get-user", server.GetUser).Methods("POST")
 	r.Handle("/create-expense", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CreateExpense))).Methods("POST")
 	r.Handle("/get-heatmap-data", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetHeatMapData))).Methods("GET")
 	r.Handle("/get-spending-types", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetSpendingTypes))).Methods("GET")
+	r.Handle("/compare-spending", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CompareSpending))).Methods("GET")
+	r.Handle("/list-expenses", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListExpenses))).Methods("GET")
+	r.Handle("/export-expenses", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ExportExpenses))).Methods("GET")
+	r.Handle("/search-expenses", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SearchExpenses))).Methods("GET")
+	r.Handle("/set-expense-group", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SetExpenseGroup))).Methods("POST")
+	r.Handle("/add-expense", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AddExpense))).Methods("POST")
+	r.Handle("/update-expense", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.UpdateExpense))).Methods("POST")
+	r.Handle("/list-tags", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListTags))).Methods("GET")
+	r.Handle("/create-custom-field", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CreateCustomField))).Methods("POST")
+	r.Handle("/update-custom-field", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.UpdateCustomField))).Methods("POST")
+	r.Handle("/delete-custom-field", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.DeleteCustomField))).Methods("POST")
+	r.Handle("/list-custom-fields", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListCustomFields))).Methods("GET")
+	r.Handle("/create-rule", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CreateRule))).Methods("POST")
+	r.Handle("/list-rules", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListRules))).Methods("GET")
+	r.Handle("/update-rule", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.UpdateRule))).Methods("POST")
+	r.Handle("/delete-rule", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.DeleteRule))).Methods("POST")
+	r.Handle("/apply-rules", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ApplyRules))).Methods("POST")
+	r.Handle("/list-categories", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListCategories))).Methods("GET")
+	r.Handle("/create-category", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CreateCategory))).Methods("POST")
+	r.Handle("/update-category", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.UpdateCategory))).Methods("POST")
+	r.Handle("/delete-category", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.DeleteCategory))).Methods("POST")
+	r.Handle("/list-merchants", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListMerchants))).Methods("GET")
+	r.Handle("/update-merchant", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.UpdateMerchant))).Methods("POST")
+	r.Handle("/merge-merchants", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.MergeMerchants))).Methods("POST")
+	r.Handle("/split-merchant", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SplitMerchant))).Methods("POST")
+	r.Handle("/top-merchants", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetTopMerchants))).Methods("GET")
+	r.Handle("/set-ingestion-consent", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SetIngestionConsent))).Methods("POST")
+	r.Handle("/list-ingestion-consents", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListIngestionConsents))).Methods("GET")
+	r.Handle("/ingest-messages", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.IngestMessages))).Methods("POST")
+	r.Handle("/import-statement", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ImportStatement))).Methods("POST")
+	r.Handle("/save-import-profile", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SaveImportProfile))).Methods("POST")
+	r.Handle("/list-import-profiles", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListImportProfiles))).Methods("GET")
+	r.Handle("/delete-import-profile", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.DeleteImportProfile))).Methods("POST")
+	r.Handle("/connect-mailbox", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ConnectMailbox))).Methods("POST")
+	r.Handle("/disconnect-mailbox", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.DisconnectMailbox))).Methods("POST")
+	r.Handle("/get-email-ingestion", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetEmailIngestion))).Methods("GET")
+	r.Handle("/poll-mailbox", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.PollMailbox))).Methods("POST")
+	r.Handle("/predict-category", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.PredictCategory))).Methods("GET")
+	r.Handle("/get-profile", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetProfile))).Methods("GET")
+	r.Handle("/update-profile", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.UpdateProfile))).Methods("POST")
+	r.HandleFunc("/verify-email", server.VerifyEmail).Methods("GET")
+	r.Handle("/export-my-data", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ExportMyData))).Methods("GET")
+	r.Handle("/delete-account", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.DeleteAccount))).Methods("POST")
+	r.Handle("/cancel-account-deletion", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CancelAccountDeletion))).Methods("POST")
+	r.HandleFunc("/verify-mfa", server.VerifyMfa).Methods("POST")
+	r.Handle("/enroll-totp", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.EnrollTotp))).Methods("POST")
+	r.Handle("/confirm-totp", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ConfirmTotp))).Methods("POST")
+	r.Handle("/disable-totp", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.DisableTotp))).Methods("POST")
+	r.Handle("/regenerate-recovery-codes", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.RegenerateRecoveryCodes))).Methods("POST")
+	r.HandleFunc("/auth/oidc/{provider}/login", server.OidcLogin).Methods("GET")
+	r.HandleFunc("/auth/oidc/{provider}/callback", server.OidcCallback).Methods("GET")
+	r.Handle("/link-identity", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.LinkIdentity))).Methods("POST")
+	r.Handle("/unlink-identity", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.UnlinkIdentity))).Methods("POST")
+	r.Handle("/list-identities", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListIdentities))).Methods("GET")
+	r.Handle("/create-api-key", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CreateApiKey))).Methods("POST")
+	r.Handle("/list-api-keys", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListApiKeys))).Methods("GET")
+	r.Handle("/revoke-api-key", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.RevokeApiKey))).Methods("POST")
+	r.Handle("/create-group", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CreateGroup))).Methods("POST")
+	r.Handle("/list-groups", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListGroups))).Methods("GET")
+	r.Handle("/get-group", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetGroup))).Methods("GET")
+	r.Handle("/invite-to-group", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.InviteToGroup))).Methods("POST")
+	r.Handle("/list-group-invitations", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListGroupInvitations))).Methods("GET")
+	r.Handle("/accept-group-invitation", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AcceptGroupInvitation))).Methods("POST")
+	r.Handle("/set-group-member-role", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SetGroupMemberRole))).Methods("POST")
+	r.Handle("/remove-group-member", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.RemoveGroupMember))).Methods("POST")
+	r.Handle("/split-expense", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SplitExpense))).Methods("POST")
+	r.Handle("/get-group-balances", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetGroupBalances))).Methods("GET")
+	r.Handle("/record-settlement", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.RecordSettlement))).Methods("POST")
+	r.Handle("/assign-expense-items", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AssignExpenseItems))).Methods("POST")
+	r.Handle("/suggest-item-assignments", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SuggestItemAssignments))).Methods("GET")
+	r.Handle("/create-expense-report", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CreateExpenseReport))).Methods("POST")
+	r.Handle("/update-expense-report", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.UpdateExpenseReport))).Methods("POST")
+	r.Handle("/delete-expense-report", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.DeleteExpenseReport))).Methods("POST")
+	r.Handle("/list-expense-reports", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListExpenseReports))).Methods("GET")
+	r.Handle("/get-expense-report", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetExpenseReport))).Methods("GET")
+	r.Handle("/submit-expense-report", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SubmitExpenseReport))).Methods("POST")
+	r.Handle("/review-expense-report", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ReviewExpenseReport))).Methods("POST")
+	r.Handle("/comment-on-expense-report", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CommentOnExpenseReport))).Methods("POST")
+	r.Handle("/mark-report-reimbursed", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.MarkReportReimbursed))).Methods("POST")
+	r.Handle("/get-report-receipt", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetReportReceipt))).Methods("GET")
+	r.Handle("/export-expense-report", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ExportExpenseReport))).Methods("GET")
+	r.Handle("/create-trip", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.CreateTrip))).Methods("POST")
+	r.Handle("/update-trip", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.UpdateTrip))).Methods("POST")
+	r.Handle("/delete-trip", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.DeleteTrip))).Methods("POST")
+	r.Handle("/list-trips", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.ListTrips))).Methods("GET")
+	r.Handle("/set-expense-trip", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.SetExpenseTrip))).Methods("POST")
+	r.Handle("/get-trip-summary", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.GetTripSummary))).Methods("GET")
+	r.Handle("/admin/list-users", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AdminListUsers))).Methods("GET")
+	r.Handle("/admin/usage-stats", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AdminGetUsageStats))).Methods("GET")
+	r.Handle("/admin/disable-user", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AdminDisableUser))).Methods("POST")
+	r.Handle("/admin/enable-user", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AdminEnableUser))).Methods("POST")
+	r.Handle("/admin/set-user-role", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AdminSetUserRole))).Methods("POST")
+	r.Handle("/admin/impersonate", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AdminImpersonate))).Methods("POST")
+	r.Handle("/admin/revoke-user-tokens", middleware.AuthorizationMiddleware(conn)(http.HandlerFunc(server.AdminRevokeUserTokens))).Methods("POST")
 }
 
 func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
@@ -39,17 +141,22 @@ func (s *Server) GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ctx = metadata.AppendToOutgoingContext(ctx, clientIPMetadata, clientIP(r))
 	res, err := pClient.GetUser(ctx, &pb.GetUserRequest{
 		Username: req.Username,
 		Password: req.Password,
 	})
 	if err != nil {
 		log.Printf("Error getting user: %v", err)
-		http.Error(w, "Failed to get user", http.StatusInternalServerError)
+		writeGRPCError(w, err, "Failed to get user")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
 
+	if res.GetMfaRequired() {
+		fmt.Fprintf(w, `{"mfa_required": true, "mfa_token": "%s"}`, res.GetMfaToken())
+		return
+	}
 	fmt.Fprintf(w, `{"auth_token": "%s", "user_id": "%s"}`,
 		res.GetAuthToken(), res.GetUserId())
 
@@ -100,6 +207,7 @@ func (s *Server) CreateExpense(w http.ResponseWriter, r *http.Request) {
 	defer file.Close()
 
 	log.Printf("File received: filename=%q, header=%+v", handler.Filename, handler.Header)
+	groupId := r.FormValue("group_id")
 
 	pClient := pb.NewExpensesServiceClient(s.Conn)
 	ctx := r.Context()
@@ -122,7 +230,7 @@ func (s *Server) CreateExpense(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		if err := stream.Send(&pb.CreateExpenseRequest{Chunks: buffer[:n]}); err != nil {
+		if err := stream.Send(&pb.CreateExpenseRequest{Chunks: buffer[:n], GroupId: groupId}); err != nil {
 			log.Printf("Error sending chunk: %v", err)
 			http.Error(w, "Failed to send file chunk", http.StatusInternalServerError)
 			return
@@ -132,7 +240,7 @@ func (s *Server) CreateExpense(w http.ResponseWriter, r *http.Request) {
 	response, err := stream.CloseAndRecv()
 	if err != nil {
 		log.Printf("Error receiving gRPC response: %v", err)
-		http.Error(w, "Failed to receive response", http.StatusInternalServerError)
+		writeGRPCError(w, err, "Failed to receive response")
 		return
 	}
 
@@ -143,15 +251,27 @@ func (s *Server) CreateExpense(w http.ResponseWriter, r *http.Request) {
 func (s *Server) GetHeatMapData(w http.ResponseWriter, r *http.Request) {
 	pbClient := pb.NewExpensesServiceClient(s.Conn)
 	ctx := r.Context()
+	page := readPageParams(r)
 	res, err := pbClient.GetHeatMapData(ctx, &pb.GetHeatMapDataRequest{
-		UserId: "01f07c5a-f6c2-652b-9f5a-00155d4c4438",
+		GroupId:           r.URL.Query().Get("group_id"),
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
 	})
 	if err != nil {
 		log.Printf("Error getting heatmap data: %v", err)
-		http.Error(w, "Failed to get heatmap data", http.StatusInternalServerError)
+		writeGRPCError(w, err, "Failed to get heatmap data")
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
+	// The body stays a plain array, so the page is described in headers.
+	if res.GetNextPageToken() != "" {
+		w.Header().Set("X-Next-Page-Token", res.GetNextPageToken())
+	}
+	if page.includeTotal {
+		w.Header().Set("X-Total-Count", strconv.Itoa(int(res.GetTotalCount())))
+	}
 
 	if err := json.NewEncoder(w).Encode(res.GetHeatMapData()); err != nil {
 		log.Printf("Error encoding heatmap data: %v", err)
@@ -169,11 +289,14 @@ func (s *Server) GetSpendingTypes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	res, err := pbClient.GetSpendingTypes(ctx, &pb.GetSpendingTypesRequest{
-		UserId: "01f07c5a-f6c2-652b-9f5a-00155d4c4438",
+		GroupId:       r.URL.Query().Get("group_id"),
+		ByParent:      r.URL.Query().Get("by_parent") == "true",
+		GroupBy:       r.URL.Query().Get("group_by"),
+		CustomFieldId: r.URL.Query().Get("custom_field_id"),
 	})
 	if err != nil {
 		log.Printf("Error getting spending types data: %v", err)
-		http.Error(w, "Failed to get spending types data", http.StatusInternalServerError)
+		writeGRPCError(w, err, "Failed to get spending types data")
 		return
 	}
 
@@ -188,3 +311,41 @@ func (s *Server) GetSpendingTypes(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Spending types data: %v", res.GetSpendingTypes())
 	log.Printf("Spending types data length: %d", len(res.GetSpendingTypes()))
 }
+
+// clientIPMetadata carries the caller's address, as worked out by the
+// gateway, to the services. The services only read it from a trusted proxy.
+const clientIPMetadata = "x-client-ip"
+
+// trustedProxies are the load balancers allowed to tell the gateway who the
+// caller is. They are read once, after main has loaded .env.
+var trustedProxies = sync.OnceValue(trustedproxy.Load)
+
+// clientIP returns the address of the caller. X-Forwarded-For is only
+// honoured when the request came through one of TRUSTED_PROXIES.
+func clientIP(r *http.Request) string {
+	return trustedProxies().ClientIP(r.RemoteAddr, r.Header.Values("X-Forwarded-For"))
+}
+
+// writeGRPCError maps the status of a failed gRPC call onto an HTTP error.
+// Internal failures are reported with the generic fallback message.
+func writeGRPCError(w http.ResponseWriter, err error, fallback string) {
+	st := status.Convert(err)
+	switch st.Code() {
+	case codes.InvalidArgument:
+		http.Error(w, st.Message(), http.StatusBadRequest)
+	case codes.Unauthenticated:
+		http.Error(w, st.Message(), http.StatusUnauthorized)
+	case codes.PermissionDenied:
+		http.Error(w, st.Message(), http.StatusForbidden)
+	case codes.NotFound:
+		http.Error(w, st.Message(), http.StatusNotFound)
+	case codes.AlreadyExists:
+		http.Error(w, st.Message(), http.StatusConflict)
+	case codes.FailedPrecondition:
+		http.Error(w, st.Message(), http.StatusPreconditionFailed)
+	case codes.ResourceExhausted:
+		http.Error(w, st.Message(), http.StatusTooManyRequests)
+	default:
+		http.Error(w, fallback, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,135 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func ruleFromRequest(req models.CategorizationRule) *pb.CategorizationRule {
+	return &pb.CategorizationRule{
+		Id:               req.ID,
+		Name:             req.Name,
+		Priority:         req.Priority,
+		MerchantContains: req.MerchantContains,
+		MerchantRegex:    req.MerchantRegex,
+		MinAmount:        req.MinAmount,
+		MaxAmount:        req.MaxAmount,
+		PaymentMethod:    req.PaymentMethod,
+		Category:         req.Category,
+		Tags:             req.Tags,
+		Enabled:          req.Enabled,
+	}
+}
+
+func (s *Server) CreateRule(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.CategorizationRule
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.CreateRule(ctx, &pb.CreateRuleRequest{Rule: ruleFromRequest(req)})
+	if err != nil {
+		log.Printf("Error creating rule: %v", err)
+		writeGRPCError(w, err, "Failed to create rule")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListRules(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	page := readPageParams(r)
+	res, err := pClient.ListRules(ctx, &pb.ListRulesRequest{
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
+	})
+	if err != nil {
+		log.Printf("Error listing rules: %v", err)
+		writeGRPCError(w, err, "Failed to list rules")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.CategorizationRule
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.UpdateRule(ctx, &pb.UpdateRuleRequest{Rule: ruleFromRequest(req)})
+	if err != nil {
+		log.Printf("Error updating rule: %v", err)
+		writeGRPCError(w, err, "Failed to update rule")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.CategorizationRule
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.DeleteRule(ctx, &pb.DeleteRuleRequest{Id: req.ID})
+	if err != nil {
+		log.Printf("Error deleting rule: %v", err)
+		writeGRPCError(w, err, "Failed to delete rule")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ApplyRules(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewCategorizationServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.ApplyRulesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.ApplyRulesToHistory(ctx, &pb.ApplyRulesToHistoryRequest{Since: req.Since})
+	if err != nil {
+		log.Printf("Error applying rules: %v", err)
+		writeGRPCError(w, err, "Failed to apply rules")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
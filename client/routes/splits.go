@@ -0,0 +1,134 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) SplitExpense(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewGroupsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.SplitExpenseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	shares := make([]*pb.SplitShare, len(req.Shares))
+	for i, share := range req.Shares {
+		shares[i] = &pb.SplitShare{UserId: share.UserID, Value: share.Value}
+	}
+	res, err := pClient.SplitExpense(ctx, &pb.SplitExpenseRequest{
+		ExpenseId: req.ExpenseID,
+		PaidBy:    req.PaidBy,
+		Method:    req.Method,
+		Shares:    shares,
+	})
+	if err != nil {
+		log.Printf("Error splitting expense: %v", err)
+		writeGRPCError(w, err, "Failed to split expense")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) GetGroupBalances(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewGroupsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.GetBalances(ctx, &pb.GetBalancesRequest{
+		GroupId: r.URL.Query().Get("group_id"),
+	})
+	if err != nil {
+		log.Printf("Error getting group balances: %v", err)
+		writeGRPCError(w, err, "Failed to get group balances")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) RecordSettlement(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewGroupsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.RecordSettlementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.RecordSettlement(ctx, &pb.RecordSettlementRequest{
+		GroupId:    req.GroupID,
+		FromUserId: req.FromUserID,
+		ToUserId:   req.ToUserID,
+		Amount:     req.Amount,
+		Currency:   req.Currency,
+		Note:       req.Note,
+	})
+	if err != nil {
+		log.Printf("Error recording settlement: %v", err)
+		writeGRPCError(w, err, "Failed to record settlement")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) AssignExpenseItems(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewGroupsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.AssignExpenseItemsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	assignments := make([]*pb.ItemAssignment, len(req.Assignments))
+	for i, assignment := range req.Assignments {
+		assignments[i] = &pb.ItemAssignment{ItemId: assignment.ItemID, UserIds: assignment.UserIDs}
+	}
+	res, err := pClient.AssignExpenseItems(ctx, &pb.AssignExpenseItemsRequest{
+		ExpenseId:   req.ExpenseID,
+		PaidBy:      req.PaidBy,
+		Assignments: assignments,
+	})
+	if err != nil {
+		log.Printf("Error assigning expense items: %v", err)
+		writeGRPCError(w, err, "Failed to assign expense items")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) SuggestItemAssignments(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewGroupsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.SuggestItemAssignments(ctx, &pb.SuggestItemAssignmentsRequest{
+		ExpenseId: r.URL.Query().Get("expense_id"),
+	})
+	if err != nil {
+		log.Printf("Error suggesting item assignments: %v", err)
+		writeGRPCError(w, err, "Failed to suggest item assignments")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,157 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+func (s *Server) CreateTrip(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewTripsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.TripRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.CreateTrip(ctx, &pb.CreateTripRequest{
+		Name:            req.Name,
+		GroupId:         req.GroupID,
+		StartsOn:        req.StartsOn,
+		EndsOn:          req.EndsOn,
+		Budget:          req.Budget,
+		HomeCurrency:    req.HomeCurrency,
+		ForeignCurrency: req.ForeignCurrency,
+		ExchangeRate:    req.ExchangeRate,
+	})
+	if err != nil {
+		log.Printf("Error creating trip: %v", err)
+		writeGRPCError(w, err, "Failed to create trip")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) UpdateTrip(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewTripsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.UpdateTripRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.UpdateTrip(ctx, &pb.UpdateTripRequest{
+		Id:              req.ID,
+		Name:            req.Name,
+		StartsOn:        req.StartsOn,
+		EndsOn:          req.EndsOn,
+		Budget:          req.Budget,
+		HomeCurrency:    req.HomeCurrency,
+		ForeignCurrency: req.ForeignCurrency,
+		ExchangeRate:    req.ExchangeRate,
+	})
+	if err != nil {
+		log.Printf("Error updating trip: %v", err)
+		writeGRPCError(w, err, "Failed to update trip")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) DeleteTrip(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewTripsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.TripRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.DeleteTrip(ctx, &pb.DeleteTripRequest{Id: req.ID})
+	if err != nil {
+		log.Printf("Error deleting trip: %v", err)
+		writeGRPCError(w, err, "Failed to delete trip")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) ListTrips(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewTripsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	page := readPageParams(r)
+	res, err := pClient.ListTrips(ctx, &pb.ListTripsRequest{
+		GroupId:           r.URL.Query().Get("group_id"),
+		PageSize:          page.size,
+		PageToken:         page.token,
+		OrderBy:           page.orderBy,
+		IncludeTotalCount: page.includeTotal,
+	})
+	if err != nil {
+		log.Printf("Error listing trips: %v", err)
+		writeGRPCError(w, err, "Failed to list trips")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) SetExpenseTrip(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewTripsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	var req models.SetExpenseTripRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+
+	res, err := pClient.SetExpenseTrip(ctx, &pb.SetExpenseTripRequest{
+		ExpenseId: req.ExpenseID,
+		TripId:    req.TripID,
+		Automatic: req.Automatic,
+	})
+	if err != nil {
+		log.Printf("Error setting expense trip: %v", err)
+		writeGRPCError(w, err, "Failed to set expense trip")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) GetTripSummary(w http.ResponseWriter, r *http.Request) {
+	pClient := pb.NewTripsServiceClient(s.Conn)
+	ctx := r.Context()
+
+	res, err := pClient.GetTripSummary(ctx, &pb.GetTripSummaryRequest{Id: r.URL.Query().Get("id")})
+	if err != nil {
+		log.Printf("Error getting trip summary: %v", err)
+		writeGRPCError(w, err, "Failed to get trip summary")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,9 @@
+drop table if exists email_verification_data cascade;
+
+alter table user_data
+    drop column if exists avatar_url,
+    drop column if exists email_verified,
+    drop column if exists default_currency,
+    drop column if exists time_zone,
+    drop column if exists locale,
+    drop column if exists week_start_day;
@@ -0,0 +1,15 @@
+alter table user_data
+    add column if not exists avatar_url varchar(500),
+    add column if not exists email_verified boolean not null default false,
+    add column if not exists default_currency varchar(3) not null default 'USD',
+    add column if not exists time_zone varchar(64) not null default 'UTC',
+    add column if not exists locale varchar(35) not null default 'en-US',
+    add column if not exists week_start_day varchar(9) not null default 'Monday';
+
+create table if not exists email_verification_data (
+    token varchar(255) primary key,
+    uuid uuid references user_data(uuid) on delete cascade,
+    email varchar(100) not null,
+    created_at timestamp with time zone default current_timestamp,
+    expires_at timestamp with time zone not null
+);
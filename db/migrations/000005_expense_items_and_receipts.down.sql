@@ -0,0 +1,8 @@
+alter table user_data
+    drop column if exists deletion_requested_at,
+    drop column if exists deletion_scheduled_at;
+
+drop table if exists receipt_data cascade;
+drop table if exists expense_item_data cascade;
+
+alter table expense_data drop column if exists id;
@@ -0,0 +1,25 @@
+alter table expense_data
+    add column if not exists id uuid primary key default gen_random_uuid();
+
+create table if not exists expense_item_data (
+    id uuid primary key default gen_random_uuid(),
+    expense_id uuid references expense_data(id) on delete cascade,
+    item_name varchar(200) not null,
+    price numeric(10, 2) not null,
+    quantity integer not null default 1,
+    category varchar(50)
+);
+
+create table if not exists receipt_data (
+    id uuid primary key default gen_random_uuid(),
+    expense_id uuid references expense_data(id) on delete cascade,
+    uuid uuid references user_data(uuid) on delete cascade,
+    storage_key varchar(255) not null unique,
+    content_type varchar(100) not null,
+    size_bytes bigint not null,
+    created_at timestamp with time zone default current_timestamp
+);
+
+alter table user_data
+    add column if not exists deletion_requested_at timestamp with time zone,
+    add column if not exists deletion_scheduled_at timestamp with time zone;
@@ -0,0 +1,2 @@
+drop table if exists auth_event_data cascade;
+drop table if exists login_throttle_data cascade;
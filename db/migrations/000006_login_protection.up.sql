@@ -0,0 +1,20 @@
+create table if not exists login_throttle_data (
+    key varchar(150) primary key,
+    failures integer not null default 0,
+    last_failure_at timestamp with time zone,
+    locked_until timestamp with time zone
+);
+
+create table if not exists auth_event_data (
+    id bigserial primary key,
+    uuid uuid references user_data(uuid) on delete cascade,
+    username varchar(50),
+    ip_address varchar(64),
+    event varchar(50) not null,
+    success boolean not null,
+    detail text,
+    created_at timestamp with time zone default current_timestamp
+);
+
+create index if not exists auth_event_data_uuid_idx on auth_event_data (uuid, created_at);
+create index if not exists auth_event_data_ip_idx on auth_event_data (ip_address, created_at);
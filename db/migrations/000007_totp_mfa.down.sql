@@ -0,0 +1,3 @@
+drop table if exists mfa_challenge_data cascade;
+drop table if exists mfa_recovery_code_data cascade;
+drop table if exists mfa_data cascade;
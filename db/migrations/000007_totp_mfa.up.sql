@@ -0,0 +1,22 @@
+create table if not exists mfa_data (
+    uuid uuid references user_data(uuid) on delete cascade primary key,
+    totp_secret varchar(64) not null,
+    enabled boolean not null default false,
+    last_used_step bigint not null default 0,
+    created_at timestamp with time zone default current_timestamp,
+    enabled_at timestamp with time zone
+);
+
+create table if not exists mfa_recovery_code_data (
+    id bigserial primary key,
+    uuid uuid references user_data(uuid) on delete cascade,
+    code_hash varchar(64) not null,
+    used_at timestamp with time zone
+);
+
+create table if not exists mfa_challenge_data (
+    token varchar(255) primary key,
+    uuid uuid references user_data(uuid) on delete cascade,
+    created_at timestamp with time zone default current_timestamp,
+    expires_at timestamp with time zone not null
+);
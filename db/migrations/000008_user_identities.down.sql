@@ -0,0 +1,14 @@
+-- Accounts created through an identity provider have no password and could
+-- not sign in once user_identities is gone. Refuse to roll back rather than
+-- delete them; give them a password or remove them by hand first.
+do $$
+begin
+    if exists (select 1 from user_data where password_hash is null) then
+        raise exception 'cannot roll back 000008: % accounts have no password', (select count(*) from user_data where password_hash is null);
+    end if;
+end
+$$;
+
+drop table if exists user_identities cascade;
+
+alter table user_data alter column password_hash set not null;
@@ -0,0 +1,13 @@
+alter table user_data alter column password_hash drop not null;
+
+create table if not exists user_identities (
+    id bigserial primary key,
+    uuid uuid references user_data(uuid) on delete cascade,
+    provider varchar(50) not null,
+    subject varchar(255) not null,
+    email varchar(100),
+    created_at timestamp with time zone default current_timestamp,
+    last_login_at timestamp with time zone,
+    unique (provider, subject),
+    unique (uuid, provider)
+);
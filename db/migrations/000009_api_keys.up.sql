@@ -0,0 +1,13 @@
+create table if not exists api_key_data (
+    id uuid primary key default gen_random_uuid(),
+    uuid uuid references user_data(uuid) on delete cascade,
+    name varchar(100) not null,
+    prefix varchar(16) not null unique,
+    key_hash varchar(64) not null,
+    scope varchar(20) not null default 'read' check (scope in ('read', 'read_write')),
+    created_at timestamp with time zone default current_timestamp,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone
+);
+
+create index if not exists api_key_data_uuid_idx on api_key_data (uuid);
@@ -0,0 +1,14 @@
+drop table if exists admin_audit_data cascade;
+
+delete from token_data where impersonator is not null;
+drop index if exists token_data_uuid_idx;
+alter table token_data
+    drop column if exists impersonator,
+    drop column if exists scope;
+alter table token_data drop constraint if exists token_data_pkey;
+alter table token_data add primary key (uuid);
+
+alter table user_data
+    drop column if exists role,
+    drop column if exists disabled_at,
+    drop column if exists disabled_reason;
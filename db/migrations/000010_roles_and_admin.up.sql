@@ -0,0 +1,26 @@
+alter table user_data
+    add column if not exists role varchar(20) not null default 'user'
+        check (role in ('user', 'admin', 'support-readonly')),
+    add column if not exists disabled_at timestamp with time zone,
+    add column if not exists disabled_reason text;
+
+-- Allow more than one token per user so support impersonation tokens can
+-- live next to the user's own session.
+alter table token_data drop constraint if exists token_data_pkey;
+alter table token_data add primary key (token);
+alter table token_data
+    add column if not exists scope varchar(20) not null default 'read_write',
+    add column if not exists impersonator uuid references user_data(uuid) on delete cascade;
+create index if not exists token_data_uuid_idx on token_data (uuid);
+
+create table if not exists admin_audit_data (
+    id bigserial primary key,
+    actor uuid references user_data(uuid) on delete set null,
+    action varchar(50) not null,
+    target uuid,
+    reason text,
+    detail text,
+    created_at timestamp with time zone default current_timestamp
+);
+
+create index if not exists admin_audit_data_target_idx on admin_audit_data (target, created_at);
@@ -0,0 +1,6 @@
+alter table expense_data
+    drop column if exists group_id;
+
+drop table if exists group_invitation_data cascade;
+drop table if exists group_member_data cascade;
+drop table if exists group_data cascade;
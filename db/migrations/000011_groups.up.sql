@@ -0,0 +1,38 @@
+create table if not exists group_data (
+    id uuid primary key default gen_random_uuid(),
+    name varchar(100) not null,
+    created_by uuid references user_data(uuid) on delete set null,
+    created_at timestamp with time zone default current_timestamp,
+    updated_at timestamp with time zone default current_timestamp
+);
+
+create table if not exists group_member_data (
+    group_id uuid references group_data(id) on delete cascade,
+    uuid uuid references user_data(uuid) on delete cascade,
+    role varchar(20) not null default 'member' check (role in ('owner', 'admin', 'member')),
+    joined_at timestamp with time zone default current_timestamp,
+    primary key (group_id, uuid)
+);
+
+create index if not exists group_member_data_uuid_idx on group_member_data (uuid);
+
+create table if not exists group_invitation_data (
+    id uuid primary key default gen_random_uuid(),
+    group_id uuid references group_data(id) on delete cascade,
+    invited_by uuid references user_data(uuid) on delete set null,
+    invitee uuid references user_data(uuid) on delete cascade,
+    email varchar(255),
+    role varchar(20) not null default 'member' check (role in ('admin', 'member')),
+    created_at timestamp with time zone default current_timestamp,
+    expires_at timestamp with time zone not null,
+    accepted_at timestamp with time zone,
+    check (invitee is not null or email is not null)
+);
+
+create index if not exists group_invitation_data_invitee_idx on group_invitation_data (invitee);
+create index if not exists group_invitation_data_email_idx on group_invitation_data (lower(email));
+
+alter table expense_data
+    add column if not exists group_id uuid references group_data(id) on delete set null;
+
+create index if not exists expense_data_group_id_idx on expense_data (group_id);
@@ -0,0 +1,6 @@
+drop table if exists settlement_data cascade;
+drop table if exists expense_split_data cascade;
+
+alter table expense_data
+    drop column if exists paid_by,
+    drop column if exists split_method;
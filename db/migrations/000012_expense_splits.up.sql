@@ -0,0 +1,27 @@
+alter table expense_data
+    add column if not exists paid_by uuid references user_data(uuid) on delete set null,
+    add column if not exists split_method varchar(20) check (split_method in ('equal', 'exact', 'percentage', 'shares'));
+
+create table if not exists expense_split_data (
+    expense_id uuid references expense_data(id) on delete cascade,
+    uuid uuid references user_data(uuid) on delete cascade,
+    amount numeric(10, 2) not null,
+    primary key (expense_id, uuid)
+);
+
+create index if not exists expense_split_data_uuid_idx on expense_split_data (uuid);
+
+create table if not exists settlement_data (
+    id uuid primary key default gen_random_uuid(),
+    group_id uuid references group_data(id) on delete cascade,
+    from_uuid uuid references user_data(uuid) on delete cascade,
+    to_uuid uuid references user_data(uuid) on delete cascade,
+    amount numeric(10, 2) not null check (amount > 0),
+    currency varchar(3) not null,
+    note varchar(255),
+    created_by uuid references user_data(uuid) on delete set null,
+    created_at timestamp with time zone default current_timestamp,
+    check (from_uuid <> to_uuid)
+);
+
+create index if not exists settlement_data_group_id_idx on settlement_data (group_id);
@@ -0,0 +1,7 @@
+drop table if exists expense_item_assignment_data cascade;
+drop index if exists expense_item_data_expense_id_idx;
+
+update expense_data set split_method = null where split_method = 'items';
+alter table expense_data drop constraint if exists expense_data_split_method_check;
+alter table expense_data add constraint expense_data_split_method_check
+    check (split_method in ('equal', 'exact', 'percentage', 'shares'));
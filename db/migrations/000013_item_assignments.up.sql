@@ -0,0 +1,13 @@
+create table if not exists expense_item_assignment_data (
+    item_id uuid references expense_item_data(id) on delete cascade,
+    uuid uuid references user_data(uuid) on delete cascade,
+    created_at timestamp with time zone default current_timestamp,
+    primary key (item_id, uuid)
+);
+
+create index if not exists expense_item_assignment_data_uuid_idx on expense_item_assignment_data (uuid);
+create index if not exists expense_item_data_expense_id_idx on expense_item_data (expense_id);
+
+alter table expense_data drop constraint if exists expense_data_split_method_check;
+alter table expense_data add constraint expense_data_split_method_check
+    check (split_method in ('equal', 'exact', 'percentage', 'shares', 'items'));
@@ -0,0 +1,2 @@
+drop table if exists categorization_rule_data cascade;
+drop table if exists expense_tag_data cascade;
@@ -0,0 +1,26 @@
+create table if not exists expense_tag_data (
+    expense_id uuid references expense_data(id) on delete cascade,
+    tag varchar(50) not null,
+    primary key (expense_id, tag)
+);
+
+create index if not exists expense_tag_data_tag_idx on expense_tag_data (tag);
+
+create table if not exists categorization_rule_data (
+    id uuid primary key default gen_random_uuid(),
+    uuid uuid references user_data(uuid) on delete cascade,
+    name varchar(100) not null,
+    priority integer not null default 100,
+    merchant_contains varchar(100),
+    merchant_regex varchar(255),
+    min_amount numeric(10, 2),
+    max_amount numeric(10, 2),
+    payment_method varchar(50),
+    category varchar(50),
+    tags text[] not null default '{}',
+    enabled boolean not null default true,
+    created_at timestamp with time zone default current_timestamp,
+    updated_at timestamp with time zone default current_timestamp
+);
+
+create index if not exists categorization_rule_data_uuid_idx on categorization_rule_data (uuid, priority);
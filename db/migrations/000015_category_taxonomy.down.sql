@@ -0,0 +1,5 @@
+alter table expense_data
+    drop column if exists category_id;
+
+drop table if exists category_synonym_data cascade;
+drop table if exists category_data cascade;
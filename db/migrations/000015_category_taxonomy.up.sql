@@ -0,0 +1,233 @@
+create table if not exists category_data (
+    id uuid primary key default gen_random_uuid(),
+    uuid uuid references user_data(uuid) on delete cascade,
+    parent_id uuid references category_data(id) on delete cascade,
+    name varchar(50) not null,
+    icon varchar(50),
+    colour varchar(7),
+    created_at timestamp with time zone default current_timestamp
+);
+
+-- System categories have no owner; names are unique per owner.
+create unique index if not exists category_data_name_idx
+    on category_data (coalesce(uuid, '00000000-0000-0000-0000-000000000000'::uuid), lower(name));
+create index if not exists category_data_parent_id_idx on category_data (parent_id);
+
+create table if not exists category_synonym_data (
+    category_id uuid references category_data(id) on delete cascade,
+    synonym varchar(50) not null
+);
+
+create unique index if not exists category_synonym_data_idx on category_synonym_data (category_id, lower(synonym));
+create index if not exists category_synonym_data_synonym_idx on category_synonym_data (lower(synonym));
+
+alter table expense_data
+    add column if not exists category_id uuid references category_data(id) on delete set null;
+
+create index if not exists expense_data_category_id_idx on expense_data (category_id);
+
+-- Default hierarchy.
+insert into category_data (name, icon, colour) values
+    ('Food & Dining', 'utensils', '#E4572E'),
+    ('Transportation', 'car', '#2E86AB'),
+    ('Shopping', 'shopping-bag', '#A23B72'),
+    ('Bills & Utilities', 'receipt', '#F18F01'),
+    ('Entertainment', 'film', '#7209B7'),
+    ('Health', 'heart', '#06A77D'),
+    ('Travel', 'plane', '#118AB2'),
+    ('Personal Care', 'scissors', '#EF476F'),
+    ('Education', 'book', '#FFD166'),
+    ('Gifts & Donations', 'gift', '#D81159'),
+    ('Fees & Charges', 'percent', '#6C757D'),
+    ('Other', 'circle', '#ADB5BD');
+
+insert into category_data (parent_id, name, icon, colour)
+select p.id, c.name, c.icon, c.colour from (values
+    ('Groceries', 'shopping-basket', '#F28C28', 'Food & Dining'),
+    ('Restaurants', 'chef-hat', '#E4572E', 'Food & Dining'),
+    ('Coffee & Cafes', 'coffee', '#8B5E3C', 'Food & Dining'),
+    ('Fast Food', 'burger', '#F4A259', 'Food & Dining'),
+    ('Bars & Alcohol', 'wine', '#9B2335', 'Food & Dining'),
+    ('Fuel', 'fuel', '#1B4965', 'Transportation'),
+    ('Public Transport', 'bus', '#3A7CA5', 'Transportation'),
+    ('Taxi & Rideshare', 'taxi', '#5FA8D3', 'Transportation'),
+    ('Parking & Tolls', 'parking', '#62B6CB', 'Transportation'),
+    ('Vehicle Maintenance', 'wrench', '#1D3557', 'Transportation'),
+    ('Clothing', 'shirt', '#C05299', 'Shopping'),
+    ('Electronics', 'laptop', '#6A4C93', 'Shopping'),
+    ('Home & Garden', 'home', '#8AC926', 'Shopping'),
+    ('General Merchandise', 'store', '#B56576', 'Shopping'),
+    ('Rent & Mortgage', 'key', '#C73E1D', 'Bills & Utilities'),
+    ('Electricity & Gas', 'zap', '#F6AE2D', 'Bills & Utilities'),
+    ('Water', 'droplet', '#33A1FD', 'Bills & Utilities'),
+    ('Internet & Phone', 'wifi', '#2F4858', 'Bills & Utilities'),
+    ('Insurance', 'shield', '#86BBD8', 'Bills & Utilities'),
+    ('Movies & Events', 'ticket', '#560BAD', 'Entertainment'),
+    ('Streaming & Subscriptions', 'play', '#480CA8', 'Entertainment'),
+    ('Games & Hobbies', 'gamepad', '#3F37C9', 'Entertainment'),
+    ('Pharmacy', 'pill', '#2EC4B6', 'Health'),
+    ('Medical', 'stethoscope', '#0B6E4F', 'Health'),
+    ('Fitness', 'dumbbell', '#08A045', 'Health'),
+    ('Flights', 'plane-takeoff', '#073B4C', 'Travel'),
+    ('Lodging', 'bed', '#26547C', 'Travel')
+) as c (name, icon, colour, parent)
+join category_data p on p.uuid is null and p.name = c.parent;
+
+insert into category_synonym_data (category_id, synonym)
+select c.id, s.synonym from (values
+    ('Food & Dining', 'food'),
+    ('Groceries', 'grocery'),
+    ('Groceries', 'supermarket'),
+    ('Groceries', 'supermarkets'),
+    ('Groceries', 'food & groceries'),
+    ('Groceries', 'food and groceries'),
+    ('Restaurants', 'restaurant'),
+    ('Restaurants', 'dining'),
+    ('Restaurants', 'dining out'),
+    ('Restaurants', 'eating out'),
+    ('Restaurants', 'food & dining'),
+    ('Restaurants', 'food and dining'),
+    ('Restaurants', 'meals'),
+    ('Coffee & Cafes', 'coffee'),
+    ('Coffee & Cafes', 'cafe'),
+    ('Coffee & Cafes', 'cafes'),
+    ('Coffee & Cafes', 'coffee shop'),
+    ('Coffee & Cafes', 'coffee shops'),
+    ('Coffee & Cafes', 'bakery'),
+    ('Fast Food', 'takeaway'),
+    ('Fast Food', 'take-out'),
+    ('Fast Food', 'takeout'),
+    ('Fast Food', 'quick service'),
+    ('Bars & Alcohol', 'bar'),
+    ('Bars & Alcohol', 'bars'),
+    ('Bars & Alcohol', 'alcohol'),
+    ('Bars & Alcohol', 'pub'),
+    ('Bars & Alcohol', 'nightlife'),
+    ('Bars & Alcohol', 'liquor'),
+    ('Transportation', 'transport'),
+    ('Transportation', 'travel & transport'),
+    ('Transportation', 'auto & transport'),
+    ('Fuel', 'gas'),
+    ('Fuel', 'petrol'),
+    ('Fuel', 'gas station'),
+    ('Fuel', 'fuel station'),
+    ('Public Transport', 'transit'),
+    ('Public Transport', 'metro'),
+    ('Public Transport', 'train'),
+    ('Public Transport', 'bus'),
+    ('Public Transport', 'subway'),
+    ('Taxi & Rideshare', 'taxi'),
+    ('Taxi & Rideshare', 'cab'),
+    ('Taxi & Rideshare', 'rideshare'),
+    ('Taxi & Rideshare', 'ride share'),
+    ('Taxi & Rideshare', 'uber'),
+    ('Taxi & Rideshare', 'lyft'),
+    ('Parking & Tolls', 'parking'),
+    ('Parking & Tolls', 'tolls'),
+    ('Parking & Tolls', 'toll'),
+    ('Vehicle Maintenance', 'car maintenance'),
+    ('Vehicle Maintenance', 'auto repair'),
+    ('Vehicle Maintenance', 'car repair'),
+    ('Vehicle Maintenance', 'vehicle'),
+    ('Shopping', 'shopping & retail'),
+    ('Shopping', 'retail shopping'),
+    ('Clothing', 'clothes'),
+    ('Clothing', 'apparel'),
+    ('Clothing', 'fashion'),
+    ('Clothing', 'shoes'),
+    ('Electronics', 'electronics & software'),
+    ('Electronics', 'gadgets'),
+    ('Electronics', 'computers'),
+    ('Home & Garden', 'home'),
+    ('Home & Garden', 'household'),
+    ('Home & Garden', 'furniture'),
+    ('Home & Garden', 'garden'),
+    ('Home & Garden', 'home improvement'),
+    ('General Merchandise', 'retail'),
+    ('General Merchandise', 'department store'),
+    ('General Merchandise', 'general'),
+    ('Bills & Utilities', 'bills'),
+    ('Bills & Utilities', 'utilities'),
+    ('Rent & Mortgage', 'rent'),
+    ('Rent & Mortgage', 'mortgage'),
+    ('Rent & Mortgage', 'housing'),
+    ('Electricity & Gas', 'electricity'),
+    ('Electricity & Gas', 'power'),
+    ('Electricity & Gas', 'energy'),
+    ('Electricity & Gas', 'gas bill'),
+    ('Water', 'water bill'),
+    ('Internet & Phone', 'internet'),
+    ('Internet & Phone', 'phone'),
+    ('Internet & Phone', 'mobile'),
+    ('Internet & Phone', 'mobile phone'),
+    ('Internet & Phone', 'telecom'),
+    ('Internet & Phone', 'broadband'),
+    ('Insurance', 'insurance premium'),
+    ('Entertainment', 'leisure'),
+    ('Entertainment', 'recreation'),
+    ('Movies & Events', 'movies'),
+    ('Movies & Events', 'cinema'),
+    ('Movies & Events', 'concerts'),
+    ('Movies & Events', 'events'),
+    ('Movies & Events', 'tickets'),
+    ('Streaming & Subscriptions', 'streaming'),
+    ('Streaming & Subscriptions', 'subscriptions'),
+    ('Streaming & Subscriptions', 'subscription'),
+    ('Games & Hobbies', 'games'),
+    ('Games & Hobbies', 'gaming'),
+    ('Games & Hobbies', 'hobbies'),
+    ('Games & Hobbies', 'books'),
+    ('Health', 'health & fitness'),
+    ('Health', 'health & wellness'),
+    ('Health', 'healthcare & fitness'),
+    ('Pharmacy', 'drugstore'),
+    ('Pharmacy', 'medicine'),
+    ('Pharmacy', 'medication'),
+    ('Medical', 'doctor'),
+    ('Medical', 'hospital'),
+    ('Medical', 'dental'),
+    ('Medical', 'healthcare'),
+    ('Medical', 'health care'),
+    ('Fitness', 'gym'),
+    ('Fitness', 'sports'),
+    ('Fitness', 'fitness club'),
+    ('Travel', 'vacation'),
+    ('Travel', 'holiday'),
+    ('Flights', 'airfare'),
+    ('Flights', 'airline'),
+    ('Flights', 'airlines'),
+    ('Lodging', 'hotel'),
+    ('Lodging', 'hotels'),
+    ('Lodging', 'accommodation'),
+    ('Lodging', 'airbnb'),
+    ('Personal Care', 'beauty'),
+    ('Personal Care', 'hair'),
+    ('Personal Care', 'salon'),
+    ('Personal Care', 'spa'),
+    ('Personal Care', 'personal'),
+    ('Education', 'tuition'),
+    ('Education', 'courses'),
+    ('Education', 'school'),
+    ('Education', 'learning'),
+    ('Gifts & Donations', 'gifts'),
+    ('Gifts & Donations', 'gift'),
+    ('Gifts & Donations', 'donations'),
+    ('Gifts & Donations', 'charity'),
+    ('Fees & Charges', 'fees'),
+    ('Fees & Charges', 'bank fees'),
+    ('Fees & Charges', 'service charge'),
+    ('Fees & Charges', 'charges'),
+    ('Other', 'misc'),
+    ('Other', 'miscellaneous'),
+    ('Other', 'uncategorized'),
+    ('Other', 'unknown'),
+    ('Other', 'others')
+) as s (category, synonym)
+join category_data c on c.uuid is null and c.name = s.category;
+
+-- Map existing free-text categories onto the taxonomy.
+update expense_data e set category_id = coalesce(
+    (select c.id from category_data c where c.uuid is null and lower(c.name) = lower(trim(e.category)) limit 1),
+    (select s.category_id from category_synonym_data s join category_data c on c.id = s.category_id
+        where c.uuid is null and lower(s.synonym) = lower(trim(e.category)) limit 1),
+    (select c.id from category_data c where c.uuid is null and c.name = 'Other'));
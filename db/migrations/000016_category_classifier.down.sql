@@ -0,0 +1,6 @@
+alter table expense_data
+    drop column if exists category_source,
+    drop column if exists category_confidence;
+
+drop table if exists category_model_data cascade;
+drop table if exists category_correction_data cascade;
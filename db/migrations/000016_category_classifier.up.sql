@@ -0,0 +1,28 @@
+create table if not exists category_correction_data (
+    id uuid primary key default gen_random_uuid(),
+    uuid uuid not null references user_data(uuid) on delete cascade,
+    expense_id uuid references expense_data(id) on delete set null,
+    place varchar(100) not null,
+    amount numeric(10, 2) not null,
+    mode_of_payment varchar(50) not null,
+    date_and_time timestamp with time zone not null,
+    from_category varchar(50) not null,
+    to_category varchar(50) not null,
+    created_at timestamp with time zone default current_timestamp
+);
+
+create index if not exists category_correction_data_uuid_idx on category_correction_data (uuid, created_at);
+create index if not exists category_correction_data_expense_id_idx on category_correction_data (expense_id);
+
+-- One naive Bayes model per user, updated with every correction.
+create table if not exists category_model_data (
+    uuid uuid primary key references user_data(uuid) on delete cascade,
+    version integer not null,
+    model jsonb not null,
+    examples integer not null default 0,
+    updated_at timestamp with time zone default current_timestamp
+);
+
+alter table expense_data
+    add column if not exists category_source varchar(20),
+    add column if not exists category_confidence double precision;
@@ -0,0 +1,5 @@
+alter table expense_data
+    drop column if exists merchant_id;
+
+drop table if exists merchant_alias_data cascade;
+drop table if exists merchant_data cascade;
@@ -0,0 +1,44 @@
+create table if not exists merchant_data (
+    id uuid primary key default gen_random_uuid(),
+    uuid uuid not null references user_data(uuid) on delete cascade,
+    name varchar(100) not null,
+    location varchar(200),
+    default_category varchar(50),
+    created_at timestamp with time zone default current_timestamp,
+    updated_at timestamp with time zone default current_timestamp
+);
+
+create index if not exists merchant_data_uuid_idx on merchant_data (uuid);
+
+-- Aliases are normalized merchant names: lower-case letters and single spaces.
+create table if not exists merchant_alias_data (
+    merchant_id uuid not null references merchant_data(id) on delete cascade,
+    uuid uuid not null references user_data(uuid) on delete cascade,
+    alias varchar(100) not null,
+    primary key (uuid, alias)
+);
+
+create index if not exists merchant_alias_data_merchant_id_idx on merchant_alias_data (merchant_id);
+
+alter table expense_data
+    add column if not exists merchant_id uuid references merchant_data(id) on delete set null;
+
+create index if not exists expense_data_merchant_id_idx on expense_data (merchant_id);
+
+-- One merchant per distinct normalized place of each user.
+insert into merchant_data (uuid, name)
+select distinct on (uuid, alias) uuid, trim(place)
+from (
+    select uuid, place, trim(regexp_replace(lower(place), '[^[:alpha:]]+', ' ', 'g')) as alias
+    from expense_data where uuid is not null
+) e
+where alias <> ''
+order by uuid, alias, place;
+
+insert into merchant_alias_data (merchant_id, uuid, alias)
+select id, uuid, trim(regexp_replace(lower(name), '[^[:alpha:]]+', ' ', 'g')) from merchant_data
+on conflict do nothing;
+
+update expense_data e set merchant_id = a.merchant_id
+from merchant_alias_data a
+where a.uuid = e.uuid and a.alias = trim(regexp_replace(lower(e.place), '[^[:alpha:]]+', ' ', 'g'));
@@ -0,0 +1,5 @@
+drop table if exists ingested_message_data cascade;
+drop table if exists message_consent_data cascade;
+
+alter table expense_data
+    drop column if exists source;
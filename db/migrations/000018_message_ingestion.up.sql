@@ -0,0 +1,31 @@
+alter table expense_data
+    add column if not exists source varchar(20);
+
+update expense_data e set source = case
+    when exists (select 1 from receipt_data r where r.expense_id = e.id) then 'receipt'
+    else 'manual'
+end
+where source is null;
+
+-- Explicit consent to read a user's messages, per channel. Revoking keeps
+-- the row as a record of when consent was given.
+create table if not exists message_consent_data (
+    uuid uuid not null references user_data(uuid) on delete cascade,
+    channel varchar(20) not null check (channel in ('sms')),
+    granted_at timestamp with time zone not null default current_timestamp,
+    revoked_at timestamp with time zone,
+    primary key (uuid, channel)
+);
+
+-- Every message handed to ingestion, identified by a hash or message id,
+-- so that re-sent messages are not recorded twice. Message bodies are not
+-- kept.
+create table if not exists ingested_message_data (
+    uuid uuid not null references user_data(uuid) on delete cascade,
+    channel varchar(20) not null,
+    message_key varchar(255) not null,
+    status varchar(20) not null,
+    expense_id uuid references expense_data(id) on delete set null,
+    created_at timestamp with time zone default current_timestamp,
+    primary key (uuid, channel, message_key)
+);
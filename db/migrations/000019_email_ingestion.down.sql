@@ -0,0 +1,11 @@
+drop table if exists inbound_address_data cascade;
+drop table if exists mailbox_data cascade;
+
+delete from ingested_message_data where channel <> 'sms';
+delete from message_consent_data where channel <> 'sms';
+
+alter table message_consent_data
+    drop constraint if exists message_consent_data_channel_check;
+
+alter table message_consent_data
+    add constraint message_consent_data_channel_check check (channel in ('sms'));
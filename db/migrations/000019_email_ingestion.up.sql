@@ -0,0 +1,30 @@
+alter table message_consent_data
+    drop constraint if exists message_consent_data_channel_check;
+
+alter table message_consent_data
+    add constraint message_consent_data_channel_check check (channel in ('sms', 'email'));
+
+-- A mailbox the user has authorised us to poll over IMAP. The password is
+-- sealed with MAILBOX_SECRET_KEY; last_uid is the highest UID read under
+-- uid_validity.
+create table if not exists mailbox_data (
+    uuid uuid primary key references user_data(uuid) on delete cascade,
+    host varchar(255) not null,
+    port integer not null check (port between 1 and 65535),
+    username varchar(255) not null,
+    password_secret text not null,
+    mailbox varchar(255) not null default 'INBOX',
+    insecure boolean not null default false,
+    uid_validity bigint not null default 0,
+    last_uid bigint not null default 0,
+    connected_at timestamp with time zone not null default current_timestamp,
+    last_polled_at timestamp with time zone,
+    last_error text
+);
+
+-- The per-user token in the address inbound mail is delivered to.
+create table if not exists inbound_address_data (
+    uuid uuid primary key references user_data(uuid) on delete cascade,
+    token varchar(64) not null unique,
+    created_at timestamp with time zone default current_timestamp
+);
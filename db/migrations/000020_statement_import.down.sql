@@ -0,0 +1,6 @@
+drop table if exists import_profile_data cascade;
+
+drop index if exists expense_data_import_key_idx;
+
+alter table expense_data
+    drop column if exists import_key;
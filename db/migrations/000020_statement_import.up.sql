@@ -0,0 +1,30 @@
+-- The statement line an expense was imported from: "fitid:<account>:<fitid>"
+-- when the bank gave the transaction an id, otherwise a hash of the line.
+alter table expense_data
+    add column if not exists import_key varchar(255);
+
+create unique index if not exists expense_data_import_key_idx
+    on expense_data (uuid, import_key) where import_key is not null;
+
+-- How to read one bank's CSV exports. Columns are header names, or 1-based
+-- positions when the export has no header.
+create table if not exists import_profile_data (
+    id uuid primary key default gen_random_uuid(),
+    uuid uuid not null references user_data(uuid) on delete cascade,
+    name varchar(100) not null,
+    delimiter varchar(5) not null default ',',
+    skip_rows integer not null default 0,
+    no_header boolean not null default false,
+    date_column varchar(100) not null,
+    date_format varchar(50),
+    description_column varchar(100) not null,
+    amount_column varchar(100),
+    debit_column varchar(100),
+    credit_column varchar(100),
+    currency_column varchar(100),
+    reference_column varchar(100),
+    debits_positive boolean not null default false,
+    decimal_comma boolean not null default false,
+    created_at timestamp with time zone default current_timestamp,
+    unique (uuid, name)
+);
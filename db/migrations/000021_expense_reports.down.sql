@@ -0,0 +1,3 @@
+drop table if exists expense_report_comment_data cascade;
+drop table if exists expense_report_line_data cascade;
+drop table if exists expense_report_data cascade;
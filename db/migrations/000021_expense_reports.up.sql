@@ -0,0 +1,50 @@
+-- A claim for reimbursement of expenses the submitter paid for. status is
+-- draft, submitted, approved, rejected or reimbursed; a rejected report can
+-- be edited and submitted again.
+create table if not exists expense_report_data (
+    id uuid primary key default gen_random_uuid(),
+    uuid uuid not null references user_data(uuid) on delete cascade,
+    title varchar(200) not null,
+    purpose text,
+    approver uuid references user_data(uuid) on delete set null,
+    status varchar(20) not null default 'draft'
+        check (status in ('draft', 'submitted', 'approved', 'rejected', 'reimbursed')),
+    submitted_at timestamp with time zone,
+    decided_at timestamp with time zone,
+    reimbursed_at timestamp with time zone,
+    reimbursement_reference varchar(100),
+    created_at timestamp with time zone default current_timestamp,
+    updated_at timestamp with time zone default current_timestamp
+);
+
+create index if not exists expense_report_data_uuid_idx on expense_report_data (uuid);
+create index if not exists expense_report_data_approver_idx on expense_report_data (approver);
+
+-- An expense claimed on a report. amount and currency are copied from the
+-- expense when the report is submitted, so later edits to the expense do
+-- not change what was approved. An expense is claimed on one report at most.
+create table if not exists expense_report_line_data (
+    id uuid primary key default gen_random_uuid(),
+    report_id uuid not null references expense_report_data(id) on delete cascade,
+    expense_id uuid not null unique references expense_data(id) on delete cascade,
+    amount numeric(10, 2),
+    currency varchar(3),
+    status varchar(20) not null default 'pending'
+        check (status in ('pending', 'approved', 'rejected')),
+    decided_at timestamp with time zone,
+    created_at timestamp with time zone default current_timestamp
+);
+
+create index if not exists expense_report_line_data_report_idx on expense_report_line_data (report_id);
+
+-- Comments by the submitter or approver on a report or on one of its lines.
+create table if not exists expense_report_comment_data (
+    id uuid primary key default gen_random_uuid(),
+    report_id uuid not null references expense_report_data(id) on delete cascade,
+    line_id uuid references expense_report_line_data(id) on delete cascade,
+    author uuid references user_data(uuid) on delete set null,
+    body text not null,
+    created_at timestamp with time zone default current_timestamp
+);
+
+create index if not exists expense_report_comment_data_report_idx on expense_report_comment_data (report_id);
@@ -0,0 +1,5 @@
+drop table if exists expense_custom_field_data cascade;
+drop table if exists custom_field_data cascade;
+
+alter table expense_data
+    drop column if exists notes;
@@ -0,0 +1,26 @@
+alter table expense_data
+    add column if not exists notes text;
+
+-- Fields a user adds to their expenses, such as a project code or client.
+-- options lists the allowed values of an enum field.
+create table if not exists custom_field_data (
+    id uuid primary key default gen_random_uuid(),
+    uuid uuid not null references user_data(uuid) on delete cascade,
+    name varchar(50) not null,
+    field_type varchar(10) not null check (field_type in ('text', 'number', 'date', 'enum')),
+    options text[] not null default '{}',
+    created_at timestamp with time zone default current_timestamp
+);
+
+create unique index if not exists custom_field_data_name_idx on custom_field_data (uuid, lower(name));
+
+-- Values are stored as text: numbers in their shortest decimal form and
+-- dates as YYYY-MM-DD, so equal values compare equal.
+create table if not exists expense_custom_field_data (
+    expense_id uuid not null references expense_data(id) on delete cascade,
+    field_id uuid not null references custom_field_data(id) on delete cascade,
+    value varchar(200) not null,
+    primary key (expense_id, field_id)
+);
+
+create index if not exists expense_custom_field_data_value_idx on expense_custom_field_data (field_id, value);
@@ -0,0 +1,12 @@
+drop trigger if exists expense_item_data_search_trigger on expense_item_data;
+drop trigger if exists expense_tag_data_search_trigger on expense_tag_data;
+drop trigger if exists expense_data_search_trigger on expense_data;
+
+drop function if exists expense_search_refresh_parent();
+drop function if exists expense_search_refresh();
+drop function if exists expense_search_vector(uuid, text, text);
+
+drop index if exists expense_data_search_idx;
+
+alter table expense_data
+    drop column if exists search_vector;
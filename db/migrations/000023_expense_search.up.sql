@@ -0,0 +1,50 @@
+-- search_vector indexes an expense's merchant, tags, line item names and
+-- notes, weighted in that order. Triggers keep it current as any of them
+-- change.
+alter table expense_data
+    add column if not exists search_vector tsvector;
+
+create or replace function expense_search_vector(expense uuid, place text, notes text) returns tsvector as $$
+    select setweight(to_tsvector('english', coalesce(place, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce((select string_agg(tag, ' ') from expense_tag_data where expense_id = expense), '')), 'B') ||
+        setweight(to_tsvector('english', coalesce((select string_agg(item_name, ' ') from expense_item_data where expense_id = expense), '')), 'C') ||
+        setweight(to_tsvector('english', coalesce(notes, '')), 'D');
+$$ language sql stable;
+
+create or replace function expense_search_refresh() returns trigger as $$
+begin
+    new.search_vector := expense_search_vector(new.id, new.place, new.notes);
+    return new;
+end;
+$$ language plpgsql;
+
+create or replace function expense_search_refresh_parent() returns trigger as $$
+begin
+    if tg_op <> 'INSERT' then
+        update expense_data set search_vector = expense_search_vector(id, place, notes) where id = old.expense_id;
+    end if;
+    if tg_op <> 'DELETE' then
+        update expense_data set search_vector = expense_search_vector(id, place, notes) where id = new.expense_id;
+    end if;
+    return null;
+end;
+$$ language plpgsql;
+
+drop trigger if exists expense_data_search_trigger on expense_data;
+create trigger expense_data_search_trigger
+    before insert or update of place, notes on expense_data
+    for each row execute function expense_search_refresh();
+
+drop trigger if exists expense_tag_data_search_trigger on expense_tag_data;
+create trigger expense_tag_data_search_trigger
+    after insert or update or delete on expense_tag_data
+    for each row execute function expense_search_refresh_parent();
+
+drop trigger if exists expense_item_data_search_trigger on expense_item_data;
+create trigger expense_item_data_search_trigger
+    after insert or update of item_name, expense_id or delete on expense_item_data
+    for each row execute function expense_search_refresh_parent();
+
+update expense_data set search_vector = expense_search_vector(id, place, notes);
+
+create index if not exists expense_data_search_idx on expense_data using gin (search_vector);
@@ -0,0 +1,5 @@
+alter table expense_data
+    drop column if exists trip_manual,
+    drop column if exists trip_id;
+
+drop table if exists trip_data cascade;
@@ -0,0 +1,29 @@
+-- A trip or event collects the expenses made during it. Personal trips
+-- collect the user's own expenses and group trips the group's. Dates are
+-- inclusive and compared in the time zone of whoever recorded the expense.
+-- exchange_rate is how much home_currency one unit of foreign_currency buys.
+create table if not exists trip_data (
+    id uuid primary key default gen_random_uuid(),
+    uuid uuid not null references user_data(uuid) on delete cascade,
+    group_id uuid references group_data(id) on delete cascade,
+    name varchar(100) not null,
+    starts_on date not null,
+    ends_on date not null,
+    budget numeric(12, 2),
+    home_currency varchar(3) not null,
+    foreign_currency varchar(3),
+    exchange_rate double precision,
+    created_at timestamp with time zone default current_timestamp,
+    check (ends_on >= starts_on)
+);
+
+create index if not exists trip_data_uuid_idx on trip_data (uuid);
+create index if not exists trip_data_group_id_idx on trip_data (group_id);
+
+-- trip_manual marks an expense whose trip was picked by hand, or that was
+-- taken out of a trip, so that it is left alone by automatic assignment.
+alter table expense_data
+    add column if not exists trip_id uuid references trip_data(id) on delete set null,
+    add column if not exists trip_manual boolean not null default false;
+
+create index if not exists expense_data_trip_id_idx on expense_data (trip_id);
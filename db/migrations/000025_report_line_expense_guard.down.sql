@@ -0,0 +1,4 @@
+alter table expense_report_line_data
+    drop constraint if exists expense_report_line_data_expense_id_fkey,
+    add constraint expense_report_line_data_expense_id_fkey
+        foreign key (expense_id) references expense_data(id) on delete cascade;
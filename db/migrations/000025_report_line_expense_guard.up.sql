@@ -0,0 +1,8 @@
+-- An expense claimed on a report can no longer be deleted on its own, so a
+-- decided report cannot lose lines. No action is checked at the end of the
+-- statement, so deleting a user still removes their reports and expenses
+-- together.
+alter table expense_report_line_data
+    drop constraint if exists expense_report_line_data_expense_id_fkey,
+    add constraint expense_report_line_data_expense_id_fkey
+        foreign key (expense_id) references expense_data(id) on delete no action;
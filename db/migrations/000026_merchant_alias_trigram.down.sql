@@ -0,0 +1 @@
+drop index if exists merchant_alias_data_alias_trgm_idx;
@@ -0,0 +1,4 @@
+create extension if not exists pg_trgm;
+
+-- Places that match no alias exactly are looked up by trigram similarity.
+create index if not exists merchant_alias_data_alias_trgm_idx on merchant_alias_data using gin (alias gin_trgm_ops);
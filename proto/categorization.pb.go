@@ -0,0 +1,1497 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.21.12
+// source: proto/categorization.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Every condition that is set must match. merchant_contains is a
+// case-insensitive substring and merchant_regex a case-insensitive RE2
+// expression, both matched against the merchant name.
+type CategorizationRule struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Priority         int32                  `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	MerchantContains string                 `protobuf:"bytes,4,opt,name=merchant_contains,json=merchantContains,proto3" json:"merchant_contains,omitempty"`
+	MerchantRegex    string                 `protobuf:"bytes,5,opt,name=merchant_regex,json=merchantRegex,proto3" json:"merchant_regex,omitempty"`
+	MinAmount        *float64               `protobuf:"fixed64,6,opt,name=min_amount,json=minAmount,proto3,oneof" json:"min_amount,omitempty"`
+	MaxAmount        *float64               `protobuf:"fixed64,7,opt,name=max_amount,json=maxAmount,proto3,oneof" json:"max_amount,omitempty"`
+	PaymentMethod    string                 `protobuf:"bytes,8,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	Category         string                 `protobuf:"bytes,9,opt,name=category,proto3" json:"category,omitempty"`
+	Tags             []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	Enabled          *bool                  `protobuf:"varint,11,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	CreatedAt        string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CategorizationRule) Reset() {
+	*x = CategorizationRule{}
+	mi := &file_proto_categorization_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorizationRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorizationRule) ProtoMessage() {}
+
+func (x *CategorizationRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorizationRule.ProtoReflect.Descriptor instead.
+func (*CategorizationRule) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CategorizationRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CategorizationRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategorizationRule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *CategorizationRule) GetMerchantContains() string {
+	if x != nil {
+		return x.MerchantContains
+	}
+	return ""
+}
+
+func (x *CategorizationRule) GetMerchantRegex() string {
+	if x != nil {
+		return x.MerchantRegex
+	}
+	return ""
+}
+
+func (x *CategorizationRule) GetMinAmount() float64 {
+	if x != nil && x.MinAmount != nil {
+		return *x.MinAmount
+	}
+	return 0
+}
+
+func (x *CategorizationRule) GetMaxAmount() float64 {
+	if x != nil && x.MaxAmount != nil {
+		return *x.MaxAmount
+	}
+	return 0
+}
+
+func (x *CategorizationRule) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *CategorizationRule) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategorizationRule) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CategorizationRule) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *CategorizationRule) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CreateRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *CategorizationRule    `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRuleRequest) Reset() {
+	*x = CreateRuleRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRuleRequest) ProtoMessage() {}
+
+func (x *CreateRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRuleRequest) GetRule() *CategorizationRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type CreateRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *CategorizationRule    `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRuleResponse) Reset() {
+	*x = CreateRuleResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRuleResponse) ProtoMessage() {}
+
+func (x *CreateRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateRuleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateRuleResponse) GetRule() *CategorizationRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// Rules are listed in the order they are tried: by priority, then oldest
+// first. order_by can only be "priority", and pages hold up to 200 rules,
+// 200 by default, which is all a user can have.
+type ListRulesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PageSize          int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken         string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,4,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListRulesRequest) Reset() {
+	*x = ListRulesRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRulesRequest) ProtoMessage() {}
+
+func (x *ListRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListRulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListRulesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRulesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRulesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListRulesRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+type ListRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*CategorizationRule  `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRulesResponse) Reset() {
+	*x = ListRulesResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRulesResponse) ProtoMessage() {}
+
+func (x *ListRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListRulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListRulesResponse) GetRules() []*CategorizationRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *ListRulesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListRulesResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// UpdateRule replaces every field of the rule with the given id, except
+// enabled, which is kept when left unset.
+type UpdateRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *CategorizationRule    `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRuleRequest) Reset() {
+	*x = UpdateRuleRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRuleRequest) ProtoMessage() {}
+
+func (x *UpdateRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRuleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateRuleRequest) GetRule() *CategorizationRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type UpdateRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *CategorizationRule    `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRuleResponse) Reset() {
+	*x = UpdateRuleResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRuleResponse) ProtoMessage() {}
+
+func (x *UpdateRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRuleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRuleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateRuleResponse) GetRule() *CategorizationRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type DeleteRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRuleRequest) Reset() {
+	*x = DeleteRuleRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRuleRequest) ProtoMessage() {}
+
+func (x *DeleteRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRuleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteRuleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRuleResponse) Reset() {
+	*x = DeleteRuleResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRuleResponse) ProtoMessage() {}
+
+func (x *DeleteRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRuleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteRuleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ApplyRulesToHistory re-runs the rules over the caller's recorded
+// expenses, optionally only those since the given RFC 3339 time. Expenses no
+// rule matches, those whose category the user set by hand and those on a
+// submitted, approved or reimbursed expense report keep their category.
+type ApplyRulesToHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         string                 `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyRulesToHistoryRequest) Reset() {
+	*x = ApplyRulesToHistoryRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyRulesToHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyRulesToHistoryRequest) ProtoMessage() {}
+
+func (x *ApplyRulesToHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyRulesToHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ApplyRulesToHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApplyRulesToHistoryRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+type ApplyRulesToHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scanned       int32                  `protobuf:"varint,1,opt,name=scanned,proto3" json:"scanned,omitempty"`
+	Updated       int32                  `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyRulesToHistoryResponse) Reset() {
+	*x = ApplyRulesToHistoryResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyRulesToHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyRulesToHistoryResponse) ProtoMessage() {}
+
+func (x *ApplyRulesToHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyRulesToHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ApplyRulesToHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ApplyRulesToHistoryResponse) GetScanned() int32 {
+	if x != nil {
+		return x.Scanned
+	}
+	return 0
+}
+
+func (x *ApplyRulesToHistoryResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+// Category is a node of the two-level taxonomy. System categories are shared
+// by everyone; custom ones belong to the caller. Free-text categories from
+// receipts, rules and manual entry are mapped onto the taxonomy by name or
+// synonym, the caller's own categories first.
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      string                 `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Icon          string                 `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
+	Colour        string                 `protobuf:"bytes,5,opt,name=colour,proto3" json:"colour,omitempty"`
+	Custom        bool                   `protobuf:"varint,6,opt,name=custom,proto3" json:"custom,omitempty"`
+	Synonyms      []string               `protobuf:"bytes,7,rep,name=synonyms,proto3" json:"synonyms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_proto_categorization_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Category) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Category) GetColour() string {
+	if x != nil {
+		return x.Colour
+	}
+	return ""
+}
+
+func (x *Category) GetCustom() bool {
+	if x != nil {
+		return x.Custom
+	}
+	return false
+}
+
+func (x *Category) GetSynonyms() []string {
+	if x != nil {
+		return x.Synonyms
+	}
+	return nil
+}
+
+// ListCategories is not paginated: it returns the taxonomy as one tree, the
+// system categories and at most 100 custom ones, and a page could split a
+// parent from its children.
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{12}
+}
+
+// Parents are listed before their children.
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// parent_id must name a top-level category, system or custom.
+type CreateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateCategoryRequest) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type CreateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryResponse) Reset() {
+	*x = CreateCategoryResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryResponse) ProtoMessage() {}
+
+func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*CreateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+// UpdateCategory replaces every field of the custom category with the given
+// id.
+type UpdateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryRequest) ProtoMessage() {}
+
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateCategoryRequest) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type UpdateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryResponse) Reset() {
+	*x = UpdateCategoryResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryResponse) ProtoMessage() {}
+
+func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+// Expenses filed under a deleted category fall back to being matched by
+// their free-text category.
+type DeleteCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryRequest) Reset() {
+	*x = DeleteCategoryRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryRequest) ProtoMessage() {}
+
+func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryResponse) Reset() {
+	*x = DeleteCategoryResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryResponse) ProtoMessage() {}
+
+func (x *DeleteCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteCategoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// PredictCategory runs the caller's personal classifier, which learns from
+// every category the caller corrects with UpdateExpense.
+type PredictCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Place         string                 `protobuf:"bytes,1,opt,name=place,proto3" json:"place,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	ModeOfPayment string                 `protobuf:"bytes,3,opt,name=mode_of_payment,json=modeOfPayment,proto3" json:"mode_of_payment,omitempty"`
+	DateAndTime   string                 `protobuf:"bytes,4,opt,name=date_and_time,json=dateAndTime,proto3" json:"date_and_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictCategoryRequest) Reset() {
+	*x = PredictCategoryRequest{}
+	mi := &file_proto_categorization_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictCategoryRequest) ProtoMessage() {}
+
+func (x *PredictCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictCategoryRequest.ProtoReflect.Descriptor instead.
+func (*PredictCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PredictCategoryRequest) GetPlace() string {
+	if x != nil {
+		return x.Place
+	}
+	return ""
+}
+
+func (x *PredictCategoryRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PredictCategoryRequest) GetModeOfPayment() string {
+	if x != nil {
+		return x.ModeOfPayment
+	}
+	return ""
+}
+
+func (x *PredictCategoryRequest) GetDateAndTime() string {
+	if x != nil {
+		return x.DateAndTime
+	}
+	return ""
+}
+
+type CategoryPrediction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,2,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryPrediction) Reset() {
+	*x = CategoryPrediction{}
+	mi := &file_proto_categorization_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryPrediction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryPrediction) ProtoMessage() {}
+
+func (x *CategoryPrediction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryPrediction.ProtoReflect.Descriptor instead.
+func (*CategoryPrediction) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CategoryPrediction) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategoryPrediction) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+// applied tells whether the top prediction is confident enough to override
+// the category extracted from a receipt.
+type PredictCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Predictions   []*CategoryPrediction  `protobuf:"bytes,1,rep,name=predictions,proto3" json:"predictions,omitempty"`
+	Examples      int32                  `protobuf:"varint,2,opt,name=examples,proto3" json:"examples,omitempty"`
+	Applied       bool                   `protobuf:"varint,3,opt,name=applied,proto3" json:"applied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PredictCategoryResponse) Reset() {
+	*x = PredictCategoryResponse{}
+	mi := &file_proto_categorization_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredictCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictCategoryResponse) ProtoMessage() {}
+
+func (x *PredictCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_categorization_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictCategoryResponse.ProtoReflect.Descriptor instead.
+func (*PredictCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_categorization_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PredictCategoryResponse) GetPredictions() []*CategoryPrediction {
+	if x != nil {
+		return x.Predictions
+	}
+	return nil
+}
+
+func (x *PredictCategoryResponse) GetExamples() int32 {
+	if x != nil {
+		return x.Examples
+	}
+	return 0
+}
+
+func (x *PredictCategoryResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+var File_proto_categorization_proto protoreflect.FileDescriptor
+
+const file_proto_categorization_proto_rawDesc = "" +
+	"\n" +
+	"\x1aproto/categorization.proto\"\xaf\x03\n" +
+	"\x12CategorizationRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x05R\bpriority\x12+\n" +
+	"\x11merchant_contains\x18\x04 \x01(\tR\x10merchantContains\x12%\n" +
+	"\x0emerchant_regex\x18\x05 \x01(\tR\rmerchantRegex\x12\"\n" +
+	"\n" +
+	"min_amount\x18\x06 \x01(\x01H\x00R\tminAmount\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_amount\x18\a \x01(\x01H\x01R\tmaxAmount\x88\x01\x01\x12%\n" +
+	"\x0epayment_method\x18\b \x01(\tR\rpaymentMethod\x12\x1a\n" +
+	"\bcategory\x18\t \x01(\tR\bcategory\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12\x1d\n" +
+	"\aenabled\x18\v \x01(\bH\x02R\aenabled\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAtB\r\n" +
+	"\v_min_amountB\r\n" +
+	"\v_max_amountB\n" +
+	"\n" +
+	"\b_enabled\"<\n" +
+	"\x11CreateRuleRequest\x12'\n" +
+	"\x04rule\x18\x01 \x01(\v2\x13.CategorizationRuleR\x04rule\"=\n" +
+	"\x12CreateRuleResponse\x12'\n" +
+	"\x04rule\x18\x01 \x01(\v2\x13.CategorizationRuleR\x04rule\"\x99\x01\n" +
+	"\x10ListRulesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12.\n" +
+	"\x13include_total_count\x18\x04 \x01(\bR\x11includeTotalCount\"\x87\x01\n" +
+	"\x11ListRulesResponse\x12)\n" +
+	"\x05rules\x18\x01 \x03(\v2\x13.CategorizationRuleR\x05rules\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"<\n" +
+	"\x11UpdateRuleRequest\x12'\n" +
+	"\x04rule\x18\x01 \x01(\v2\x13.CategorizationRuleR\x04rule\"=\n" +
+	"\x12UpdateRuleResponse\x12'\n" +
+	"\x04rule\x18\x01 \x01(\v2\x13.CategorizationRuleR\x04rule\"#\n" +
+	"\x11DeleteRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\".\n" +
+	"\x12DeleteRuleResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"2\n" +
+	"\x1aApplyRulesToHistoryRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\tR\x05since\"Q\n" +
+	"\x1bApplyRulesToHistoryResponse\x12\x18\n" +
+	"\ascanned\x18\x01 \x01(\x05R\ascanned\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x05R\aupdated\"\xab\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\x12\x12\n" +
+	"\x04icon\x18\x04 \x01(\tR\x04icon\x12\x16\n" +
+	"\x06colour\x18\x05 \x01(\tR\x06colour\x12\x16\n" +
+	"\x06custom\x18\x06 \x01(\bR\x06custom\x12\x1a\n" +
+	"\bsynonyms\x18\a \x03(\tR\bsynonyms\"\x17\n" +
+	"\x15ListCategoriesRequest\"C\n" +
+	"\x16ListCategoriesResponse\x12)\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\t.CategoryR\n" +
+	"categories\">\n" +
+	"\x15CreateCategoryRequest\x12%\n" +
+	"\bcategory\x18\x01 \x01(\v2\t.CategoryR\bcategory\"?\n" +
+	"\x16CreateCategoryResponse\x12%\n" +
+	"\bcategory\x18\x01 \x01(\v2\t.CategoryR\bcategory\">\n" +
+	"\x15UpdateCategoryRequest\x12%\n" +
+	"\bcategory\x18\x01 \x01(\v2\t.CategoryR\bcategory\"?\n" +
+	"\x16UpdateCategoryResponse\x12%\n" +
+	"\bcategory\x18\x01 \x01(\v2\t.CategoryR\bcategory\"'\n" +
+	"\x15DeleteCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x16DeleteCategoryResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\x92\x01\n" +
+	"\x16PredictCategoryRequest\x12\x14\n" +
+	"\x05place\x18\x01 \x01(\tR\x05place\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12&\n" +
+	"\x0fmode_of_payment\x18\x03 \x01(\tR\rmodeOfPayment\x12\"\n" +
+	"\rdate_and_time\x18\x04 \x01(\tR\vdateAndTime\"P\n" +
+	"\x12CategoryPrediction\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x02 \x01(\x01R\n" +
+	"confidence\"\x86\x01\n" +
+	"\x17PredictCategoryResponse\x125\n" +
+	"\vpredictions\x18\x01 \x03(\v2\x13.CategoryPredictionR\vpredictions\x12\x1a\n" +
+	"\bexamples\x18\x02 \x01(\x05R\bexamples\x12\x18\n" +
+	"\aapplied\x18\x03 \x01(\bR\aapplied2\x94\x05\n" +
+	"\x15CategorizationService\x125\n" +
+	"\n" +
+	"CreateRule\x12\x12.CreateRuleRequest\x1a\x13.CreateRuleResponse\x122\n" +
+	"\tListRules\x12\x11.ListRulesRequest\x1a\x12.ListRulesResponse\x125\n" +
+	"\n" +
+	"UpdateRule\x12\x12.UpdateRuleRequest\x1a\x13.UpdateRuleResponse\x125\n" +
+	"\n" +
+	"DeleteRule\x12\x12.DeleteRuleRequest\x1a\x13.DeleteRuleResponse\x12P\n" +
+	"\x13ApplyRulesToHistory\x12\x1b.ApplyRulesToHistoryRequest\x1a\x1c.ApplyRulesToHistoryResponse\x12A\n" +
+	"\x0eListCategories\x12\x16.ListCategoriesRequest\x1a\x17.ListCategoriesResponse\x12A\n" +
+	"\x0eCreateCategory\x12\x16.CreateCategoryRequest\x1a\x17.CreateCategoryResponse\x12A\n" +
+	"\x0eUpdateCategory\x12\x16.UpdateCategoryRequest\x1a\x17.UpdateCategoryResponse\x12A\n" +
+	"\x0eDeleteCategory\x12\x16.DeleteCategoryRequest\x1a\x17.DeleteCategoryResponse\x12D\n" +
+	"\x0fPredictCategory\x12\x17.PredictCategoryRequest\x1a\x18.PredictCategoryResponseB+Z)github.com/barathsurya2004/expenses/protob\x06proto3"
+
+var (
+	file_proto_categorization_proto_rawDescOnce sync.Once
+	file_proto_categorization_proto_rawDescData []byte
+)
+
+func file_proto_categorization_proto_rawDescGZIP() []byte {
+	file_proto_categorization_proto_rawDescOnce.Do(func() {
+		file_proto_categorization_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_categorization_proto_rawDesc), len(file_proto_categorization_proto_rawDesc)))
+	})
+	return file_proto_categorization_proto_rawDescData
+}
+
+var file_proto_categorization_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_proto_categorization_proto_goTypes = []any{
+	(*CategorizationRule)(nil),          // 0: CategorizationRule
+	(*CreateRuleRequest)(nil),           // 1: CreateRuleRequest
+	(*CreateRuleResponse)(nil),          // 2: CreateRuleResponse
+	(*ListRulesRequest)(nil),            // 3: ListRulesRequest
+	(*ListRulesResponse)(nil),           // 4: ListRulesResponse
+	(*UpdateRuleRequest)(nil),           // 5: UpdateRuleRequest
+	(*UpdateRuleResponse)(nil),          // 6: UpdateRuleResponse
+	(*DeleteRuleRequest)(nil),           // 7: DeleteRuleRequest
+	(*DeleteRuleResponse)(nil),          // 8: DeleteRuleResponse
+	(*ApplyRulesToHistoryRequest)(nil),  // 9: ApplyRulesToHistoryRequest
+	(*ApplyRulesToHistoryResponse)(nil), // 10: ApplyRulesToHistoryResponse
+	(*Category)(nil),                    // 11: Category
+	(*ListCategoriesRequest)(nil),       // 12: ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),      // 13: ListCategoriesResponse
+	(*CreateCategoryRequest)(nil),       // 14: CreateCategoryRequest
+	(*CreateCategoryResponse)(nil),      // 15: CreateCategoryResponse
+	(*UpdateCategoryRequest)(nil),       // 16: UpdateCategoryRequest
+	(*UpdateCategoryResponse)(nil),      // 17: UpdateCategoryResponse
+	(*DeleteCategoryRequest)(nil),       // 18: DeleteCategoryRequest
+	(*DeleteCategoryResponse)(nil),      // 19: DeleteCategoryResponse
+	(*PredictCategoryRequest)(nil),      // 20: PredictCategoryRequest
+	(*CategoryPrediction)(nil),          // 21: CategoryPrediction
+	(*PredictCategoryResponse)(nil),     // 22: PredictCategoryResponse
+}
+var file_proto_categorization_proto_depIdxs = []int32{
+	0,  // 0: CreateRuleRequest.rule:type_name -> CategorizationRule
+	0,  // 1: CreateRuleResponse.rule:type_name -> CategorizationRule
+	0,  // 2: ListRulesResponse.rules:type_name -> CategorizationRule
+	0,  // 3: UpdateRuleRequest.rule:type_name -> CategorizationRule
+	0,  // 4: UpdateRuleResponse.rule:type_name -> CategorizationRule
+	11, // 5: ListCategoriesResponse.categories:type_name -> Category
+	11, // 6: CreateCategoryRequest.category:type_name -> Category
+	11, // 7: CreateCategoryResponse.category:type_name -> Category
+	11, // 8: UpdateCategoryRequest.category:type_name -> Category
+	11, // 9: UpdateCategoryResponse.category:type_name -> Category
+	21, // 10: PredictCategoryResponse.predictions:type_name -> CategoryPrediction
+	1,  // 11: CategorizationService.CreateRule:input_type -> CreateRuleRequest
+	3,  // 12: CategorizationService.ListRules:input_type -> ListRulesRequest
+	5,  // 13: CategorizationService.UpdateRule:input_type -> UpdateRuleRequest
+	7,  // 14: CategorizationService.DeleteRule:input_type -> DeleteRuleRequest
+	9,  // 15: CategorizationService.ApplyRulesToHistory:input_type -> ApplyRulesToHistoryRequest
+	12, // 16: CategorizationService.ListCategories:input_type -> ListCategoriesRequest
+	14, // 17: CategorizationService.CreateCategory:input_type -> CreateCategoryRequest
+	16, // 18: CategorizationService.UpdateCategory:input_type -> UpdateCategoryRequest
+	18, // 19: CategorizationService.DeleteCategory:input_type -> DeleteCategoryRequest
+	20, // 20: CategorizationService.PredictCategory:input_type -> PredictCategoryRequest
+	2,  // 21: CategorizationService.CreateRule:output_type -> CreateRuleResponse
+	4,  // 22: CategorizationService.ListRules:output_type -> ListRulesResponse
+	6,  // 23: CategorizationService.UpdateRule:output_type -> UpdateRuleResponse
+	8,  // 24: CategorizationService.DeleteRule:output_type -> DeleteRuleResponse
+	10, // 25: CategorizationService.ApplyRulesToHistory:output_type -> ApplyRulesToHistoryResponse
+	13, // 26: CategorizationService.ListCategories:output_type -> ListCategoriesResponse
+	15, // 27: CategorizationService.CreateCategory:output_type -> CreateCategoryResponse
+	17, // 28: CategorizationService.UpdateCategory:output_type -> UpdateCategoryResponse
+	19, // 29: CategorizationService.DeleteCategory:output_type -> DeleteCategoryResponse
+	22, // 30: CategorizationService.PredictCategory:output_type -> PredictCategoryResponse
+	21, // [21:31] is the sub-list for method output_type
+	11, // [11:21] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_proto_categorization_proto_init() }
+func file_proto_categorization_proto_init() {
+	if File_proto_categorization_proto != nil {
+		return
+	}
+	file_proto_categorization_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_categorization_proto_rawDesc), len(file_proto_categorization_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_categorization_proto_goTypes,
+		DependencyIndexes: file_proto_categorization_proto_depIdxs,
+		MessageInfos:      file_proto_categorization_proto_msgTypes,
+	}.Build()
+	File_proto_categorization_proto = out.File
+	file_proto_categorization_proto_goTypes = nil
+	file_proto_categorization_proto_depIdxs = nil
+}
@@ -21,9 +21,12 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// group_id is read from the first message of the stream and files the
+// expense under that group.
 type CreateExpenseRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Chunks        []byte                 `protobuf:"bytes,1,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -65,6 +68,13 @@ func (x *CreateExpenseRequest) GetChunks() []byte {
 	return nil
 }
 
+func (x *CreateExpenseRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
 type CreateExpenseResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
@@ -109,11 +119,18 @@ func (x *CreateExpenseResponse) GetStatus() string {
 	return ""
 }
 
+// With group_id set, the group's expenses are aggregated instead of the
+// caller's own.
+// Pages hold up to 1000 expenses, 1000 by default.
 type GetHeatMapDataRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GroupId           string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	PageSize          int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken         string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,6,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetHeatMapDataRequest) Reset() {
@@ -146,16 +163,46 @@ func (*GetHeatMapDataRequest) Descriptor() ([]byte, []int) {
 	return file_proto_expenses_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetHeatMapDataRequest) GetUserId() string {
+func (x *GetHeatMapDataRequest) GetGroupId() string {
 	if x != nil {
-		return x.UserId
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GetHeatMapDataRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetHeatMapDataRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetHeatMapDataRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
 	}
 	return ""
 }
 
+func (x *GetHeatMapDataRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
 type GetHeatMapDataResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	HeatMapData   []*HeatMapData         `protobuf:"bytes,1,rep,name=heat_map_data,json=heatMapData,proto3" json:"heat_map_data,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -197,6 +244,20 @@ func (x *GetHeatMapDataResponse) GetHeatMapData() []*HeatMapData {
 	return nil
 }
 
+func (x *GetHeatMapDataResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetHeatMapDataResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
 type HeatMapData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Day           string                 `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
@@ -257,9 +318,16 @@ func (x *HeatMapData) GetCurrency() string {
 	return ""
 }
 
+// by_parent rolls sub-categories up into their top-level category.
+// group_by is "category" (the default), "tag", "merchant" or
+// "custom_field", which totals by the values of the field with
+// custom_field_id. An expense with several tags counts towards each of them.
 type GetSpendingTypesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	ByParent      bool                   `protobuf:"varint,3,opt,name=by_parent,json=byParent,proto3" json:"by_parent,omitempty"`
+	GroupBy       string                 `protobuf:"bytes,4,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	CustomFieldId string                 `protobuf:"bytes,5,opt,name=custom_field_id,json=customFieldId,proto3" json:"custom_field_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -294,17 +362,43 @@ func (*GetSpendingTypesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_expenses_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetSpendingTypesRequest) GetUserId() string {
+func (x *GetSpendingTypesRequest) GetGroupId() string {
 	if x != nil {
-		return x.UserId
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GetSpendingTypesRequest) GetByParent() bool {
+	if x != nil {
+		return x.ByParent
+	}
+	return false
+}
+
+func (x *GetSpendingTypesRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
 	}
 	return ""
 }
 
+func (x *GetSpendingTypesRequest) GetCustomFieldId() string {
+	if x != nil {
+		return x.CustomFieldId
+	}
+	return ""
+}
+
+// category_id, icon and colour are empty for categories outside the
+// taxonomy.
 type SpendingType struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Spent         float64                `protobuf:"fixed64,2,opt,name=spent,proto3" json:"spent,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,3,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Icon          string                 `protobuf:"bytes,4,opt,name=icon,proto3" json:"icon,omitempty"`
+	Colour        string                 `protobuf:"bytes,5,opt,name=colour,proto3" json:"colour,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -353,6 +447,27 @@ func (x *SpendingType) GetSpent() float64 {
 	return 0
 }
 
+func (x *SpendingType) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *SpendingType) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *SpendingType) GetColour() string {
+	if x != nil {
+		return x.Colour
+	}
+	return ""
+}
+
 type GetSpendingTypesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SpendingTypes []*SpendingType        `protobuf:"bytes,1,rep,name=spending_types,json=spendingTypes,proto3" json:"spending_types,omitempty"`
@@ -397,34 +512,2671 @@ func (x *GetSpendingTypesResponse) GetSpendingTypes() []*SpendingType {
 	return nil
 }
 
-var File_proto_expenses_proto protoreflect.FileDescriptor
+type Expense struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	DateAndTime   string                 `protobuf:"bytes,4,opt,name=date_and_time,json=dateAndTime,proto3" json:"date_and_time,omitempty"`
+	Place         string                 `protobuf:"bytes,5,opt,name=place,proto3" json:"place,omitempty"`
+	Amount        float64                `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Category      string                 `protobuf:"bytes,8,opt,name=category,proto3" json:"category,omitempty"`
+	ModeOfPayment string                 `protobuf:"bytes,9,opt,name=mode_of_payment,json=modeOfPayment,proto3" json:"mode_of_payment,omitempty"`
+	Tags          []string               `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
+	// category_source is one of genai, classifier, rule or user;
+	// category_confidence is set when the classifier picked the category.
+	CategorySource     string  `protobuf:"bytes,11,opt,name=category_source,json=categorySource,proto3" json:"category_source,omitempty"`
+	CategoryConfidence float64 `protobuf:"fixed64,12,opt,name=category_confidence,json=categoryConfidence,proto3" json:"category_confidence,omitempty"`
+	// source is how the expense was recorded: receipt, manual, sms, email or
+	// import.
+	Source       string              `protobuf:"bytes,13,opt,name=source,proto3" json:"source,omitempty"`
+	Notes        string              `protobuf:"bytes,14,opt,name=notes,proto3" json:"notes,omitempty"`
+	CustomFields []*CustomFieldValue `protobuf:"bytes,15,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	// trip_id is the trip or event the expense was made during.
+	TripId        string `protobuf:"bytes,16,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_expenses_proto_rawDesc = "" +
-	"\n" +
-	"\x14proto/expenses.proto\".\n" +
-	"\x14CreateExpenseRequest\x12\x16\n" +
-	"\x06chunks\x18\x01 \x01(\fR\x06chunks\"/\n" +
-	"\x15CreateExpenseResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\"0\n" +
-	"\x15GetHeatMapDataRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"J\n" +
-	"\x16GetHeatMapDataResponse\x120\n" +
-	"\rheat_map_data\x18\x01 \x03(\v2\f.HeatMapDataR\vheatMapData\"S\n" +
-	"\vHeatMapData\x12\x10\n" +
-	"\x03day\x18\x01 \x01(\tR\x03day\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
-	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"2\n" +
-	"\x17GetSpendingTypesRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"8\n" +
-	"\fSpendingType\x12\x12\n" +
-	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
-	"\x05spent\x18\x02 \x01(\x01R\x05spent\"P\n" +
-	"\x18GetSpendingTypesResponse\x124\n" +
-	"\x0espending_types\x18\x01 \x03(\v2\r.SpendingTypeR\rspendingTypes2\xdf\x01\n" +
-	"\x0fExpensesService\x12@\n" +
-	"\rCreateExpense\x12\x15.CreateExpenseRequest\x1a\x16.CreateExpenseResponse(\x01\x12A\n" +
-	"\x0eGetHeatMapData\x12\x16.GetHeatMapDataRequest\x1a\x17.GetHeatMapDataResponse\x12G\n" +
-	"\x10GetSpendingTypes\x12\x18.GetSpendingTypesRequest\x1a\x19.GetSpendingTypesResponseB+Z)github.com/barathsurya2004/expenses/protob\x06proto3"
+func (x *Expense) Reset() {
+	*x = Expense{}
+	mi := &file_proto_expenses_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Expense) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expense) ProtoMessage() {}
+
+func (x *Expense) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expense.ProtoReflect.Descriptor instead.
+func (*Expense) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Expense) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Expense) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Expense) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *Expense) GetDateAndTime() string {
+	if x != nil {
+		return x.DateAndTime
+	}
+	return ""
+}
+
+func (x *Expense) GetPlace() string {
+	if x != nil {
+		return x.Place
+	}
+	return ""
+}
+
+func (x *Expense) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Expense) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Expense) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Expense) GetModeOfPayment() string {
+	if x != nil {
+		return x.ModeOfPayment
+	}
+	return ""
+}
+
+func (x *Expense) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Expense) GetCategorySource() string {
+	if x != nil {
+		return x.CategorySource
+	}
+	return ""
+}
+
+func (x *Expense) GetCategoryConfidence() float64 {
+	if x != nil {
+		return x.CategoryConfidence
+	}
+	return 0
+}
+
+func (x *Expense) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Expense) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Expense) GetCustomFields() []*CustomFieldValue {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *Expense) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+// The value of a custom field on an expense. Requests name the field by
+// field_id or by name; numbers are decimals and dates YYYY-MM-DD.
+type CustomFieldValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FieldId       string                 `protobuf:"bytes,1,opt,name=field_id,json=fieldId,proto3" json:"field_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomFieldValue) Reset() {
+	*x = CustomFieldValue{}
+	mi := &file_proto_expenses_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomFieldValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFieldValue) ProtoMessage() {}
+
+func (x *CustomFieldValue) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFieldValue.ProtoReflect.Descriptor instead.
+func (*CustomFieldValue) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CustomFieldValue) GetFieldId() string {
+	if x != nil {
+		return x.FieldId
+	}
+	return ""
+}
+
+func (x *CustomFieldValue) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomFieldValue) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CustomFieldValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// With group_id set, the group's expenses are listed instead of the
+// caller's own. Only expenses with every one of tags and every one of
+// custom_fields are listed; a custom field without a value matches any
+// value. trip_id lists the expenses of a trip. Pages hold up to 1000
+// expenses, 100 by default.
+type ListExpensesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GroupId           string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	PageSize          int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Tags              []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	CustomFields      []*CustomFieldValue    `protobuf:"bytes,4,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	PageToken         string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,7,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	TripId            string                 `protobuf:"bytes,8,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListExpensesRequest) Reset() {
+	*x = ListExpensesRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpensesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpensesRequest) ProtoMessage() {}
+
+func (x *ListExpensesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpensesRequest.ProtoReflect.Descriptor instead.
+func (*ListExpensesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListExpensesRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ListExpensesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExpensesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListExpensesRequest) GetCustomFields() []*CustomFieldValue {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+func (x *ListExpensesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListExpensesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListExpensesRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+func (x *ListExpensesRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+type ListExpensesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expenses      []*Expense             `protobuf:"bytes,1,rep,name=expenses,proto3" json:"expenses,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpensesResponse) Reset() {
+	*x = ListExpensesResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpensesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpensesResponse) ProtoMessage() {}
+
+func (x *ListExpensesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpensesResponse.ProtoReflect.Descriptor instead.
+func (*ListExpensesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListExpensesResponse) GetExpenses() []*Expense {
+	if x != nil {
+		return x.Expenses
+	}
+	return nil
+}
+
+func (x *ListExpensesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListExpensesResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// An empty group_id moves the expense back to the caller's personal
+// expenses. Like UpdateExpense, it fails for expenses on a submitted report.
+type SetExpenseGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpenseId     string                 `protobuf:"bytes,1,opt,name=expense_id,json=expenseId,proto3" json:"expense_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExpenseGroupRequest) Reset() {
+	*x = SetExpenseGroupRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExpenseGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExpenseGroupRequest) ProtoMessage() {}
+
+func (x *SetExpenseGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExpenseGroupRequest.ProtoReflect.Descriptor instead.
+func (*SetExpenseGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SetExpenseGroupRequest) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+func (x *SetExpenseGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+type SetExpenseGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExpenseGroupResponse) Reset() {
+	*x = SetExpenseGroupResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExpenseGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExpenseGroupResponse) ProtoMessage() {}
+
+func (x *SetExpenseGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExpenseGroupResponse.ProtoReflect.Descriptor instead.
+func (*SetExpenseGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetExpenseGroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// AddExpense records an expense entered by hand. Categorization rules run
+// on it; an explicit category takes precedence over the rules and the given
+// tags are merged with theirs.
+type AddExpenseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DateAndTime   string                 `protobuf:"bytes,1,opt,name=date_and_time,json=dateAndTime,proto3" json:"date_and_time,omitempty"`
+	Place         string                 `protobuf:"bytes,2,opt,name=place,proto3" json:"place,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	ModeOfPayment string                 `protobuf:"bytes,6,opt,name=mode_of_payment,json=modeOfPayment,proto3" json:"mode_of_payment,omitempty"`
+	GroupId       string                 `protobuf:"bytes,7,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Notes         string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+	CustomFields  []*CustomFieldValue    `protobuf:"bytes,10,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddExpenseRequest) Reset() {
+	*x = AddExpenseRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddExpenseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddExpenseRequest) ProtoMessage() {}
+
+func (x *AddExpenseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddExpenseRequest.ProtoReflect.Descriptor instead.
+func (*AddExpenseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AddExpenseRequest) GetDateAndTime() string {
+	if x != nil {
+		return x.DateAndTime
+	}
+	return ""
+}
+
+func (x *AddExpenseRequest) GetPlace() string {
+	if x != nil {
+		return x.Place
+	}
+	return ""
+}
+
+func (x *AddExpenseRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AddExpenseRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AddExpenseRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *AddExpenseRequest) GetModeOfPayment() string {
+	if x != nil {
+		return x.ModeOfPayment
+	}
+	return ""
+}
+
+func (x *AddExpenseRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *AddExpenseRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AddExpenseRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *AddExpenseRequest) GetCustomFields() []*CustomFieldValue {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+type AddExpenseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expense       *Expense               `protobuf:"bytes,1,opt,name=expense,proto3" json:"expense,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddExpenseResponse) Reset() {
+	*x = AddExpenseResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddExpenseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddExpenseResponse) ProtoMessage() {}
+
+func (x *AddExpenseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddExpenseResponse.ProtoReflect.Descriptor instead.
+func (*AddExpenseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AddExpenseResponse) GetExpense() *Expense {
+	if x != nil {
+		return x.Expense
+	}
+	return nil
+}
+
+// UpdateExpense changes the given fields of an expense the caller recorded.
+// A changed category is recorded as a correction and trains the caller's
+// personal classifier; a changed amount discards the expense's split.
+// Custom fields given with an empty value are cleared. Expenses on an
+// expense report that has been submitted and not rejected cannot be changed.
+type UpdateExpenseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpenseId     string                 `protobuf:"bytes,1,opt,name=expense_id,json=expenseId,proto3" json:"expense_id,omitempty"`
+	DateAndTime   *string                `protobuf:"bytes,2,opt,name=date_and_time,json=dateAndTime,proto3,oneof" json:"date_and_time,omitempty"`
+	Place         *string                `protobuf:"bytes,3,opt,name=place,proto3,oneof" json:"place,omitempty"`
+	Amount        *float64               `protobuf:"fixed64,4,opt,name=amount,proto3,oneof" json:"amount,omitempty"`
+	Currency      *string                `protobuf:"bytes,5,opt,name=currency,proto3,oneof" json:"currency,omitempty"`
+	Category      *string                `protobuf:"bytes,6,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	ModeOfPayment *string                `protobuf:"bytes,7,opt,name=mode_of_payment,json=modeOfPayment,proto3,oneof" json:"mode_of_payment,omitempty"`
+	Notes         *string                `protobuf:"bytes,8,opt,name=notes,proto3,oneof" json:"notes,omitempty"`
+	AddTags       []string               `protobuf:"bytes,9,rep,name=add_tags,json=addTags,proto3" json:"add_tags,omitempty"`
+	RemoveTags    []string               `protobuf:"bytes,10,rep,name=remove_tags,json=removeTags,proto3" json:"remove_tags,omitempty"`
+	CustomFields  []*CustomFieldValue    `protobuf:"bytes,11,rep,name=custom_fields,json=customFields,proto3" json:"custom_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateExpenseRequest) Reset() {
+	*x = UpdateExpenseRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateExpenseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateExpenseRequest) ProtoMessage() {}
+
+func (x *UpdateExpenseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateExpenseRequest.ProtoReflect.Descriptor instead.
+func (*UpdateExpenseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateExpenseRequest) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+func (x *UpdateExpenseRequest) GetDateAndTime() string {
+	if x != nil && x.DateAndTime != nil {
+		return *x.DateAndTime
+	}
+	return ""
+}
+
+func (x *UpdateExpenseRequest) GetPlace() string {
+	if x != nil && x.Place != nil {
+		return *x.Place
+	}
+	return ""
+}
+
+func (x *UpdateExpenseRequest) GetAmount() float64 {
+	if x != nil && x.Amount != nil {
+		return *x.Amount
+	}
+	return 0
+}
+
+func (x *UpdateExpenseRequest) GetCurrency() string {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return ""
+}
+
+func (x *UpdateExpenseRequest) GetCategory() string {
+	if x != nil && x.Category != nil {
+		return *x.Category
+	}
+	return ""
+}
+
+func (x *UpdateExpenseRequest) GetModeOfPayment() string {
+	if x != nil && x.ModeOfPayment != nil {
+		return *x.ModeOfPayment
+	}
+	return ""
+}
+
+func (x *UpdateExpenseRequest) GetNotes() string {
+	if x != nil && x.Notes != nil {
+		return *x.Notes
+	}
+	return ""
+}
+
+func (x *UpdateExpenseRequest) GetAddTags() []string {
+	if x != nil {
+		return x.AddTags
+	}
+	return nil
+}
+
+func (x *UpdateExpenseRequest) GetRemoveTags() []string {
+	if x != nil {
+		return x.RemoveTags
+	}
+	return nil
+}
+
+func (x *UpdateExpenseRequest) GetCustomFields() []*CustomFieldValue {
+	if x != nil {
+		return x.CustomFields
+	}
+	return nil
+}
+
+type UpdateExpenseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expense       *Expense               `protobuf:"bytes,1,opt,name=expense,proto3" json:"expense,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateExpenseResponse) Reset() {
+	*x = UpdateExpenseResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateExpenseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateExpenseResponse) ProtoMessage() {}
+
+func (x *UpdateExpenseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateExpenseResponse.ProtoReflect.Descriptor instead.
+func (*UpdateExpenseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateExpenseResponse) GetExpense() *Expense {
+	if x != nil {
+		return x.Expense
+	}
+	return nil
+}
+
+// format is csv, ndjson, xlsx or pdf. from and to are RFC 3339 times or
+// YYYY-MM-DD dates, both inclusive. category_id also matches the category's
+// sub-categories. With group_id set, the group's expenses are exported
+// instead of the caller's own.
+type ExportExpensesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,4,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	MerchantId    string                 `protobuf:"bytes,5,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,6,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportExpensesRequest) Reset() {
+	*x = ExportExpensesRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportExpensesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportExpensesRequest) ProtoMessage() {}
+
+func (x *ExportExpensesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportExpensesRequest.ProtoReflect.Descriptor instead.
+func (*ExportExpensesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExportExpensesRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportExpensesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ExportExpensesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ExportExpensesRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ExportExpensesRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *ExportExpensesRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+// content_type and filename are set on the first message only.
+type ExportExpensesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunks        []byte                 `protobuf:"bytes,1,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportExpensesResponse) Reset() {
+	*x = ExportExpensesResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportExpensesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportExpensesResponse) ProtoMessage() {}
+
+func (x *ExportExpensesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportExpensesResponse.ProtoReflect.Descriptor instead.
+func (*ExportExpensesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExportExpensesResponse) GetChunks() []byte {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *ExportExpensesResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportExpensesResponse) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+// With group_id set, the tags on the group's expenses are listed instead of
+// the caller's own. order_by is "expense_count desc", the default, or
+// "tag". Pages hold up to 1000 tags, 100 by default.
+type ListTagsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GroupId           string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	PageSize          int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken         string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,5,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListTagsRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ListTagsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTagsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTagsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListTagsRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+type TagCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	ExpenseCount  int32                  `protobuf:"varint,2,opt,name=expense_count,json=expenseCount,proto3" json:"expense_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagCount) Reset() {
+	*x = TagCount{}
+	mi := &file_proto_expenses_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagCount) ProtoMessage() {}
+
+func (x *TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagCount.ProtoReflect.Descriptor instead.
+func (*TagCount) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TagCount) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagCount) GetExpenseCount() int32 {
+	if x != nil {
+		return x.ExpenseCount
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*TagCount            `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListTagsResponse) GetTags() []*TagCount {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTagsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTagsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// type is text, number, date or enum; options lists the values an enum
+// field can take.
+type CustomField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Options       []string               `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	ExpenseCount  int32                  `protobuf:"varint,5,opt,name=expense_count,json=expenseCount,proto3" json:"expense_count,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomField) Reset() {
+	*x = CustomField{}
+	mi := &file_proto_expenses_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomField) ProtoMessage() {}
+
+func (x *CustomField) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomField.ProtoReflect.Descriptor instead.
+func (*CustomField) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CustomField) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CustomField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomField) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CustomField) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *CustomField) GetExpenseCount() int32 {
+	if x != nil {
+		return x.ExpenseCount
+	}
+	return 0
+}
+
+func (x *CustomField) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CustomFieldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         *CustomField           `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomFieldResponse) Reset() {
+	*x = CustomFieldResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomFieldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFieldResponse) ProtoMessage() {}
+
+func (x *CustomFieldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFieldResponse.ProtoReflect.Descriptor instead.
+func (*CustomFieldResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CustomFieldResponse) GetField() *CustomField {
+	if x != nil {
+		return x.Field
+	}
+	return nil
+}
+
+type CreateCustomFieldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Options       []string               `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomFieldRequest) Reset() {
+	*x = CreateCustomFieldRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomFieldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomFieldRequest) ProtoMessage() {}
+
+func (x *CreateCustomFieldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomFieldRequest.ProtoReflect.Descriptor instead.
+func (*CreateCustomFieldRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateCustomFieldRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCustomFieldRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateCustomFieldRequest) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// UpdateCustomField renames a field and, for an enum, replaces its options.
+// An empty name is left unchanged. Options still in use cannot be removed,
+// and the type of a field cannot change.
+type UpdateCustomFieldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Options       []string               `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCustomFieldRequest) Reset() {
+	*x = UpdateCustomFieldRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCustomFieldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCustomFieldRequest) ProtoMessage() {}
+
+func (x *UpdateCustomFieldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCustomFieldRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCustomFieldRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateCustomFieldRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCustomFieldRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCustomFieldRequest) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// DeleteCustomField removes the field and its values from every expense.
+type DeleteCustomFieldRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomFieldRequest) Reset() {
+	*x = DeleteCustomFieldRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomFieldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomFieldRequest) ProtoMessage() {}
+
+func (x *DeleteCustomFieldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomFieldRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCustomFieldRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteCustomFieldRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteCustomFieldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomFieldResponse) Reset() {
+	*x = DeleteCustomFieldResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomFieldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomFieldResponse) ProtoMessage() {}
+
+func (x *DeleteCustomFieldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomFieldResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCustomFieldResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteCustomFieldResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// order_by is "name", the default, or "created_at". Pages hold up to 50
+// fields, 50 by default, which is all a user can have.
+type ListCustomFieldsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PageSize          int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken         string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,4,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListCustomFieldsRequest) Reset() {
+	*x = ListCustomFieldsRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomFieldsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomFieldsRequest) ProtoMessage() {}
+
+func (x *ListCustomFieldsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomFieldsRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomFieldsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListCustomFieldsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCustomFieldsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCustomFieldsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListCustomFieldsRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+type ListCustomFieldsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        []*CustomField         `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomFieldsResponse) Reset() {
+	*x = ListCustomFieldsResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomFieldsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomFieldsResponse) ProtoMessage() {}
+
+func (x *ListCustomFieldsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomFieldsResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomFieldsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListCustomFieldsResponse) GetFields() []*CustomField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ListCustomFieldsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListCustomFieldsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// query is free text matched against the merchant, tags, line item names
+// and notes, with these operators:
+//
+//	"two words"       the words next to each other
+//	-word             expenses without the word
+//	amount:>5         also >=, <, <=, an exact amount or a range 5..20
+//	after:2025-01-01  on or after a date; before: and on: likewise
+//	category:, merchant:, tag:, payment:, currency:
+//
+// Values with spaces are quoted, as in category:"Dining Out". Words match
+// by prefix, so "starb" finds Starbucks. order_by is "relevance", the
+// default for queries with free text, or an expense order. Pages hold up to
+// 100 results, 20 by default.
+type SearchExpensesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy       string                 `protobuf:"bytes,6,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchExpensesRequest) Reset() {
+	*x = SearchExpensesRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchExpensesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchExpensesRequest) ProtoMessage() {}
+
+func (x *SearchExpensesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchExpensesRequest.ProtoReflect.Descriptor instead.
+func (*SearchExpensesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SearchExpensesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchExpensesRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *SearchExpensesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchExpensesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchExpensesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+// snippet shows where the text matched, with the matching words wrapped in
+// <mark> tags and everything else HTML-escaped. rank is 0 and snippet empty
+// for queries without free text.
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Expense       *Expense               `protobuf:"bytes,1,opt,name=expense,proto3" json:"expense,omitempty"`
+	Rank          float64                `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Snippet       string                 `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_proto_expenses_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SearchResult) GetExpense() *Expense {
+	if x != nil {
+		return x.Expense
+	}
+	return nil
+}
+
+func (x *SearchResult) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+// query is the operator that narrows a search down to this value.
+type FacetValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Query         string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetValue) Reset() {
+	*x = FacetValue{}
+	mi := &file_proto_expenses_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetValue) ProtoMessage() {}
+
+func (x *FacetValue) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetValue.ProtoReflect.Descriptor instead.
+func (*FacetValue) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FacetValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetValue) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *FacetValue) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+// Facets count every match, not only the returned page, and list at most
+// 10 values each by count.
+type SearchFacets struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Categories     []*FacetValue          `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	Merchants      []*FacetValue          `protobuf:"bytes,2,rep,name=merchants,proto3" json:"merchants,omitempty"`
+	PaymentMethods []*FacetValue          `protobuf:"bytes,3,rep,name=payment_methods,json=paymentMethods,proto3" json:"payment_methods,omitempty"`
+	Currencies     []*FacetValue          `protobuf:"bytes,4,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	AmountRanges   []*FacetValue          `protobuf:"bytes,5,rep,name=amount_ranges,json=amountRanges,proto3" json:"amount_ranges,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchFacets) Reset() {
+	*x = SearchFacets{}
+	mi := &file_proto_expenses_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFacets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFacets) ProtoMessage() {}
+
+func (x *SearchFacets) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFacets.ProtoReflect.Descriptor instead.
+func (*SearchFacets) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SearchFacets) GetCategories() []*FacetValue {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *SearchFacets) GetMerchants() []*FacetValue {
+	if x != nil {
+		return x.Merchants
+	}
+	return nil
+}
+
+func (x *SearchFacets) GetPaymentMethods() []*FacetValue {
+	if x != nil {
+		return x.PaymentMethods
+	}
+	return nil
+}
+
+func (x *SearchFacets) GetCurrencies() []*FacetValue {
+	if x != nil {
+		return x.Currencies
+	}
+	return nil
+}
+
+func (x *SearchFacets) GetAmountRanges() []*FacetValue {
+	if x != nil {
+		return x.AmountRanges
+	}
+	return nil
+}
+
+// total_count is always set.
+type SearchExpensesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Facets        *SearchFacets          `protobuf:"bytes,3,opt,name=facets,proto3" json:"facets,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchExpensesResponse) Reset() {
+	*x = SearchExpensesResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchExpensesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchExpensesResponse) ProtoMessage() {}
+
+func (x *SearchExpensesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchExpensesResponse.ProtoReflect.Descriptor instead.
+func (*SearchExpensesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SearchExpensesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchExpensesResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchExpensesResponse) GetFacets() *SearchFacets {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *SearchExpensesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// CompareSpending compares what was spent in two periods. The periods are
+// either given as current_from to current_to and previous_from to
+// previous_to, RFC 3339 times or YYYY-MM-DD dates with both ends inclusive,
+// or picked with period, "month" or "year", which compares the calendar
+// month or year so far, in the caller's time zone, with the one before. A
+// current period that has not ended yet is compared up to now with the same
+// length of the previous period. currency only counts expenses in that
+// currency. by_parent rolls sub-categories up into their top-level category.
+// limit caps the categories and merchants returned, 10 by default and at
+// most 50.
+type CompareSpendingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrentFrom   string                 `protobuf:"bytes,1,opt,name=current_from,json=currentFrom,proto3" json:"current_from,omitempty"`
+	CurrentTo     string                 `protobuf:"bytes,2,opt,name=current_to,json=currentTo,proto3" json:"current_to,omitempty"`
+	PreviousFrom  string                 `protobuf:"bytes,3,opt,name=previous_from,json=previousFrom,proto3" json:"previous_from,omitempty"`
+	PreviousTo    string                 `protobuf:"bytes,4,opt,name=previous_to,json=previousTo,proto3" json:"previous_to,omitempty"`
+	Period        string                 `protobuf:"bytes,5,opt,name=period,proto3" json:"period,omitempty"`
+	GroupId       string                 `protobuf:"bytes,6,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	ByParent      bool                   `protobuf:"varint,8,opt,name=by_parent,json=byParent,proto3" json:"by_parent,omitempty"`
+	Limit         int32                  `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareSpendingRequest) Reset() {
+	*x = CompareSpendingRequest{}
+	mi := &file_proto_expenses_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareSpendingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareSpendingRequest) ProtoMessage() {}
+
+func (x *CompareSpendingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareSpendingRequest.ProtoReflect.Descriptor instead.
+func (*CompareSpendingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CompareSpendingRequest) GetCurrentFrom() string {
+	if x != nil {
+		return x.CurrentFrom
+	}
+	return ""
+}
+
+func (x *CompareSpendingRequest) GetCurrentTo() string {
+	if x != nil {
+		return x.CurrentTo
+	}
+	return ""
+}
+
+func (x *CompareSpendingRequest) GetPreviousFrom() string {
+	if x != nil {
+		return x.PreviousFrom
+	}
+	return ""
+}
+
+func (x *CompareSpendingRequest) GetPreviousTo() string {
+	if x != nil {
+		return x.PreviousTo
+	}
+	return ""
+}
+
+func (x *CompareSpendingRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *CompareSpendingRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *CompareSpendingRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CompareSpendingRequest) GetByParent() bool {
+	if x != nil {
+		return x.ByParent
+	}
+	return false
+}
+
+func (x *CompareSpendingRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// A period compared. to is exclusive.
+type SpendingPeriod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Total         float64                `protobuf:"fixed64,3,opt,name=total,proto3" json:"total,omitempty"`
+	ExpenseCount  int32                  `protobuf:"varint,4,opt,name=expense_count,json=expenseCount,proto3" json:"expense_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpendingPeriod) Reset() {
+	*x = SpendingPeriod{}
+	mi := &file_proto_expenses_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpendingPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpendingPeriod) ProtoMessage() {}
+
+func (x *SpendingPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpendingPeriod.ProtoReflect.Descriptor instead.
+func (*SpendingPeriod) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SpendingPeriod) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SpendingPeriod) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SpendingPeriod) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SpendingPeriod) GetExpenseCount() int32 {
+	if x != nil {
+		return x.ExpenseCount
+	}
+	return 0
+}
+
+// change is current less previous. percent_change is left out when nothing
+// was spent in the previous period. category_id, icon and colour are only
+// set for categories in the taxonomy.
+type SpendingChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CategoryId    string                 `protobuf:"bytes,2,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Icon          string                 `protobuf:"bytes,3,opt,name=icon,proto3" json:"icon,omitempty"`
+	Colour        string                 `protobuf:"bytes,4,opt,name=colour,proto3" json:"colour,omitempty"`
+	Current       float64                `protobuf:"fixed64,5,opt,name=current,proto3" json:"current,omitempty"`
+	Previous      float64                `protobuf:"fixed64,6,opt,name=previous,proto3" json:"previous,omitempty"`
+	Change        float64                `protobuf:"fixed64,7,opt,name=change,proto3" json:"change,omitempty"`
+	PercentChange *float64               `protobuf:"fixed64,8,opt,name=percent_change,json=percentChange,proto3,oneof" json:"percent_change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpendingChange) Reset() {
+	*x = SpendingChange{}
+	mi := &file_proto_expenses_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpendingChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpendingChange) ProtoMessage() {}
+
+func (x *SpendingChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpendingChange.ProtoReflect.Descriptor instead.
+func (*SpendingChange) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SpendingChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SpendingChange) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *SpendingChange) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *SpendingChange) GetColour() string {
+	if x != nil {
+		return x.Colour
+	}
+	return ""
+}
+
+func (x *SpendingChange) GetCurrent() float64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *SpendingChange) GetPrevious() float64 {
+	if x != nil {
+		return x.Previous
+	}
+	return 0
+}
+
+func (x *SpendingChange) GetChange() float64 {
+	if x != nil {
+		return x.Change
+	}
+	return 0
+}
+
+func (x *SpendingChange) GetPercentChange() float64 {
+	if x != nil && x.PercentChange != nil {
+		return *x.PercentChange
+	}
+	return 0
+}
+
+// partial is set when the current period was cut short at now. categories
+// and merchants are the biggest movers, by the size of their change.
+type CompareSpendingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       *SpendingPeriod        `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	Previous      *SpendingPeriod        `protobuf:"bytes,2,opt,name=previous,proto3" json:"previous,omitempty"`
+	Change        float64                `protobuf:"fixed64,3,opt,name=change,proto3" json:"change,omitempty"`
+	PercentChange *float64               `protobuf:"fixed64,4,opt,name=percent_change,json=percentChange,proto3,oneof" json:"percent_change,omitempty"`
+	Partial       bool                   `protobuf:"varint,5,opt,name=partial,proto3" json:"partial,omitempty"`
+	Categories    []*SpendingChange      `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
+	Merchants     []*SpendingChange      `protobuf:"bytes,7,rep,name=merchants,proto3" json:"merchants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareSpendingResponse) Reset() {
+	*x = CompareSpendingResponse{}
+	mi := &file_proto_expenses_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareSpendingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareSpendingResponse) ProtoMessage() {}
+
+func (x *CompareSpendingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_expenses_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareSpendingResponse.ProtoReflect.Descriptor instead.
+func (*CompareSpendingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_expenses_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CompareSpendingResponse) GetCurrent() *SpendingPeriod {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *CompareSpendingResponse) GetPrevious() *SpendingPeriod {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *CompareSpendingResponse) GetChange() float64 {
+	if x != nil {
+		return x.Change
+	}
+	return 0
+}
+
+func (x *CompareSpendingResponse) GetPercentChange() float64 {
+	if x != nil && x.PercentChange != nil {
+		return *x.PercentChange
+	}
+	return 0
+}
+
+func (x *CompareSpendingResponse) GetPartial() bool {
+	if x != nil {
+		return x.Partial
+	}
+	return false
+}
+
+func (x *CompareSpendingResponse) GetCategories() []*SpendingChange {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *CompareSpendingResponse) GetMerchants() []*SpendingChange {
+	if x != nil {
+		return x.Merchants
+	}
+	return nil
+}
+
+var File_proto_expenses_proto protoreflect.FileDescriptor
+
+const file_proto_expenses_proto_rawDesc = "" +
+	"\n" +
+	"\x14proto/expenses.proto\"I\n" +
+	"\x14CreateExpenseRequest\x12\x16\n" +
+	"\x06chunks\x18\x01 \x01(\fR\x06chunks\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\"/\n" +
+	"\x15CreateExpenseResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\xbf\x01\n" +
+	"\x15GetHeatMapDataRequest\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x05 \x01(\tR\aorderBy\x12.\n" +
+	"\x13include_total_count\x18\x06 \x01(\bR\x11includeTotalCountJ\x04\b\x01\x10\x02\"\x93\x01\n" +
+	"\x16GetHeatMapDataResponse\x120\n" +
+	"\rheat_map_data\x18\x01 \x03(\v2\f.HeatMapDataR\vheatMapData\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"S\n" +
+	"\vHeatMapData\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\x9a\x01\n" +
+	"\x17GetSpendingTypesRequest\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tby_parent\x18\x03 \x01(\bR\bbyParent\x12\x19\n" +
+	"\bgroup_by\x18\x04 \x01(\tR\agroupBy\x12&\n" +
+	"\x0fcustom_field_id\x18\x05 \x01(\tR\rcustomFieldIdJ\x04\b\x01\x10\x02\"\x85\x01\n" +
+	"\fSpendingType\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05spent\x18\x02 \x01(\x01R\x05spent\x12\x1f\n" +
+	"\vcategory_id\x18\x03 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04icon\x18\x04 \x01(\tR\x04icon\x12\x16\n" +
+	"\x06colour\x18\x05 \x01(\tR\x06colour\"P\n" +
+	"\x18GetSpendingTypesResponse\x124\n" +
+	"\x0espending_types\x18\x01 \x03(\v2\r.SpendingTypeR\rspendingTypes\"\xec\x03\n" +
+	"\aExpense\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12\"\n" +
+	"\rdate_and_time\x18\x04 \x01(\tR\vdateAndTime\x12\x14\n" +
+	"\x05place\x18\x05 \x01(\tR\x05place\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x1a\n" +
+	"\bcategory\x18\b \x01(\tR\bcategory\x12&\n" +
+	"\x0fmode_of_payment\x18\t \x01(\tR\rmodeOfPayment\x12\x12\n" +
+	"\x04tags\x18\n" +
+	" \x03(\tR\x04tags\x12'\n" +
+	"\x0fcategory_source\x18\v \x01(\tR\x0ecategorySource\x12/\n" +
+	"\x13category_confidence\x18\f \x01(\x01R\x12categoryConfidence\x12\x16\n" +
+	"\x06source\x18\r \x01(\tR\x06source\x12\x14\n" +
+	"\x05notes\x18\x0e \x01(\tR\x05notes\x126\n" +
+	"\rcustom_fields\x18\x0f \x03(\v2\x11.CustomFieldValueR\fcustomFields\x12\x17\n" +
+	"\atrip_id\x18\x10 \x01(\tR\x06tripId\"k\n" +
+	"\x10CustomFieldValue\x12\x19\n" +
+	"\bfield_id\x18\x01 \x01(\tR\afieldId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"\x9c\x02\n" +
+	"\x13ListExpensesRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x126\n" +
+	"\rcustom_fields\x18\x04 \x03(\v2\x11.CustomFieldValueR\fcustomFields\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x06 \x01(\tR\aorderBy\x12.\n" +
+	"\x13include_total_count\x18\a \x01(\bR\x11includeTotalCount\x12\x17\n" +
+	"\atrip_id\x18\b \x01(\tR\x06tripId\"\x85\x01\n" +
+	"\x14ListExpensesResponse\x12$\n" +
+	"\bexpenses\x18\x01 \x03(\v2\b.ExpenseR\bexpenses\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"R\n" +
+	"\x16SetExpenseGroupRequest\x12\x1d\n" +
+	"\n" +
+	"expense_id\x18\x01 \x01(\tR\texpenseId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\"3\n" +
+	"\x17SetExpenseGroupResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xc2\x02\n" +
+	"\x11AddExpenseRequest\x12\"\n" +
+	"\rdate_and_time\x18\x01 \x01(\tR\vdateAndTime\x12\x14\n" +
+	"\x05place\x18\x02 \x01(\tR\x05place\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12&\n" +
+	"\x0fmode_of_payment\x18\x06 \x01(\tR\rmodeOfPayment\x12\x19\n" +
+	"\bgroup_id\x18\a \x01(\tR\agroupId\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x14\n" +
+	"\x05notes\x18\t \x01(\tR\x05notes\x126\n" +
+	"\rcustom_fields\x18\n" +
+	" \x03(\v2\x11.CustomFieldValueR\fcustomFields\"8\n" +
+	"\x12AddExpenseResponse\x12\"\n" +
+	"\aexpense\x18\x01 \x01(\v2\b.ExpenseR\aexpense\"\xf3\x03\n" +
+	"\x14UpdateExpenseRequest\x12\x1d\n" +
+	"\n" +
+	"expense_id\x18\x01 \x01(\tR\texpenseId\x12'\n" +
+	"\rdate_and_time\x18\x02 \x01(\tH\x00R\vdateAndTime\x88\x01\x01\x12\x19\n" +
+	"\x05place\x18\x03 \x01(\tH\x01R\x05place\x88\x01\x01\x12\x1b\n" +
+	"\x06amount\x18\x04 \x01(\x01H\x02R\x06amount\x88\x01\x01\x12\x1f\n" +
+	"\bcurrency\x18\x05 \x01(\tH\x03R\bcurrency\x88\x01\x01\x12\x1f\n" +
+	"\bcategory\x18\x06 \x01(\tH\x04R\bcategory\x88\x01\x01\x12+\n" +
+	"\x0fmode_of_payment\x18\a \x01(\tH\x05R\rmodeOfPayment\x88\x01\x01\x12\x19\n" +
+	"\x05notes\x18\b \x01(\tH\x06R\x05notes\x88\x01\x01\x12\x19\n" +
+	"\badd_tags\x18\t \x03(\tR\aaddTags\x12\x1f\n" +
+	"\vremove_tags\x18\n" +
+	" \x03(\tR\n" +
+	"removeTags\x126\n" +
+	"\rcustom_fields\x18\v \x03(\v2\x11.CustomFieldValueR\fcustomFieldsB\x10\n" +
+	"\x0e_date_and_timeB\b\n" +
+	"\x06_placeB\t\n" +
+	"\a_amountB\v\n" +
+	"\t_currencyB\v\n" +
+	"\t_categoryB\x12\n" +
+	"\x10_mode_of_paymentB\b\n" +
+	"\x06_notes\";\n" +
+	"\x15UpdateExpenseResponse\x12\"\n" +
+	"\aexpense\x18\x01 \x01(\v2\b.ExpenseR\aexpense\"\xb0\x01\n" +
+	"\x15ExportExpensesRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x1f\n" +
+	"\vcategory_id\x18\x04 \x01(\tR\n" +
+	"categoryId\x12\x1f\n" +
+	"\vmerchant_id\x18\x05 \x01(\tR\n" +
+	"merchantId\x12\x19\n" +
+	"\bgroup_id\x18\x06 \x01(\tR\agroupId\"o\n" +
+	"\x16ExportExpensesResponse\x12\x16\n" +
+	"\x06chunks\x18\x01 \x01(\fR\x06chunks\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\"\xb3\x01\n" +
+	"\x0fListTagsRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12.\n" +
+	"\x13include_total_count\x18\x05 \x01(\bR\x11includeTotalCount\"A\n" +
+	"\bTagCount\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12#\n" +
+	"\rexpense_count\x18\x02 \x01(\x05R\fexpenseCount\"z\n" +
+	"\x10ListTagsResponse\x12\x1d\n" +
+	"\x04tags\x18\x01 \x03(\v2\t.TagCountR\x04tags\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"\xa3\x01\n" +
+	"\vCustomField\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\aoptions\x18\x04 \x03(\tR\aoptions\x12#\n" +
+	"\rexpense_count\x18\x05 \x01(\x05R\fexpenseCount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"9\n" +
+	"\x13CustomFieldResponse\x12\"\n" +
+	"\x05field\x18\x01 \x01(\v2\f.CustomFieldR\x05field\"\\\n" +
+	"\x18CreateCustomFieldRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\aoptions\x18\x03 \x03(\tR\aoptions\"X\n" +
+	"\x18UpdateCustomFieldRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aoptions\x18\x03 \x03(\tR\aoptions\"*\n" +
+	"\x18DeleteCustomFieldRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
+	"\x19DeleteCustomFieldResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xa0\x01\n" +
+	"\x17ListCustomFieldsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12.\n" +
+	"\x13include_total_count\x18\x04 \x01(\bR\x11includeTotalCount\"\x89\x01\n" +
+	"\x18ListCustomFieldsResponse\x12$\n" +
+	"\x06fields\x18\x01 \x03(\v2\f.CustomFieldR\x06fields\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"\xa5\x01\n" +
+	"\x15SearchExpensesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x06 \x01(\tR\aorderByJ\x04\b\x04\x10\x05\"`\n" +
+	"\fSearchResult\x12\"\n" +
+	"\aexpense\x18\x01 \x01(\v2\b.ExpenseR\aexpense\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x01R\x04rank\x12\x18\n" +
+	"\asnippet\x18\x03 \x01(\tR\asnippet\"N\n" +
+	"\n" +
+	"FacetValue\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\"\xfb\x01\n" +
+	"\fSearchFacets\x12+\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\v.FacetValueR\n" +
+	"categories\x12)\n" +
+	"\tmerchants\x18\x02 \x03(\v2\v.FacetValueR\tmerchants\x124\n" +
+	"\x0fpayment_methods\x18\x03 \x03(\v2\v.FacetValueR\x0epaymentMethods\x12+\n" +
+	"\n" +
+	"currencies\x18\x04 \x03(\v2\v.FacetValueR\n" +
+	"currencies\x120\n" +
+	"\ramount_ranges\x18\x05 \x03(\v2\v.FacetValueR\famountRanges\"\xb1\x01\n" +
+	"\x16SearchExpensesResponse\x12'\n" +
+	"\aresults\x18\x01 \x03(\v2\r.SearchResultR\aresults\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12%\n" +
+	"\x06facets\x18\x03 \x01(\v2\r.SearchFacetsR\x06facets\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\"\xa2\x02\n" +
+	"\x16CompareSpendingRequest\x12!\n" +
+	"\fcurrent_from\x18\x01 \x01(\tR\vcurrentFrom\x12\x1d\n" +
+	"\n" +
+	"current_to\x18\x02 \x01(\tR\tcurrentTo\x12#\n" +
+	"\rprevious_from\x18\x03 \x01(\tR\fpreviousFrom\x12\x1f\n" +
+	"\vprevious_to\x18\x04 \x01(\tR\n" +
+	"previousTo\x12\x16\n" +
+	"\x06period\x18\x05 \x01(\tR\x06period\x12\x19\n" +
+	"\bgroup_id\x18\x06 \x01(\tR\agroupId\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x1b\n" +
+	"\tby_parent\x18\b \x01(\bR\bbyParent\x12\x14\n" +
+	"\x05limit\x18\t \x01(\x05R\x05limit\"o\n" +
+	"\x0eSpendingPeriod\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x01R\x05total\x12#\n" +
+	"\rexpense_count\x18\x04 \x01(\x05R\fexpenseCount\"\xfe\x01\n" +
+	"\x0eSpendingChange\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcategory_id\x18\x02 \x01(\tR\n" +
+	"categoryId\x12\x12\n" +
+	"\x04icon\x18\x03 \x01(\tR\x04icon\x12\x16\n" +
+	"\x06colour\x18\x04 \x01(\tR\x06colour\x12\x18\n" +
+	"\acurrent\x18\x05 \x01(\x01R\acurrent\x12\x1a\n" +
+	"\bprevious\x18\x06 \x01(\x01R\bprevious\x12\x16\n" +
+	"\x06change\x18\a \x01(\x01R\x06change\x12*\n" +
+	"\x0epercent_change\x18\b \x01(\x01H\x00R\rpercentChange\x88\x01\x01B\x11\n" +
+	"\x0f_percent_change\"\xc2\x02\n" +
+	"\x17CompareSpendingResponse\x12)\n" +
+	"\acurrent\x18\x01 \x01(\v2\x0f.SpendingPeriodR\acurrent\x12+\n" +
+	"\bprevious\x18\x02 \x01(\v2\x0f.SpendingPeriodR\bprevious\x12\x16\n" +
+	"\x06change\x18\x03 \x01(\x01R\x06change\x12*\n" +
+	"\x0epercent_change\x18\x04 \x01(\x01H\x00R\rpercentChange\x88\x01\x01\x12\x18\n" +
+	"\apartial\x18\x05 \x01(\bR\apartial\x12/\n" +
+	"\n" +
+	"categories\x18\x06 \x03(\v2\x0f.SpendingChangeR\n" +
+	"categories\x12-\n" +
+	"\tmerchants\x18\a \x03(\v2\x0f.SpendingChangeR\tmerchantsB\x11\n" +
+	"\x0f_percent_change2\xf9\a\n" +
+	"\x0fExpensesService\x12@\n" +
+	"\rCreateExpense\x12\x15.CreateExpenseRequest\x1a\x16.CreateExpenseResponse(\x01\x12A\n" +
+	"\x0eGetHeatMapData\x12\x16.GetHeatMapDataRequest\x1a\x17.GetHeatMapDataResponse\x12G\n" +
+	"\x10GetSpendingTypes\x12\x18.GetSpendingTypesRequest\x1a\x19.GetSpendingTypesResponse\x12;\n" +
+	"\fListExpenses\x12\x14.ListExpensesRequest\x1a\x15.ListExpensesResponse\x12D\n" +
+	"\x0fSetExpenseGroup\x12\x17.SetExpenseGroupRequest\x1a\x18.SetExpenseGroupResponse\x125\n" +
+	"\n" +
+	"AddExpense\x12\x12.AddExpenseRequest\x1a\x13.AddExpenseResponse\x12>\n" +
+	"\rUpdateExpense\x12\x15.UpdateExpenseRequest\x1a\x16.UpdateExpenseResponse\x12C\n" +
+	"\x0eExportExpenses\x12\x16.ExportExpensesRequest\x1a\x17.ExportExpensesResponse0\x01\x12/\n" +
+	"\bListTags\x12\x10.ListTagsRequest\x1a\x11.ListTagsResponse\x12D\n" +
+	"\x11CreateCustomField\x12\x19.CreateCustomFieldRequest\x1a\x14.CustomFieldResponse\x12D\n" +
+	"\x11UpdateCustomField\x12\x19.UpdateCustomFieldRequest\x1a\x14.CustomFieldResponse\x12J\n" +
+	"\x11DeleteCustomField\x12\x19.DeleteCustomFieldRequest\x1a\x1a.DeleteCustomFieldResponse\x12G\n" +
+	"\x10ListCustomFields\x12\x18.ListCustomFieldsRequest\x1a\x19.ListCustomFieldsResponse\x12A\n" +
+	"\x0eSearchExpenses\x12\x16.SearchExpensesRequest\x1a\x17.SearchExpensesResponse\x12D\n" +
+	"\x0fCompareSpending\x12\x17.CompareSpendingRequest\x1a\x18.CompareSpendingResponseB+Z)github.com/barathsurya2004/expenses/protob\x06proto3"
 
 var (
 	file_proto_expenses_proto_rawDescOnce sync.Once
@@ -438,31 +3190,109 @@ func file_proto_expenses_proto_rawDescGZIP() []byte {
 	return file_proto_expenses_proto_rawDescData
 }
 
-var file_proto_expenses_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_expenses_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_proto_expenses_proto_goTypes = []any{
-	(*CreateExpenseRequest)(nil),     // 0: CreateExpenseRequest
-	(*CreateExpenseResponse)(nil),    // 1: CreateExpenseResponse
-	(*GetHeatMapDataRequest)(nil),    // 2: GetHeatMapDataRequest
-	(*GetHeatMapDataResponse)(nil),   // 3: GetHeatMapDataResponse
-	(*HeatMapData)(nil),              // 4: HeatMapData
-	(*GetSpendingTypesRequest)(nil),  // 5: GetSpendingTypesRequest
-	(*SpendingType)(nil),             // 6: SpendingType
-	(*GetSpendingTypesResponse)(nil), // 7: GetSpendingTypesResponse
+	(*CreateExpenseRequest)(nil),      // 0: CreateExpenseRequest
+	(*CreateExpenseResponse)(nil),     // 1: CreateExpenseResponse
+	(*GetHeatMapDataRequest)(nil),     // 2: GetHeatMapDataRequest
+	(*GetHeatMapDataResponse)(nil),    // 3: GetHeatMapDataResponse
+	(*HeatMapData)(nil),               // 4: HeatMapData
+	(*GetSpendingTypesRequest)(nil),   // 5: GetSpendingTypesRequest
+	(*SpendingType)(nil),              // 6: SpendingType
+	(*GetSpendingTypesResponse)(nil),  // 7: GetSpendingTypesResponse
+	(*Expense)(nil),                   // 8: Expense
+	(*CustomFieldValue)(nil),          // 9: CustomFieldValue
+	(*ListExpensesRequest)(nil),       // 10: ListExpensesRequest
+	(*ListExpensesResponse)(nil),      // 11: ListExpensesResponse
+	(*SetExpenseGroupRequest)(nil),    // 12: SetExpenseGroupRequest
+	(*SetExpenseGroupResponse)(nil),   // 13: SetExpenseGroupResponse
+	(*AddExpenseRequest)(nil),         // 14: AddExpenseRequest
+	(*AddExpenseResponse)(nil),        // 15: AddExpenseResponse
+	(*UpdateExpenseRequest)(nil),      // 16: UpdateExpenseRequest
+	(*UpdateExpenseResponse)(nil),     // 17: UpdateExpenseResponse
+	(*ExportExpensesRequest)(nil),     // 18: ExportExpensesRequest
+	(*ExportExpensesResponse)(nil),    // 19: ExportExpensesResponse
+	(*ListTagsRequest)(nil),           // 20: ListTagsRequest
+	(*TagCount)(nil),                  // 21: TagCount
+	(*ListTagsResponse)(nil),          // 22: ListTagsResponse
+	(*CustomField)(nil),               // 23: CustomField
+	(*CustomFieldResponse)(nil),       // 24: CustomFieldResponse
+	(*CreateCustomFieldRequest)(nil),  // 25: CreateCustomFieldRequest
+	(*UpdateCustomFieldRequest)(nil),  // 26: UpdateCustomFieldRequest
+	(*DeleteCustomFieldRequest)(nil),  // 27: DeleteCustomFieldRequest
+	(*DeleteCustomFieldResponse)(nil), // 28: DeleteCustomFieldResponse
+	(*ListCustomFieldsRequest)(nil),   // 29: ListCustomFieldsRequest
+	(*ListCustomFieldsResponse)(nil),  // 30: ListCustomFieldsResponse
+	(*SearchExpensesRequest)(nil),     // 31: SearchExpensesRequest
+	(*SearchResult)(nil),              // 32: SearchResult
+	(*FacetValue)(nil),                // 33: FacetValue
+	(*SearchFacets)(nil),              // 34: SearchFacets
+	(*SearchExpensesResponse)(nil),    // 35: SearchExpensesResponse
+	(*CompareSpendingRequest)(nil),    // 36: CompareSpendingRequest
+	(*SpendingPeriod)(nil),            // 37: SpendingPeriod
+	(*SpendingChange)(nil),            // 38: SpendingChange
+	(*CompareSpendingResponse)(nil),   // 39: CompareSpendingResponse
 }
 var file_proto_expenses_proto_depIdxs = []int32{
-	4, // 0: GetHeatMapDataResponse.heat_map_data:type_name -> HeatMapData
-	6, // 1: GetSpendingTypesResponse.spending_types:type_name -> SpendingType
-	0, // 2: ExpensesService.CreateExpense:input_type -> CreateExpenseRequest
-	2, // 3: ExpensesService.GetHeatMapData:input_type -> GetHeatMapDataRequest
-	5, // 4: ExpensesService.GetSpendingTypes:input_type -> GetSpendingTypesRequest
-	1, // 5: ExpensesService.CreateExpense:output_type -> CreateExpenseResponse
-	3, // 6: ExpensesService.GetHeatMapData:output_type -> GetHeatMapDataResponse
-	7, // 7: ExpensesService.GetSpendingTypes:output_type -> GetSpendingTypesResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4,  // 0: GetHeatMapDataResponse.heat_map_data:type_name -> HeatMapData
+	6,  // 1: GetSpendingTypesResponse.spending_types:type_name -> SpendingType
+	9,  // 2: Expense.custom_fields:type_name -> CustomFieldValue
+	9,  // 3: ListExpensesRequest.custom_fields:type_name -> CustomFieldValue
+	8,  // 4: ListExpensesResponse.expenses:type_name -> Expense
+	9,  // 5: AddExpenseRequest.custom_fields:type_name -> CustomFieldValue
+	8,  // 6: AddExpenseResponse.expense:type_name -> Expense
+	9,  // 7: UpdateExpenseRequest.custom_fields:type_name -> CustomFieldValue
+	8,  // 8: UpdateExpenseResponse.expense:type_name -> Expense
+	21, // 9: ListTagsResponse.tags:type_name -> TagCount
+	23, // 10: CustomFieldResponse.field:type_name -> CustomField
+	23, // 11: ListCustomFieldsResponse.fields:type_name -> CustomField
+	8,  // 12: SearchResult.expense:type_name -> Expense
+	33, // 13: SearchFacets.categories:type_name -> FacetValue
+	33, // 14: SearchFacets.merchants:type_name -> FacetValue
+	33, // 15: SearchFacets.payment_methods:type_name -> FacetValue
+	33, // 16: SearchFacets.currencies:type_name -> FacetValue
+	33, // 17: SearchFacets.amount_ranges:type_name -> FacetValue
+	32, // 18: SearchExpensesResponse.results:type_name -> SearchResult
+	34, // 19: SearchExpensesResponse.facets:type_name -> SearchFacets
+	37, // 20: CompareSpendingResponse.current:type_name -> SpendingPeriod
+	37, // 21: CompareSpendingResponse.previous:type_name -> SpendingPeriod
+	38, // 22: CompareSpendingResponse.categories:type_name -> SpendingChange
+	38, // 23: CompareSpendingResponse.merchants:type_name -> SpendingChange
+	0,  // 24: ExpensesService.CreateExpense:input_type -> CreateExpenseRequest
+	2,  // 25: ExpensesService.GetHeatMapData:input_type -> GetHeatMapDataRequest
+	5,  // 26: ExpensesService.GetSpendingTypes:input_type -> GetSpendingTypesRequest
+	10, // 27: ExpensesService.ListExpenses:input_type -> ListExpensesRequest
+	12, // 28: ExpensesService.SetExpenseGroup:input_type -> SetExpenseGroupRequest
+	14, // 29: ExpensesService.AddExpense:input_type -> AddExpenseRequest
+	16, // 30: ExpensesService.UpdateExpense:input_type -> UpdateExpenseRequest
+	18, // 31: ExpensesService.ExportExpenses:input_type -> ExportExpensesRequest
+	20, // 32: ExpensesService.ListTags:input_type -> ListTagsRequest
+	25, // 33: ExpensesService.CreateCustomField:input_type -> CreateCustomFieldRequest
+	26, // 34: ExpensesService.UpdateCustomField:input_type -> UpdateCustomFieldRequest
+	27, // 35: ExpensesService.DeleteCustomField:input_type -> DeleteCustomFieldRequest
+	29, // 36: ExpensesService.ListCustomFields:input_type -> ListCustomFieldsRequest
+	31, // 37: ExpensesService.SearchExpenses:input_type -> SearchExpensesRequest
+	36, // 38: ExpensesService.CompareSpending:input_type -> CompareSpendingRequest
+	1,  // 39: ExpensesService.CreateExpense:output_type -> CreateExpenseResponse
+	3,  // 40: ExpensesService.GetHeatMapData:output_type -> GetHeatMapDataResponse
+	7,  // 41: ExpensesService.GetSpendingTypes:output_type -> GetSpendingTypesResponse
+	11, // 42: ExpensesService.ListExpenses:output_type -> ListExpensesResponse
+	13, // 43: ExpensesService.SetExpenseGroup:output_type -> SetExpenseGroupResponse
+	15, // 44: ExpensesService.AddExpense:output_type -> AddExpenseResponse
+	17, // 45: ExpensesService.UpdateExpense:output_type -> UpdateExpenseResponse
+	19, // 46: ExpensesService.ExportExpenses:output_type -> ExportExpensesResponse
+	22, // 47: ExpensesService.ListTags:output_type -> ListTagsResponse
+	24, // 48: ExpensesService.CreateCustomField:output_type -> CustomFieldResponse
+	24, // 49: ExpensesService.UpdateCustomField:output_type -> CustomFieldResponse
+	28, // 50: ExpensesService.DeleteCustomField:output_type -> DeleteCustomFieldResponse
+	30, // 51: ExpensesService.ListCustomFields:output_type -> ListCustomFieldsResponse
+	35, // 52: ExpensesService.SearchExpenses:output_type -> SearchExpensesResponse
+	39, // 53: ExpensesService.CompareSpending:output_type -> CompareSpendingResponse
+	39, // [39:54] is the sub-list for method output_type
+	24, // [24:39] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_proto_expenses_proto_init() }
@@ -470,13 +3300,16 @@ func file_proto_expenses_proto_init() {
 	if File_proto_expenses_proto != nil {
 		return
 	}
+	file_proto_expenses_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_expenses_proto_msgTypes[38].OneofWrappers = []any{}
+	file_proto_expenses_proto_msgTypes[39].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_expenses_proto_rawDesc), len(file_proto_expenses_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
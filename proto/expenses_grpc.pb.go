@@ -19,18 +19,51 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ExpensesService_CreateExpense_FullMethodName    = "/ExpensesService/CreateExpense"
-	ExpensesService_GetHeatMapData_FullMethodName   = "/ExpensesService/GetHeatMapData"
-	ExpensesService_GetSpendingTypes_FullMethodName = "/ExpensesService/GetSpendingTypes"
+	ExpensesService_CreateExpense_FullMethodName     = "/ExpensesService/CreateExpense"
+	ExpensesService_GetHeatMapData_FullMethodName    = "/ExpensesService/GetHeatMapData"
+	ExpensesService_GetSpendingTypes_FullMethodName  = "/ExpensesService/GetSpendingTypes"
+	ExpensesService_ListExpenses_FullMethodName      = "/ExpensesService/ListExpenses"
+	ExpensesService_SetExpenseGroup_FullMethodName   = "/ExpensesService/SetExpenseGroup"
+	ExpensesService_AddExpense_FullMethodName        = "/ExpensesService/AddExpense"
+	ExpensesService_UpdateExpense_FullMethodName     = "/ExpensesService/UpdateExpense"
+	ExpensesService_ExportExpenses_FullMethodName    = "/ExpensesService/ExportExpenses"
+	ExpensesService_ListTags_FullMethodName          = "/ExpensesService/ListTags"
+	ExpensesService_CreateCustomField_FullMethodName = "/ExpensesService/CreateCustomField"
+	ExpensesService_UpdateCustomField_FullMethodName = "/ExpensesService/UpdateCustomField"
+	ExpensesService_DeleteCustomField_FullMethodName = "/ExpensesService/DeleteCustomField"
+	ExpensesService_ListCustomFields_FullMethodName  = "/ExpensesService/ListCustomFields"
+	ExpensesService_SearchExpenses_FullMethodName    = "/ExpensesService/SearchExpenses"
+	ExpensesService_CompareSpending_FullMethodName   = "/ExpensesService/CompareSpending"
 )
 
 // ExpensesServiceClient is the client API for ExpensesService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// List RPCs share a pagination contract. page_size caps the results
+// returned, each RPC giving its default and maximum. next_page_token is set
+// when more results follow; passing it as page_token returns the next page,
+// and it is only valid with the same order_by and filters. order_by names a
+// field and optionally a direction, as in "amount desc"; expenses can be
+// ordered by "date_and_time" or "amount" and default to "date_and_time
+// desc". total_count counts every result across pages, and is only worked
+// out when include_total_count is set.
 type ExpensesServiceClient interface {
 	CreateExpense(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateExpenseRequest, CreateExpenseResponse], error)
 	GetHeatMapData(ctx context.Context, in *GetHeatMapDataRequest, opts ...grpc.CallOption) (*GetHeatMapDataResponse, error)
 	GetSpendingTypes(ctx context.Context, in *GetSpendingTypesRequest, opts ...grpc.CallOption) (*GetSpendingTypesResponse, error)
+	ListExpenses(ctx context.Context, in *ListExpensesRequest, opts ...grpc.CallOption) (*ListExpensesResponse, error)
+	SetExpenseGroup(ctx context.Context, in *SetExpenseGroupRequest, opts ...grpc.CallOption) (*SetExpenseGroupResponse, error)
+	AddExpense(ctx context.Context, in *AddExpenseRequest, opts ...grpc.CallOption) (*AddExpenseResponse, error)
+	UpdateExpense(ctx context.Context, in *UpdateExpenseRequest, opts ...grpc.CallOption) (*UpdateExpenseResponse, error)
+	ExportExpenses(ctx context.Context, in *ExportExpensesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportExpensesResponse], error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	CreateCustomField(ctx context.Context, in *CreateCustomFieldRequest, opts ...grpc.CallOption) (*CustomFieldResponse, error)
+	UpdateCustomField(ctx context.Context, in *UpdateCustomFieldRequest, opts ...grpc.CallOption) (*CustomFieldResponse, error)
+	DeleteCustomField(ctx context.Context, in *DeleteCustomFieldRequest, opts ...grpc.CallOption) (*DeleteCustomFieldResponse, error)
+	ListCustomFields(ctx context.Context, in *ListCustomFieldsRequest, opts ...grpc.CallOption) (*ListCustomFieldsResponse, error)
+	SearchExpenses(ctx context.Context, in *SearchExpensesRequest, opts ...grpc.CallOption) (*SearchExpensesResponse, error)
+	CompareSpending(ctx context.Context, in *CompareSpendingRequest, opts ...grpc.CallOption) (*CompareSpendingResponse, error)
 }
 
 type expensesServiceClient struct {
@@ -74,13 +107,163 @@ func (c *expensesServiceClient) GetSpendingTypes(ctx context.Context, in *GetSpe
 	return out, nil
 }
 
+func (c *expensesServiceClient) ListExpenses(ctx context.Context, in *ListExpensesRequest, opts ...grpc.CallOption) (*ListExpensesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExpensesResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_ListExpenses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) SetExpenseGroup(ctx context.Context, in *SetExpenseGroupRequest, opts ...grpc.CallOption) (*SetExpenseGroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetExpenseGroupResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_SetExpenseGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) AddExpense(ctx context.Context, in *AddExpenseRequest, opts ...grpc.CallOption) (*AddExpenseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddExpenseResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_AddExpense_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) UpdateExpense(ctx context.Context, in *UpdateExpenseRequest, opts ...grpc.CallOption) (*UpdateExpenseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateExpenseResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_UpdateExpense_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) ExportExpenses(ctx context.Context, in *ExportExpensesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportExpensesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExpensesService_ServiceDesc.Streams[1], ExpensesService_ExportExpenses_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportExpensesRequest, ExportExpensesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExpensesService_ExportExpensesClient = grpc.ServerStreamingClient[ExportExpensesResponse]
+
+func (c *expensesServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) CreateCustomField(ctx context.Context, in *CreateCustomFieldRequest, opts ...grpc.CallOption) (*CustomFieldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomFieldResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_CreateCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) UpdateCustomField(ctx context.Context, in *UpdateCustomFieldRequest, opts ...grpc.CallOption) (*CustomFieldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomFieldResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_UpdateCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) DeleteCustomField(ctx context.Context, in *DeleteCustomFieldRequest, opts ...grpc.CallOption) (*DeleteCustomFieldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCustomFieldResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_DeleteCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) ListCustomFields(ctx context.Context, in *ListCustomFieldsRequest, opts ...grpc.CallOption) (*ListCustomFieldsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCustomFieldsResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_ListCustomFields_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) SearchExpenses(ctx context.Context, in *SearchExpensesRequest, opts ...grpc.CallOption) (*SearchExpensesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchExpensesResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_SearchExpenses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expensesServiceClient) CompareSpending(ctx context.Context, in *CompareSpendingRequest, opts ...grpc.CallOption) (*CompareSpendingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareSpendingResponse)
+	err := c.cc.Invoke(ctx, ExpensesService_CompareSpending_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExpensesServiceServer is the server API for ExpensesService service.
 // All implementations must embed UnimplementedExpensesServiceServer
 // for forward compatibility.
+//
+// List RPCs share a pagination contract. page_size caps the results
+// returned, each RPC giving its default and maximum. next_page_token is set
+// when more results follow; passing it as page_token returns the next page,
+// and it is only valid with the same order_by and filters. order_by names a
+// field and optionally a direction, as in "amount desc"; expenses can be
+// ordered by "date_and_time" or "amount" and default to "date_and_time
+// desc". total_count counts every result across pages, and is only worked
+// out when include_total_count is set.
 type ExpensesServiceServer interface {
 	CreateExpense(grpc.ClientStreamingServer[CreateExpenseRequest, CreateExpenseResponse]) error
 	GetHeatMapData(context.Context, *GetHeatMapDataRequest) (*GetHeatMapDataResponse, error)
 	GetSpendingTypes(context.Context, *GetSpendingTypesRequest) (*GetSpendingTypesResponse, error)
+	ListExpenses(context.Context, *ListExpensesRequest) (*ListExpensesResponse, error)
+	SetExpenseGroup(context.Context, *SetExpenseGroupRequest) (*SetExpenseGroupResponse, error)
+	AddExpense(context.Context, *AddExpenseRequest) (*AddExpenseResponse, error)
+	UpdateExpense(context.Context, *UpdateExpenseRequest) (*UpdateExpenseResponse, error)
+	ExportExpenses(*ExportExpensesRequest, grpc.ServerStreamingServer[ExportExpensesResponse]) error
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	CreateCustomField(context.Context, *CreateCustomFieldRequest) (*CustomFieldResponse, error)
+	UpdateCustomField(context.Context, *UpdateCustomFieldRequest) (*CustomFieldResponse, error)
+	DeleteCustomField(context.Context, *DeleteCustomFieldRequest) (*DeleteCustomFieldResponse, error)
+	ListCustomFields(context.Context, *ListCustomFieldsRequest) (*ListCustomFieldsResponse, error)
+	SearchExpenses(context.Context, *SearchExpensesRequest) (*SearchExpensesResponse, error)
+	CompareSpending(context.Context, *CompareSpendingRequest) (*CompareSpendingResponse, error)
 	mustEmbedUnimplementedExpensesServiceServer()
 }
 
@@ -100,6 +283,42 @@ func (UnimplementedExpensesServiceServer) GetHeatMapData(context.Context, *GetHe
 func (UnimplementedExpensesServiceServer) GetSpendingTypes(context.Context, *GetSpendingTypesRequest) (*GetSpendingTypesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSpendingTypes not implemented")
 }
+func (UnimplementedExpensesServiceServer) ListExpenses(context.Context, *ListExpensesRequest) (*ListExpensesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpenses not implemented")
+}
+func (UnimplementedExpensesServiceServer) SetExpenseGroup(context.Context, *SetExpenseGroupRequest) (*SetExpenseGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExpenseGroup not implemented")
+}
+func (UnimplementedExpensesServiceServer) AddExpense(context.Context, *AddExpenseRequest) (*AddExpenseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddExpense not implemented")
+}
+func (UnimplementedExpensesServiceServer) UpdateExpense(context.Context, *UpdateExpenseRequest) (*UpdateExpenseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateExpense not implemented")
+}
+func (UnimplementedExpensesServiceServer) ExportExpenses(*ExportExpensesRequest, grpc.ServerStreamingServer[ExportExpensesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportExpenses not implemented")
+}
+func (UnimplementedExpensesServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedExpensesServiceServer) CreateCustomField(context.Context, *CreateCustomFieldRequest) (*CustomFieldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCustomField not implemented")
+}
+func (UnimplementedExpensesServiceServer) UpdateCustomField(context.Context, *UpdateCustomFieldRequest) (*CustomFieldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCustomField not implemented")
+}
+func (UnimplementedExpensesServiceServer) DeleteCustomField(context.Context, *DeleteCustomFieldRequest) (*DeleteCustomFieldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCustomField not implemented")
+}
+func (UnimplementedExpensesServiceServer) ListCustomFields(context.Context, *ListCustomFieldsRequest) (*ListCustomFieldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCustomFields not implemented")
+}
+func (UnimplementedExpensesServiceServer) SearchExpenses(context.Context, *SearchExpensesRequest) (*SearchExpensesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchExpenses not implemented")
+}
+func (UnimplementedExpensesServiceServer) CompareSpending(context.Context, *CompareSpendingRequest) (*CompareSpendingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareSpending not implemented")
+}
 func (UnimplementedExpensesServiceServer) mustEmbedUnimplementedExpensesServiceServer() {}
 func (UnimplementedExpensesServiceServer) testEmbeddedByValue()                         {}
 
@@ -164,6 +383,215 @@ func _ExpensesService_GetSpendingTypes_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExpensesService_ListExpenses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpensesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).ListExpenses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_ListExpenses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).ListExpenses(ctx, req.(*ListExpensesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_SetExpenseGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExpenseGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).SetExpenseGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_SetExpenseGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).SetExpenseGroup(ctx, req.(*SetExpenseGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_AddExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).AddExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_AddExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).AddExpense(ctx, req.(*AddExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_UpdateExpense_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExpenseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).UpdateExpense(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_UpdateExpense_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).UpdateExpense(ctx, req.(*UpdateExpenseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_ExportExpenses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportExpensesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExpensesServiceServer).ExportExpenses(m, &grpc.GenericServerStream[ExportExpensesRequest, ExportExpensesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExpensesService_ExportExpensesServer = grpc.ServerStreamingServer[ExportExpensesResponse]
+
+func _ExpensesService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_CreateCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCustomFieldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).CreateCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_CreateCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).CreateCustomField(ctx, req.(*CreateCustomFieldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_UpdateCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCustomFieldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).UpdateCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_UpdateCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).UpdateCustomField(ctx, req.(*UpdateCustomFieldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_DeleteCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCustomFieldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).DeleteCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_DeleteCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).DeleteCustomField(ctx, req.(*DeleteCustomFieldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_ListCustomFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCustomFieldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).ListCustomFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_ListCustomFields_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).ListCustomFields(ctx, req.(*ListCustomFieldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_SearchExpenses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchExpensesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).SearchExpenses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_SearchExpenses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).SearchExpenses(ctx, req.(*SearchExpensesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpensesService_CompareSpending_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareSpendingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpensesServiceServer).CompareSpending(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpensesService_CompareSpending_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpensesServiceServer).CompareSpending(ctx, req.(*CompareSpendingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ExpensesService_ServiceDesc is the grpc.ServiceDesc for ExpensesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -179,6 +607,50 @@ var ExpensesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSpendingTypes",
 			Handler:    _ExpensesService_GetSpendingTypes_Handler,
 		},
+		{
+			MethodName: "ListExpenses",
+			Handler:    _ExpensesService_ListExpenses_Handler,
+		},
+		{
+			MethodName: "SetExpenseGroup",
+			Handler:    _ExpensesService_SetExpenseGroup_Handler,
+		},
+		{
+			MethodName: "AddExpense",
+			Handler:    _ExpensesService_AddExpense_Handler,
+		},
+		{
+			MethodName: "UpdateExpense",
+			Handler:    _ExpensesService_UpdateExpense_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _ExpensesService_ListTags_Handler,
+		},
+		{
+			MethodName: "CreateCustomField",
+			Handler:    _ExpensesService_CreateCustomField_Handler,
+		},
+		{
+			MethodName: "UpdateCustomField",
+			Handler:    _ExpensesService_UpdateCustomField_Handler,
+		},
+		{
+			MethodName: "DeleteCustomField",
+			Handler:    _ExpensesService_DeleteCustomField_Handler,
+		},
+		{
+			MethodName: "ListCustomFields",
+			Handler:    _ExpensesService_ListCustomFields_Handler,
+		},
+		{
+			MethodName: "SearchExpenses",
+			Handler:    _ExpensesService_SearchExpenses_Handler,
+		},
+		{
+			MethodName: "CompareSpending",
+			Handler:    _ExpensesService_CompareSpending_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -186,6 +658,11 @@ var ExpensesService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ExpensesService_CreateExpense_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ExportExpenses",
+			Handler:       _ExpensesService_ExportExpenses_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/expenses.proto",
 }
@@ -0,0 +1,2356 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.21.12
+// source: proto/groups.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Group struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	MemberCount   int32                  `protobuf:"varint,4,opt,name=memberCount,proto3" json:"memberCount,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_proto_groups_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Group) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Group) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *Group) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GroupMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	FirstName     string                 `protobuf:"bytes,3,opt,name=firstName,proto3" json:"firstName,omitempty"`
+	LastName      string                 `protobuf:"bytes,4,opt,name=lastName,proto3" json:"lastName,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	JoinedAt      string                 `protobuf:"bytes,6,opt,name=joinedAt,proto3" json:"joinedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupMember) Reset() {
+	*x = GroupMember{}
+	mi := &file_proto_groups_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMember) ProtoMessage() {}
+
+func (x *GroupMember) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMember.ProtoReflect.Descriptor instead.
+func (*GroupMember) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GroupMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GroupMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GroupMember) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *GroupMember) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *GroupMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GroupMember) GetJoinedAt() string {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return ""
+}
+
+type GroupInvitation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	GroupName     string                 `protobuf:"bytes,3,opt,name=groupName,proto3" json:"groupName,omitempty"`
+	InvitedBy     string                 `protobuf:"bytes,4,opt,name=invitedBy,proto3" json:"invitedBy,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,7,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupInvitation) Reset() {
+	*x = GroupInvitation{}
+	mi := &file_proto_groups_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupInvitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupInvitation) ProtoMessage() {}
+
+func (x *GroupInvitation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupInvitation.ProtoReflect.Descriptor instead.
+func (*GroupInvitation) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GroupInvitation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GroupInvitation) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GroupInvitation) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *GroupInvitation) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *GroupInvitation) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GroupInvitation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *GroupInvitation) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type CreateGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupRequest) Reset() {
+	*x = CreateGroupRequest{}
+	mi := &file_proto_groups_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupRequest) ProtoMessage() {}
+
+func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *Group                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupResponse) Reset() {
+	*x = CreateGroupResponse{}
+	mi := &file_proto_groups_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupResponse) ProtoMessage() {}
+
+func (x *CreateGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateGroupResponse) GetGroup() *Group {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// orderBy is "name", the default, or "created_at", and pages hold up to
+// 1000 groups, 100 by default, as described on ExpensesService.
+type ListGroupsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PageSize          int32                  `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	PageToken         string                 `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,3,opt,name=orderBy,proto3" json:"orderBy,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,4,opt,name=includeTotalCount,proto3" json:"includeTotalCount,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListGroupsRequest) Reset() {
+	*x = ListGroupsRequest{}
+	mi := &file_proto_groups_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsRequest) ProtoMessage() {}
+
+func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListGroupsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListGroupsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListGroupsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListGroupsRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+type ListGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*Group               `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=totalCount,proto3" json:"totalCount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsResponse) Reset() {
+	*x = ListGroupsResponse{}
+	mi := &file_proto_groups_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsResponse) ProtoMessage() {}
+
+func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListGroupsResponse) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *ListGroupsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListGroupsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGroupRequest) Reset() {
+	*x = GetGroupRequest{}
+	mi := &file_proto_groups_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGroupRequest) ProtoMessage() {}
+
+func (x *GetGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+type GetGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *Group                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Members       []*GroupMember         `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGroupResponse) Reset() {
+	*x = GetGroupResponse{}
+	mi := &file_proto_groups_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGroupResponse) ProtoMessage() {}
+
+func (x *GetGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGroupResponse.ProtoReflect.Descriptor instead.
+func (*GetGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetGroupResponse) GetGroup() *Group {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *GetGroupResponse) GetMembers() []*GroupMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Exactly one of username or email identifies the invitee. Inviting an email
+// address without an account sends an invitation that can be accepted after
+// signing up with that address.
+type InviteToGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteToGroupRequest) Reset() {
+	*x = InviteToGroupRequest{}
+	mi := &file_proto_groups_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteToGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteToGroupRequest) ProtoMessage() {}
+
+func (x *InviteToGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteToGroupRequest.ProtoReflect.Descriptor instead.
+func (*InviteToGroupRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InviteToGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *InviteToGroupRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *InviteToGroupRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InviteToGroupRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type InviteToGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvitationId  string                 `protobuf:"bytes,1,opt,name=invitationId,proto3" json:"invitationId,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteToGroupResponse) Reset() {
+	*x = InviteToGroupResponse{}
+	mi := &file_proto_groups_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteToGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteToGroupResponse) ProtoMessage() {}
+
+func (x *InviteToGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteToGroupResponse.ProtoReflect.Descriptor instead.
+func (*InviteToGroupResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InviteToGroupResponse) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+func (x *InviteToGroupResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListInvitationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsRequest) Reset() {
+	*x = ListInvitationsRequest{}
+	mi := &file_proto_groups_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsRequest) ProtoMessage() {}
+
+func (x *ListInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{11}
+}
+
+type ListInvitationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitations   []*GroupInvitation     `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsResponse) Reset() {
+	*x = ListInvitationsResponse{}
+	mi := &file_proto_groups_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsResponse) ProtoMessage() {}
+
+func (x *ListInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListInvitationsResponse) GetInvitations() []*GroupInvitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+type AcceptInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvitationId  string                 `protobuf:"bytes,1,opt,name=invitationId,proto3" json:"invitationId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInvitationRequest) Reset() {
+	*x = AcceptInvitationRequest{}
+	mi := &file_proto_groups_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInvitationRequest) ProtoMessage() {}
+
+func (x *AcceptInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInvitationRequest.ProtoReflect.Descriptor instead.
+func (*AcceptInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AcceptInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+type AcceptInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *Group                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptInvitationResponse) Reset() {
+	*x = AcceptInvitationResponse{}
+	mi := &file_proto_groups_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptInvitationResponse) ProtoMessage() {}
+
+func (x *AcceptInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptInvitationResponse.ProtoReflect.Descriptor instead.
+func (*AcceptInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AcceptInvitationResponse) GetGroup() *Group {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type SetMemberRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMemberRoleRequest) Reset() {
+	*x = SetMemberRoleRequest{}
+	mi := &file_proto_groups_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMemberRoleRequest) ProtoMessage() {}
+
+func (x *SetMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetMemberRoleRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *SetMemberRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetMemberRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type SetMemberRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMemberRoleResponse) Reset() {
+	*x = SetMemberRoleResponse{}
+	mi := &file_proto_groups_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMemberRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMemberRoleResponse) ProtoMessage() {}
+
+func (x *SetMemberRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMemberRoleResponse.ProtoReflect.Descriptor instead.
+func (*SetMemberRoleResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetMemberRoleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// RemoveMember removes another member, or leaves the group when userId is
+// the caller.
+type RemoveMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_proto_groups_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveMemberRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_proto_groups_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RemoveMemberResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// value is ignored for "equal" splits, an amount for "exact", a percentage
+// for "percentage" and a weight for "shares".
+type SplitShare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitShare) Reset() {
+	*x = SplitShare{}
+	mi := &file_proto_groups_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitShare) ProtoMessage() {}
+
+func (x *SplitShare) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitShare.ProtoReflect.Descriptor instead.
+func (*SplitShare) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SplitShare) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SplitShare) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// SplitExpense divides a group expense between members. method is "equal",
+// "exact", "percentage" or "shares"; an equal split without shares covers
+// every member. paidBy defaults to the member who recorded the expense.
+type SplitExpenseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpenseId     string                 `protobuf:"bytes,1,opt,name=expenseId,proto3" json:"expenseId,omitempty"`
+	PaidBy        string                 `protobuf:"bytes,2,opt,name=paidBy,proto3" json:"paidBy,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Shares        []*SplitShare          `protobuf:"bytes,4,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitExpenseRequest) Reset() {
+	*x = SplitExpenseRequest{}
+	mi := &file_proto_groups_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitExpenseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitExpenseRequest) ProtoMessage() {}
+
+func (x *SplitExpenseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitExpenseRequest.ProtoReflect.Descriptor instead.
+func (*SplitExpenseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SplitExpenseRequest) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+func (x *SplitExpenseRequest) GetPaidBy() string {
+	if x != nil {
+		return x.PaidBy
+	}
+	return ""
+}
+
+func (x *SplitExpenseRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *SplitExpenseRequest) GetShares() []*SplitShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type ExpenseSplit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpenseSplit) Reset() {
+	*x = ExpenseSplit{}
+	mi := &file_proto_groups_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpenseSplit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpenseSplit) ProtoMessage() {}
+
+func (x *ExpenseSplit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpenseSplit.ProtoReflect.Descriptor instead.
+func (*ExpenseSplit) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ExpenseSplit) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExpenseSplit) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ExpenseSplit) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type SplitExpenseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaidBy        string                 `protobuf:"bytes,1,opt,name=paidBy,proto3" json:"paidBy,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Splits        []*ExpenseSplit        `protobuf:"bytes,3,rep,name=splits,proto3" json:"splits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitExpenseResponse) Reset() {
+	*x = SplitExpenseResponse{}
+	mi := &file_proto_groups_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitExpenseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitExpenseResponse) ProtoMessage() {}
+
+func (x *SplitExpenseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitExpenseResponse.ProtoReflect.Descriptor instead.
+func (*SplitExpenseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SplitExpenseResponse) GetPaidBy() string {
+	if x != nil {
+		return x.PaidBy
+	}
+	return ""
+}
+
+func (x *SplitExpenseResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SplitExpenseResponse) GetSplits() []*ExpenseSplit {
+	if x != nil {
+		return x.Splits
+	}
+	return nil
+}
+
+type GetBalancesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalancesRequest) Reset() {
+	*x = GetBalancesRequest{}
+	mi := &file_proto_groups_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalancesRequest) ProtoMessage() {}
+
+func (x *GetBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalancesRequest.ProtoReflect.Descriptor instead.
+func (*GetBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetBalancesRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+// A positive net means the member is owed money.
+type MemberBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Net           float64                `protobuf:"fixed64,4,opt,name=net,proto3" json:"net,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberBalance) Reset() {
+	*x = MemberBalance{}
+	mi := &file_proto_groups_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberBalance) ProtoMessage() {}
+
+func (x *MemberBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberBalance.ProtoReflect.Descriptor instead.
+func (*MemberBalance) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MemberBalance) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MemberBalance) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *MemberBalance) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *MemberBalance) GetNet() float64 {
+	if x != nil {
+		return x.Net
+	}
+	return 0
+}
+
+type Transfer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromUserId    string                 `protobuf:"bytes,1,opt,name=fromUserId,proto3" json:"fromUserId,omitempty"`
+	FromUsername  string                 `protobuf:"bytes,2,opt,name=fromUsername,proto3" json:"fromUsername,omitempty"`
+	ToUserId      string                 `protobuf:"bytes,3,opt,name=toUserId,proto3" json:"toUserId,omitempty"`
+	ToUsername    string                 `protobuf:"bytes,4,opt,name=toUsername,proto3" json:"toUsername,omitempty"`
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transfer) Reset() {
+	*x = Transfer{}
+	mi := &file_proto_groups_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transfer) ProtoMessage() {}
+
+func (x *Transfer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transfer.ProtoReflect.Descriptor instead.
+func (*Transfer) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Transfer) GetFromUserId() string {
+	if x != nil {
+		return x.FromUserId
+	}
+	return ""
+}
+
+func (x *Transfer) GetFromUsername() string {
+	if x != nil {
+		return x.FromUsername
+	}
+	return ""
+}
+
+func (x *Transfer) GetToUserId() string {
+	if x != nil {
+		return x.ToUserId
+	}
+	return ""
+}
+
+func (x *Transfer) GetToUsername() string {
+	if x != nil {
+		return x.ToUsername
+	}
+	return ""
+}
+
+func (x *Transfer) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transfer) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// transfers is the smallest set of payments found that settles every
+// balance.
+type GetBalancesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balances      []*MemberBalance       `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	Transfers     []*Transfer            `protobuf:"bytes,2,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalancesResponse) Reset() {
+	*x = GetBalancesResponse{}
+	mi := &file_proto_groups_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalancesResponse) ProtoMessage() {}
+
+func (x *GetBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalancesResponse.ProtoReflect.Descriptor instead.
+func (*GetBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetBalancesResponse) GetBalances() []*MemberBalance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+func (x *GetBalancesResponse) GetTransfers() []*Transfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+// fromUserId defaults to the caller.
+type RecordSettlementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	FromUserId    string                 `protobuf:"bytes,2,opt,name=fromUserId,proto3" json:"fromUserId,omitempty"`
+	ToUserId      string                 `protobuf:"bytes,3,opt,name=toUserId,proto3" json:"toUserId,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Note          string                 `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordSettlementRequest) Reset() {
+	*x = RecordSettlementRequest{}
+	mi := &file_proto_groups_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordSettlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSettlementRequest) ProtoMessage() {}
+
+func (x *RecordSettlementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSettlementRequest.ProtoReflect.Descriptor instead.
+func (*RecordSettlementRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RecordSettlementRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *RecordSettlementRequest) GetFromUserId() string {
+	if x != nil {
+		return x.FromUserId
+	}
+	return ""
+}
+
+func (x *RecordSettlementRequest) GetToUserId() string {
+	if x != nil {
+		return x.ToUserId
+	}
+	return ""
+}
+
+func (x *RecordSettlementRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RecordSettlementRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RecordSettlementRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type RecordSettlementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SettlementId  string                 `protobuf:"bytes,1,opt,name=settlementId,proto3" json:"settlementId,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordSettlementResponse) Reset() {
+	*x = RecordSettlementResponse{}
+	mi := &file_proto_groups_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordSettlementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSettlementResponse) ProtoMessage() {}
+
+func (x *RecordSettlementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSettlementResponse.ProtoReflect.Descriptor instead.
+func (*RecordSettlementResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RecordSettlementResponse) GetSettlementId() string {
+	if x != nil {
+		return x.SettlementId
+	}
+	return ""
+}
+
+func (x *RecordSettlementResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// An item shared by several members is divided equally between them.
+type ItemAssignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        string                 `protobuf:"bytes,1,opt,name=itemId,proto3" json:"itemId,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=userIds,proto3" json:"userIds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemAssignment) Reset() {
+	*x = ItemAssignment{}
+	mi := &file_proto_groups_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemAssignment) ProtoMessage() {}
+
+func (x *ItemAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemAssignment.ProtoReflect.Descriptor instead.
+func (*ItemAssignment) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ItemAssignment) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ItemAssignment) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+// AssignExpenseItems splits an expense by its receipt line items. Items left
+// out are shared by everyone who was assigned something, and the difference
+// between the items and the total (tax, tip, discounts) is divided in
+// proportion to each member's items.
+type AssignExpenseItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpenseId     string                 `protobuf:"bytes,1,opt,name=expenseId,proto3" json:"expenseId,omitempty"`
+	PaidBy        string                 `protobuf:"bytes,2,opt,name=paidBy,proto3" json:"paidBy,omitempty"`
+	Assignments   []*ItemAssignment      `protobuf:"bytes,3,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignExpenseItemsRequest) Reset() {
+	*x = AssignExpenseItemsRequest{}
+	mi := &file_proto_groups_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignExpenseItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignExpenseItemsRequest) ProtoMessage() {}
+
+func (x *AssignExpenseItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignExpenseItemsRequest.ProtoReflect.Descriptor instead.
+func (*AssignExpenseItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AssignExpenseItemsRequest) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+func (x *AssignExpenseItemsRequest) GetPaidBy() string {
+	if x != nil {
+		return x.PaidBy
+	}
+	return ""
+}
+
+func (x *AssignExpenseItemsRequest) GetAssignments() []*ItemAssignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+// confidence is the share of similar past items this member was assigned.
+type AssignmentSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Occurrences   int32                  `protobuf:"varint,4,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentSuggestion) Reset() {
+	*x = AssignmentSuggestion{}
+	mi := &file_proto_groups_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentSuggestion) ProtoMessage() {}
+
+func (x *AssignmentSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentSuggestion.ProtoReflect.Descriptor instead.
+func (*AssignmentSuggestion) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AssignmentSuggestion) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AssignmentSuggestion) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AssignmentSuggestion) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *AssignmentSuggestion) GetOccurrences() int32 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return 0
+}
+
+type ExpenseItemSuggestion struct {
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	ItemId          string                  `protobuf:"bytes,1,opt,name=itemId,proto3" json:"itemId,omitempty"`
+	ItemName        string                  `protobuf:"bytes,2,opt,name=itemName,proto3" json:"itemName,omitempty"`
+	Price           float64                 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity        int32                   `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	AssignedUserIds []string                `protobuf:"bytes,5,rep,name=assignedUserIds,proto3" json:"assignedUserIds,omitempty"`
+	Suggestions     []*AssignmentSuggestion `protobuf:"bytes,6,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExpenseItemSuggestion) Reset() {
+	*x = ExpenseItemSuggestion{}
+	mi := &file_proto_groups_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpenseItemSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpenseItemSuggestion) ProtoMessage() {}
+
+func (x *ExpenseItemSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpenseItemSuggestion.ProtoReflect.Descriptor instead.
+func (*ExpenseItemSuggestion) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ExpenseItemSuggestion) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ExpenseItemSuggestion) GetItemName() string {
+	if x != nil {
+		return x.ItemName
+	}
+	return ""
+}
+
+func (x *ExpenseItemSuggestion) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ExpenseItemSuggestion) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ExpenseItemSuggestion) GetAssignedUserIds() []string {
+	if x != nil {
+		return x.AssignedUserIds
+	}
+	return nil
+}
+
+func (x *ExpenseItemSuggestion) GetSuggestions() []*AssignmentSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type SuggestItemAssignmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpenseId     string                 `protobuf:"bytes,1,opt,name=expenseId,proto3" json:"expenseId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestItemAssignmentsRequest) Reset() {
+	*x = SuggestItemAssignmentsRequest{}
+	mi := &file_proto_groups_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestItemAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestItemAssignmentsRequest) ProtoMessage() {}
+
+func (x *SuggestItemAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestItemAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestItemAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SuggestItemAssignmentsRequest) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+type SuggestItemAssignmentsResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Items         []*ExpenseItemSuggestion `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestItemAssignmentsResponse) Reset() {
+	*x = SuggestItemAssignmentsResponse{}
+	mi := &file_proto_groups_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestItemAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestItemAssignmentsResponse) ProtoMessage() {}
+
+func (x *SuggestItemAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_groups_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestItemAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestItemAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_groups_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SuggestItemAssignmentsResponse) GetItems() []*ExpenseItemSuggestion {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_proto_groups_proto protoreflect.FileDescriptor
+
+const file_proto_groups_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/groups.proto\"\x7f\n" +
+	"\x05Group\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12 \n" +
+	"\vmemberCount\x18\x04 \x01(\x05R\vmemberCount\x12\x1c\n" +
+	"\tcreatedAt\x18\x05 \x01(\tR\tcreatedAt\"\xab\x01\n" +
+	"\vGroupMember\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1c\n" +
+	"\tfirstName\x18\x03 \x01(\tR\tfirstName\x12\x1a\n" +
+	"\blastName\x18\x04 \x01(\tR\blastName\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1a\n" +
+	"\bjoinedAt\x18\x06 \x01(\tR\bjoinedAt\"\xc7\x01\n" +
+	"\x0fGroupInvitation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\agroupId\x18\x02 \x01(\tR\agroupId\x12\x1c\n" +
+	"\tgroupName\x18\x03 \x01(\tR\tgroupName\x12\x1c\n" +
+	"\tinvitedBy\x18\x04 \x01(\tR\tinvitedBy\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x1c\n" +
+	"\tcreatedAt\x18\x06 \x01(\tR\tcreatedAt\x12\x1c\n" +
+	"\texpiresAt\x18\a \x01(\tR\texpiresAt\"(\n" +
+	"\x12CreateGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"3\n" +
+	"\x13CreateGroupResponse\x12\x1c\n" +
+	"\x05group\x18\x01 \x01(\v2\x06.GroupR\x05group\"\x95\x01\n" +
+	"\x11ListGroupsRequest\x12\x1a\n" +
+	"\bpageSize\x18\x01 \x01(\x05R\bpageSize\x12\x1c\n" +
+	"\tpageToken\x18\x02 \x01(\tR\tpageToken\x12\x18\n" +
+	"\aorderBy\x18\x03 \x01(\tR\aorderBy\x12,\n" +
+	"\x11includeTotalCount\x18\x04 \x01(\bR\x11includeTotalCount\"z\n" +
+	"\x12ListGroupsResponse\x12\x1e\n" +
+	"\x06groups\x18\x01 \x03(\v2\x06.GroupR\x06groups\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\x12\x1e\n" +
+	"\n" +
+	"totalCount\x18\x03 \x01(\x05R\n" +
+	"totalCount\"+\n" +
+	"\x0fGetGroupRequest\x12\x18\n" +
+	"\agroupId\x18\x01 \x01(\tR\agroupId\"X\n" +
+	"\x10GetGroupResponse\x12\x1c\n" +
+	"\x05group\x18\x01 \x01(\v2\x06.GroupR\x05group\x12&\n" +
+	"\amembers\x18\x02 \x03(\v2\f.GroupMemberR\amembers\"v\n" +
+	"\x14InviteToGroupRequest\x12\x18\n" +
+	"\agroupId\x18\x01 \x01(\tR\agroupId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"U\n" +
+	"\x15InviteToGroupResponse\x12\"\n" +
+	"\finvitationId\x18\x01 \x01(\tR\finvitationId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x18\n" +
+	"\x16ListInvitationsRequest\"M\n" +
+	"\x17ListInvitationsResponse\x122\n" +
+	"\vinvitations\x18\x01 \x03(\v2\x10.GroupInvitationR\vinvitations\"=\n" +
+	"\x17AcceptInvitationRequest\x12\"\n" +
+	"\finvitationId\x18\x01 \x01(\tR\finvitationId\"8\n" +
+	"\x18AcceptInvitationResponse\x12\x1c\n" +
+	"\x05group\x18\x01 \x01(\v2\x06.GroupR\x05group\"\\\n" +
+	"\x14SetMemberRoleRequest\x12\x18\n" +
+	"\agroupId\x18\x01 \x01(\tR\agroupId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"1\n" +
+	"\x15SetMemberRoleResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"G\n" +
+	"\x13RemoveMemberRequest\x12\x18\n" +
+	"\agroupId\x18\x01 \x01(\tR\agroupId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\"0\n" +
+	"\x14RemoveMemberResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\":\n" +
+	"\n" +
+	"SplitShare\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"\x88\x01\n" +
+	"\x13SplitExpenseRequest\x12\x1c\n" +
+	"\texpenseId\x18\x01 \x01(\tR\texpenseId\x12\x16\n" +
+	"\x06paidBy\x18\x02 \x01(\tR\x06paidBy\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12#\n" +
+	"\x06shares\x18\x04 \x03(\v2\v.SplitShareR\x06shares\"Z\n" +
+	"\fExpenseSplit\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\"q\n" +
+	"\x14SplitExpenseResponse\x12\x16\n" +
+	"\x06paidBy\x18\x01 \x01(\tR\x06paidBy\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12%\n" +
+	"\x06splits\x18\x03 \x03(\v2\r.ExpenseSplitR\x06splits\".\n" +
+	"\x12GetBalancesRequest\x12\x18\n" +
+	"\agroupId\x18\x01 \x01(\tR\agroupId\"q\n" +
+	"\rMemberBalance\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x10\n" +
+	"\x03net\x18\x04 \x01(\x01R\x03net\"\xbe\x01\n" +
+	"\bTransfer\x12\x1e\n" +
+	"\n" +
+	"fromUserId\x18\x01 \x01(\tR\n" +
+	"fromUserId\x12\"\n" +
+	"\ffromUsername\x18\x02 \x01(\tR\ffromUsername\x12\x1a\n" +
+	"\btoUserId\x18\x03 \x01(\tR\btoUserId\x12\x1e\n" +
+	"\n" +
+	"toUsername\x18\x04 \x01(\tR\n" +
+	"toUsername\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\"j\n" +
+	"\x13GetBalancesResponse\x12*\n" +
+	"\bbalances\x18\x01 \x03(\v2\x0e.MemberBalanceR\bbalances\x12'\n" +
+	"\ttransfers\x18\x02 \x03(\v2\t.TransferR\ttransfers\"\xb7\x01\n" +
+	"\x17RecordSettlementRequest\x12\x18\n" +
+	"\agroupId\x18\x01 \x01(\tR\agroupId\x12\x1e\n" +
+	"\n" +
+	"fromUserId\x18\x02 \x01(\tR\n" +
+	"fromUserId\x12\x1a\n" +
+	"\btoUserId\x18\x03 \x01(\tR\btoUserId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\"X\n" +
+	"\x18RecordSettlementResponse\x12\"\n" +
+	"\fsettlementId\x18\x01 \x01(\tR\fsettlementId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"B\n" +
+	"\x0eItemAssignment\x12\x16\n" +
+	"\x06itemId\x18\x01 \x01(\tR\x06itemId\x12\x18\n" +
+	"\auserIds\x18\x02 \x03(\tR\auserIds\"\x84\x01\n" +
+	"\x19AssignExpenseItemsRequest\x12\x1c\n" +
+	"\texpenseId\x18\x01 \x01(\tR\texpenseId\x12\x16\n" +
+	"\x06paidBy\x18\x02 \x01(\tR\x06paidBy\x121\n" +
+	"\vassignments\x18\x03 \x03(\v2\x0f.ItemAssignmentR\vassignments\"\x8c\x01\n" +
+	"\x14AssignmentSuggestion\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x03 \x01(\x01R\n" +
+	"confidence\x12 \n" +
+	"\voccurrences\x18\x04 \x01(\x05R\voccurrences\"\xe0\x01\n" +
+	"\x15ExpenseItemSuggestion\x12\x16\n" +
+	"\x06itemId\x18\x01 \x01(\tR\x06itemId\x12\x1a\n" +
+	"\bitemName\x18\x02 \x01(\tR\bitemName\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12(\n" +
+	"\x0fassignedUserIds\x18\x05 \x03(\tR\x0fassignedUserIds\x127\n" +
+	"\vsuggestions\x18\x06 \x03(\v2\x15.AssignmentSuggestionR\vsuggestions\"=\n" +
+	"\x1dSuggestItemAssignmentsRequest\x12\x1c\n" +
+	"\texpenseId\x18\x01 \x01(\tR\texpenseId\"N\n" +
+	"\x1eSuggestItemAssignmentsResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.ExpenseItemSuggestionR\x05items2\xe1\x06\n" +
+	"\rGroupsService\x128\n" +
+	"\vCreateGroup\x12\x13.CreateGroupRequest\x1a\x14.CreateGroupResponse\x125\n" +
+	"\n" +
+	"ListGroups\x12\x12.ListGroupsRequest\x1a\x13.ListGroupsResponse\x12/\n" +
+	"\bGetGroup\x12\x10.GetGroupRequest\x1a\x11.GetGroupResponse\x12>\n" +
+	"\rInviteToGroup\x12\x15.InviteToGroupRequest\x1a\x16.InviteToGroupResponse\x12D\n" +
+	"\x0fListInvitations\x12\x17.ListInvitationsRequest\x1a\x18.ListInvitationsResponse\x12G\n" +
+	"\x10AcceptInvitation\x12\x18.AcceptInvitationRequest\x1a\x19.AcceptInvitationResponse\x12>\n" +
+	"\rSetMemberRole\x12\x15.SetMemberRoleRequest\x1a\x16.SetMemberRoleResponse\x12;\n" +
+	"\fRemoveMember\x12\x14.RemoveMemberRequest\x1a\x15.RemoveMemberResponse\x12;\n" +
+	"\fSplitExpense\x12\x14.SplitExpenseRequest\x1a\x15.SplitExpenseResponse\x128\n" +
+	"\vGetBalances\x12\x13.GetBalancesRequest\x1a\x14.GetBalancesResponse\x12G\n" +
+	"\x10RecordSettlement\x12\x18.RecordSettlementRequest\x1a\x19.RecordSettlementResponse\x12G\n" +
+	"\x12AssignExpenseItems\x12\x1a.AssignExpenseItemsRequest\x1a\x15.SplitExpenseResponse\x12Y\n" +
+	"\x16SuggestItemAssignments\x12\x1e.SuggestItemAssignmentsRequest\x1a\x1f.SuggestItemAssignmentsResponseB+Z)github.com/barathsurya2004/expenses/protob\x06proto3"
+
+var (
+	file_proto_groups_proto_rawDescOnce sync.Once
+	file_proto_groups_proto_rawDescData []byte
+)
+
+func file_proto_groups_proto_rawDescGZIP() []byte {
+	file_proto_groups_proto_rawDescOnce.Do(func() {
+		file_proto_groups_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_groups_proto_rawDesc), len(file_proto_groups_proto_rawDesc)))
+	})
+	return file_proto_groups_proto_rawDescData
+}
+
+var file_proto_groups_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_proto_groups_proto_goTypes = []any{
+	(*Group)(nil),                          // 0: Group
+	(*GroupMember)(nil),                    // 1: GroupMember
+	(*GroupInvitation)(nil),                // 2: GroupInvitation
+	(*CreateGroupRequest)(nil),             // 3: CreateGroupRequest
+	(*CreateGroupResponse)(nil),            // 4: CreateGroupResponse
+	(*ListGroupsRequest)(nil),              // 5: ListGroupsRequest
+	(*ListGroupsResponse)(nil),             // 6: ListGroupsResponse
+	(*GetGroupRequest)(nil),                // 7: GetGroupRequest
+	(*GetGroupResponse)(nil),               // 8: GetGroupResponse
+	(*InviteToGroupRequest)(nil),           // 9: InviteToGroupRequest
+	(*InviteToGroupResponse)(nil),          // 10: InviteToGroupResponse
+	(*ListInvitationsRequest)(nil),         // 11: ListInvitationsRequest
+	(*ListInvitationsResponse)(nil),        // 12: ListInvitationsResponse
+	(*AcceptInvitationRequest)(nil),        // 13: AcceptInvitationRequest
+	(*AcceptInvitationResponse)(nil),       // 14: AcceptInvitationResponse
+	(*SetMemberRoleRequest)(nil),           // 15: SetMemberRoleRequest
+	(*SetMemberRoleResponse)(nil),          // 16: SetMemberRoleResponse
+	(*RemoveMemberRequest)(nil),            // 17: RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),           // 18: RemoveMemberResponse
+	(*SplitShare)(nil),                     // 19: SplitShare
+	(*SplitExpenseRequest)(nil),            // 20: SplitExpenseRequest
+	(*ExpenseSplit)(nil),                   // 21: ExpenseSplit
+	(*SplitExpenseResponse)(nil),           // 22: SplitExpenseResponse
+	(*GetBalancesRequest)(nil),             // 23: GetBalancesRequest
+	(*MemberBalance)(nil),                  // 24: MemberBalance
+	(*Transfer)(nil),                       // 25: Transfer
+	(*GetBalancesResponse)(nil),            // 26: GetBalancesResponse
+	(*RecordSettlementRequest)(nil),        // 27: RecordSettlementRequest
+	(*RecordSettlementResponse)(nil),       // 28: RecordSettlementResponse
+	(*ItemAssignment)(nil),                 // 29: ItemAssignment
+	(*AssignExpenseItemsRequest)(nil),      // 30: AssignExpenseItemsRequest
+	(*AssignmentSuggestion)(nil),           // 31: AssignmentSuggestion
+	(*ExpenseItemSuggestion)(nil),          // 32: ExpenseItemSuggestion
+	(*SuggestItemAssignmentsRequest)(nil),  // 33: SuggestItemAssignmentsRequest
+	(*SuggestItemAssignmentsResponse)(nil), // 34: SuggestItemAssignmentsResponse
+}
+var file_proto_groups_proto_depIdxs = []int32{
+	0,  // 0: CreateGroupResponse.group:type_name -> Group
+	0,  // 1: ListGroupsResponse.groups:type_name -> Group
+	0,  // 2: GetGroupResponse.group:type_name -> Group
+	1,  // 3: GetGroupResponse.members:type_name -> GroupMember
+	2,  // 4: ListInvitationsResponse.invitations:type_name -> GroupInvitation
+	0,  // 5: AcceptInvitationResponse.group:type_name -> Group
+	19, // 6: SplitExpenseRequest.shares:type_name -> SplitShare
+	21, // 7: SplitExpenseResponse.splits:type_name -> ExpenseSplit
+	24, // 8: GetBalancesResponse.balances:type_name -> MemberBalance
+	25, // 9: GetBalancesResponse.transfers:type_name -> Transfer
+	29, // 10: AssignExpenseItemsRequest.assignments:type_name -> ItemAssignment
+	31, // 11: ExpenseItemSuggestion.suggestions:type_name -> AssignmentSuggestion
+	32, // 12: SuggestItemAssignmentsResponse.items:type_name -> ExpenseItemSuggestion
+	3,  // 13: GroupsService.CreateGroup:input_type -> CreateGroupRequest
+	5,  // 14: GroupsService.ListGroups:input_type -> ListGroupsRequest
+	7,  // 15: GroupsService.GetGroup:input_type -> GetGroupRequest
+	9,  // 16: GroupsService.InviteToGroup:input_type -> InviteToGroupRequest
+	11, // 17: GroupsService.ListInvitations:input_type -> ListInvitationsRequest
+	13, // 18: GroupsService.AcceptInvitation:input_type -> AcceptInvitationRequest
+	15, // 19: GroupsService.SetMemberRole:input_type -> SetMemberRoleRequest
+	17, // 20: GroupsService.RemoveMember:input_type -> RemoveMemberRequest
+	20, // 21: GroupsService.SplitExpense:input_type -> SplitExpenseRequest
+	23, // 22: GroupsService.GetBalances:input_type -> GetBalancesRequest
+	27, // 23: GroupsService.RecordSettlement:input_type -> RecordSettlementRequest
+	30, // 24: GroupsService.AssignExpenseItems:input_type -> AssignExpenseItemsRequest
+	33, // 25: GroupsService.SuggestItemAssignments:input_type -> SuggestItemAssignmentsRequest
+	4,  // 26: GroupsService.CreateGroup:output_type -> CreateGroupResponse
+	6,  // 27: GroupsService.ListGroups:output_type -> ListGroupsResponse
+	8,  // 28: GroupsService.GetGroup:output_type -> GetGroupResponse
+	10, // 29: GroupsService.InviteToGroup:output_type -> InviteToGroupResponse
+	12, // 30: GroupsService.ListInvitations:output_type -> ListInvitationsResponse
+	14, // 31: GroupsService.AcceptInvitation:output_type -> AcceptInvitationResponse
+	16, // 32: GroupsService.SetMemberRole:output_type -> SetMemberRoleResponse
+	18, // 33: GroupsService.RemoveMember:output_type -> RemoveMemberResponse
+	22, // 34: GroupsService.SplitExpense:output_type -> SplitExpenseResponse
+	26, // 35: GroupsService.GetBalances:output_type -> GetBalancesResponse
+	28, // 36: GroupsService.RecordSettlement:output_type -> RecordSettlementResponse
+	22, // 37: GroupsService.AssignExpenseItems:output_type -> SplitExpenseResponse
+	34, // 38: GroupsService.SuggestItemAssignments:output_type -> SuggestItemAssignmentsResponse
+	26, // [26:39] is the sub-list for method output_type
+	13, // [13:26] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_proto_groups_proto_init() }
+func file_proto_groups_proto_init() {
+	if File_proto_groups_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_groups_proto_rawDesc), len(file_proto_groups_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_groups_proto_goTypes,
+		DependencyIndexes: file_proto_groups_proto_depIdxs,
+		MessageInfos:      file_proto_groups_proto_msgTypes,
+	}.Build()
+	File_proto_groups_proto = out.File
+	file_proto_groups_proto_goTypes = nil
+	file_proto_groups_proto_depIdxs = nil
+}
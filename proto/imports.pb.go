@@ -0,0 +1,1041 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.21.12
+// source: proto/imports.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CsvMapping says how to read a bank's CSV export. Columns are header names,
+// or 1-based positions when no_header is set. Either amount_column or both
+// debit_column and credit_column are required. Amounts in amount_column are
+// negative for money spent unless debits_positive is set. date_format uses
+// YYYY, YY, MMM, MM, M, DD, D, HH, mm and ss, e.g. "DD/MM/YYYY"; ISO dates
+// are read without one. delimiter defaults to "," and may be "tab".
+type CsvMapping struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Delimiter         string                 `protobuf:"bytes,1,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	SkipRows          int32                  `protobuf:"varint,2,opt,name=skip_rows,json=skipRows,proto3" json:"skip_rows,omitempty"`
+	NoHeader          bool                   `protobuf:"varint,3,opt,name=no_header,json=noHeader,proto3" json:"no_header,omitempty"`
+	DateColumn        string                 `protobuf:"bytes,4,opt,name=date_column,json=dateColumn,proto3" json:"date_column,omitempty"`
+	DateFormat        string                 `protobuf:"bytes,5,opt,name=date_format,json=dateFormat,proto3" json:"date_format,omitempty"`
+	DescriptionColumn string                 `protobuf:"bytes,6,opt,name=description_column,json=descriptionColumn,proto3" json:"description_column,omitempty"`
+	AmountColumn      string                 `protobuf:"bytes,7,opt,name=amount_column,json=amountColumn,proto3" json:"amount_column,omitempty"`
+	DebitColumn       string                 `protobuf:"bytes,8,opt,name=debit_column,json=debitColumn,proto3" json:"debit_column,omitempty"`
+	CreditColumn      string                 `protobuf:"bytes,9,opt,name=credit_column,json=creditColumn,proto3" json:"credit_column,omitempty"`
+	CurrencyColumn    string                 `protobuf:"bytes,10,opt,name=currency_column,json=currencyColumn,proto3" json:"currency_column,omitempty"`
+	ReferenceColumn   string                 `protobuf:"bytes,11,opt,name=reference_column,json=referenceColumn,proto3" json:"reference_column,omitempty"`
+	DebitsPositive    bool                   `protobuf:"varint,12,opt,name=debits_positive,json=debitsPositive,proto3" json:"debits_positive,omitempty"`
+	DecimalComma      bool                   `protobuf:"varint,13,opt,name=decimal_comma,json=decimalComma,proto3" json:"decimal_comma,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CsvMapping) Reset() {
+	*x = CsvMapping{}
+	mi := &file_proto_imports_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CsvMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CsvMapping) ProtoMessage() {}
+
+func (x *CsvMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CsvMapping.ProtoReflect.Descriptor instead.
+func (*CsvMapping) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CsvMapping) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *CsvMapping) GetSkipRows() int32 {
+	if x != nil {
+		return x.SkipRows
+	}
+	return 0
+}
+
+func (x *CsvMapping) GetNoHeader() bool {
+	if x != nil {
+		return x.NoHeader
+	}
+	return false
+}
+
+func (x *CsvMapping) GetDateColumn() string {
+	if x != nil {
+		return x.DateColumn
+	}
+	return ""
+}
+
+func (x *CsvMapping) GetDateFormat() string {
+	if x != nil {
+		return x.DateFormat
+	}
+	return ""
+}
+
+func (x *CsvMapping) GetDescriptionColumn() string {
+	if x != nil {
+		return x.DescriptionColumn
+	}
+	return ""
+}
+
+func (x *CsvMapping) GetAmountColumn() string {
+	if x != nil {
+		return x.AmountColumn
+	}
+	return ""
+}
+
+func (x *CsvMapping) GetDebitColumn() string {
+	if x != nil {
+		return x.DebitColumn
+	}
+	return ""
+}
+
+func (x *CsvMapping) GetCreditColumn() string {
+	if x != nil {
+		return x.CreditColumn
+	}
+	return ""
+}
+
+func (x *CsvMapping) GetCurrencyColumn() string {
+	if x != nil {
+		return x.CurrencyColumn
+	}
+	return ""
+}
+
+func (x *CsvMapping) GetReferenceColumn() string {
+	if x != nil {
+		return x.ReferenceColumn
+	}
+	return ""
+}
+
+func (x *CsvMapping) GetDebitsPositive() bool {
+	if x != nil {
+		return x.DebitsPositive
+	}
+	return false
+}
+
+func (x *CsvMapping) GetDecimalComma() bool {
+	if x != nil {
+		return x.DecimalComma
+	}
+	return false
+}
+
+type ImportProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Mapping       *CsvMapping            `protobuf:"bytes,3,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProfile) Reset() {
+	*x = ImportProfile{}
+	mi := &file_proto_imports_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProfile) ProtoMessage() {}
+
+func (x *ImportProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProfile.ProtoReflect.Descriptor instead.
+func (*ImportProfile) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ImportProfile) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportProfile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImportProfile) GetMapping() *CsvMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+func (x *ImportProfile) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// The options are read from the first message of the stream. format is csv,
+// ofx, qfx or qif and is detected from the file name or content when empty.
+// A CSV statement needs profile_id or mapping. currency applies to lines
+// that do not state one and defaults to the caller's default currency.
+// date_order, "mdy" or "dmy", reads QIF dates and defaults to "mdy".
+// dry_run previews the import without storing anything. Lines that may
+// duplicate an expense recorded another way are skipped unless
+// include_possible_duplicates is set; money received is skipped when
+// skip_credits is set.
+type ImportStatementRequest struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	Chunks                    []byte                 `protobuf:"bytes,1,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Format                    string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Filename                  string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	ProfileId                 string                 `protobuf:"bytes,4,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`
+	Mapping                   *CsvMapping            `protobuf:"bytes,5,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	Currency                  string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	GroupId                   string                 `protobuf:"bytes,7,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	DryRun                    bool                   `protobuf:"varint,8,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	DateOrder                 string                 `protobuf:"bytes,9,opt,name=date_order,json=dateOrder,proto3" json:"date_order,omitempty"`
+	IncludePossibleDuplicates bool                   `protobuf:"varint,10,opt,name=include_possible_duplicates,json=includePossibleDuplicates,proto3" json:"include_possible_duplicates,omitempty"`
+	SkipCredits               bool                   `protobuf:"varint,11,opt,name=skip_credits,json=skipCredits,proto3" json:"skip_credits,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ImportStatementRequest) Reset() {
+	*x = ImportStatementRequest{}
+	mi := &file_proto_imports_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStatementRequest) ProtoMessage() {}
+
+func (x *ImportStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStatementRequest.ProtoReflect.Descriptor instead.
+func (*ImportStatementRequest) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ImportStatementRequest) GetChunks() []byte {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *ImportStatementRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportStatementRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ImportStatementRequest) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
+func (x *ImportStatementRequest) GetMapping() *CsvMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+func (x *ImportStatementRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ImportStatementRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ImportStatementRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportStatementRequest) GetDateOrder() string {
+	if x != nil {
+		return x.DateOrder
+	}
+	return ""
+}
+
+func (x *ImportStatementRequest) GetIncludePossibleDuplicates() bool {
+	if x != nil {
+		return x.IncludePossibleDuplicates
+	}
+	return false
+}
+
+func (x *ImportStatementRequest) GetSkipCredits() bool {
+	if x != nil {
+		return x.SkipCredits
+	}
+	return false
+}
+
+// status is one of new (dry runs only), created, duplicate,
+// possible_duplicate, skipped or error, with reason saying why. line is the
+// line of the file the row starts on. amount is positive for money spent.
+type StatementRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Category      string                 `protobuf:"bytes,6,opt,name=category,proto3" json:"category,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	ExpenseId     string                 `protobuf:"bytes,9,opt,name=expense_id,json=expenseId,proto3" json:"expense_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatementRow) Reset() {
+	*x = StatementRow{}
+	mi := &file_proto_imports_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementRow) ProtoMessage() {}
+
+func (x *StatementRow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementRow.ProtoReflect.Descriptor instead.
+func (*StatementRow) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StatementRow) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *StatementRow) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *StatementRow) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StatementRow) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *StatementRow) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *StatementRow) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *StatementRow) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatementRow) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StatementRow) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+type ImportStatementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Rows          []*StatementRow        `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	Imported      int32                  `protobuf:"varint,4,opt,name=imported,proto3" json:"imported,omitempty"`
+	Duplicates    int32                  `protobuf:"varint,5,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Skipped       int32                  `protobuf:"varint,6,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Errors        int32                  `protobuf:"varint,7,opt,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportStatementResponse) Reset() {
+	*x = ImportStatementResponse{}
+	mi := &file_proto_imports_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStatementResponse) ProtoMessage() {}
+
+func (x *ImportStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStatementResponse.ProtoReflect.Descriptor instead.
+func (*ImportStatementResponse) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImportStatementResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportStatementResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportStatementResponse) GetRows() []*StatementRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *ImportStatementResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportStatementResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *ImportStatementResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportStatementResponse) GetErrors() int32 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+// Saving a profile under an existing name replaces it.
+type SaveImportProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Mapping       *CsvMapping            `protobuf:"bytes,2,opt,name=mapping,proto3" json:"mapping,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveImportProfileRequest) Reset() {
+	*x = SaveImportProfileRequest{}
+	mi := &file_proto_imports_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveImportProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveImportProfileRequest) ProtoMessage() {}
+
+func (x *SaveImportProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveImportProfileRequest.ProtoReflect.Descriptor instead.
+func (*SaveImportProfileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SaveImportProfileRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SaveImportProfileRequest) GetMapping() *CsvMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+type SaveImportProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *ImportProfile         `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveImportProfileResponse) Reset() {
+	*x = SaveImportProfileResponse{}
+	mi := &file_proto_imports_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveImportProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveImportProfileResponse) ProtoMessage() {}
+
+func (x *SaveImportProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveImportProfileResponse.ProtoReflect.Descriptor instead.
+func (*SaveImportProfileResponse) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SaveImportProfileResponse) GetProfile() *ImportProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+// order_by is "name", the default, or "created_at", and pages hold up to
+// 1000 profiles, 100 by default, as described on ExpensesService.
+type ListImportProfilesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PageSize          int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken         string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,4,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListImportProfilesRequest) Reset() {
+	*x = ListImportProfilesRequest{}
+	mi := &file_proto_imports_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportProfilesRequest) ProtoMessage() {}
+
+func (x *ListImportProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportProfilesRequest.ProtoReflect.Descriptor instead.
+func (*ListImportProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListImportProfilesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListImportProfilesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListImportProfilesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListImportProfilesRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+type ListImportProfilesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*ImportProfile       `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListImportProfilesResponse) Reset() {
+	*x = ListImportProfilesResponse{}
+	mi := &file_proto_imports_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListImportProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListImportProfilesResponse) ProtoMessage() {}
+
+func (x *ListImportProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListImportProfilesResponse.ProtoReflect.Descriptor instead.
+func (*ListImportProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListImportProfilesResponse) GetProfiles() []*ImportProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+func (x *ListImportProfilesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListImportProfilesResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type DeleteImportProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteImportProfileRequest) Reset() {
+	*x = DeleteImportProfileRequest{}
+	mi := &file_proto_imports_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteImportProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteImportProfileRequest) ProtoMessage() {}
+
+func (x *DeleteImportProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteImportProfileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteImportProfileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteImportProfileRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteImportProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteImportProfileResponse) Reset() {
+	*x = DeleteImportProfileResponse{}
+	mi := &file_proto_imports_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteImportProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteImportProfileResponse) ProtoMessage() {}
+
+func (x *DeleteImportProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_imports_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteImportProfileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteImportProfileResponse) Descriptor() ([]byte, []int) {
+	return file_proto_imports_proto_rawDescGZIP(), []int{10}
+}
+
+var File_proto_imports_proto protoreflect.FileDescriptor
+
+const file_proto_imports_proto_rawDesc = "" +
+	"\n" +
+	"\x13proto/imports.proto\"\xe4\x03\n" +
+	"\n" +
+	"CsvMapping\x12\x1c\n" +
+	"\tdelimiter\x18\x01 \x01(\tR\tdelimiter\x12\x1b\n" +
+	"\tskip_rows\x18\x02 \x01(\x05R\bskipRows\x12\x1b\n" +
+	"\tno_header\x18\x03 \x01(\bR\bnoHeader\x12\x1f\n" +
+	"\vdate_column\x18\x04 \x01(\tR\n" +
+	"dateColumn\x12\x1f\n" +
+	"\vdate_format\x18\x05 \x01(\tR\n" +
+	"dateFormat\x12-\n" +
+	"\x12description_column\x18\x06 \x01(\tR\x11descriptionColumn\x12#\n" +
+	"\ramount_column\x18\a \x01(\tR\famountColumn\x12!\n" +
+	"\fdebit_column\x18\b \x01(\tR\vdebitColumn\x12#\n" +
+	"\rcredit_column\x18\t \x01(\tR\fcreditColumn\x12'\n" +
+	"\x0fcurrency_column\x18\n" +
+	" \x01(\tR\x0ecurrencyColumn\x12)\n" +
+	"\x10reference_column\x18\v \x01(\tR\x0freferenceColumn\x12'\n" +
+	"\x0fdebits_positive\x18\f \x01(\bR\x0edebitsPositive\x12#\n" +
+	"\rdecimal_comma\x18\r \x01(\bR\fdecimalComma\"y\n" +
+	"\rImportProfile\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\amapping\x18\x03 \x01(\v2\v.CsvMappingR\amapping\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\xfc\x02\n" +
+	"\x16ImportStatementRequest\x12\x16\n" +
+	"\x06chunks\x18\x01 \x01(\fR\x06chunks\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x04 \x01(\tR\tprofileId\x12%\n" +
+	"\amapping\x18\x05 \x01(\v2\v.CsvMappingR\amapping\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x19\n" +
+	"\bgroup_id\x18\a \x01(\tR\agroupId\x12\x17\n" +
+	"\adry_run\x18\b \x01(\bR\x06dryRun\x12\x1d\n" +
+	"\n" +
+	"date_order\x18\t \x01(\tR\tdateOrder\x12>\n" +
+	"\x1binclude_possible_duplicates\x18\n" +
+	" \x01(\bR\x19includePossibleDuplicates\x12!\n" +
+	"\fskip_credits\x18\v \x01(\bR\vskipCredits\"\xf7\x01\n" +
+	"\fStatementRow\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\bcategory\x18\x06 \x01(\tR\bcategory\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"expense_id\x18\t \x01(\tR\texpenseId\"\xdb\x01\n" +
+	"\x17ImportStatementResponse\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12!\n" +
+	"\x04rows\x18\x03 \x03(\v2\r.StatementRowR\x04rows\x12\x1a\n" +
+	"\bimported\x18\x04 \x01(\x05R\bimported\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x05 \x01(\x05R\n" +
+	"duplicates\x12\x18\n" +
+	"\askipped\x18\x06 \x01(\x05R\askipped\x12\x16\n" +
+	"\x06errors\x18\a \x01(\x05R\x06errors\"U\n" +
+	"\x18SaveImportProfileRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\amapping\x18\x02 \x01(\v2\v.CsvMappingR\amapping\"E\n" +
+	"\x19SaveImportProfileResponse\x12(\n" +
+	"\aprofile\x18\x01 \x01(\v2\x0e.ImportProfileR\aprofile\"\xa2\x01\n" +
+	"\x19ListImportProfilesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12.\n" +
+	"\x13include_total_count\x18\x04 \x01(\bR\x11includeTotalCount\"\x91\x01\n" +
+	"\x1aListImportProfilesResponse\x12*\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x0e.ImportProfileR\bprofiles\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\",\n" +
+	"\x1aDeleteImportProfileRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1d\n" +
+	"\x1bDeleteImportProfileResponse2\xc4\x02\n" +
+	"\rImportService\x12F\n" +
+	"\x0fImportStatement\x12\x17.ImportStatementRequest\x1a\x18.ImportStatementResponse(\x01\x12J\n" +
+	"\x11SaveImportProfile\x12\x19.SaveImportProfileRequest\x1a\x1a.SaveImportProfileResponse\x12M\n" +
+	"\x12ListImportProfiles\x12\x1a.ListImportProfilesRequest\x1a\x1b.ListImportProfilesResponse\x12P\n" +
+	"\x13DeleteImportProfile\x12\x1b.DeleteImportProfileRequest\x1a\x1c.DeleteImportProfileResponseB+Z)github.com/barathsurya2004/expenses/protob\x06proto3"
+
+var (
+	file_proto_imports_proto_rawDescOnce sync.Once
+	file_proto_imports_proto_rawDescData []byte
+)
+
+func file_proto_imports_proto_rawDescGZIP() []byte {
+	file_proto_imports_proto_rawDescOnce.Do(func() {
+		file_proto_imports_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_imports_proto_rawDesc), len(file_proto_imports_proto_rawDesc)))
+	})
+	return file_proto_imports_proto_rawDescData
+}
+
+var file_proto_imports_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_imports_proto_goTypes = []any{
+	(*CsvMapping)(nil),                  // 0: CsvMapping
+	(*ImportProfile)(nil),               // 1: ImportProfile
+	(*ImportStatementRequest)(nil),      // 2: ImportStatementRequest
+	(*StatementRow)(nil),                // 3: StatementRow
+	(*ImportStatementResponse)(nil),     // 4: ImportStatementResponse
+	(*SaveImportProfileRequest)(nil),    // 5: SaveImportProfileRequest
+	(*SaveImportProfileResponse)(nil),   // 6: SaveImportProfileResponse
+	(*ListImportProfilesRequest)(nil),   // 7: ListImportProfilesRequest
+	(*ListImportProfilesResponse)(nil),  // 8: ListImportProfilesResponse
+	(*DeleteImportProfileRequest)(nil),  // 9: DeleteImportProfileRequest
+	(*DeleteImportProfileResponse)(nil), // 10: DeleteImportProfileResponse
+}
+var file_proto_imports_proto_depIdxs = []int32{
+	0,  // 0: ImportProfile.mapping:type_name -> CsvMapping
+	0,  // 1: ImportStatementRequest.mapping:type_name -> CsvMapping
+	3,  // 2: ImportStatementResponse.rows:type_name -> StatementRow
+	0,  // 3: SaveImportProfileRequest.mapping:type_name -> CsvMapping
+	1,  // 4: SaveImportProfileResponse.profile:type_name -> ImportProfile
+	1,  // 5: ListImportProfilesResponse.profiles:type_name -> ImportProfile
+	2,  // 6: ImportService.ImportStatement:input_type -> ImportStatementRequest
+	5,  // 7: ImportService.SaveImportProfile:input_type -> SaveImportProfileRequest
+	7,  // 8: ImportService.ListImportProfiles:input_type -> ListImportProfilesRequest
+	9,  // 9: ImportService.DeleteImportProfile:input_type -> DeleteImportProfileRequest
+	4,  // 10: ImportService.ImportStatement:output_type -> ImportStatementResponse
+	6,  // 11: ImportService.SaveImportProfile:output_type -> SaveImportProfileResponse
+	8,  // 12: ImportService.ListImportProfiles:output_type -> ListImportProfilesResponse
+	10, // 13: ImportService.DeleteImportProfile:output_type -> DeleteImportProfileResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_imports_proto_init() }
+func file_proto_imports_proto_init() {
+	if File_proto_imports_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_imports_proto_rawDesc), len(file_proto_imports_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_imports_proto_goTypes,
+		DependencyIndexes: file_proto_imports_proto_depIdxs,
+		MessageInfos:      file_proto_imports_proto_msgTypes,
+	}.Build()
+	File_proto_imports_proto = out.File
+	file_proto_imports_proto_goTypes = nil
+	file_proto_imports_proto_depIdxs = nil
+}
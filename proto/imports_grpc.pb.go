@@ -0,0 +1,233 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: proto/imports.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ImportService_ImportStatement_FullMethodName     = "/ImportService/ImportStatement"
+	ImportService_SaveImportProfile_FullMethodName   = "/ImportService/SaveImportProfile"
+	ImportService_ListImportProfiles_FullMethodName  = "/ImportService/ListImportProfiles"
+	ImportService_DeleteImportProfile_FullMethodName = "/ImportService/DeleteImportProfile"
+)
+
+// ImportServiceClient is the client API for ImportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ImportService imports bank statements into the caller's expenses.
+type ImportServiceClient interface {
+	ImportStatement(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportStatementRequest, ImportStatementResponse], error)
+	SaveImportProfile(ctx context.Context, in *SaveImportProfileRequest, opts ...grpc.CallOption) (*SaveImportProfileResponse, error)
+	ListImportProfiles(ctx context.Context, in *ListImportProfilesRequest, opts ...grpc.CallOption) (*ListImportProfilesResponse, error)
+	DeleteImportProfile(ctx context.Context, in *DeleteImportProfileRequest, opts ...grpc.CallOption) (*DeleteImportProfileResponse, error)
+}
+
+type importServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewImportServiceClient(cc grpc.ClientConnInterface) ImportServiceClient {
+	return &importServiceClient{cc}
+}
+
+func (c *importServiceClient) ImportStatement(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportStatementRequest, ImportStatementResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ImportService_ServiceDesc.Streams[0], ImportService_ImportStatement_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportStatementRequest, ImportStatementResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ImportService_ImportStatementClient = grpc.ClientStreamingClient[ImportStatementRequest, ImportStatementResponse]
+
+func (c *importServiceClient) SaveImportProfile(ctx context.Context, in *SaveImportProfileRequest, opts ...grpc.CallOption) (*SaveImportProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveImportProfileResponse)
+	err := c.cc.Invoke(ctx, ImportService_SaveImportProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *importServiceClient) ListImportProfiles(ctx context.Context, in *ListImportProfilesRequest, opts ...grpc.CallOption) (*ListImportProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListImportProfilesResponse)
+	err := c.cc.Invoke(ctx, ImportService_ListImportProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *importServiceClient) DeleteImportProfile(ctx context.Context, in *DeleteImportProfileRequest, opts ...grpc.CallOption) (*DeleteImportProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteImportProfileResponse)
+	err := c.cc.Invoke(ctx, ImportService_DeleteImportProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ImportServiceServer is the server API for ImportService service.
+// All implementations must embed UnimplementedImportServiceServer
+// for forward compatibility.
+//
+// ImportService imports bank statements into the caller's expenses.
+type ImportServiceServer interface {
+	ImportStatement(grpc.ClientStreamingServer[ImportStatementRequest, ImportStatementResponse]) error
+	SaveImportProfile(context.Context, *SaveImportProfileRequest) (*SaveImportProfileResponse, error)
+	ListImportProfiles(context.Context, *ListImportProfilesRequest) (*ListImportProfilesResponse, error)
+	DeleteImportProfile(context.Context, *DeleteImportProfileRequest) (*DeleteImportProfileResponse, error)
+	mustEmbedUnimplementedImportServiceServer()
+}
+
+// UnimplementedImportServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedImportServiceServer struct{}
+
+func (UnimplementedImportServiceServer) ImportStatement(grpc.ClientStreamingServer[ImportStatementRequest, ImportStatementResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportStatement not implemented")
+}
+func (UnimplementedImportServiceServer) SaveImportProfile(context.Context, *SaveImportProfileRequest) (*SaveImportProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveImportProfile not implemented")
+}
+func (UnimplementedImportServiceServer) ListImportProfiles(context.Context, *ListImportProfilesRequest) (*ListImportProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListImportProfiles not implemented")
+}
+func (UnimplementedImportServiceServer) DeleteImportProfile(context.Context, *DeleteImportProfileRequest) (*DeleteImportProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteImportProfile not implemented")
+}
+func (UnimplementedImportServiceServer) mustEmbedUnimplementedImportServiceServer() {}
+func (UnimplementedImportServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeImportServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ImportServiceServer will
+// result in compilation errors.
+type UnsafeImportServiceServer interface {
+	mustEmbedUnimplementedImportServiceServer()
+}
+
+func RegisterImportServiceServer(s grpc.ServiceRegistrar, srv ImportServiceServer) {
+	// If the following call pancis, it indicates UnimplementedImportServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ImportService_ServiceDesc, srv)
+}
+
+func _ImportService_ImportStatement_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ImportServiceServer).ImportStatement(&grpc.GenericServerStream[ImportStatementRequest, ImportStatementResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ImportService_ImportStatementServer = grpc.ClientStreamingServer[ImportStatementRequest, ImportStatementResponse]
+
+func _ImportService_SaveImportProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveImportProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImportServiceServer).SaveImportProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImportService_SaveImportProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImportServiceServer).SaveImportProfile(ctx, req.(*SaveImportProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImportService_ListImportProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImportProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImportServiceServer).ListImportProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImportService_ListImportProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImportServiceServer).ListImportProfiles(ctx, req.(*ListImportProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImportService_DeleteImportProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteImportProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImportServiceServer).DeleteImportProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ImportService_DeleteImportProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImportServiceServer).DeleteImportProfile(ctx, req.(*DeleteImportProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ImportService_ServiceDesc is the grpc.ServiceDesc for ImportService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ImportService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ImportService",
+	HandlerType: (*ImportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SaveImportProfile",
+			Handler:    _ImportService_SaveImportProfile_Handler,
+		},
+		{
+			MethodName: "ListImportProfiles",
+			Handler:    _ImportService_ListImportProfiles_Handler,
+		},
+		{
+			MethodName: "DeleteImportProfile",
+			Handler:    _ImportService_DeleteImportProfile_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportStatement",
+			Handler:       _ImportService_ImportStatement_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/imports.proto",
+}
@@ -0,0 +1,1161 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.21.12
+// source: proto/ingestion.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// revoked_at is set once consent has been withdrawn.
+type IngestionConsent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Granted       bool                   `protobuf:"varint,2,opt,name=granted,proto3" json:"granted,omitempty"`
+	GrantedAt     string                 `protobuf:"bytes,3,opt,name=granted_at,json=grantedAt,proto3" json:"granted_at,omitempty"`
+	RevokedAt     string                 `protobuf:"bytes,4,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestionConsent) Reset() {
+	*x = IngestionConsent{}
+	mi := &file_proto_ingestion_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestionConsent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestionConsent) ProtoMessage() {}
+
+func (x *IngestionConsent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestionConsent.ProtoReflect.Descriptor instead.
+func (*IngestionConsent) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IngestionConsent) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *IngestionConsent) GetGranted() bool {
+	if x != nil {
+		return x.Granted
+	}
+	return false
+}
+
+func (x *IngestionConsent) GetGrantedAt() string {
+	if x != nil {
+		return x.GrantedAt
+	}
+	return ""
+}
+
+func (x *IngestionConsent) GetRevokedAt() string {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return ""
+}
+
+// channel is "sms" or "email".
+type SetIngestionConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Granted       bool                   `protobuf:"varint,2,opt,name=granted,proto3" json:"granted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIngestionConsentRequest) Reset() {
+	*x = SetIngestionConsentRequest{}
+	mi := &file_proto_ingestion_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIngestionConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIngestionConsentRequest) ProtoMessage() {}
+
+func (x *SetIngestionConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIngestionConsentRequest.ProtoReflect.Descriptor instead.
+func (*SetIngestionConsentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SetIngestionConsentRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *SetIngestionConsentRequest) GetGranted() bool {
+	if x != nil {
+		return x.Granted
+	}
+	return false
+}
+
+type SetIngestionConsentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consent       *IngestionConsent      `protobuf:"bytes,1,opt,name=consent,proto3" json:"consent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIngestionConsentResponse) Reset() {
+	*x = SetIngestionConsentResponse{}
+	mi := &file_proto_ingestion_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIngestionConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIngestionConsentResponse) ProtoMessage() {}
+
+func (x *SetIngestionConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIngestionConsentResponse.ProtoReflect.Descriptor instead.
+func (*SetIngestionConsentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetIngestionConsentResponse) GetConsent() *IngestionConsent {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+type ListIngestionConsentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIngestionConsentsRequest) Reset() {
+	*x = ListIngestionConsentsRequest{}
+	mi := &file_proto_ingestion_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIngestionConsentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIngestionConsentsRequest) ProtoMessage() {}
+
+func (x *ListIngestionConsentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIngestionConsentsRequest.ProtoReflect.Descriptor instead.
+func (*ListIngestionConsentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{3}
+}
+
+type ListIngestionConsentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consents      []*IngestionConsent    `protobuf:"bytes,1,rep,name=consents,proto3" json:"consents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIngestionConsentsResponse) Reset() {
+	*x = ListIngestionConsentsResponse{}
+	mi := &file_proto_ingestion_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIngestionConsentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIngestionConsentsResponse) ProtoMessage() {}
+
+func (x *ListIngestionConsentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIngestionConsentsResponse.ProtoReflect.Descriptor instead.
+func (*ListIngestionConsentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListIngestionConsentsResponse) GetConsents() []*IngestionConsent {
+	if x != nil {
+		return x.Consents
+	}
+	return nil
+}
+
+// received_at is an RFC 3339 time and defaults to now.
+type SmsMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sender        string                 `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	ReceivedAt    string                 `protobuf:"bytes,3,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SmsMessage) Reset() {
+	*x = SmsMessage{}
+	mi := &file_proto_ingestion_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SmsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SmsMessage) ProtoMessage() {}
+
+func (x *SmsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SmsMessage.ProtoReflect.Descriptor instead.
+func (*SmsMessage) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SmsMessage) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *SmsMessage) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *SmsMessage) GetReceivedAt() string {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return ""
+}
+
+type IngestMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*SmsMessage          `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestMessagesRequest) Reset() {
+	*x = IngestMessagesRequest{}
+	mi := &file_proto_ingestion_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestMessagesRequest) ProtoMessage() {}
+
+func (x *IngestMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestMessagesRequest.ProtoReflect.Descriptor instead.
+func (*IngestMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *IngestMessagesRequest) GetMessages() []*SmsMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// status is one of created, duplicate, otp, promotional, not_transaction or
+// failed. parser names the bank template that matched, or "model" when the
+// extraction model read the message. For email parser is "pdf", "html" or
+// "text", the part the expense was read from, and message_id is the
+// message's Message-ID.
+type IngestedMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	ExpenseId     string                 `protobuf:"bytes,3,opt,name=expense_id,json=expenseId,proto3" json:"expense_id,omitempty"`
+	Parser        string                 `protobuf:"bytes,4,opt,name=parser,proto3" json:"parser,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	MessageId     string                 `protobuf:"bytes,6,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestedMessage) Reset() {
+	*x = IngestedMessage{}
+	mi := &file_proto_ingestion_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestedMessage) ProtoMessage() {}
+
+func (x *IngestedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestedMessage.ProtoReflect.Descriptor instead.
+func (*IngestedMessage) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *IngestedMessage) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IngestedMessage) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *IngestedMessage) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+func (x *IngestedMessage) GetParser() string {
+	if x != nil {
+		return x.Parser
+	}
+	return ""
+}
+
+func (x *IngestedMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *IngestedMessage) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type IngestMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*IngestedMessage     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestMessagesResponse) Reset() {
+	*x = IngestMessagesResponse{}
+	mi := &file_proto_ingestion_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestMessagesResponse) ProtoMessage() {}
+
+func (x *IngestMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestMessagesResponse.ProtoReflect.Descriptor instead.
+func (*IngestMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IngestMessagesResponse) GetResults() []*IngestedMessage {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *IngestMessagesResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+// Mailbox is an IMAP mailbox polled for e-receipts. The password is never
+// returned.
+type Mailbox struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Mailbox       string                 `protobuf:"bytes,4,opt,name=mailbox,proto3" json:"mailbox,omitempty"`
+	Insecure      bool                   `protobuf:"varint,5,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	ConnectedAt   string                 `protobuf:"bytes,6,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	LastPolledAt  string                 `protobuf:"bytes,7,opt,name=last_polled_at,json=lastPolledAt,proto3" json:"last_polled_at,omitempty"`
+	LastError     string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mailbox) Reset() {
+	*x = Mailbox{}
+	mi := &file_proto_ingestion_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mailbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mailbox) ProtoMessage() {}
+
+func (x *Mailbox) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mailbox.ProtoReflect.Descriptor instead.
+func (*Mailbox) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Mailbox) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Mailbox) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Mailbox) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Mailbox) GetMailbox() string {
+	if x != nil {
+		return x.Mailbox
+	}
+	return ""
+}
+
+func (x *Mailbox) GetInsecure() bool {
+	if x != nil {
+		return x.Insecure
+	}
+	return false
+}
+
+func (x *Mailbox) GetConnectedAt() string {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return ""
+}
+
+func (x *Mailbox) GetLastPolledAt() string {
+	if x != nil {
+		return x.LastPolledAt
+	}
+	return ""
+}
+
+func (x *Mailbox) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+// port defaults to 993 and mailbox to INBOX. host must resolve to public
+// addresses. insecure connects without TLS; it, and hosts on internal
+// addresses, are only allowed when the server runs with
+// MAILBOX_ALLOW_INSECURE, for a local test server.
+type ConnectMailboxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	Mailbox       string                 `protobuf:"bytes,5,opt,name=mailbox,proto3" json:"mailbox,omitempty"`
+	Insecure      bool                   `protobuf:"varint,6,opt,name=insecure,proto3" json:"insecure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectMailboxRequest) Reset() {
+	*x = ConnectMailboxRequest{}
+	mi := &file_proto_ingestion_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectMailboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectMailboxRequest) ProtoMessage() {}
+
+func (x *ConnectMailboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectMailboxRequest.ProtoReflect.Descriptor instead.
+func (*ConnectMailboxRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ConnectMailboxRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ConnectMailboxRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ConnectMailboxRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ConnectMailboxRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ConnectMailboxRequest) GetMailbox() string {
+	if x != nil {
+		return x.Mailbox
+	}
+	return ""
+}
+
+func (x *ConnectMailboxRequest) GetInsecure() bool {
+	if x != nil {
+		return x.Insecure
+	}
+	return false
+}
+
+type ConnectMailboxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mailbox       *Mailbox               `protobuf:"bytes,1,opt,name=mailbox,proto3" json:"mailbox,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectMailboxResponse) Reset() {
+	*x = ConnectMailboxResponse{}
+	mi := &file_proto_ingestion_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectMailboxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectMailboxResponse) ProtoMessage() {}
+
+func (x *ConnectMailboxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectMailboxResponse.ProtoReflect.Descriptor instead.
+func (*ConnectMailboxResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ConnectMailboxResponse) GetMailbox() *Mailbox {
+	if x != nil {
+		return x.Mailbox
+	}
+	return nil
+}
+
+type DisconnectMailboxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectMailboxRequest) Reset() {
+	*x = DisconnectMailboxRequest{}
+	mi := &file_proto_ingestion_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectMailboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectMailboxRequest) ProtoMessage() {}
+
+func (x *DisconnectMailboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectMailboxRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectMailboxRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{12}
+}
+
+type DisconnectMailboxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectMailboxResponse) Reset() {
+	*x = DisconnectMailboxResponse{}
+	mi := &file_proto_ingestion_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectMailboxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectMailboxResponse) ProtoMessage() {}
+
+func (x *DisconnectMailboxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectMailboxResponse.ProtoReflect.Descriptor instead.
+func (*DisconnectMailboxResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{13}
+}
+
+type GetEmailIngestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmailIngestionRequest) Reset() {
+	*x = GetEmailIngestionRequest{}
+	mi := &file_proto_ingestion_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmailIngestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmailIngestionRequest) ProtoMessage() {}
+
+func (x *GetEmailIngestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmailIngestionRequest.ProtoReflect.Descriptor instead.
+func (*GetEmailIngestionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{14}
+}
+
+// inbound_address is empty when the server does not accept inbound mail.
+type GetEmailIngestionResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	InboundAddress string                 `protobuf:"bytes,1,opt,name=inbound_address,json=inboundAddress,proto3" json:"inbound_address,omitempty"`
+	Mailbox        *Mailbox               `protobuf:"bytes,2,opt,name=mailbox,proto3" json:"mailbox,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetEmailIngestionResponse) Reset() {
+	*x = GetEmailIngestionResponse{}
+	mi := &file_proto_ingestion_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmailIngestionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmailIngestionResponse) ProtoMessage() {}
+
+func (x *GetEmailIngestionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmailIngestionResponse.ProtoReflect.Descriptor instead.
+func (*GetEmailIngestionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetEmailIngestionResponse) GetInboundAddress() string {
+	if x != nil {
+		return x.InboundAddress
+	}
+	return ""
+}
+
+func (x *GetEmailIngestionResponse) GetMailbox() *Mailbox {
+	if x != nil {
+		return x.Mailbox
+	}
+	return nil
+}
+
+type PollMailboxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollMailboxRequest) Reset() {
+	*x = PollMailboxRequest{}
+	mi := &file_proto_ingestion_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollMailboxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollMailboxRequest) ProtoMessage() {}
+
+func (x *PollMailboxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollMailboxRequest.ProtoReflect.Descriptor instead.
+func (*PollMailboxRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{16}
+}
+
+type PollMailboxResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*IngestedMessage     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollMailboxResponse) Reset() {
+	*x = PollMailboxResponse{}
+	mi := &file_proto_ingestion_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollMailboxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollMailboxResponse) ProtoMessage() {}
+
+func (x *PollMailboxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ingestion_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollMailboxResponse.ProtoReflect.Descriptor instead.
+func (*PollMailboxResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ingestion_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PollMailboxResponse) GetResults() []*IngestedMessage {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *PollMailboxResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+var File_proto_ingestion_proto protoreflect.FileDescriptor
+
+const file_proto_ingestion_proto_rawDesc = "" +
+	"\n" +
+	"\x15proto/ingestion.proto\"\x84\x01\n" +
+	"\x10IngestionConsent\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x18\n" +
+	"\agranted\x18\x02 \x01(\bR\agranted\x12\x1d\n" +
+	"\n" +
+	"granted_at\x18\x03 \x01(\tR\tgrantedAt\x12\x1d\n" +
+	"\n" +
+	"revoked_at\x18\x04 \x01(\tR\trevokedAt\"P\n" +
+	"\x1aSetIngestionConsentRequest\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x18\n" +
+	"\agranted\x18\x02 \x01(\bR\agranted\"J\n" +
+	"\x1bSetIngestionConsentResponse\x12+\n" +
+	"\aconsent\x18\x01 \x01(\v2\x11.IngestionConsentR\aconsent\"\x1e\n" +
+	"\x1cListIngestionConsentsRequest\"N\n" +
+	"\x1dListIngestionConsentsResponse\x12-\n" +
+	"\bconsents\x18\x01 \x03(\v2\x11.IngestionConsentR\bconsents\"Y\n" +
+	"\n" +
+	"SmsMessage\x12\x16\n" +
+	"\x06sender\x18\x01 \x01(\tR\x06sender\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x12\x1f\n" +
+	"\vreceived_at\x18\x03 \x01(\tR\n" +
+	"receivedAt\"@\n" +
+	"\x15IngestMessagesRequest\x12'\n" +
+	"\bmessages\x18\x01 \x03(\v2\v.SmsMessageR\bmessages\"\xad\x01\n" +
+	"\x0fIngestedMessage\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"expense_id\x18\x03 \x01(\tR\texpenseId\x12\x16\n" +
+	"\x06parser\x18\x04 \x01(\tR\x06parser\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x06 \x01(\tR\tmessageId\"^\n" +
+	"\x16IngestMessagesResponse\x12*\n" +
+	"\aresults\x18\x01 \x03(\v2\x10.IngestedMessageR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\"\xeb\x01\n" +
+	"\aMailbox\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x18\n" +
+	"\amailbox\x18\x04 \x01(\tR\amailbox\x12\x1a\n" +
+	"\binsecure\x18\x05 \x01(\bR\binsecure\x12!\n" +
+	"\fconnected_at\x18\x06 \x01(\tR\vconnectedAt\x12$\n" +
+	"\x0elast_polled_at\x18\a \x01(\tR\flastPolledAt\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\"\xad\x01\n" +
+	"\x15ConnectMailboxRequest\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12\x18\n" +
+	"\amailbox\x18\x05 \x01(\tR\amailbox\x12\x1a\n" +
+	"\binsecure\x18\x06 \x01(\bR\binsecure\"<\n" +
+	"\x16ConnectMailboxResponse\x12\"\n" +
+	"\amailbox\x18\x01 \x01(\v2\b.MailboxR\amailbox\"\x1a\n" +
+	"\x18DisconnectMailboxRequest\"\x1b\n" +
+	"\x19DisconnectMailboxResponse\"\x1a\n" +
+	"\x18GetEmailIngestionRequest\"h\n" +
+	"\x19GetEmailIngestionResponse\x12'\n" +
+	"\x0finbound_address\x18\x01 \x01(\tR\x0einboundAddress\x12\"\n" +
+	"\amailbox\x18\x02 \x01(\v2\b.MailboxR\amailbox\"\x14\n" +
+	"\x12PollMailboxRequest\"[\n" +
+	"\x13PollMailboxResponse\x12*\n" +
+	"\aresults\x18\x01 \x03(\v2\x10.IngestedMessageR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated2\x94\x04\n" +
+	"\x10IngestionService\x12P\n" +
+	"\x13SetIngestionConsent\x12\x1b.SetIngestionConsentRequest\x1a\x1c.SetIngestionConsentResponse\x12V\n" +
+	"\x15ListIngestionConsents\x12\x1d.ListIngestionConsentsRequest\x1a\x1e.ListIngestionConsentsResponse\x12A\n" +
+	"\x0eIngestMessages\x12\x16.IngestMessagesRequest\x1a\x17.IngestMessagesResponse\x12A\n" +
+	"\x0eConnectMailbox\x12\x16.ConnectMailboxRequest\x1a\x17.ConnectMailboxResponse\x12J\n" +
+	"\x11DisconnectMailbox\x12\x19.DisconnectMailboxRequest\x1a\x1a.DisconnectMailboxResponse\x12J\n" +
+	"\x11GetEmailIngestion\x12\x19.GetEmailIngestionRequest\x1a\x1a.GetEmailIngestionResponse\x128\n" +
+	"\vPollMailbox\x12\x13.PollMailboxRequest\x1a\x14.PollMailboxResponseB+Z)github.com/barathsurya2004/expenses/protob\x06proto3"
+
+var (
+	file_proto_ingestion_proto_rawDescOnce sync.Once
+	file_proto_ingestion_proto_rawDescData []byte
+)
+
+func file_proto_ingestion_proto_rawDescGZIP() []byte {
+	file_proto_ingestion_proto_rawDescOnce.Do(func() {
+		file_proto_ingestion_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_ingestion_proto_rawDesc), len(file_proto_ingestion_proto_rawDesc)))
+	})
+	return file_proto_ingestion_proto_rawDescData
+}
+
+var file_proto_ingestion_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_proto_ingestion_proto_goTypes = []any{
+	(*IngestionConsent)(nil),              // 0: IngestionConsent
+	(*SetIngestionConsentRequest)(nil),    // 1: SetIngestionConsentRequest
+	(*SetIngestionConsentResponse)(nil),   // 2: SetIngestionConsentResponse
+	(*ListIngestionConsentsRequest)(nil),  // 3: ListIngestionConsentsRequest
+	(*ListIngestionConsentsResponse)(nil), // 4: ListIngestionConsentsResponse
+	(*SmsMessage)(nil),                    // 5: SmsMessage
+	(*IngestMessagesRequest)(nil),         // 6: IngestMessagesRequest
+	(*IngestedMessage)(nil),               // 7: IngestedMessage
+	(*IngestMessagesResponse)(nil),        // 8: IngestMessagesResponse
+	(*Mailbox)(nil),                       // 9: Mailbox
+	(*ConnectMailboxRequest)(nil),         // 10: ConnectMailboxRequest
+	(*ConnectMailboxResponse)(nil),        // 11: ConnectMailboxResponse
+	(*DisconnectMailboxRequest)(nil),      // 12: DisconnectMailboxRequest
+	(*DisconnectMailboxResponse)(nil),     // 13: DisconnectMailboxResponse
+	(*GetEmailIngestionRequest)(nil),      // 14: GetEmailIngestionRequest
+	(*GetEmailIngestionResponse)(nil),     // 15: GetEmailIngestionResponse
+	(*PollMailboxRequest)(nil),            // 16: PollMailboxRequest
+	(*PollMailboxResponse)(nil),           // 17: PollMailboxResponse
+}
+var file_proto_ingestion_proto_depIdxs = []int32{
+	0,  // 0: SetIngestionConsentResponse.consent:type_name -> IngestionConsent
+	0,  // 1: ListIngestionConsentsResponse.consents:type_name -> IngestionConsent
+	5,  // 2: IngestMessagesRequest.messages:type_name -> SmsMessage
+	7,  // 3: IngestMessagesResponse.results:type_name -> IngestedMessage
+	9,  // 4: ConnectMailboxResponse.mailbox:type_name -> Mailbox
+	9,  // 5: GetEmailIngestionResponse.mailbox:type_name -> Mailbox
+	7,  // 6: PollMailboxResponse.results:type_name -> IngestedMessage
+	1,  // 7: IngestionService.SetIngestionConsent:input_type -> SetIngestionConsentRequest
+	3,  // 8: IngestionService.ListIngestionConsents:input_type -> ListIngestionConsentsRequest
+	6,  // 9: IngestionService.IngestMessages:input_type -> IngestMessagesRequest
+	10, // 10: IngestionService.ConnectMailbox:input_type -> ConnectMailboxRequest
+	12, // 11: IngestionService.DisconnectMailbox:input_type -> DisconnectMailboxRequest
+	14, // 12: IngestionService.GetEmailIngestion:input_type -> GetEmailIngestionRequest
+	16, // 13: IngestionService.PollMailbox:input_type -> PollMailboxRequest
+	2,  // 14: IngestionService.SetIngestionConsent:output_type -> SetIngestionConsentResponse
+	4,  // 15: IngestionService.ListIngestionConsents:output_type -> ListIngestionConsentsResponse
+	8,  // 16: IngestionService.IngestMessages:output_type -> IngestMessagesResponse
+	11, // 17: IngestionService.ConnectMailbox:output_type -> ConnectMailboxResponse
+	13, // 18: IngestionService.DisconnectMailbox:output_type -> DisconnectMailboxResponse
+	15, // 19: IngestionService.GetEmailIngestion:output_type -> GetEmailIngestionResponse
+	17, // 20: IngestionService.PollMailbox:output_type -> PollMailboxResponse
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_proto_ingestion_proto_init() }
+func file_proto_ingestion_proto_init() {
+	if File_proto_ingestion_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_ingestion_proto_rawDesc), len(file_proto_ingestion_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_ingestion_proto_goTypes,
+		DependencyIndexes: file_proto_ingestion_proto_depIdxs,
+		MessageInfos:      file_proto_ingestion_proto_msgTypes,
+	}.Build()
+	File_proto_ingestion_proto = out.File
+	file_proto_ingestion_proto_goTypes = nil
+	file_proto_ingestion_proto_depIdxs = nil
+}
@@ -0,0 +1,355 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: proto/ingestion.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	IngestionService_SetIngestionConsent_FullMethodName   = "/IngestionService/SetIngestionConsent"
+	IngestionService_ListIngestionConsents_FullMethodName = "/IngestionService/ListIngestionConsents"
+	IngestionService_IngestMessages_FullMethodName        = "/IngestionService/IngestMessages"
+	IngestionService_ConnectMailbox_FullMethodName        = "/IngestionService/ConnectMailbox"
+	IngestionService_DisconnectMailbox_FullMethodName     = "/IngestionService/DisconnectMailbox"
+	IngestionService_GetEmailIngestion_FullMethodName     = "/IngestionService/GetEmailIngestion"
+	IngestionService_PollMailbox_FullMethodName           = "/IngestionService/PollMailbox"
+)
+
+// IngestionServiceClient is the client API for IngestionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// IngestionService records expenses from the caller's messages. Nothing is
+// read before the caller has granted consent for the channel.
+type IngestionServiceClient interface {
+	SetIngestionConsent(ctx context.Context, in *SetIngestionConsentRequest, opts ...grpc.CallOption) (*SetIngestionConsentResponse, error)
+	ListIngestionConsents(ctx context.Context, in *ListIngestionConsentsRequest, opts ...grpc.CallOption) (*ListIngestionConsentsResponse, error)
+	IngestMessages(ctx context.Context, in *IngestMessagesRequest, opts ...grpc.CallOption) (*IngestMessagesResponse, error)
+	ConnectMailbox(ctx context.Context, in *ConnectMailboxRequest, opts ...grpc.CallOption) (*ConnectMailboxResponse, error)
+	DisconnectMailbox(ctx context.Context, in *DisconnectMailboxRequest, opts ...grpc.CallOption) (*DisconnectMailboxResponse, error)
+	GetEmailIngestion(ctx context.Context, in *GetEmailIngestionRequest, opts ...grpc.CallOption) (*GetEmailIngestionResponse, error)
+	PollMailbox(ctx context.Context, in *PollMailboxRequest, opts ...grpc.CallOption) (*PollMailboxResponse, error)
+}
+
+type ingestionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIngestionServiceClient(cc grpc.ClientConnInterface) IngestionServiceClient {
+	return &ingestionServiceClient{cc}
+}
+
+func (c *ingestionServiceClient) SetIngestionConsent(ctx context.Context, in *SetIngestionConsentRequest, opts ...grpc.CallOption) (*SetIngestionConsentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetIngestionConsentResponse)
+	err := c.cc.Invoke(ctx, IngestionService_SetIngestionConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestionServiceClient) ListIngestionConsents(ctx context.Context, in *ListIngestionConsentsRequest, opts ...grpc.CallOption) (*ListIngestionConsentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIngestionConsentsResponse)
+	err := c.cc.Invoke(ctx, IngestionService_ListIngestionConsents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestionServiceClient) IngestMessages(ctx context.Context, in *IngestMessagesRequest, opts ...grpc.CallOption) (*IngestMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestMessagesResponse)
+	err := c.cc.Invoke(ctx, IngestionService_IngestMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestionServiceClient) ConnectMailbox(ctx context.Context, in *ConnectMailboxRequest, opts ...grpc.CallOption) (*ConnectMailboxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConnectMailboxResponse)
+	err := c.cc.Invoke(ctx, IngestionService_ConnectMailbox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestionServiceClient) DisconnectMailbox(ctx context.Context, in *DisconnectMailboxRequest, opts ...grpc.CallOption) (*DisconnectMailboxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisconnectMailboxResponse)
+	err := c.cc.Invoke(ctx, IngestionService_DisconnectMailbox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestionServiceClient) GetEmailIngestion(ctx context.Context, in *GetEmailIngestionRequest, opts ...grpc.CallOption) (*GetEmailIngestionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEmailIngestionResponse)
+	err := c.cc.Invoke(ctx, IngestionService_GetEmailIngestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ingestionServiceClient) PollMailbox(ctx context.Context, in *PollMailboxRequest, opts ...grpc.CallOption) (*PollMailboxResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollMailboxResponse)
+	err := c.cc.Invoke(ctx, IngestionService_PollMailbox_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IngestionServiceServer is the server API for IngestionService service.
+// All implementations must embed UnimplementedIngestionServiceServer
+// for forward compatibility.
+//
+// IngestionService records expenses from the caller's messages. Nothing is
+// read before the caller has granted consent for the channel.
+type IngestionServiceServer interface {
+	SetIngestionConsent(context.Context, *SetIngestionConsentRequest) (*SetIngestionConsentResponse, error)
+	ListIngestionConsents(context.Context, *ListIngestionConsentsRequest) (*ListIngestionConsentsResponse, error)
+	IngestMessages(context.Context, *IngestMessagesRequest) (*IngestMessagesResponse, error)
+	ConnectMailbox(context.Context, *ConnectMailboxRequest) (*ConnectMailboxResponse, error)
+	DisconnectMailbox(context.Context, *DisconnectMailboxRequest) (*DisconnectMailboxResponse, error)
+	GetEmailIngestion(context.Context, *GetEmailIngestionRequest) (*GetEmailIngestionResponse, error)
+	PollMailbox(context.Context, *PollMailboxRequest) (*PollMailboxResponse, error)
+	mustEmbedUnimplementedIngestionServiceServer()
+}
+
+// UnimplementedIngestionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedIngestionServiceServer struct{}
+
+func (UnimplementedIngestionServiceServer) SetIngestionConsent(context.Context, *SetIngestionConsentRequest) (*SetIngestionConsentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIngestionConsent not implemented")
+}
+func (UnimplementedIngestionServiceServer) ListIngestionConsents(context.Context, *ListIngestionConsentsRequest) (*ListIngestionConsentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIngestionConsents not implemented")
+}
+func (UnimplementedIngestionServiceServer) IngestMessages(context.Context, *IngestMessagesRequest) (*IngestMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestMessages not implemented")
+}
+func (UnimplementedIngestionServiceServer) ConnectMailbox(context.Context, *ConnectMailboxRequest) (*ConnectMailboxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConnectMailbox not implemented")
+}
+func (UnimplementedIngestionServiceServer) DisconnectMailbox(context.Context, *DisconnectMailboxRequest) (*DisconnectMailboxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectMailbox not implemented")
+}
+func (UnimplementedIngestionServiceServer) GetEmailIngestion(context.Context, *GetEmailIngestionRequest) (*GetEmailIngestionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmailIngestion not implemented")
+}
+func (UnimplementedIngestionServiceServer) PollMailbox(context.Context, *PollMailboxRequest) (*PollMailboxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PollMailbox not implemented")
+}
+func (UnimplementedIngestionServiceServer) mustEmbedUnimplementedIngestionServiceServer() {}
+func (UnimplementedIngestionServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeIngestionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IngestionServiceServer will
+// result in compilation errors.
+type UnsafeIngestionServiceServer interface {
+	mustEmbedUnimplementedIngestionServiceServer()
+}
+
+func RegisterIngestionServiceServer(s grpc.ServiceRegistrar, srv IngestionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedIngestionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&IngestionService_ServiceDesc, srv)
+}
+
+func _IngestionService_SetIngestionConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIngestionConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestionServiceServer).SetIngestionConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IngestionService_SetIngestionConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestionServiceServer).SetIngestionConsent(ctx, req.(*SetIngestionConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngestionService_ListIngestionConsents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIngestionConsentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestionServiceServer).ListIngestionConsents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IngestionService_ListIngestionConsents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestionServiceServer).ListIngestionConsents(ctx, req.(*ListIngestionConsentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngestionService_IngestMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestionServiceServer).IngestMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IngestionService_IngestMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestionServiceServer).IngestMessages(ctx, req.(*IngestMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngestionService_ConnectMailbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectMailboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestionServiceServer).ConnectMailbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IngestionService_ConnectMailbox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestionServiceServer).ConnectMailbox(ctx, req.(*ConnectMailboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngestionService_DisconnectMailbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectMailboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestionServiceServer).DisconnectMailbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IngestionService_DisconnectMailbox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestionServiceServer).DisconnectMailbox(ctx, req.(*DisconnectMailboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngestionService_GetEmailIngestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmailIngestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestionServiceServer).GetEmailIngestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IngestionService_GetEmailIngestion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestionServiceServer).GetEmailIngestion(ctx, req.(*GetEmailIngestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IngestionService_PollMailbox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PollMailboxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IngestionServiceServer).PollMailbox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IngestionService_PollMailbox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngestionServiceServer).PollMailbox(ctx, req.(*PollMailboxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IngestionService_ServiceDesc is the grpc.ServiceDesc for IngestionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IngestionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "IngestionService",
+	HandlerType: (*IngestionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetIngestionConsent",
+			Handler:    _IngestionService_SetIngestionConsent_Handler,
+		},
+		{
+			MethodName: "ListIngestionConsents",
+			Handler:    _IngestionService_ListIngestionConsents_Handler,
+		},
+		{
+			MethodName: "IngestMessages",
+			Handler:    _IngestionService_IngestMessages_Handler,
+		},
+		{
+			MethodName: "ConnectMailbox",
+			Handler:    _IngestionService_ConnectMailbox_Handler,
+		},
+		{
+			MethodName: "DisconnectMailbox",
+			Handler:    _IngestionService_DisconnectMailbox_Handler,
+		},
+		{
+			MethodName: "GetEmailIngestion",
+			Handler:    _IngestionService_GetEmailIngestion_Handler,
+		},
+		{
+			MethodName: "PollMailbox",
+			Handler:    _IngestionService_PollMailbox_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/ingestion.proto",
+}
@@ -0,0 +1,879 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.21.12
+// source: proto/merchants.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// default_category is used for receipts from the merchant unless the
+// personal classifier or a rule picks a category.
+type Merchant struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Location        string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	DefaultCategory string                 `protobuf:"bytes,4,opt,name=default_category,json=defaultCategory,proto3" json:"default_category,omitempty"`
+	Aliases         []string               `protobuf:"bytes,5,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	ExpenseCount    int32                  `protobuf:"varint,6,opt,name=expense_count,json=expenseCount,proto3" json:"expense_count,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Merchant) Reset() {
+	*x = Merchant{}
+	mi := &file_proto_merchants_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Merchant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Merchant) ProtoMessage() {}
+
+func (x *Merchant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Merchant.ProtoReflect.Descriptor instead.
+func (*Merchant) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Merchant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Merchant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Merchant) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Merchant) GetDefaultCategory() string {
+	if x != nil {
+		return x.DefaultCategory
+	}
+	return ""
+}
+
+func (x *Merchant) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Merchant) GetExpenseCount() int32 {
+	if x != nil {
+		return x.ExpenseCount
+	}
+	return 0
+}
+
+func (x *Merchant) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// query filters merchants whose name or aliases contain it. order_by is
+// "name", the default, or "created_at", and pages hold up to 1000
+// merchants, 100 by default, as described on ExpensesService.
+type ListMerchantsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Query             string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	PageSize          int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken         string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,5,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListMerchantsRequest) Reset() {
+	*x = ListMerchantsRequest{}
+	mi := &file_proto_merchants_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMerchantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMerchantsRequest) ProtoMessage() {}
+
+func (x *ListMerchantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMerchantsRequest.ProtoReflect.Descriptor instead.
+func (*ListMerchantsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListMerchantsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListMerchantsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMerchantsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListMerchantsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListMerchantsRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+type ListMerchantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchants     []*Merchant            `protobuf:"bytes,1,rep,name=merchants,proto3" json:"merchants,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMerchantsResponse) Reset() {
+	*x = ListMerchantsResponse{}
+	mi := &file_proto_merchants_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMerchantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMerchantsResponse) ProtoMessage() {}
+
+func (x *ListMerchantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMerchantsResponse.ProtoReflect.Descriptor instead.
+func (*ListMerchantsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListMerchantsResponse) GetMerchants() []*Merchant {
+	if x != nil {
+		return x.Merchants
+	}
+	return nil
+}
+
+func (x *ListMerchantsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListMerchantsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// UpdateMerchant replaces the name, location and default category of the
+// merchant with the given id.
+type UpdateMerchantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchant      *Merchant              `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMerchantRequest) Reset() {
+	*x = UpdateMerchantRequest{}
+	mi := &file_proto_merchants_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMerchantRequest) ProtoMessage() {}
+
+func (x *UpdateMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMerchantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateMerchantRequest) GetMerchant() *Merchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+type UpdateMerchantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchant      *Merchant              `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMerchantResponse) Reset() {
+	*x = UpdateMerchantResponse{}
+	mi := &file_proto_merchants_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMerchantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMerchantResponse) ProtoMessage() {}
+
+func (x *UpdateMerchantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMerchantResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMerchantResponse) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateMerchantResponse) GetMerchant() *Merchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+// MergeMerchants moves the aliases and expenses of the source merchants to
+// the target and deletes the sources.
+type MergeMerchantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetId      string                 `protobuf:"bytes,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	SourceIds     []string               `protobuf:"bytes,2,rep,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeMerchantsRequest) Reset() {
+	*x = MergeMerchantsRequest{}
+	mi := &file_proto_merchants_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeMerchantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeMerchantsRequest) ProtoMessage() {}
+
+func (x *MergeMerchantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeMerchantsRequest.ProtoReflect.Descriptor instead.
+func (*MergeMerchantsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MergeMerchantsRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *MergeMerchantsRequest) GetSourceIds() []string {
+	if x != nil {
+		return x.SourceIds
+	}
+	return nil
+}
+
+type MergeMerchantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchant      *Merchant              `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeMerchantsResponse) Reset() {
+	*x = MergeMerchantsResponse{}
+	mi := &file_proto_merchants_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeMerchantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeMerchantsResponse) ProtoMessage() {}
+
+func (x *MergeMerchantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeMerchantsResponse.ProtoReflect.Descriptor instead.
+func (*MergeMerchantsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MergeMerchantsResponse) GetMerchant() *Merchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+// SplitMerchant moves the given aliases, and the expenses whose place
+// normalizes to one of them, to a new merchant called name.
+type SplitMerchantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Aliases       []string               `protobuf:"bytes,2,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitMerchantRequest) Reset() {
+	*x = SplitMerchantRequest{}
+	mi := &file_proto_merchants_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitMerchantRequest) ProtoMessage() {}
+
+func (x *SplitMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitMerchantRequest.ProtoReflect.Descriptor instead.
+func (*SplitMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SplitMerchantRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *SplitMerchantRequest) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *SplitMerchantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SplitMerchantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchant      *Merchant              `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	Split         *Merchant              `protobuf:"bytes,2,opt,name=split,proto3" json:"split,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitMerchantResponse) Reset() {
+	*x = SplitMerchantResponse{}
+	mi := &file_proto_merchants_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitMerchantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitMerchantResponse) ProtoMessage() {}
+
+func (x *SplitMerchantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitMerchantResponse.ProtoReflect.Descriptor instead.
+func (*SplitMerchantResponse) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SplitMerchantResponse) GetMerchant() *Merchant {
+	if x != nil {
+		return x.Merchant
+	}
+	return nil
+}
+
+func (x *SplitMerchantResponse) GetSplit() *Merchant {
+	if x != nil {
+		return x.Split
+	}
+	return nil
+}
+
+// since and until are optional RFC 3339 times; limit defaults to 10.
+type GetTopMerchantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Since         string                 `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	Until         string                 `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopMerchantsRequest) Reset() {
+	*x = GetTopMerchantsRequest{}
+	mi := &file_proto_merchants_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopMerchantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopMerchantsRequest) ProtoMessage() {}
+
+func (x *GetTopMerchantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopMerchantsRequest.ProtoReflect.Descriptor instead.
+func (*GetTopMerchantsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTopMerchantsRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *GetTopMerchantsRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *GetTopMerchantsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TopMerchant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Spent         float64                `protobuf:"fixed64,3,opt,name=spent,proto3" json:"spent,omitempty"`
+	ExpenseCount  int32                  `protobuf:"varint,4,opt,name=expense_count,json=expenseCount,proto3" json:"expense_count,omitempty"`
+	LastVisit     string                 `protobuf:"bytes,5,opt,name=last_visit,json=lastVisit,proto3" json:"last_visit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopMerchant) Reset() {
+	*x = TopMerchant{}
+	mi := &file_proto_merchants_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopMerchant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopMerchant) ProtoMessage() {}
+
+func (x *TopMerchant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopMerchant.ProtoReflect.Descriptor instead.
+func (*TopMerchant) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TopMerchant) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *TopMerchant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TopMerchant) GetSpent() float64 {
+	if x != nil {
+		return x.Spent
+	}
+	return 0
+}
+
+func (x *TopMerchant) GetExpenseCount() int32 {
+	if x != nil {
+		return x.ExpenseCount
+	}
+	return 0
+}
+
+func (x *TopMerchant) GetLastVisit() string {
+	if x != nil {
+		return x.LastVisit
+	}
+	return ""
+}
+
+type GetTopMerchantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Merchants     []*TopMerchant         `protobuf:"bytes,1,rep,name=merchants,proto3" json:"merchants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopMerchantsResponse) Reset() {
+	*x = GetTopMerchantsResponse{}
+	mi := &file_proto_merchants_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopMerchantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopMerchantsResponse) ProtoMessage() {}
+
+func (x *GetTopMerchantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_merchants_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopMerchantsResponse.ProtoReflect.Descriptor instead.
+func (*GetTopMerchantsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_merchants_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTopMerchantsResponse) GetMerchants() []*TopMerchant {
+	if x != nil {
+		return x.Merchants
+	}
+	return nil
+}
+
+var File_proto_merchants_proto protoreflect.FileDescriptor
+
+const file_proto_merchants_proto_rawDesc = "" +
+	"\n" +
+	"\x15proto/merchants.proto\"\xd3\x01\n" +
+	"\bMerchant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12)\n" +
+	"\x10default_category\x18\x04 \x01(\tR\x0fdefaultCategory\x12\x18\n" +
+	"\aaliases\x18\x05 \x03(\tR\aaliases\x12#\n" +
+	"\rexpense_count\x18\x06 \x01(\x05R\fexpenseCount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"\xb3\x01\n" +
+	"\x14ListMerchantsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12.\n" +
+	"\x13include_total_count\x18\x05 \x01(\bR\x11includeTotalCount\"\x89\x01\n" +
+	"\x15ListMerchantsResponse\x12'\n" +
+	"\tmerchants\x18\x01 \x03(\v2\t.MerchantR\tmerchants\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\">\n" +
+	"\x15UpdateMerchantRequest\x12%\n" +
+	"\bmerchant\x18\x01 \x01(\v2\t.MerchantR\bmerchant\"?\n" +
+	"\x16UpdateMerchantResponse\x12%\n" +
+	"\bmerchant\x18\x01 \x01(\v2\t.MerchantR\bmerchant\"S\n" +
+	"\x15MergeMerchantsRequest\x12\x1b\n" +
+	"\ttarget_id\x18\x01 \x01(\tR\btargetId\x12\x1d\n" +
+	"\n" +
+	"source_ids\x18\x02 \x03(\tR\tsourceIds\"?\n" +
+	"\x16MergeMerchantsResponse\x12%\n" +
+	"\bmerchant\x18\x01 \x01(\v2\t.MerchantR\bmerchant\"e\n" +
+	"\x14SplitMerchantRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12\x18\n" +
+	"\aaliases\x18\x02 \x03(\tR\aaliases\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"_\n" +
+	"\x15SplitMerchantResponse\x12%\n" +
+	"\bmerchant\x18\x01 \x01(\v2\t.MerchantR\bmerchant\x12\x1f\n" +
+	"\x05split\x18\x02 \x01(\v2\t.MerchantR\x05split\"Z\n" +
+	"\x16GetTopMerchantsRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x02 \x01(\tR\x05until\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x9c\x01\n" +
+	"\vTopMerchant\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05spent\x18\x03 \x01(\x01R\x05spent\x12#\n" +
+	"\rexpense_count\x18\x04 \x01(\x05R\fexpenseCount\x12\x1d\n" +
+	"\n" +
+	"last_visit\x18\x05 \x01(\tR\tlastVisit\"E\n" +
+	"\x17GetTopMerchantsResponse\x12*\n" +
+	"\tmerchants\x18\x01 \x03(\v2\f.TopMerchantR\tmerchants2\xde\x02\n" +
+	"\x10MerchantsService\x12>\n" +
+	"\rListMerchants\x12\x15.ListMerchantsRequest\x1a\x16.ListMerchantsResponse\x12A\n" +
+	"\x0eUpdateMerchant\x12\x16.UpdateMerchantRequest\x1a\x17.UpdateMerchantResponse\x12A\n" +
+	"\x0eMergeMerchants\x12\x16.MergeMerchantsRequest\x1a\x17.MergeMerchantsResponse\x12>\n" +
+	"\rSplitMerchant\x12\x15.SplitMerchantRequest\x1a\x16.SplitMerchantResponse\x12D\n" +
+	"\x0fGetTopMerchants\x12\x17.GetTopMerchantsRequest\x1a\x18.GetTopMerchantsResponseB+Z)github.com/barathsurya2004/expenses/protob\x06proto3"
+
+var (
+	file_proto_merchants_proto_rawDescOnce sync.Once
+	file_proto_merchants_proto_rawDescData []byte
+)
+
+func file_proto_merchants_proto_rawDescGZIP() []byte {
+	file_proto_merchants_proto_rawDescOnce.Do(func() {
+		file_proto_merchants_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_merchants_proto_rawDesc), len(file_proto_merchants_proto_rawDesc)))
+	})
+	return file_proto_merchants_proto_rawDescData
+}
+
+var file_proto_merchants_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_merchants_proto_goTypes = []any{
+	(*Merchant)(nil),                // 0: Merchant
+	(*ListMerchantsRequest)(nil),    // 1: ListMerchantsRequest
+	(*ListMerchantsResponse)(nil),   // 2: ListMerchantsResponse
+	(*UpdateMerchantRequest)(nil),   // 3: UpdateMerchantRequest
+	(*UpdateMerchantResponse)(nil),  // 4: UpdateMerchantResponse
+	(*MergeMerchantsRequest)(nil),   // 5: MergeMerchantsRequest
+	(*MergeMerchantsResponse)(nil),  // 6: MergeMerchantsResponse
+	(*SplitMerchantRequest)(nil),    // 7: SplitMerchantRequest
+	(*SplitMerchantResponse)(nil),   // 8: SplitMerchantResponse
+	(*GetTopMerchantsRequest)(nil),  // 9: GetTopMerchantsRequest
+	(*TopMerchant)(nil),             // 10: TopMerchant
+	(*GetTopMerchantsResponse)(nil), // 11: GetTopMerchantsResponse
+}
+var file_proto_merchants_proto_depIdxs = []int32{
+	0,  // 0: ListMerchantsResponse.merchants:type_name -> Merchant
+	0,  // 1: UpdateMerchantRequest.merchant:type_name -> Merchant
+	0,  // 2: UpdateMerchantResponse.merchant:type_name -> Merchant
+	0,  // 3: MergeMerchantsResponse.merchant:type_name -> Merchant
+	0,  // 4: SplitMerchantResponse.merchant:type_name -> Merchant
+	0,  // 5: SplitMerchantResponse.split:type_name -> Merchant
+	10, // 6: GetTopMerchantsResponse.merchants:type_name -> TopMerchant
+	1,  // 7: MerchantsService.ListMerchants:input_type -> ListMerchantsRequest
+	3,  // 8: MerchantsService.UpdateMerchant:input_type -> UpdateMerchantRequest
+	5,  // 9: MerchantsService.MergeMerchants:input_type -> MergeMerchantsRequest
+	7,  // 10: MerchantsService.SplitMerchant:input_type -> SplitMerchantRequest
+	9,  // 11: MerchantsService.GetTopMerchants:input_type -> GetTopMerchantsRequest
+	2,  // 12: MerchantsService.ListMerchants:output_type -> ListMerchantsResponse
+	4,  // 13: MerchantsService.UpdateMerchant:output_type -> UpdateMerchantResponse
+	6,  // 14: MerchantsService.MergeMerchants:output_type -> MergeMerchantsResponse
+	8,  // 15: MerchantsService.SplitMerchant:output_type -> SplitMerchantResponse
+	11, // 16: MerchantsService.GetTopMerchants:output_type -> GetTopMerchantsResponse
+	12, // [12:17] is the sub-list for method output_type
+	7,  // [7:12] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_proto_merchants_proto_init() }
+func file_proto_merchants_proto_init() {
+	if File_proto_merchants_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_merchants_proto_rawDesc), len(file_proto_merchants_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_merchants_proto_goTypes,
+		DependencyIndexes: file_proto_merchants_proto_depIdxs,
+		MessageInfos:      file_proto_merchants_proto_msgTypes,
+	}.Build()
+	File_proto_merchants_proto = out.File
+	file_proto_merchants_proto_goTypes = nil
+	file_proto_merchants_proto_depIdxs = nil
+}
@@ -0,0 +1,1635 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.21.12
+// source: proto/reports.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReportAmount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportAmount) Reset() {
+	*x = ReportAmount{}
+	mi := &file_proto_reports_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportAmount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportAmount) ProtoMessage() {}
+
+func (x *ReportAmount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportAmount.ProtoReflect.Descriptor instead.
+func (*ReportAmount) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReportAmount) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ReportAmount) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// amount and currency are those submitted for approval, or the expense's
+// current ones while the report is a draft.
+type ExpenseReportLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpenseId     string                 `protobuf:"bytes,2,opt,name=expense_id,json=expenseId,proto3" json:"expense_id,omitempty"`
+	DateAndTime   string                 `protobuf:"bytes,3,opt,name=date_and_time,json=dateAndTime,proto3" json:"date_and_time,omitempty"`
+	Place         string                 `protobuf:"bytes,4,opt,name=place,proto3" json:"place,omitempty"`
+	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	ModeOfPayment string                 `protobuf:"bytes,6,opt,name=mode_of_payment,json=modeOfPayment,proto3" json:"mode_of_payment,omitempty"`
+	Amount        float64                `protobuf:"fixed64,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	ReceiptIds    []string               `protobuf:"bytes,10,rep,name=receipt_ids,json=receiptIds,proto3" json:"receipt_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpenseReportLine) Reset() {
+	*x = ExpenseReportLine{}
+	mi := &file_proto_reports_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpenseReportLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpenseReportLine) ProtoMessage() {}
+
+func (x *ExpenseReportLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpenseReportLine.ProtoReflect.Descriptor instead.
+func (*ExpenseReportLine) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExpenseReportLine) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExpenseReportLine) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+func (x *ExpenseReportLine) GetDateAndTime() string {
+	if x != nil {
+		return x.DateAndTime
+	}
+	return ""
+}
+
+func (x *ExpenseReportLine) GetPlace() string {
+	if x != nil {
+		return x.Place
+	}
+	return ""
+}
+
+func (x *ExpenseReportLine) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ExpenseReportLine) GetModeOfPayment() string {
+	if x != nil {
+		return x.ModeOfPayment
+	}
+	return ""
+}
+
+func (x *ExpenseReportLine) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ExpenseReportLine) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ExpenseReportLine) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExpenseReportLine) GetReceiptIds() []string {
+	if x != nil {
+		return x.ReceiptIds
+	}
+	return nil
+}
+
+// line_id is empty for comments on the whole report.
+type ReportComment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LineId        string                 `protobuf:"bytes,2,opt,name=line_id,json=lineId,proto3" json:"line_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Author        string                 `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string                 `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportComment) Reset() {
+	*x = ReportComment{}
+	mi := &file_proto_reports_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportComment) ProtoMessage() {}
+
+func (x *ReportComment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportComment.ProtoReflect.Descriptor instead.
+func (*ReportComment) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReportComment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReportComment) GetLineId() string {
+	if x != nil {
+		return x.LineId
+	}
+	return ""
+}
+
+func (x *ReportComment) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ReportComment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ReportComment) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *ReportComment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// claimed and approved are totalled per currency. lines and comments are
+// left out of listings.
+type ExpenseReport struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title                  string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Purpose                string                 `protobuf:"bytes,3,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Status                 string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	SubmitterId            string                 `protobuf:"bytes,5,opt,name=submitter_id,json=submitterId,proto3" json:"submitter_id,omitempty"`
+	Submitter              string                 `protobuf:"bytes,6,opt,name=submitter,proto3" json:"submitter,omitempty"`
+	ApproverId             string                 `protobuf:"bytes,7,opt,name=approver_id,json=approverId,proto3" json:"approver_id,omitempty"`
+	Approver               string                 `protobuf:"bytes,8,opt,name=approver,proto3" json:"approver,omitempty"`
+	LineCount              int32                  `protobuf:"varint,9,opt,name=line_count,json=lineCount,proto3" json:"line_count,omitempty"`
+	Claimed                []*ReportAmount        `protobuf:"bytes,10,rep,name=claimed,proto3" json:"claimed,omitempty"`
+	Approved               []*ReportAmount        `protobuf:"bytes,11,rep,name=approved,proto3" json:"approved,omitempty"`
+	CreatedAt              string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SubmittedAt            string                 `protobuf:"bytes,13,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	DecidedAt              string                 `protobuf:"bytes,14,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	ReimbursedAt           string                 `protobuf:"bytes,15,opt,name=reimbursed_at,json=reimbursedAt,proto3" json:"reimbursed_at,omitempty"`
+	ReimbursementReference string                 `protobuf:"bytes,16,opt,name=reimbursement_reference,json=reimbursementReference,proto3" json:"reimbursement_reference,omitempty"`
+	Lines                  []*ExpenseReportLine   `protobuf:"bytes,17,rep,name=lines,proto3" json:"lines,omitempty"`
+	Comments               []*ReportComment       `protobuf:"bytes,18,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ExpenseReport) Reset() {
+	*x = ExpenseReport{}
+	mi := &file_proto_reports_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpenseReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpenseReport) ProtoMessage() {}
+
+func (x *ExpenseReport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpenseReport.ProtoReflect.Descriptor instead.
+func (*ExpenseReport) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExpenseReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetSubmitterId() string {
+	if x != nil {
+		return x.SubmitterId
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetSubmitter() string {
+	if x != nil {
+		return x.Submitter
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetApproverId() string {
+	if x != nil {
+		return x.ApproverId
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetLineCount() int32 {
+	if x != nil {
+		return x.LineCount
+	}
+	return 0
+}
+
+func (x *ExpenseReport) GetClaimed() []*ReportAmount {
+	if x != nil {
+		return x.Claimed
+	}
+	return nil
+}
+
+func (x *ExpenseReport) GetApproved() []*ReportAmount {
+	if x != nil {
+		return x.Approved
+	}
+	return nil
+}
+
+func (x *ExpenseReport) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetSubmittedAt() string {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetDecidedAt() string {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetReimbursedAt() string {
+	if x != nil {
+		return x.ReimbursedAt
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetReimbursementReference() string {
+	if x != nil {
+		return x.ReimbursementReference
+	}
+	return ""
+}
+
+func (x *ExpenseReport) GetLines() []*ExpenseReportLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ExpenseReport) GetComments() []*ReportComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type ExpenseReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Report        *ExpenseReport         `protobuf:"bytes,1,opt,name=report,proto3" json:"report,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpenseReportResponse) Reset() {
+	*x = ExpenseReportResponse{}
+	mi := &file_proto_reports_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpenseReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpenseReportResponse) ProtoMessage() {}
+
+func (x *ExpenseReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpenseReportResponse.ProtoReflect.Descriptor instead.
+func (*ExpenseReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExpenseReportResponse) GetReport() *ExpenseReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+// expense_ids are expenses the caller recorded that are not on another
+// report.
+type CreateExpenseReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Purpose       string                 `protobuf:"bytes,2,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	ExpenseIds    []string               `protobuf:"bytes,3,rep,name=expense_ids,json=expenseIds,proto3" json:"expense_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateExpenseReportRequest) Reset() {
+	*x = CreateExpenseReportRequest{}
+	mi := &file_proto_reports_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateExpenseReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateExpenseReportRequest) ProtoMessage() {}
+
+func (x *CreateExpenseReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateExpenseReportRequest.ProtoReflect.Descriptor instead.
+func (*CreateExpenseReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateExpenseReportRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateExpenseReportRequest) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *CreateExpenseReportRequest) GetExpenseIds() []string {
+	if x != nil {
+		return x.ExpenseIds
+	}
+	return nil
+}
+
+// An empty title or purpose is left unchanged.
+type UpdateExpenseReportRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReportId         string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Purpose          string                 `protobuf:"bytes,3,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	AddExpenseIds    []string               `protobuf:"bytes,4,rep,name=add_expense_ids,json=addExpenseIds,proto3" json:"add_expense_ids,omitempty"`
+	RemoveExpenseIds []string               `protobuf:"bytes,5,rep,name=remove_expense_ids,json=removeExpenseIds,proto3" json:"remove_expense_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateExpenseReportRequest) Reset() {
+	*x = UpdateExpenseReportRequest{}
+	mi := &file_proto_reports_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateExpenseReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateExpenseReportRequest) ProtoMessage() {}
+
+func (x *UpdateExpenseReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateExpenseReportRequest.ProtoReflect.Descriptor instead.
+func (*UpdateExpenseReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateExpenseReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *UpdateExpenseReportRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateExpenseReportRequest) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *UpdateExpenseReportRequest) GetAddExpenseIds() []string {
+	if x != nil {
+		return x.AddExpenseIds
+	}
+	return nil
+}
+
+func (x *UpdateExpenseReportRequest) GetRemoveExpenseIds() []string {
+	if x != nil {
+		return x.RemoveExpenseIds
+	}
+	return nil
+}
+
+type DeleteExpenseReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteExpenseReportRequest) Reset() {
+	*x = DeleteExpenseReportRequest{}
+	mi := &file_proto_reports_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteExpenseReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteExpenseReportRequest) ProtoMessage() {}
+
+func (x *DeleteExpenseReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteExpenseReportRequest.ProtoReflect.Descriptor instead.
+func (*DeleteExpenseReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteExpenseReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type DeleteExpenseReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteExpenseReportResponse) Reset() {
+	*x = DeleteExpenseReportResponse{}
+	mi := &file_proto_reports_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteExpenseReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteExpenseReportResponse) ProtoMessage() {}
+
+func (x *DeleteExpenseReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteExpenseReportResponse.ProtoReflect.Descriptor instead.
+func (*DeleteExpenseReportResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteExpenseReportResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// role is "submitter" (the default) for the caller's own reports or
+// "approver" for reports submitted to the caller. status optionally
+// filters by report status. order_by is "created_at desc", the default,
+// "created_at" or "title", and pages hold up to 1000 reports, 100 by
+// default, as described on ExpensesService.
+type ListExpenseReportsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Role              string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Status            string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	PageSize          int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken         string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,6,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListExpenseReportsRequest) Reset() {
+	*x = ListExpenseReportsRequest{}
+	mi := &file_proto_reports_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpenseReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpenseReportsRequest) ProtoMessage() {}
+
+func (x *ListExpenseReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpenseReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListExpenseReportsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListExpenseReportsRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListExpenseReportsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListExpenseReportsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListExpenseReportsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListExpenseReportsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListExpenseReportsRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+type ListExpenseReportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*ExpenseReport       `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpenseReportsResponse) Reset() {
+	*x = ListExpenseReportsResponse{}
+	mi := &file_proto_reports_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpenseReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpenseReportsResponse) ProtoMessage() {}
+
+func (x *ListExpenseReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpenseReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListExpenseReportsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListExpenseReportsResponse) GetReports() []*ExpenseReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+func (x *ListExpenseReportsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListExpenseReportsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetExpenseReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExpenseReportRequest) Reset() {
+	*x = GetExpenseReportRequest{}
+	mi := &file_proto_reports_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExpenseReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExpenseReportRequest) ProtoMessage() {}
+
+func (x *GetExpenseReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExpenseReportRequest.ProtoReflect.Descriptor instead.
+func (*GetExpenseReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetExpenseReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+// Exactly one of approver_username or approver_email names the approver,
+// who needs an account and cannot be the submitter.
+type SubmitExpenseReportRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReportId         string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	ApproverUsername string                 `protobuf:"bytes,2,opt,name=approver_username,json=approverUsername,proto3" json:"approver_username,omitempty"`
+	ApproverEmail    string                 `protobuf:"bytes,3,opt,name=approver_email,json=approverEmail,proto3" json:"approver_email,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SubmitExpenseReportRequest) Reset() {
+	*x = SubmitExpenseReportRequest{}
+	mi := &file_proto_reports_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitExpenseReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitExpenseReportRequest) ProtoMessage() {}
+
+func (x *SubmitExpenseReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitExpenseReportRequest.ProtoReflect.Descriptor instead.
+func (*SubmitExpenseReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SubmitExpenseReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *SubmitExpenseReportRequest) GetApproverUsername() string {
+	if x != nil {
+		return x.ApproverUsername
+	}
+	return ""
+}
+
+func (x *SubmitExpenseReportRequest) GetApproverEmail() string {
+	if x != nil {
+		return x.ApproverEmail
+	}
+	return ""
+}
+
+// decision is "approve" or "reject". A comment is added to the line.
+type LineDecision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LineId        string                 `protobuf:"bytes,1,opt,name=line_id,json=lineId,proto3" json:"line_id,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineDecision) Reset() {
+	*x = LineDecision{}
+	mi := &file_proto_reports_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineDecision) ProtoMessage() {}
+
+func (x *LineDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineDecision.ProtoReflect.Descriptor instead.
+func (*LineDecision) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LineDecision) GetLineId() string {
+	if x != nil {
+		return x.LineId
+	}
+	return ""
+}
+
+func (x *LineDecision) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *LineDecision) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// ReviewExpenseReport records the approver's decisions. decision, when set,
+// applies to every pending line not listed in decisions, and comment is
+// added to the report. Once no line is pending the report is approved if
+// any line was, and rejected otherwise.
+type ReviewExpenseReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Decisions     []*LineDecision        `protobuf:"bytes,2,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	Decision      string                 `protobuf:"bytes,3,opt,name=decision,proto3" json:"decision,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewExpenseReportRequest) Reset() {
+	*x = ReviewExpenseReportRequest{}
+	mi := &file_proto_reports_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewExpenseReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewExpenseReportRequest) ProtoMessage() {}
+
+func (x *ReviewExpenseReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewExpenseReportRequest.ProtoReflect.Descriptor instead.
+func (*ReviewExpenseReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReviewExpenseReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ReviewExpenseReportRequest) GetDecisions() []*LineDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+func (x *ReviewExpenseReportRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *ReviewExpenseReportRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// line_id is optional.
+type CommentOnExpenseReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	LineId        string                 `protobuf:"bytes,2,opt,name=line_id,json=lineId,proto3" json:"line_id,omitempty"`
+	Body          string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentOnExpenseReportRequest) Reset() {
+	*x = CommentOnExpenseReportRequest{}
+	mi := &file_proto_reports_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentOnExpenseReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentOnExpenseReportRequest) ProtoMessage() {}
+
+func (x *CommentOnExpenseReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentOnExpenseReportRequest.ProtoReflect.Descriptor instead.
+func (*CommentOnExpenseReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CommentOnExpenseReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *CommentOnExpenseReportRequest) GetLineId() string {
+	if x != nil {
+		return x.LineId
+	}
+	return ""
+}
+
+func (x *CommentOnExpenseReportRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// reimbursed_at is an RFC 3339 time or YYYY-MM-DD date and defaults to now.
+type MarkReportReimbursedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Reference     string                 `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	ReimbursedAt  string                 `protobuf:"bytes,3,opt,name=reimbursed_at,json=reimbursedAt,proto3" json:"reimbursed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReportReimbursedRequest) Reset() {
+	*x = MarkReportReimbursedRequest{}
+	mi := &file_proto_reports_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReportReimbursedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReportReimbursedRequest) ProtoMessage() {}
+
+func (x *MarkReportReimbursedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReportReimbursedRequest.ProtoReflect.Descriptor instead.
+func (*MarkReportReimbursedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MarkReportReimbursedRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *MarkReportReimbursedRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *MarkReportReimbursedRequest) GetReimbursedAt() string {
+	if x != nil {
+		return x.ReimbursedAt
+	}
+	return ""
+}
+
+type GetReportReceiptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	ReceiptId     string                 `protobuf:"bytes,2,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportReceiptRequest) Reset() {
+	*x = GetReportReceiptRequest{}
+	mi := &file_proto_reports_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportReceiptRequest) ProtoMessage() {}
+
+func (x *GetReportReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportReceiptRequest.ProtoReflect.Descriptor instead.
+func (*GetReportReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetReportReceiptRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *GetReportReceiptRequest) GetReceiptId() string {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return ""
+}
+
+// format is "csv" or "pdf", the default. The PDF includes the receipt
+// images.
+type ExportExpenseReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportExpenseReportRequest) Reset() {
+	*x = ExportExpenseReportRequest{}
+	mi := &file_proto_reports_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportExpenseReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportExpenseReportRequest) ProtoMessage() {}
+
+func (x *ExportExpenseReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportExpenseReportRequest.ProtoReflect.Descriptor instead.
+func (*ExportExpenseReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExportExpenseReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *ExportExpenseReportRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// content_type and filename are set on the first message only.
+type ReportFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chunks        []byte                 `protobuf:"bytes,1,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportFile) Reset() {
+	*x = ReportFile{}
+	mi := &file_proto_reports_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportFile) ProtoMessage() {}
+
+func (x *ReportFile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_reports_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportFile.ProtoReflect.Descriptor instead.
+func (*ReportFile) Descriptor() ([]byte, []int) {
+	return file_proto_reports_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReportFile) GetChunks() []byte {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *ReportFile) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ReportFile) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+var File_proto_reports_proto protoreflect.FileDescriptor
+
+const file_proto_reports_proto_rawDesc = "" +
+	"\n" +
+	"\x13proto/reports.proto\"B\n" +
+	"\fReportAmount\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\xad\x02\n" +
+	"\x11ExpenseReportLine\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"expense_id\x18\x02 \x01(\tR\texpenseId\x12\"\n" +
+	"\rdate_and_time\x18\x03 \x01(\tR\vdateAndTime\x12\x14\n" +
+	"\x05place\x18\x04 \x01(\tR\x05place\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12&\n" +
+	"\x0fmode_of_payment\x18\x06 \x01(\tR\rmodeOfPayment\x12\x16\n" +
+	"\x06amount\x18\a \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1f\n" +
+	"\vreceipt_ids\x18\n" +
+	" \x03(\tR\n" +
+	"receiptIds\"\xa0\x01\n" +
+	"\rReportComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\aline_id\x18\x02 \x01(\tR\x06lineId\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\x12\x12\n" +
+	"\x04body\x18\x05 \x01(\tR\x04body\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"\xed\x04\n" +
+	"\rExpenseReport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\apurpose\x18\x03 \x01(\tR\apurpose\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12!\n" +
+	"\fsubmitter_id\x18\x05 \x01(\tR\vsubmitterId\x12\x1c\n" +
+	"\tsubmitter\x18\x06 \x01(\tR\tsubmitter\x12\x1f\n" +
+	"\vapprover_id\x18\a \x01(\tR\n" +
+	"approverId\x12\x1a\n" +
+	"\bapprover\x18\b \x01(\tR\bapprover\x12\x1d\n" +
+	"\n" +
+	"line_count\x18\t \x01(\x05R\tlineCount\x12'\n" +
+	"\aclaimed\x18\n" +
+	" \x03(\v2\r.ReportAmountR\aclaimed\x12)\n" +
+	"\bapproved\x18\v \x03(\v2\r.ReportAmountR\bapproved\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\x12!\n" +
+	"\fsubmitted_at\x18\r \x01(\tR\vsubmittedAt\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\x0e \x01(\tR\tdecidedAt\x12#\n" +
+	"\rreimbursed_at\x18\x0f \x01(\tR\freimbursedAt\x127\n" +
+	"\x17reimbursement_reference\x18\x10 \x01(\tR\x16reimbursementReference\x12(\n" +
+	"\x05lines\x18\x11 \x03(\v2\x12.ExpenseReportLineR\x05lines\x12*\n" +
+	"\bcomments\x18\x12 \x03(\v2\x0e.ReportCommentR\bcomments\"?\n" +
+	"\x15ExpenseReportResponse\x12&\n" +
+	"\x06report\x18\x01 \x01(\v2\x0e.ExpenseReportR\x06report\"m\n" +
+	"\x1aCreateExpenseReportRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\apurpose\x18\x02 \x01(\tR\apurpose\x12\x1f\n" +
+	"\vexpense_ids\x18\x03 \x03(\tR\n" +
+	"expenseIds\"\xbf\x01\n" +
+	"\x1aUpdateExpenseReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\apurpose\x18\x03 \x01(\tR\apurpose\x12&\n" +
+	"\x0fadd_expense_ids\x18\x04 \x03(\tR\raddExpenseIds\x12,\n" +
+	"\x12remove_expense_ids\x18\x05 \x03(\tR\x10removeExpenseIds\"9\n" +
+	"\x1aDeleteExpenseReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\"7\n" +
+	"\x1bDeleteExpenseReportResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xce\x01\n" +
+	"\x19ListExpenseReportsRequest\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x05 \x01(\tR\aorderBy\x12.\n" +
+	"\x13include_total_count\x18\x06 \x01(\bR\x11includeTotalCount\"\x8f\x01\n" +
+	"\x1aListExpenseReportsResponse\x12(\n" +
+	"\areports\x18\x01 \x03(\v2\x0e.ExpenseReportR\areports\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"6\n" +
+	"\x17GetExpenseReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\"\x8d\x01\n" +
+	"\x1aSubmitExpenseReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12+\n" +
+	"\x11approver_username\x18\x02 \x01(\tR\x10approverUsername\x12%\n" +
+	"\x0eapprover_email\x18\x03 \x01(\tR\rapproverEmail\"]\n" +
+	"\fLineDecision\x12\x17\n" +
+	"\aline_id\x18\x01 \x01(\tR\x06lineId\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"\x9c\x01\n" +
+	"\x1aReviewExpenseReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12+\n" +
+	"\tdecisions\x18\x02 \x03(\v2\r.LineDecisionR\tdecisions\x12\x1a\n" +
+	"\bdecision\x18\x03 \x01(\tR\bdecision\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"i\n" +
+	"\x1dCommentOnExpenseReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12\x17\n" +
+	"\aline_id\x18\x02 \x01(\tR\x06lineId\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\"}\n" +
+	"\x1bMarkReportReimbursedRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12\x1c\n" +
+	"\treference\x18\x02 \x01(\tR\treference\x12#\n" +
+	"\rreimbursed_at\x18\x03 \x01(\tR\freimbursedAt\"U\n" +
+	"\x17GetReportReceiptRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12\x1d\n" +
+	"\n" +
+	"receipt_id\x18\x02 \x01(\tR\treceiptId\"Q\n" +
+	"\x1aExportExpenseReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"c\n" +
+	"\n" +
+	"ReportFile\x12\x16\n" +
+	"\x06chunks\x18\x01 \x01(\fR\x06chunks\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename2\xce\x06\n" +
+	"\x15ExpenseReportsService\x12J\n" +
+	"\x13CreateExpenseReport\x12\x1b.CreateExpenseReportRequest\x1a\x16.ExpenseReportResponse\x12J\n" +
+	"\x13UpdateExpenseReport\x12\x1b.UpdateExpenseReportRequest\x1a\x16.ExpenseReportResponse\x12P\n" +
+	"\x13DeleteExpenseReport\x12\x1b.DeleteExpenseReportRequest\x1a\x1c.DeleteExpenseReportResponse\x12M\n" +
+	"\x12ListExpenseReports\x12\x1a.ListExpenseReportsRequest\x1a\x1b.ListExpenseReportsResponse\x12D\n" +
+	"\x10GetExpenseReport\x12\x18.GetExpenseReportRequest\x1a\x16.ExpenseReportResponse\x12J\n" +
+	"\x13SubmitExpenseReport\x12\x1b.SubmitExpenseReportRequest\x1a\x16.ExpenseReportResponse\x12J\n" +
+	"\x13ReviewExpenseReport\x12\x1b.ReviewExpenseReportRequest\x1a\x16.ExpenseReportResponse\x12P\n" +
+	"\x16CommentOnExpenseReport\x12\x1e.CommentOnExpenseReportRequest\x1a\x16.ExpenseReportResponse\x12L\n" +
+	"\x14MarkReportReimbursed\x12\x1c.MarkReportReimbursedRequest\x1a\x16.ExpenseReportResponse\x12;\n" +
+	"\x10GetReportReceipt\x12\x18.GetReportReceiptRequest\x1a\v.ReportFile0\x01\x12A\n" +
+	"\x13ExportExpenseReport\x12\x1b.ExportExpenseReportRequest\x1a\v.ReportFile0\x01B+Z)github.com/barathsurya2004/expenses/protob\x06proto3"
+
+var (
+	file_proto_reports_proto_rawDescOnce sync.Once
+	file_proto_reports_proto_rawDescData []byte
+)
+
+func file_proto_reports_proto_rawDescGZIP() []byte {
+	file_proto_reports_proto_rawDescOnce.Do(func() {
+		file_proto_reports_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_reports_proto_rawDesc), len(file_proto_reports_proto_rawDesc)))
+	})
+	return file_proto_reports_proto_rawDescData
+}
+
+var file_proto_reports_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_proto_reports_proto_goTypes = []any{
+	(*ReportAmount)(nil),                  // 0: ReportAmount
+	(*ExpenseReportLine)(nil),             // 1: ExpenseReportLine
+	(*ReportComment)(nil),                 // 2: ReportComment
+	(*ExpenseReport)(nil),                 // 3: ExpenseReport
+	(*ExpenseReportResponse)(nil),         // 4: ExpenseReportResponse
+	(*CreateExpenseReportRequest)(nil),    // 5: CreateExpenseReportRequest
+	(*UpdateExpenseReportRequest)(nil),    // 6: UpdateExpenseReportRequest
+	(*DeleteExpenseReportRequest)(nil),    // 7: DeleteExpenseReportRequest
+	(*DeleteExpenseReportResponse)(nil),   // 8: DeleteExpenseReportResponse
+	(*ListExpenseReportsRequest)(nil),     // 9: ListExpenseReportsRequest
+	(*ListExpenseReportsResponse)(nil),    // 10: ListExpenseReportsResponse
+	(*GetExpenseReportRequest)(nil),       // 11: GetExpenseReportRequest
+	(*SubmitExpenseReportRequest)(nil),    // 12: SubmitExpenseReportRequest
+	(*LineDecision)(nil),                  // 13: LineDecision
+	(*ReviewExpenseReportRequest)(nil),    // 14: ReviewExpenseReportRequest
+	(*CommentOnExpenseReportRequest)(nil), // 15: CommentOnExpenseReportRequest
+	(*MarkReportReimbursedRequest)(nil),   // 16: MarkReportReimbursedRequest
+	(*GetReportReceiptRequest)(nil),       // 17: GetReportReceiptRequest
+	(*ExportExpenseReportRequest)(nil),    // 18: ExportExpenseReportRequest
+	(*ReportFile)(nil),                    // 19: ReportFile
+}
+var file_proto_reports_proto_depIdxs = []int32{
+	0,  // 0: ExpenseReport.claimed:type_name -> ReportAmount
+	0,  // 1: ExpenseReport.approved:type_name -> ReportAmount
+	1,  // 2: ExpenseReport.lines:type_name -> ExpenseReportLine
+	2,  // 3: ExpenseReport.comments:type_name -> ReportComment
+	3,  // 4: ExpenseReportResponse.report:type_name -> ExpenseReport
+	3,  // 5: ListExpenseReportsResponse.reports:type_name -> ExpenseReport
+	13, // 6: ReviewExpenseReportRequest.decisions:type_name -> LineDecision
+	5,  // 7: ExpenseReportsService.CreateExpenseReport:input_type -> CreateExpenseReportRequest
+	6,  // 8: ExpenseReportsService.UpdateExpenseReport:input_type -> UpdateExpenseReportRequest
+	7,  // 9: ExpenseReportsService.DeleteExpenseReport:input_type -> DeleteExpenseReportRequest
+	9,  // 10: ExpenseReportsService.ListExpenseReports:input_type -> ListExpenseReportsRequest
+	11, // 11: ExpenseReportsService.GetExpenseReport:input_type -> GetExpenseReportRequest
+	12, // 12: ExpenseReportsService.SubmitExpenseReport:input_type -> SubmitExpenseReportRequest
+	14, // 13: ExpenseReportsService.ReviewExpenseReport:input_type -> ReviewExpenseReportRequest
+	15, // 14: ExpenseReportsService.CommentOnExpenseReport:input_type -> CommentOnExpenseReportRequest
+	16, // 15: ExpenseReportsService.MarkReportReimbursed:input_type -> MarkReportReimbursedRequest
+	17, // 16: ExpenseReportsService.GetReportReceipt:input_type -> GetReportReceiptRequest
+	18, // 17: ExpenseReportsService.ExportExpenseReport:input_type -> ExportExpenseReportRequest
+	4,  // 18: ExpenseReportsService.CreateExpenseReport:output_type -> ExpenseReportResponse
+	4,  // 19: ExpenseReportsService.UpdateExpenseReport:output_type -> ExpenseReportResponse
+	8,  // 20: ExpenseReportsService.DeleteExpenseReport:output_type -> DeleteExpenseReportResponse
+	10, // 21: ExpenseReportsService.ListExpenseReports:output_type -> ListExpenseReportsResponse
+	4,  // 22: ExpenseReportsService.GetExpenseReport:output_type -> ExpenseReportResponse
+	4,  // 23: ExpenseReportsService.SubmitExpenseReport:output_type -> ExpenseReportResponse
+	4,  // 24: ExpenseReportsService.ReviewExpenseReport:output_type -> ExpenseReportResponse
+	4,  // 25: ExpenseReportsService.CommentOnExpenseReport:output_type -> ExpenseReportResponse
+	4,  // 26: ExpenseReportsService.MarkReportReimbursed:output_type -> ExpenseReportResponse
+	19, // 27: ExpenseReportsService.GetReportReceipt:output_type -> ReportFile
+	19, // 28: ExpenseReportsService.ExportExpenseReport:output_type -> ReportFile
+	18, // [18:29] is the sub-list for method output_type
+	7,  // [7:18] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_proto_reports_proto_init() }
+func file_proto_reports_proto_init() {
+	if File_proto_reports_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_reports_proto_rawDesc), len(file_proto_reports_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_reports_proto_goTypes,
+		DependencyIndexes: file_proto_reports_proto_depIdxs,
+		MessageInfos:      file_proto_reports_proto_msgTypes,
+	}.Build()
+	File_proto_reports_proto = out.File
+	file_proto_reports_proto_goTypes = nil
+	file_proto_reports_proto_depIdxs = nil
+}
@@ -0,0 +1,522 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: proto/reports.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExpenseReportsService_CreateExpenseReport_FullMethodName    = "/ExpenseReportsService/CreateExpenseReport"
+	ExpenseReportsService_UpdateExpenseReport_FullMethodName    = "/ExpenseReportsService/UpdateExpenseReport"
+	ExpenseReportsService_DeleteExpenseReport_FullMethodName    = "/ExpenseReportsService/DeleteExpenseReport"
+	ExpenseReportsService_ListExpenseReports_FullMethodName     = "/ExpenseReportsService/ListExpenseReports"
+	ExpenseReportsService_GetExpenseReport_FullMethodName       = "/ExpenseReportsService/GetExpenseReport"
+	ExpenseReportsService_SubmitExpenseReport_FullMethodName    = "/ExpenseReportsService/SubmitExpenseReport"
+	ExpenseReportsService_ReviewExpenseReport_FullMethodName    = "/ExpenseReportsService/ReviewExpenseReport"
+	ExpenseReportsService_CommentOnExpenseReport_FullMethodName = "/ExpenseReportsService/CommentOnExpenseReport"
+	ExpenseReportsService_MarkReportReimbursed_FullMethodName   = "/ExpenseReportsService/MarkReportReimbursed"
+	ExpenseReportsService_GetReportReceipt_FullMethodName       = "/ExpenseReportsService/GetReportReceipt"
+	ExpenseReportsService_ExportExpenseReport_FullMethodName    = "/ExpenseReportsService/ExportExpenseReport"
+)
+
+// ExpenseReportsServiceClient is the client API for ExpenseReportsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ExpenseReportsService runs reimbursement claims. The submitter groups
+// expenses they paid for into a report and submits it to an approver, who
+// approves or rejects each line and finally marks the report reimbursed.
+// Report statuses are "draft", "submitted", "approved", "rejected" and
+// "reimbursed"; line statuses are "pending", "approved" and "rejected". A
+// report can only be changed while it is a draft or after it was rejected.
+type ExpenseReportsServiceClient interface {
+	CreateExpenseReport(ctx context.Context, in *CreateExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error)
+	UpdateExpenseReport(ctx context.Context, in *UpdateExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error)
+	DeleteExpenseReport(ctx context.Context, in *DeleteExpenseReportRequest, opts ...grpc.CallOption) (*DeleteExpenseReportResponse, error)
+	ListExpenseReports(ctx context.Context, in *ListExpenseReportsRequest, opts ...grpc.CallOption) (*ListExpenseReportsResponse, error)
+	GetExpenseReport(ctx context.Context, in *GetExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error)
+	SubmitExpenseReport(ctx context.Context, in *SubmitExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error)
+	ReviewExpenseReport(ctx context.Context, in *ReviewExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error)
+	CommentOnExpenseReport(ctx context.Context, in *CommentOnExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error)
+	MarkReportReimbursed(ctx context.Context, in *MarkReportReimbursedRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error)
+	GetReportReceipt(ctx context.Context, in *GetReportReceiptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReportFile], error)
+	ExportExpenseReport(ctx context.Context, in *ExportExpenseReportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReportFile], error)
+}
+
+type expenseReportsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExpenseReportsServiceClient(cc grpc.ClientConnInterface) ExpenseReportsServiceClient {
+	return &expenseReportsServiceClient{cc}
+}
+
+func (c *expenseReportsServiceClient) CreateExpenseReport(ctx context.Context, in *CreateExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpenseReportResponse)
+	err := c.cc.Invoke(ctx, ExpenseReportsService_CreateExpenseReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseReportsServiceClient) UpdateExpenseReport(ctx context.Context, in *UpdateExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpenseReportResponse)
+	err := c.cc.Invoke(ctx, ExpenseReportsService_UpdateExpenseReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseReportsServiceClient) DeleteExpenseReport(ctx context.Context, in *DeleteExpenseReportRequest, opts ...grpc.CallOption) (*DeleteExpenseReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteExpenseReportResponse)
+	err := c.cc.Invoke(ctx, ExpenseReportsService_DeleteExpenseReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseReportsServiceClient) ListExpenseReports(ctx context.Context, in *ListExpenseReportsRequest, opts ...grpc.CallOption) (*ListExpenseReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExpenseReportsResponse)
+	err := c.cc.Invoke(ctx, ExpenseReportsService_ListExpenseReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseReportsServiceClient) GetExpenseReport(ctx context.Context, in *GetExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpenseReportResponse)
+	err := c.cc.Invoke(ctx, ExpenseReportsService_GetExpenseReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseReportsServiceClient) SubmitExpenseReport(ctx context.Context, in *SubmitExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpenseReportResponse)
+	err := c.cc.Invoke(ctx, ExpenseReportsService_SubmitExpenseReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseReportsServiceClient) ReviewExpenseReport(ctx context.Context, in *ReviewExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpenseReportResponse)
+	err := c.cc.Invoke(ctx, ExpenseReportsService_ReviewExpenseReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseReportsServiceClient) CommentOnExpenseReport(ctx context.Context, in *CommentOnExpenseReportRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpenseReportResponse)
+	err := c.cc.Invoke(ctx, ExpenseReportsService_CommentOnExpenseReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseReportsServiceClient) MarkReportReimbursed(ctx context.Context, in *MarkReportReimbursedRequest, opts ...grpc.CallOption) (*ExpenseReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpenseReportResponse)
+	err := c.cc.Invoke(ctx, ExpenseReportsService_MarkReportReimbursed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expenseReportsServiceClient) GetReportReceipt(ctx context.Context, in *GetReportReceiptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReportFile], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExpenseReportsService_ServiceDesc.Streams[0], ExpenseReportsService_GetReportReceipt_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetReportReceiptRequest, ReportFile]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExpenseReportsService_GetReportReceiptClient = grpc.ServerStreamingClient[ReportFile]
+
+func (c *expenseReportsServiceClient) ExportExpenseReport(ctx context.Context, in *ExportExpenseReportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReportFile], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExpenseReportsService_ServiceDesc.Streams[1], ExpenseReportsService_ExportExpenseReport_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportExpenseReportRequest, ReportFile]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExpenseReportsService_ExportExpenseReportClient = grpc.ServerStreamingClient[ReportFile]
+
+// ExpenseReportsServiceServer is the server API for ExpenseReportsService service.
+// All implementations must embed UnimplementedExpenseReportsServiceServer
+// for forward compatibility.
+//
+// ExpenseReportsService runs reimbursement claims. The submitter groups
+// expenses they paid for into a report and submits it to an approver, who
+// approves or rejects each line and finally marks the report reimbursed.
+// Report statuses are "draft", "submitted", "approved", "rejected" and
+// "reimbursed"; line statuses are "pending", "approved" and "rejected". A
+// report can only be changed while it is a draft or after it was rejected.
+type ExpenseReportsServiceServer interface {
+	CreateExpenseReport(context.Context, *CreateExpenseReportRequest) (*ExpenseReportResponse, error)
+	UpdateExpenseReport(context.Context, *UpdateExpenseReportRequest) (*ExpenseReportResponse, error)
+	DeleteExpenseReport(context.Context, *DeleteExpenseReportRequest) (*DeleteExpenseReportResponse, error)
+	ListExpenseReports(context.Context, *ListExpenseReportsRequest) (*ListExpenseReportsResponse, error)
+	GetExpenseReport(context.Context, *GetExpenseReportRequest) (*ExpenseReportResponse, error)
+	SubmitExpenseReport(context.Context, *SubmitExpenseReportRequest) (*ExpenseReportResponse, error)
+	ReviewExpenseReport(context.Context, *ReviewExpenseReportRequest) (*ExpenseReportResponse, error)
+	CommentOnExpenseReport(context.Context, *CommentOnExpenseReportRequest) (*ExpenseReportResponse, error)
+	MarkReportReimbursed(context.Context, *MarkReportReimbursedRequest) (*ExpenseReportResponse, error)
+	GetReportReceipt(*GetReportReceiptRequest, grpc.ServerStreamingServer[ReportFile]) error
+	ExportExpenseReport(*ExportExpenseReportRequest, grpc.ServerStreamingServer[ReportFile]) error
+	mustEmbedUnimplementedExpenseReportsServiceServer()
+}
+
+// UnimplementedExpenseReportsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExpenseReportsServiceServer struct{}
+
+func (UnimplementedExpenseReportsServiceServer) CreateExpenseReport(context.Context, *CreateExpenseReportRequest) (*ExpenseReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExpenseReport not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) UpdateExpenseReport(context.Context, *UpdateExpenseReportRequest) (*ExpenseReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateExpenseReport not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) DeleteExpenseReport(context.Context, *DeleteExpenseReportRequest) (*DeleteExpenseReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteExpenseReport not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) ListExpenseReports(context.Context, *ListExpenseReportsRequest) (*ListExpenseReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExpenseReports not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) GetExpenseReport(context.Context, *GetExpenseReportRequest) (*ExpenseReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpenseReport not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) SubmitExpenseReport(context.Context, *SubmitExpenseReportRequest) (*ExpenseReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitExpenseReport not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) ReviewExpenseReport(context.Context, *ReviewExpenseReportRequest) (*ExpenseReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewExpenseReport not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) CommentOnExpenseReport(context.Context, *CommentOnExpenseReportRequest) (*ExpenseReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommentOnExpenseReport not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) MarkReportReimbursed(context.Context, *MarkReportReimbursedRequest) (*ExpenseReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkReportReimbursed not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) GetReportReceipt(*GetReportReceiptRequest, grpc.ServerStreamingServer[ReportFile]) error {
+	return status.Errorf(codes.Unimplemented, "method GetReportReceipt not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) ExportExpenseReport(*ExportExpenseReportRequest, grpc.ServerStreamingServer[ReportFile]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportExpenseReport not implemented")
+}
+func (UnimplementedExpenseReportsServiceServer) mustEmbedUnimplementedExpenseReportsServiceServer() {}
+func (UnimplementedExpenseReportsServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeExpenseReportsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExpenseReportsServiceServer will
+// result in compilation errors.
+type UnsafeExpenseReportsServiceServer interface {
+	mustEmbedUnimplementedExpenseReportsServiceServer()
+}
+
+func RegisterExpenseReportsServiceServer(s grpc.ServiceRegistrar, srv ExpenseReportsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedExpenseReportsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExpenseReportsService_ServiceDesc, srv)
+}
+
+func _ExpenseReportsService_CreateExpenseReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExpenseReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseReportsServiceServer).CreateExpenseReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpenseReportsService_CreateExpenseReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseReportsServiceServer).CreateExpenseReport(ctx, req.(*CreateExpenseReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseReportsService_UpdateExpenseReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExpenseReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseReportsServiceServer).UpdateExpenseReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpenseReportsService_UpdateExpenseReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseReportsServiceServer).UpdateExpenseReport(ctx, req.(*UpdateExpenseReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseReportsService_DeleteExpenseReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExpenseReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseReportsServiceServer).DeleteExpenseReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpenseReportsService_DeleteExpenseReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseReportsServiceServer).DeleteExpenseReport(ctx, req.(*DeleteExpenseReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseReportsService_ListExpenseReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExpenseReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseReportsServiceServer).ListExpenseReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpenseReportsService_ListExpenseReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseReportsServiceServer).ListExpenseReports(ctx, req.(*ListExpenseReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseReportsService_GetExpenseReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExpenseReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseReportsServiceServer).GetExpenseReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpenseReportsService_GetExpenseReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseReportsServiceServer).GetExpenseReport(ctx, req.(*GetExpenseReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseReportsService_SubmitExpenseReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitExpenseReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseReportsServiceServer).SubmitExpenseReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpenseReportsService_SubmitExpenseReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseReportsServiceServer).SubmitExpenseReport(ctx, req.(*SubmitExpenseReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseReportsService_ReviewExpenseReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewExpenseReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseReportsServiceServer).ReviewExpenseReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpenseReportsService_ReviewExpenseReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseReportsServiceServer).ReviewExpenseReport(ctx, req.(*ReviewExpenseReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseReportsService_CommentOnExpenseReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommentOnExpenseReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseReportsServiceServer).CommentOnExpenseReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpenseReportsService_CommentOnExpenseReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseReportsServiceServer).CommentOnExpenseReport(ctx, req.(*CommentOnExpenseReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseReportsService_MarkReportReimbursed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkReportReimbursedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpenseReportsServiceServer).MarkReportReimbursed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExpenseReportsService_MarkReportReimbursed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpenseReportsServiceServer).MarkReportReimbursed(ctx, req.(*MarkReportReimbursedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpenseReportsService_GetReportReceipt_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetReportReceiptRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExpenseReportsServiceServer).GetReportReceipt(m, &grpc.GenericServerStream[GetReportReceiptRequest, ReportFile]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExpenseReportsService_GetReportReceiptServer = grpc.ServerStreamingServer[ReportFile]
+
+func _ExpenseReportsService_ExportExpenseReport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportExpenseReportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExpenseReportsServiceServer).ExportExpenseReport(m, &grpc.GenericServerStream[ExportExpenseReportRequest, ReportFile]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExpenseReportsService_ExportExpenseReportServer = grpc.ServerStreamingServer[ReportFile]
+
+// ExpenseReportsService_ServiceDesc is the grpc.ServiceDesc for ExpenseReportsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExpenseReportsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ExpenseReportsService",
+	HandlerType: (*ExpenseReportsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateExpenseReport",
+			Handler:    _ExpenseReportsService_CreateExpenseReport_Handler,
+		},
+		{
+			MethodName: "UpdateExpenseReport",
+			Handler:    _ExpenseReportsService_UpdateExpenseReport_Handler,
+		},
+		{
+			MethodName: "DeleteExpenseReport",
+			Handler:    _ExpenseReportsService_DeleteExpenseReport_Handler,
+		},
+		{
+			MethodName: "ListExpenseReports",
+			Handler:    _ExpenseReportsService_ListExpenseReports_Handler,
+		},
+		{
+			MethodName: "GetExpenseReport",
+			Handler:    _ExpenseReportsService_GetExpenseReport_Handler,
+		},
+		{
+			MethodName: "SubmitExpenseReport",
+			Handler:    _ExpenseReportsService_SubmitExpenseReport_Handler,
+		},
+		{
+			MethodName: "ReviewExpenseReport",
+			Handler:    _ExpenseReportsService_ReviewExpenseReport_Handler,
+		},
+		{
+			MethodName: "CommentOnExpenseReport",
+			Handler:    _ExpenseReportsService_CommentOnExpenseReport_Handler,
+		},
+		{
+			MethodName: "MarkReportReimbursed",
+			Handler:    _ExpenseReportsService_MarkReportReimbursed_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetReportReceipt",
+			Handler:       _ExpenseReportsService_GetReportReceipt_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportExpenseReport",
+			Handler:       _ExpenseReportsService_ExportExpenseReport_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/reports.proto",
+}
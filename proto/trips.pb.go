@@ -0,0 +1,1296 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v3.21.12
+// source: proto/trips.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// budget is in home_currency, and 0 when there is none. exchange_rate is
+// how much home_currency one unit of foreign_currency buys.
+type Trip struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	GroupId         string                 `protobuf:"bytes,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	CreatedBy       string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	StartsOn        string                 `protobuf:"bytes,5,opt,name=starts_on,json=startsOn,proto3" json:"starts_on,omitempty"`
+	EndsOn          string                 `protobuf:"bytes,6,opt,name=ends_on,json=endsOn,proto3" json:"ends_on,omitempty"`
+	Budget          float64                `protobuf:"fixed64,7,opt,name=budget,proto3" json:"budget,omitempty"`
+	HomeCurrency    string                 `protobuf:"bytes,8,opt,name=home_currency,json=homeCurrency,proto3" json:"home_currency,omitempty"`
+	ForeignCurrency string                 `protobuf:"bytes,9,opt,name=foreign_currency,json=foreignCurrency,proto3" json:"foreign_currency,omitempty"`
+	ExchangeRate    float64                `protobuf:"fixed64,10,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	ExpenseCount    int32                  `protobuf:"varint,11,opt,name=expense_count,json=expenseCount,proto3" json:"expense_count,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Trip) Reset() {
+	*x = Trip{}
+	mi := &file_proto_trips_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trip) ProtoMessage() {}
+
+func (x *Trip) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trip.ProtoReflect.Descriptor instead.
+func (*Trip) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Trip) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Trip) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Trip) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *Trip) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Trip) GetStartsOn() string {
+	if x != nil {
+		return x.StartsOn
+	}
+	return ""
+}
+
+func (x *Trip) GetEndsOn() string {
+	if x != nil {
+		return x.EndsOn
+	}
+	return ""
+}
+
+func (x *Trip) GetBudget() float64 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *Trip) GetHomeCurrency() string {
+	if x != nil {
+		return x.HomeCurrency
+	}
+	return ""
+}
+
+func (x *Trip) GetForeignCurrency() string {
+	if x != nil {
+		return x.ForeignCurrency
+	}
+	return ""
+}
+
+func (x *Trip) GetExchangeRate() float64 {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return 0
+}
+
+func (x *Trip) GetExpenseCount() int32 {
+	if x != nil {
+		return x.ExpenseCount
+	}
+	return 0
+}
+
+func (x *Trip) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type TripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripResponse) Reset() {
+	*x = TripResponse{}
+	mi := &file_proto_trips_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripResponse) ProtoMessage() {}
+
+func (x *TripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripResponse.ProtoReflect.Descriptor instead.
+func (*TripResponse) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TripResponse) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+// home_currency defaults to the caller's default currency. A trip spans at
+// most 366 days.
+type CreateTripRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	GroupId         string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	StartsOn        string                 `protobuf:"bytes,3,opt,name=starts_on,json=startsOn,proto3" json:"starts_on,omitempty"`
+	EndsOn          string                 `protobuf:"bytes,4,opt,name=ends_on,json=endsOn,proto3" json:"ends_on,omitempty"`
+	Budget          float64                `protobuf:"fixed64,5,opt,name=budget,proto3" json:"budget,omitempty"`
+	HomeCurrency    string                 `protobuf:"bytes,6,opt,name=home_currency,json=homeCurrency,proto3" json:"home_currency,omitempty"`
+	ForeignCurrency string                 `protobuf:"bytes,7,opt,name=foreign_currency,json=foreignCurrency,proto3" json:"foreign_currency,omitempty"`
+	ExchangeRate    float64                `protobuf:"fixed64,8,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateTripRequest) Reset() {
+	*x = CreateTripRequest{}
+	mi := &file_proto_trips_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTripRequest) ProtoMessage() {}
+
+func (x *CreateTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTripRequest.ProtoReflect.Descriptor instead.
+func (*CreateTripRequest) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateTripRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetStartsOn() string {
+	if x != nil {
+		return x.StartsOn
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetEndsOn() string {
+	if x != nil {
+		return x.EndsOn
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetBudget() float64 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+func (x *CreateTripRequest) GetHomeCurrency() string {
+	if x != nil {
+		return x.HomeCurrency
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetForeignCurrency() string {
+	if x != nil {
+		return x.ForeignCurrency
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetExchangeRate() float64 {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return 0
+}
+
+// Only the fields that are present change. An empty foreign_currency
+// removes it, along with the exchange rate. A group trip can be changed by
+// whoever created it and the group's owners and admins.
+type UpdateTripRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	StartsOn        *string                `protobuf:"bytes,3,opt,name=starts_on,json=startsOn,proto3,oneof" json:"starts_on,omitempty"`
+	EndsOn          *string                `protobuf:"bytes,4,opt,name=ends_on,json=endsOn,proto3,oneof" json:"ends_on,omitempty"`
+	Budget          *float64               `protobuf:"fixed64,5,opt,name=budget,proto3,oneof" json:"budget,omitempty"`
+	HomeCurrency    *string                `protobuf:"bytes,6,opt,name=home_currency,json=homeCurrency,proto3,oneof" json:"home_currency,omitempty"`
+	ForeignCurrency *string                `protobuf:"bytes,7,opt,name=foreign_currency,json=foreignCurrency,proto3,oneof" json:"foreign_currency,omitempty"`
+	ExchangeRate    *float64               `protobuf:"fixed64,8,opt,name=exchange_rate,json=exchangeRate,proto3,oneof" json:"exchange_rate,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateTripRequest) Reset() {
+	*x = UpdateTripRequest{}
+	mi := &file_proto_trips_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTripRequest) ProtoMessage() {}
+
+func (x *UpdateTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTripRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTripRequest) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateTripRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTripRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTripRequest) GetStartsOn() string {
+	if x != nil && x.StartsOn != nil {
+		return *x.StartsOn
+	}
+	return ""
+}
+
+func (x *UpdateTripRequest) GetEndsOn() string {
+	if x != nil && x.EndsOn != nil {
+		return *x.EndsOn
+	}
+	return ""
+}
+
+func (x *UpdateTripRequest) GetBudget() float64 {
+	if x != nil && x.Budget != nil {
+		return *x.Budget
+	}
+	return 0
+}
+
+func (x *UpdateTripRequest) GetHomeCurrency() string {
+	if x != nil && x.HomeCurrency != nil {
+		return *x.HomeCurrency
+	}
+	return ""
+}
+
+func (x *UpdateTripRequest) GetForeignCurrency() string {
+	if x != nil && x.ForeignCurrency != nil {
+		return *x.ForeignCurrency
+	}
+	return ""
+}
+
+func (x *UpdateTripRequest) GetExchangeRate() float64 {
+	if x != nil && x.ExchangeRate != nil {
+		return *x.ExchangeRate
+	}
+	return 0
+}
+
+// Expenses on a deleted trip go back to automatic assignment.
+type DeleteTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTripRequest) Reset() {
+	*x = DeleteTripRequest{}
+	mi := &file_proto_trips_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTripRequest) ProtoMessage() {}
+
+func (x *DeleteTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTripRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTripRequest) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteTripRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTripResponse) Reset() {
+	*x = DeleteTripResponse{}
+	mi := &file_proto_trips_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTripResponse) ProtoMessage() {}
+
+func (x *DeleteTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTripResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTripResponse) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteTripResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// With group_id set, the group's trips are listed instead of the caller's
+// own. order_by is "starts_on desc", the default, "starts_on" or "name",
+// and pages hold up to 1000 trips, 100 by default, as described on
+// ExpensesService.
+type ListTripsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	GroupId           string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	PageSize          int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken         string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	OrderBy           string                 `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,5,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListTripsRequest) Reset() {
+	*x = ListTripsRequest{}
+	mi := &file_proto_trips_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTripsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTripsRequest) ProtoMessage() {}
+
+func (x *ListTripsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTripsRequest.ProtoReflect.Descriptor instead.
+func (*ListTripsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTripsRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ListTripsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTripsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTripsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListTripsRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+type ListTripsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trips         []*Trip                `protobuf:"bytes,1,rep,name=trips,proto3" json:"trips,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTripsResponse) Reset() {
+	*x = ListTripsResponse{}
+	mi := &file_proto_trips_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTripsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTripsResponse) ProtoMessage() {}
+
+func (x *ListTripsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTripsResponse.ProtoReflect.Descriptor instead.
+func (*ListTripsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTripsResponse) GetTrips() []*Trip {
+	if x != nil {
+		return x.Trips
+	}
+	return nil
+}
+
+func (x *ListTripsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTripsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// SetExpenseTrip files an expense the caller recorded under trip_id. An
+// empty trip_id takes it out of every trip, and automatic hands it back to
+// automatic assignment, ignoring trip_id.
+type SetExpenseTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpenseId     string                 `protobuf:"bytes,1,opt,name=expense_id,json=expenseId,proto3" json:"expense_id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Automatic     bool                   `protobuf:"varint,3,opt,name=automatic,proto3" json:"automatic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExpenseTripRequest) Reset() {
+	*x = SetExpenseTripRequest{}
+	mi := &file_proto_trips_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExpenseTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExpenseTripRequest) ProtoMessage() {}
+
+func (x *SetExpenseTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExpenseTripRequest.ProtoReflect.Descriptor instead.
+func (*SetExpenseTripRequest) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetExpenseTripRequest) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+func (x *SetExpenseTripRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *SetExpenseTripRequest) GetAutomatic() bool {
+	if x != nil {
+		return x.Automatic
+	}
+	return false
+}
+
+type SetExpenseTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExpenseId     string                 `protobuf:"bytes,1,opt,name=expense_id,json=expenseId,proto3" json:"expense_id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetExpenseTripResponse) Reset() {
+	*x = SetExpenseTripResponse{}
+	mi := &file_proto_trips_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetExpenseTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetExpenseTripResponse) ProtoMessage() {}
+
+func (x *SetExpenseTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetExpenseTripResponse.ProtoReflect.Descriptor instead.
+func (*SetExpenseTripResponse) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SetExpenseTripResponse) GetExpenseId() string {
+	if x != nil {
+		return x.ExpenseId
+	}
+	return ""
+}
+
+func (x *SetExpenseTripResponse) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *SetExpenseTripResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetTripSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripSummaryRequest) Reset() {
+	*x = GetTripSummaryRequest{}
+	mi := &file_proto_trips_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripSummaryRequest) ProtoMessage() {}
+
+func (x *GetTripSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetTripSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTripSummaryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type TripAmount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripAmount) Reset() {
+	*x = TripAmount{}
+	mi := &file_proto_trips_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripAmount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripAmount) ProtoMessage() {}
+
+func (x *TripAmount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripAmount.ProtoReflect.Descriptor instead.
+func (*TripAmount) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TripAmount) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TripAmount) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// home and foreign are totals in the trip's two currencies. Amounts spent
+// in one of them are converted to the other with the trip's exchange rate,
+// and left out of the other total when it has none.
+type TripDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Home          float64                `protobuf:"fixed64,2,opt,name=home,proto3" json:"home,omitempty"`
+	Foreign       float64                `protobuf:"fixed64,3,opt,name=foreign,proto3" json:"foreign,omitempty"`
+	ExpenseCount  int32                  `protobuf:"varint,4,opt,name=expense_count,json=expenseCount,proto3" json:"expense_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripDay) Reset() {
+	*x = TripDay{}
+	mi := &file_proto_trips_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripDay) ProtoMessage() {}
+
+func (x *TripDay) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripDay.ProtoReflect.Descriptor instead.
+func (*TripDay) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TripDay) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *TripDay) GetHome() float64 {
+	if x != nil {
+		return x.Home
+	}
+	return 0
+}
+
+func (x *TripDay) GetForeign() float64 {
+	if x != nil {
+		return x.Foreign
+	}
+	return 0
+}
+
+func (x *TripDay) GetExpenseCount() int32 {
+	if x != nil {
+		return x.ExpenseCount
+	}
+	return 0
+}
+
+type TripCategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Home          float64                `protobuf:"fixed64,2,opt,name=home,proto3" json:"home,omitempty"`
+	Foreign       float64                `protobuf:"fixed64,3,opt,name=foreign,proto3" json:"foreign,omitempty"`
+	ExpenseCount  int32                  `protobuf:"varint,4,opt,name=expense_count,json=expenseCount,proto3" json:"expense_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripCategory) Reset() {
+	*x = TripCategory{}
+	mi := &file_proto_trips_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripCategory) ProtoMessage() {}
+
+func (x *TripCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripCategory.ProtoReflect.Descriptor instead.
+func (*TripCategory) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TripCategory) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *TripCategory) GetHome() float64 {
+	if x != nil {
+		return x.Home
+	}
+	return 0
+}
+
+func (x *TripCategory) GetForeign() float64 {
+	if x != nil {
+		return x.Foreign
+	}
+	return 0
+}
+
+func (x *TripCategory) GetExpenseCount() int32 {
+	if x != nil {
+		return x.ExpenseCount
+	}
+	return 0
+}
+
+// days covers every day of the trip, and the days of expenses assigned to
+// it by hand that fall outside it. unconverted totals what was spent in
+// other currencies, and in the foreign currency while the trip has no
+// exchange rate; none of it counts towards the totals or the budget.
+// budget_remaining is only set when the trip has a budget, and is negative
+// once it is overspent.
+type TripSummary struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Trip            *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
+	ExpenseCount    int32                  `protobuf:"varint,2,opt,name=expense_count,json=expenseCount,proto3" json:"expense_count,omitempty"`
+	HomeTotal       float64                `protobuf:"fixed64,3,opt,name=home_total,json=homeTotal,proto3" json:"home_total,omitempty"`
+	ForeignTotal    float64                `protobuf:"fixed64,4,opt,name=foreign_total,json=foreignTotal,proto3" json:"foreign_total,omitempty"`
+	BudgetRemaining *float64               `protobuf:"fixed64,5,opt,name=budget_remaining,json=budgetRemaining,proto3,oneof" json:"budget_remaining,omitempty"`
+	Unconverted     []*TripAmount          `protobuf:"bytes,6,rep,name=unconverted,proto3" json:"unconverted,omitempty"`
+	Days            []*TripDay             `protobuf:"bytes,7,rep,name=days,proto3" json:"days,omitempty"`
+	Categories      []*TripCategory        `protobuf:"bytes,8,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TripSummary) Reset() {
+	*x = TripSummary{}
+	mi := &file_proto_trips_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripSummary) ProtoMessage() {}
+
+func (x *TripSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trips_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripSummary.ProtoReflect.Descriptor instead.
+func (*TripSummary) Descriptor() ([]byte, []int) {
+	return file_proto_trips_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TripSummary) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+func (x *TripSummary) GetExpenseCount() int32 {
+	if x != nil {
+		return x.ExpenseCount
+	}
+	return 0
+}
+
+func (x *TripSummary) GetHomeTotal() float64 {
+	if x != nil {
+		return x.HomeTotal
+	}
+	return 0
+}
+
+func (x *TripSummary) GetForeignTotal() float64 {
+	if x != nil {
+		return x.ForeignTotal
+	}
+	return 0
+}
+
+func (x *TripSummary) GetBudgetRemaining() float64 {
+	if x != nil && x.BudgetRemaining != nil {
+		return *x.BudgetRemaining
+	}
+	return 0
+}
+
+func (x *TripSummary) GetUnconverted() []*TripAmount {
+	if x != nil {
+		return x.Unconverted
+	}
+	return nil
+}
+
+func (x *TripSummary) GetDays() []*TripDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *TripSummary) GetCategories() []*TripCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+var File_proto_trips_proto protoreflect.FileDescriptor
+
+const file_proto_trips_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/trips.proto\"\xeb\x02\n" +
+	"\x04Trip\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\tR\agroupId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x12\x1b\n" +
+	"\tstarts_on\x18\x05 \x01(\tR\bstartsOn\x12\x17\n" +
+	"\aends_on\x18\x06 \x01(\tR\x06endsOn\x12\x16\n" +
+	"\x06budget\x18\a \x01(\x01R\x06budget\x12#\n" +
+	"\rhome_currency\x18\b \x01(\tR\fhomeCurrency\x12)\n" +
+	"\x10foreign_currency\x18\t \x01(\tR\x0fforeignCurrency\x12#\n" +
+	"\rexchange_rate\x18\n" +
+	" \x01(\x01R\fexchangeRate\x12#\n" +
+	"\rexpense_count\x18\v \x01(\x05R\fexpenseCount\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\")\n" +
+	"\fTripResponse\x12\x19\n" +
+	"\x04trip\x18\x01 \x01(\v2\x05.TripR\x04trip\"\x85\x02\n" +
+	"\x11CreateTripRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tstarts_on\x18\x03 \x01(\tR\bstartsOn\x12\x17\n" +
+	"\aends_on\x18\x04 \x01(\tR\x06endsOn\x12\x16\n" +
+	"\x06budget\x18\x05 \x01(\x01R\x06budget\x12#\n" +
+	"\rhome_currency\x18\x06 \x01(\tR\fhomeCurrency\x12)\n" +
+	"\x10foreign_currency\x18\a \x01(\tR\x0fforeignCurrency\x12#\n" +
+	"\rexchange_rate\x18\b \x01(\x01R\fexchangeRate\"\x84\x03\n" +
+	"\x11UpdateTripRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12 \n" +
+	"\tstarts_on\x18\x03 \x01(\tH\x01R\bstartsOn\x88\x01\x01\x12\x1c\n" +
+	"\aends_on\x18\x04 \x01(\tH\x02R\x06endsOn\x88\x01\x01\x12\x1b\n" +
+	"\x06budget\x18\x05 \x01(\x01H\x03R\x06budget\x88\x01\x01\x12(\n" +
+	"\rhome_currency\x18\x06 \x01(\tH\x04R\fhomeCurrency\x88\x01\x01\x12.\n" +
+	"\x10foreign_currency\x18\a \x01(\tH\x05R\x0fforeignCurrency\x88\x01\x01\x12(\n" +
+	"\rexchange_rate\x18\b \x01(\x01H\x06R\fexchangeRate\x88\x01\x01B\a\n" +
+	"\x05_nameB\f\n" +
+	"\n" +
+	"_starts_onB\n" +
+	"\n" +
+	"\b_ends_onB\t\n" +
+	"\a_budgetB\x10\n" +
+	"\x0e_home_currencyB\x13\n" +
+	"\x11_foreign_currencyB\x10\n" +
+	"\x0e_exchange_rate\"#\n" +
+	"\x11DeleteTripRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\".\n" +
+	"\x12DeleteTripResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xb4\x01\n" +
+	"\x10ListTripsRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\x12.\n" +
+	"\x13include_total_count\x18\x05 \x01(\bR\x11includeTotalCount\"y\n" +
+	"\x11ListTripsResponse\x12\x1b\n" +
+	"\x05trips\x18\x01 \x03(\v2\x05.TripR\x05trips\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\"m\n" +
+	"\x15SetExpenseTripRequest\x12\x1d\n" +
+	"\n" +
+	"expense_id\x18\x01 \x01(\tR\texpenseId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x1c\n" +
+	"\tautomatic\x18\x03 \x01(\bR\tautomatic\"j\n" +
+	"\x16SetExpenseTripResponse\x12\x1d\n" +
+	"\n" +
+	"expense_id\x18\x01 \x01(\tR\texpenseId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"'\n" +
+	"\x15GetTripSummaryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
+	"\n" +
+	"TripAmount\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"p\n" +
+	"\aTripDay\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x12\n" +
+	"\x04home\x18\x02 \x01(\x01R\x04home\x12\x18\n" +
+	"\aforeign\x18\x03 \x01(\x01R\aforeign\x12#\n" +
+	"\rexpense_count\x18\x04 \x01(\x05R\fexpenseCount\"}\n" +
+	"\fTripCategory\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04home\x18\x02 \x01(\x01R\x04home\x12\x18\n" +
+	"\aforeign\x18\x03 \x01(\x01R\aforeign\x12#\n" +
+	"\rexpense_count\x18\x04 \x01(\x05R\fexpenseCount\"\xd2\x02\n" +
+	"\vTripSummary\x12\x19\n" +
+	"\x04trip\x18\x01 \x01(\v2\x05.TripR\x04trip\x12#\n" +
+	"\rexpense_count\x18\x02 \x01(\x05R\fexpenseCount\x12\x1d\n" +
+	"\n" +
+	"home_total\x18\x03 \x01(\x01R\thomeTotal\x12#\n" +
+	"\rforeign_total\x18\x04 \x01(\x01R\fforeignTotal\x12.\n" +
+	"\x10budget_remaining\x18\x05 \x01(\x01H\x00R\x0fbudgetRemaining\x88\x01\x01\x12-\n" +
+	"\vunconverted\x18\x06 \x03(\v2\v.TripAmountR\vunconverted\x12\x1c\n" +
+	"\x04days\x18\a \x03(\v2\b.TripDayR\x04days\x12-\n" +
+	"\n" +
+	"categories\x18\b \x03(\v2\r.TripCategoryR\n" +
+	"categoriesB\x13\n" +
+	"\x11_budget_remaining2\xd6\x02\n" +
+	"\fTripsService\x12/\n" +
+	"\n" +
+	"CreateTrip\x12\x12.CreateTripRequest\x1a\r.TripResponse\x12/\n" +
+	"\n" +
+	"UpdateTrip\x12\x12.UpdateTripRequest\x1a\r.TripResponse\x125\n" +
+	"\n" +
+	"DeleteTrip\x12\x12.DeleteTripRequest\x1a\x13.DeleteTripResponse\x122\n" +
+	"\tListTrips\x12\x11.ListTripsRequest\x1a\x12.ListTripsResponse\x12A\n" +
+	"\x0eSetExpenseTrip\x12\x16.SetExpenseTripRequest\x1a\x17.SetExpenseTripResponse\x126\n" +
+	"\x0eGetTripSummary\x12\x16.GetTripSummaryRequest\x1a\f.TripSummaryB+Z)github.com/barathsurya2004/expenses/protob\x06proto3"
+
+var (
+	file_proto_trips_proto_rawDescOnce sync.Once
+	file_proto_trips_proto_rawDescData []byte
+)
+
+func file_proto_trips_proto_rawDescGZIP() []byte {
+	file_proto_trips_proto_rawDescOnce.Do(func() {
+		file_proto_trips_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_trips_proto_rawDesc), len(file_proto_trips_proto_rawDesc)))
+	})
+	return file_proto_trips_proto_rawDescData
+}
+
+var file_proto_trips_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_trips_proto_goTypes = []any{
+	(*Trip)(nil),                   // 0: Trip
+	(*TripResponse)(nil),           // 1: TripResponse
+	(*CreateTripRequest)(nil),      // 2: CreateTripRequest
+	(*UpdateTripRequest)(nil),      // 3: UpdateTripRequest
+	(*DeleteTripRequest)(nil),      // 4: DeleteTripRequest
+	(*DeleteTripResponse)(nil),     // 5: DeleteTripResponse
+	(*ListTripsRequest)(nil),       // 6: ListTripsRequest
+	(*ListTripsResponse)(nil),      // 7: ListTripsResponse
+	(*SetExpenseTripRequest)(nil),  // 8: SetExpenseTripRequest
+	(*SetExpenseTripResponse)(nil), // 9: SetExpenseTripResponse
+	(*GetTripSummaryRequest)(nil),  // 10: GetTripSummaryRequest
+	(*TripAmount)(nil),             // 11: TripAmount
+	(*TripDay)(nil),                // 12: TripDay
+	(*TripCategory)(nil),           // 13: TripCategory
+	(*TripSummary)(nil),            // 14: TripSummary
+}
+var file_proto_trips_proto_depIdxs = []int32{
+	0,  // 0: TripResponse.trip:type_name -> Trip
+	0,  // 1: ListTripsResponse.trips:type_name -> Trip
+	0,  // 2: TripSummary.trip:type_name -> Trip
+	11, // 3: TripSummary.unconverted:type_name -> TripAmount
+	12, // 4: TripSummary.days:type_name -> TripDay
+	13, // 5: TripSummary.categories:type_name -> TripCategory
+	2,  // 6: TripsService.CreateTrip:input_type -> CreateTripRequest
+	3,  // 7: TripsService.UpdateTrip:input_type -> UpdateTripRequest
+	4,  // 8: TripsService.DeleteTrip:input_type -> DeleteTripRequest
+	6,  // 9: TripsService.ListTrips:input_type -> ListTripsRequest
+	8,  // 10: TripsService.SetExpenseTrip:input_type -> SetExpenseTripRequest
+	10, // 11: TripsService.GetTripSummary:input_type -> GetTripSummaryRequest
+	1,  // 12: TripsService.CreateTrip:output_type -> TripResponse
+	1,  // 13: TripsService.UpdateTrip:output_type -> TripResponse
+	5,  // 14: TripsService.DeleteTrip:output_type -> DeleteTripResponse
+	7,  // 15: TripsService.ListTrips:output_type -> ListTripsResponse
+	9,  // 16: TripsService.SetExpenseTrip:output_type -> SetExpenseTripResponse
+	14, // 17: TripsService.GetTripSummary:output_type -> TripSummary
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_trips_proto_init() }
+func file_proto_trips_proto_init() {
+	if File_proto_trips_proto != nil {
+		return
+	}
+	file_proto_trips_proto_msgTypes[3].OneofWrappers = []any{}
+	file_proto_trips_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_trips_proto_rawDesc), len(file_proto_trips_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_trips_proto_goTypes,
+		DependencyIndexes: file_proto_trips_proto_depIdxs,
+		MessageInfos:      file_proto_trips_proto_msgTypes,
+	}.Build()
+	File_proto_trips_proto = out.File
+	file_proto_trips_proto_goTypes = nil
+	file_proto_trips_proto_depIdxs = nil
+}
@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: proto/trips.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TripsService_CreateTrip_FullMethodName     = "/TripsService/CreateTrip"
+	TripsService_UpdateTrip_FullMethodName     = "/TripsService/UpdateTrip"
+	TripsService_DeleteTrip_FullMethodName     = "/TripsService/DeleteTrip"
+	TripsService_ListTrips_FullMethodName      = "/TripsService/ListTrips"
+	TripsService_SetExpenseTrip_FullMethodName = "/TripsService/SetExpenseTrip"
+	TripsService_GetTripSummary_FullMethodName = "/TripsService/GetTripSummary"
+)
+
+// TripsServiceClient is the client API for TripsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TripsService groups expenses into trips and events. A personal trip
+// collects the caller's own expenses and a group trip the group's. Expenses
+// are assigned to the trip their date falls in, the one that started last
+// when trips overlap, unless their trip was set by hand with SetExpenseTrip.
+// Dates are YYYY-MM-DD and inclusive.
+type TripsServiceClient interface {
+	CreateTrip(ctx context.Context, in *CreateTripRequest, opts ...grpc.CallOption) (*TripResponse, error)
+	UpdateTrip(ctx context.Context, in *UpdateTripRequest, opts ...grpc.CallOption) (*TripResponse, error)
+	DeleteTrip(ctx context.Context, in *DeleteTripRequest, opts ...grpc.CallOption) (*DeleteTripResponse, error)
+	ListTrips(ctx context.Context, in *ListTripsRequest, opts ...grpc.CallOption) (*ListTripsResponse, error)
+	SetExpenseTrip(ctx context.Context, in *SetExpenseTripRequest, opts ...grpc.CallOption) (*SetExpenseTripResponse, error)
+	GetTripSummary(ctx context.Context, in *GetTripSummaryRequest, opts ...grpc.CallOption) (*TripSummary, error)
+}
+
+type tripsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTripsServiceClient(cc grpc.ClientConnInterface) TripsServiceClient {
+	return &tripsServiceClient{cc}
+}
+
+func (c *tripsServiceClient) CreateTrip(ctx context.Context, in *CreateTripRequest, opts ...grpc.CallOption) (*TripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripResponse)
+	err := c.cc.Invoke(ctx, TripsService_CreateTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripsServiceClient) UpdateTrip(ctx context.Context, in *UpdateTripRequest, opts ...grpc.CallOption) (*TripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripResponse)
+	err := c.cc.Invoke(ctx, TripsService_UpdateTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripsServiceClient) DeleteTrip(ctx context.Context, in *DeleteTripRequest, opts ...grpc.CallOption) (*DeleteTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTripResponse)
+	err := c.cc.Invoke(ctx, TripsService_DeleteTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripsServiceClient) ListTrips(ctx context.Context, in *ListTripsRequest, opts ...grpc.CallOption) (*ListTripsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTripsResponse)
+	err := c.cc.Invoke(ctx, TripsService_ListTrips_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripsServiceClient) SetExpenseTrip(ctx context.Context, in *SetExpenseTripRequest, opts ...grpc.CallOption) (*SetExpenseTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetExpenseTripResponse)
+	err := c.cc.Invoke(ctx, TripsService_SetExpenseTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripsServiceClient) GetTripSummary(ctx context.Context, in *GetTripSummaryRequest, opts ...grpc.CallOption) (*TripSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripSummary)
+	err := c.cc.Invoke(ctx, TripsService_GetTripSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TripsServiceServer is the server API for TripsService service.
+// All implementations must embed UnimplementedTripsServiceServer
+// for forward compatibility.
+//
+// TripsService groups expenses into trips and events. A personal trip
+// collects the caller's own expenses and a group trip the group's. Expenses
+// are assigned to the trip their date falls in, the one that started last
+// when trips overlap, unless their trip was set by hand with SetExpenseTrip.
+// Dates are YYYY-MM-DD and inclusive.
+type TripsServiceServer interface {
+	CreateTrip(context.Context, *CreateTripRequest) (*TripResponse, error)
+	UpdateTrip(context.Context, *UpdateTripRequest) (*TripResponse, error)
+	DeleteTrip(context.Context, *DeleteTripRequest) (*DeleteTripResponse, error)
+	ListTrips(context.Context, *ListTripsRequest) (*ListTripsResponse, error)
+	SetExpenseTrip(context.Context, *SetExpenseTripRequest) (*SetExpenseTripResponse, error)
+	GetTripSummary(context.Context, *GetTripSummaryRequest) (*TripSummary, error)
+	mustEmbedUnimplementedTripsServiceServer()
+}
+
+// UnimplementedTripsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTripsServiceServer struct{}
+
+func (UnimplementedTripsServiceServer) CreateTrip(context.Context, *CreateTripRequest) (*TripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTrip not implemented")
+}
+func (UnimplementedTripsServiceServer) UpdateTrip(context.Context, *UpdateTripRequest) (*TripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTrip not implemented")
+}
+func (UnimplementedTripsServiceServer) DeleteTrip(context.Context, *DeleteTripRequest) (*DeleteTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTrip not implemented")
+}
+func (UnimplementedTripsServiceServer) ListTrips(context.Context, *ListTripsRequest) (*ListTripsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrips not implemented")
+}
+func (UnimplementedTripsServiceServer) SetExpenseTrip(context.Context, *SetExpenseTripRequest) (*SetExpenseTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExpenseTrip not implemented")
+}
+func (UnimplementedTripsServiceServer) GetTripSummary(context.Context, *GetTripSummaryRequest) (*TripSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTripSummary not implemented")
+}
+func (UnimplementedTripsServiceServer) mustEmbedUnimplementedTripsServiceServer() {}
+func (UnimplementedTripsServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeTripsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TripsServiceServer will
+// result in compilation errors.
+type UnsafeTripsServiceServer interface {
+	mustEmbedUnimplementedTripsServiceServer()
+}
+
+func RegisterTripsServiceServer(s grpc.ServiceRegistrar, srv TripsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTripsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TripsService_ServiceDesc, srv)
+}
+
+func _TripsService_CreateTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripsServiceServer).CreateTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripsService_CreateTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripsServiceServer).CreateTrip(ctx, req.(*CreateTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripsService_UpdateTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripsServiceServer).UpdateTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripsService_UpdateTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripsServiceServer).UpdateTrip(ctx, req.(*UpdateTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripsService_DeleteTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripsServiceServer).DeleteTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripsService_DeleteTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripsServiceServer).DeleteTrip(ctx, req.(*DeleteTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripsService_ListTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTripsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripsServiceServer).ListTrips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripsService_ListTrips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripsServiceServer).ListTrips(ctx, req.(*ListTripsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripsService_SetExpenseTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExpenseTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripsServiceServer).SetExpenseTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripsService_SetExpenseTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripsServiceServer).SetExpenseTrip(ctx, req.(*SetExpenseTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripsService_GetTripSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripsServiceServer).GetTripSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripsService_GetTripSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripsServiceServer).GetTripSummary(ctx, req.(*GetTripSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TripsService_ServiceDesc is the grpc.ServiceDesc for TripsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TripsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "TripsService",
+	HandlerType: (*TripsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTrip",
+			Handler:    _TripsService_CreateTrip_Handler,
+		},
+		{
+			MethodName: "UpdateTrip",
+			Handler:    _TripsService_UpdateTrip_Handler,
+		},
+		{
+			MethodName: "DeleteTrip",
+			Handler:    _TripsService_DeleteTrip_Handler,
+		},
+		{
+			MethodName: "ListTrips",
+			Handler:    _TripsService_ListTrips_Handler,
+		},
+		{
+			MethodName: "SetExpenseTrip",
+			Handler:    _TripsService_SetExpenseTrip_Handler,
+		},
+		{
+			MethodName: "GetTripSummary",
+			Handler:    _TripsService_GetTripSummary_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/trips.proto",
+}
@@ -0,0 +1,486 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	exportChunkSize            = 64 * 1024
+	defaultDeletionGracePeriod = 30 * 24 * time.Hour
+)
+
+type exportedExpense struct {
+	ID            string    `json:"id"`
+	DateTime      time.Time `json:"date_and_time"`
+	Place         string    `json:"place"`
+	PaymentMethod string    `json:"mode_of_payment"`
+	Amount        string    `json:"amount"`
+	Currency      string    `json:"currency"`
+	Category      string    `json:"category"`
+	Notes         string    `json:"notes"`
+	Tags          []string  `json:"tags"`
+}
+
+type exportedItem struct {
+	ID        string `json:"id"`
+	ExpenseID string `json:"expense_id"`
+	ItemName  string `json:"item_name"`
+	Price     string `json:"price"`
+	Quantity  int    `json:"quantity"`
+	Category  string `json:"category"`
+}
+
+type exportedCustomField struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	FieldType string    `json:"field_type"`
+	Options   []string  `json:"options"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type exportedFieldValue struct {
+	ExpenseID string `json:"expense_id"`
+	FieldID   string `json:"field_id"`
+	Field     string `json:"field"`
+	Value     string `json:"value"`
+}
+
+type exportedReceipt struct {
+	ID          string    `json:"id"`
+	ExpenseID   string    `json:"expense_id"`
+	StorageKey  string    `json:"-"`
+	ContentType string    `json:"content_type"`
+	File        string    `json:"file"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// exportStreamWriter forwards everything written to it as ExportMyData chunks.
+type exportStreamWriter struct {
+	stream pb.UsersService_ExportMyDataServer
+}
+
+func (w exportStreamWriter) Write(p []byte) (int, error) {
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+	if err := w.stream.Send(&pb.ExportMyDataResponse{Chunks: chunk}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *usersServer) ExportMyData(req *pb.ExportMyDataRequest, stream pb.UsersService_ExportMyDataServer) error {
+	ctx := stream.Context()
+	userId, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("Exporting data for user %s", userId)
+
+	profile, err := loadProfile(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Failed to load profile: %v", err)
+		return err
+	}
+	expenses, err := s.exportExpenses(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to load expenses: %v", err)
+		return err
+	}
+	items, err := s.exportItems(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to load line items: %v", err)
+		return err
+	}
+	fields, err := s.exportCustomFields(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to load custom fields: %v", err)
+		return err
+	}
+	values, err := s.exportFieldValues(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to load custom field values: %v", err)
+		return err
+	}
+	receipts, err := s.exportReceipts(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to load receipts: %v", err)
+		return err
+	}
+
+	buf := bufio.NewWriterSize(exportStreamWriter{stream: stream}, exportChunkSize)
+	archive := zip.NewWriter(buf)
+
+	if err := writeZipJSON(archive, "profile.json", profile); err != nil {
+		return err
+	}
+	if err := writeZipJSON(archive, "expenses.json", expenses); err != nil {
+		return err
+	}
+	expenseRows := [][]string{{"id", "date_and_time", "place", "mode_of_payment", "amount", "currency", "category", "notes", "tags"}}
+	for _, e := range expenses {
+		expenseRows = append(expenseRows, []string{e.ID, e.DateTime.Format(time.RFC3339), e.Place, e.PaymentMethod, e.Amount, e.Currency, e.Category,
+			e.Notes, strings.Join(e.Tags, ";")})
+	}
+	if err := writeZipCSV(archive, "expenses.csv", expenseRows); err != nil {
+		return err
+	}
+	if err := writeZipJSON(archive, "line_items.json", items); err != nil {
+		return err
+	}
+	itemRows := [][]string{{"id", "expense_id", "item_name", "price", "quantity", "category"}}
+	for _, i := range items {
+		itemRows = append(itemRows, []string{i.ID, i.ExpenseID, i.ItemName, i.Price, fmt.Sprint(i.Quantity), i.Category})
+	}
+	if err := writeZipCSV(archive, "line_items.csv", itemRows); err != nil {
+		return err
+	}
+	if err := writeZipJSON(archive, "custom_fields.json", fields); err != nil {
+		return err
+	}
+	if err := writeZipJSON(archive, "custom_field_values.json", values); err != nil {
+		return err
+	}
+	valueRows := [][]string{{"expense_id", "field_id", "field", "value"}}
+	for _, v := range values {
+		valueRows = append(valueRows, []string{v.ExpenseID, v.FieldID, v.Field, v.Value})
+	}
+	if err := writeZipCSV(archive, "custom_field_values.csv", valueRows); err != nil {
+		return err
+	}
+
+	for idx := range receipts {
+		receipt := &receipts[idx]
+		data, err := s.blobs.Get(ctx, receipt.StorageKey)
+		if err != nil {
+			log.Printf("Failed to read receipt %s: %v", receipt.StorageKey, err)
+			continue
+		}
+		receipt.File = "receipts/" + receipt.ID + extensionFor(receipt.ContentType)
+		w, err := archive.Create(receipt.File)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := writeZipJSON(archive, "receipts.json", receipts); err != nil {
+		return err
+	}
+
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+
+	log.Printf("Data export for user %s completed", userId)
+	return nil
+}
+
+func (s *usersServer) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reauthenticate(ctx, userId, req); err != nil {
+		return nil, err
+	}
+
+	scheduled := time.Now().Add(deletionGracePeriod())
+	query := `UPDATE user_data SET deletion_requested_at = current_timestamp, deletion_scheduled_at = $1, updated_at = current_timestamp WHERE uuid = $2`
+	if _, err := s.db.ExecContext(ctx, query, scheduled, userId); err != nil {
+		log.Printf("Failed to schedule account deletion: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Account %s scheduled for deletion at %s", userId, scheduled.Format(time.RFC3339))
+	return &pb.DeleteAccountResponse{
+		DeletionScheduledAt: scheduled.Format(time.RFC3339),
+		Message:             "Account scheduled for deletion",
+	}, nil
+}
+
+func (s *usersServer) CancelAccountDeletion(ctx context.Context, req *pb.CancelAccountDeletionRequest) (*pb.CancelAccountDeletionResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE user_data SET deletion_requested_at = NULL, deletion_scheduled_at = NULL, updated_at = current_timestamp WHERE uuid = $1 AND deletion_scheduled_at IS NOT NULL`
+	res, err := s.db.ExecContext(ctx, query, userId)
+	if err != nil {
+		log.Printf("Failed to cancel account deletion: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return &pb.CancelAccountDeletionResponse{Message: "Account is not scheduled for deletion"}, nil
+	}
+
+	return &pb.CancelAccountDeletionResponse{Message: "Account deletion cancelled"}, nil
+}
+
+// purgeDeletedAccounts periodically hard-deletes accounts whose grace period
+// has passed. Dependent rows go through the ON DELETE CASCADE foreign keys;
+// receipt images are removed from blob storage afterwards.
+func purgeDeletedAccounts(ctx context.Context, db *sql.DB, blobs BlobStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := purgeDueAccounts(ctx, db, blobs); err != nil {
+			log.Printf("Failed to purge deleted accounts: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func purgeDueAccounts(ctx context.Context, db *sql.DB, blobs BlobStore) error {
+	rows, err := db.QueryContext(ctx, `SELECT uuid FROM user_data WHERE deletion_scheduled_at <= current_timestamp`)
+	if err != nil {
+		return err
+	}
+	var userIds []string
+	for rows.Next() {
+		var userId string
+		if err := rows.Scan(&userId); err != nil {
+			rows.Close()
+			return err
+		}
+		userIds = append(userIds, userId)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, userId := range userIds {
+		if err := purgeAccount(ctx, db, blobs, userId); err != nil {
+			log.Printf("Failed to purge account %s: %v", userId, err)
+			continue
+		}
+		log.Printf("Account %s permanently deleted", userId)
+	}
+	return nil
+}
+
+func purgeAccount(ctx context.Context, db *sql.DB, blobs BlobStore, userId string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT storage_key FROM receipt_data WHERE uuid = $1`, userId)
+	if err != nil {
+		return err
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return err
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Re-check the schedule so a cancellation that raced the purge wins.
+	res, err := tx.ExecContext(ctx, `DELETE FROM user_data WHERE uuid = $1 AND deletion_scheduled_at <= current_timestamp`, userId)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := blobs.Delete(ctx, key); err != nil {
+			log.Printf("Failed to delete receipt %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+func deletionGracePeriod() time.Duration {
+	if v := os.Getenv("ACCOUNT_DELETION_GRACE_PERIOD"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+		log.Printf("Invalid ACCOUNT_DELETION_GRACE_PERIOD %q, using default", v)
+	}
+	return defaultDeletionGracePeriod
+}
+
+func (s *usersServer) exportExpenses(ctx context.Context, userId string) ([]exportedExpense, error) {
+	query := `
+		SELECT e.id, e.date_and_time, e.place, e.mode_of_payment, e.amount, e.currency, e.category, coalesce(e.notes, ''),
+			coalesce((SELECT array_agg(tag ORDER BY tag) FROM expense_tag_data WHERE expense_id = e.id), '{}')
+		FROM expense_data e WHERE e.uuid = $1 ORDER BY e.date_and_time`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expenses := []exportedExpense{}
+	for rows.Next() {
+		var e exportedExpense
+		if err := rows.Scan(&e.ID, &e.DateTime, &e.Place, &e.PaymentMethod, &e.Amount, &e.Currency, &e.Category, &e.Notes, pq.Array(&e.Tags)); err != nil {
+			return nil, err
+		}
+		if e.Tags == nil {
+			e.Tags = []string{}
+		}
+		expenses = append(expenses, e)
+	}
+	return expenses, rows.Err()
+}
+
+func (s *usersServer) exportCustomFields(ctx context.Context, userId string) ([]exportedCustomField, error) {
+	query := `SELECT id, name, field_type, options, created_at FROM custom_field_data WHERE uuid = $1 ORDER BY created_at, id`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := []exportedCustomField{}
+	for rows.Next() {
+		var f exportedCustomField
+		if err := rows.Scan(&f.ID, &f.Name, &f.FieldType, pq.Array(&f.Options), &f.CreatedAt); err != nil {
+			return nil, err
+		}
+		if f.Options == nil {
+			f.Options = []string{}
+		}
+		fields = append(fields, f)
+	}
+	return fields, rows.Err()
+}
+
+func (s *usersServer) exportFieldValues(ctx context.Context, userId string) ([]exportedFieldValue, error) {
+	query := `
+		SELECT v.expense_id, v.field_id, f.name, v.value
+		FROM expense_custom_field_data v
+		JOIN expense_data e ON e.id = v.expense_id
+		JOIN custom_field_data f ON f.id = v.field_id
+		WHERE e.uuid = $1 ORDER BY e.date_and_time, v.expense_id, lower(f.name)`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := []exportedFieldValue{}
+	for rows.Next() {
+		var v exportedFieldValue
+		if err := rows.Scan(&v.ExpenseID, &v.FieldID, &v.Field, &v.Value); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
+
+func (s *usersServer) exportItems(ctx context.Context, userId string) ([]exportedItem, error) {
+	query := `
+		SELECT i.id, i.expense_id, i.item_name, i.price, i.quantity, coalesce(i.category, '')
+		FROM expense_item_data i JOIN expense_data e ON e.id = i.expense_id
+		WHERE e.uuid = $1 ORDER BY e.date_and_time, i.id`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []exportedItem{}
+	for rows.Next() {
+		var i exportedItem
+		if err := rows.Scan(&i.ID, &i.ExpenseID, &i.ItemName, &i.Price, &i.Quantity, &i.Category); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	return items, rows.Err()
+}
+
+func (s *usersServer) exportReceipts(ctx context.Context, userId string) ([]exportedReceipt, error) {
+	query := `SELECT id, coalesce(expense_id::text, ''), storage_key, content_type, created_at FROM receipt_data WHERE uuid = $1 ORDER BY created_at`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := []exportedReceipt{}
+	for rows.Next() {
+		var r exportedReceipt
+		if err := rows.Scan(&r.ID, &r.ExpenseID, &r.StorageKey, &r.ContentType, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, r)
+	}
+	return receipts, rows.Err()
+}
+
+func writeZipJSON(archive *zip.Writer, name string, v any) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeZipCSV(archive *zip.Writer, name string, rows [][]string) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func extensionFor(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "application/pdf":
+		return ".pdf"
+	}
+	if exts, err := mime.ExtensionsByType(contentType); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ".bin"
+}
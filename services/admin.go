@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	impersonationTTL     = time.Hour
+	defaultListUsersSize = 50
+	maxListUsersSize     = 500
+)
+
+// Actions recorded in admin_audit_data.
+const (
+	adminActionDisableUser      = "disable_user"
+	adminActionEnableUser       = "enable_user"
+	adminActionSetRole          = "set_role"
+	adminActionImpersonate      = "impersonate"
+	adminActionImpersonatedCall = "impersonated_call"
+	adminActionRevokeTokens     = "revoke_tokens"
+)
+
+// adminServer implements AdminService. Role checks happen in the auth
+// interceptor; handlers can rely on an identity being present.
+type adminServer struct {
+	pb.UnimplementedAdminServiceServer
+	db *sql.DB
+}
+
+func (s *adminServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultListUsersSize
+	}
+	if limit > maxListUsersSize {
+		limit = maxListUsersSize
+	}
+	offset := int(req.GetOffset())
+	if offset < 0 {
+		offset = 0
+	}
+	pattern := "%" + strings.ToLower(strings.TrimSpace(req.GetQuery())) + "%"
+
+	var total int64
+	countQuery := `SELECT count(*) FROM user_data WHERE lower(username) LIKE $1 OR lower(email) LIKE $1`
+	if err := s.db.QueryRowContext(ctx, countQuery, pattern).Scan(&total); err != nil {
+		log.Printf("Failed to count users: %v", err)
+		return nil, err
+	}
+
+	query := `
+		SELECT u.uuid, u.username, u.email, coalesce(u.first_name, ''), coalesce(u.last_name, ''), u.role,
+			u.created_at, u.disabled_at, coalesce(u.disabled_reason, ''),
+			(SELECT max(created_at) FROM auth_event_data a WHERE a.uuid = u.uuid AND a.success),
+			(SELECT count(*) FROM expense_data e WHERE e.uuid = u.uuid)
+		FROM user_data u
+		WHERE lower(u.username) LIKE $1 OR lower(u.email) LIKE $1
+		ORDER BY u.created_at, u.uuid
+		LIMIT $2 OFFSET $3`
+	rows, err := s.db.QueryContext(ctx, query, pattern, limit, offset)
+	if err != nil {
+		log.Printf("Failed to list users: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*pb.AdminUser
+	for rows.Next() {
+		var user pb.AdminUser
+		var createdAt time.Time
+		var disabledAt, lastLogin sql.NullTime
+		if err := rows.Scan(&user.UserId, &user.Username, &user.Email, &user.FirstName, &user.LastName, &user.Role,
+			&createdAt, &disabledAt, &user.DisabledReason, &lastLogin, &user.ExpenseCount); err != nil {
+			return nil, err
+		}
+		user.CreatedAt = createdAt.Format(time.RFC3339)
+		if disabledAt.Valid {
+			user.DisabledAt = disabledAt.Time.Format(time.RFC3339)
+		}
+		if lastLogin.Valid {
+			user.LastLoginAt = lastLogin.Time.Format(time.RFC3339)
+		}
+		users = append(users, &user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListUsersResponse{
+		Users:      users,
+		TotalCount: total,
+	}, nil
+}
+
+func (s *adminServer) GetUsageStats(ctx context.Context, req *pb.GetUsageStatsRequest) (*pb.GetUsageStatsResponse, error) {
+	var stats pb.GetUsageStatsResponse
+	query := `
+		SELECT
+			(SELECT count(*) FROM user_data),
+			(SELECT count(*) FROM user_data WHERE disabled_at IS NOT NULL),
+			(SELECT count(DISTINCT uuid) FROM auth_event_data WHERE success AND created_at > current_timestamp - interval '30 days'),
+			(SELECT count(*) FROM expense_data),
+			(SELECT count(*) FROM expense_data WHERE date_and_time > current_timestamp - interval '30 days'),
+			(SELECT count(*) FROM receipt_data),
+			(SELECT coalesce(sum(size_bytes), 0) FROM receipt_data),
+			(SELECT count(*) FROM api_key_data WHERE revoked_at IS NULL),
+			(SELECT count(*) FROM auth_event_data WHERE event = $1 AND success AND created_at > current_timestamp - interval '24 hours'),
+			(SELECT count(*) FROM auth_event_data WHERE event = $1 AND NOT success AND created_at > current_timestamp - interval '24 hours')`
+	err := s.db.QueryRowContext(ctx, query, authEventLogin).Scan(
+		&stats.TotalUsers, &stats.DisabledUsers, &stats.ActiveUsers30D,
+		&stats.TotalExpenses, &stats.ExpensesLast30D,
+		&stats.TotalReceipts, &stats.ReceiptStorageBytes,
+		&stats.ActiveApiKeys, &stats.Logins24H, &stats.FailedLogins24H,
+	)
+	if err != nil {
+		log.Printf("Failed to load usage stats: %v", err)
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (s *adminServer) DisableUser(ctx context.Context, req *pb.DisableUserRequest) (*pb.DisableUserResponse, error) {
+	actor := identityFromContext(ctx)
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+	if req.GetUserId() == actor.UserID {
+		return nil, status.Error(codes.FailedPrecondition, "you cannot disable your own account")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE user_data SET disabled_at = current_timestamp, disabled_reason = $1, updated_at = current_timestamp WHERE uuid = $2 AND disabled_at IS NULL`
+	res, err := tx.ExecContext(ctx, query, req.GetReason(), req.GetUserId())
+	if err != nil {
+		log.Printf("Failed to disable user: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "user not found or already disabled")
+	}
+	if _, _, err := revokeCredentials(ctx, tx, req.GetUserId(), true); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	recordAdminAudit(ctx, s.db, actor.UserID, adminActionDisableUser, req.GetUserId(), req.GetReason(), "")
+	return &pb.DisableUserResponse{Message: "User disabled"}, nil
+}
+
+func (s *adminServer) EnableUser(ctx context.Context, req *pb.EnableUserRequest) (*pb.EnableUserResponse, error) {
+	actor := identityFromContext(ctx)
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	query := `UPDATE user_data SET disabled_at = NULL, disabled_reason = NULL, updated_at = current_timestamp WHERE uuid = $1 AND disabled_at IS NOT NULL`
+	res, err := s.db.ExecContext(ctx, query, req.GetUserId())
+	if err != nil {
+		log.Printf("Failed to enable user: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "user not found or not disabled")
+	}
+
+	recordAdminAudit(ctx, s.db, actor.UserID, adminActionEnableUser, req.GetUserId(), "", "")
+	return &pb.EnableUserResponse{Message: "User enabled"}, nil
+}
+
+func (s *adminServer) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.SetUserRoleResponse, error) {
+	actor := identityFromContext(ctx)
+	switch req.GetRole() {
+	case roleUser, roleAdmin, roleSupportReadonly:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown role %q", req.GetRole())
+	}
+	if req.GetUserId() == actor.UserID && req.GetRole() != roleAdmin {
+		return nil, status.Error(codes.FailedPrecondition, "you cannot remove your own admin role")
+	}
+
+	query := `UPDATE user_data SET role = $1, updated_at = current_timestamp WHERE uuid = $2`
+	res, err := s.db.ExecContext(ctx, query, req.GetRole(), req.GetUserId())
+	if err != nil {
+		log.Printf("Failed to set role: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+
+	recordAdminAudit(ctx, s.db, actor.UserID, adminActionSetRole, req.GetUserId(), "", req.GetRole())
+	return &pb.SetUserRoleResponse{Message: "Role updated"}, nil
+}
+
+func (s *adminServer) ImpersonateForSupport(ctx context.Context, req *pb.ImpersonateForSupportRequest) (*pb.ImpersonateForSupportResponse, error) {
+	actor := identityFromContext(ctx)
+	reason := strings.TrimSpace(req.GetReason())
+	if req.GetUserId() == "" || reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id and reason are required")
+	}
+	if req.GetUserId() == actor.UserID {
+		return nil, status.Error(codes.InvalidArgument, "you cannot impersonate yourself")
+	}
+
+	var disabled bool
+	err := s.db.QueryRowContext(ctx, `SELECT disabled_at IS NOT NULL FROM user_data WHERE uuid = $1`, req.GetUserId()).Scan(&disabled)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if disabled {
+		return nil, status.Error(codes.FailedPrecondition, "user is disabled")
+	}
+
+	token, err := uuid.NewV6()
+	if err != nil {
+		return nil, err
+	}
+	expires := time.Now().Add(impersonationTTL)
+	query := `INSERT INTO token_data (uuid, token, expires_at, context, scope, impersonator) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = s.db.ExecContext(ctx, query, req.GetUserId(), token.String(), expires, "support_impersonation", scopeRead, actor.UserID)
+	if err != nil {
+		log.Printf("Failed to create impersonation token: %v", err)
+		return nil, err
+	}
+
+	recordAdminAudit(ctx, s.db, actor.UserID, adminActionImpersonate, req.GetUserId(), reason, "")
+	log.Printf("User %s is impersonating %s: %s", actor.UserID, req.GetUserId(), reason)
+	return &pb.ImpersonateForSupportResponse{
+		AuthToken: token.String(),
+		ExpiresAt: expires.Format(time.RFC3339),
+	}, nil
+}
+
+func (s *adminServer) RevokeUserTokens(ctx context.Context, req *pb.RevokeUserTokensRequest) (*pb.RevokeUserTokensResponse, error) {
+	actor := identityFromContext(ctx)
+	if req.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user id is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	sessions, apiKeys, err := revokeCredentials(ctx, tx, req.GetUserId(), req.GetIncludeApiKeys())
+	if err != nil {
+		log.Printf("Failed to revoke tokens: %v", err)
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	recordAdminAudit(ctx, s.db, actor.UserID, adminActionRevokeTokens, req.GetUserId(), "", "")
+	return &pb.RevokeUserTokensResponse{
+		RevokedSessions: sessions,
+		RevokedApiKeys:  apiKeys,
+	}, nil
+}
+
+// revokeCredentials deletes every session of the user, including support
+// impersonation tokens, and optionally revokes their API keys.
+func revokeCredentials(ctx context.Context, tx *sql.Tx, userId string, includeAPIKeys bool) (int64, int64, error) {
+	res, err := tx.ExecContext(ctx, `DELETE FROM token_data WHERE uuid = $1`, userId)
+	if err != nil {
+		return 0, 0, err
+	}
+	sessions, _ := res.RowsAffected()
+
+	var apiKeys int64
+	if includeAPIKeys {
+		res, err := tx.ExecContext(ctx, `UPDATE api_key_data SET revoked_at = current_timestamp WHERE uuid = $1 AND revoked_at IS NULL`, userId)
+		if err != nil {
+			return 0, 0, err
+		}
+		apiKeys, _ = res.RowsAffected()
+	}
+	return sessions, apiKeys, nil
+}
+
+// recordAdminAudit writes to the admin audit table. Like recordAuthEvent it
+// only logs failures.
+func recordAdminAudit(ctx context.Context, db *sql.DB, actorId, action, targetId, reason, detail string) {
+	query := `INSERT INTO admin_audit_data (actor, action, target, reason, detail) VALUES ($1, $2, $3, $4, $5)`
+	var target any
+	if targetId != "" {
+		target = targetId
+	}
+	if _, err := db.ExecContext(ctx, query, actorId, action, target, reason, detail); err != nil {
+		log.Printf("Failed to record admin audit entry: %v", err)
+	}
+}
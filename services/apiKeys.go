@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// API keys look like "exk_<prefix>_<secret>". The prefix is stored in clear
+// text to find the key and to let users tell their keys apart; the whole key
+// is only stored as a SHA-256 hash.
+const (
+	apiKeyPrefix      = "exk_"
+	apiKeyPrefixBytes = 4
+	apiKeySecretBytes = 24
+	maxAPIKeysPerUser = 25
+)
+
+func apiKeyLookupPrefix(key string) (string, bool) {
+	rest := strings.TrimPrefix(key, apiKeyPrefix)
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != apiKeyPrefixBytes*2 || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+func (s *usersServer) CreateApiKey(ctx context.Context, req *pb.CreateApiKeyRequest) (*pb.CreateApiKeyResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.GetName())
+	if name == "" || len(name) > 100 {
+		return nil, status.Error(codes.InvalidArgument, "name must be between 1 and 100 characters")
+	}
+	scope := req.GetScope()
+	if scope == "" {
+		scope = scopeRead
+	}
+	if scope != scopeRead && scope != scopeReadWrite {
+		return nil, status.Errorf(codes.InvalidArgument, "scope must be %q or %q", scopeRead, scopeReadWrite)
+	}
+
+	var active int
+	err = s.db.QueryRowContext(ctx, `SELECT count(*) FROM api_key_data WHERE uuid = $1 AND revoked_at IS NULL`, userId).Scan(&active)
+	if err != nil {
+		return nil, err
+	}
+	if active >= maxAPIKeysPerUser {
+		return nil, status.Errorf(codes.ResourceExhausted, "at most %d active API keys are allowed", maxAPIKeysPerUser)
+	}
+
+	prefixBytes := make([]byte, apiKeyPrefixBytes)
+	secretBytes := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, err
+	}
+	prefix := hex.EncodeToString(prefixBytes)
+	key := apiKeyPrefix + prefix + "_" + hex.EncodeToString(secretBytes)
+
+	var apiKey pb.ApiKey
+	var createdAt time.Time
+	query := `INSERT INTO api_key_data (uuid, name, prefix, key_hash, scope) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+	err = s.db.QueryRowContext(ctx, query, userId, name, prefix, hashAPIKey(key), scope).Scan(&apiKey.Id, &createdAt)
+	if err != nil {
+		log.Printf("Failed to create API key: %v", err)
+		return nil, err
+	}
+	apiKey.Name = name
+	apiKey.Prefix = apiKeyPrefix + prefix
+	apiKey.Scope = scope
+	apiKey.CreatedAt = createdAt.Format(time.RFC3339)
+
+	log.Printf("Created API key %s for user %s", apiKey.Prefix, userId)
+	return &pb.CreateApiKeyResponse{
+		ApiKey: &apiKey,
+		Key:    key,
+	}, nil
+}
+
+func (s *usersServer) ListApiKeys(ctx context.Context, req *pb.ListApiKeysRequest) (*pb.ListApiKeysResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, name, prefix, scope, created_at, last_used_at, revoked_at FROM api_key_data WHERE uuid = $1 ORDER BY created_at DESC`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		log.Printf("Failed to list API keys: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apiKeys []*pb.ApiKey
+	for rows.Next() {
+		var apiKey pb.ApiKey
+		var createdAt time.Time
+		var lastUsed, revoked sql.NullTime
+		if err := rows.Scan(&apiKey.Id, &apiKey.Name, &apiKey.Prefix, &apiKey.Scope, &createdAt, &lastUsed, &revoked); err != nil {
+			return nil, err
+		}
+		apiKey.Prefix = apiKeyPrefix + apiKey.Prefix
+		apiKey.CreatedAt = createdAt.Format(time.RFC3339)
+		if lastUsed.Valid {
+			apiKey.LastUsedAt = lastUsed.Time.Format(time.RFC3339)
+		}
+		if revoked.Valid {
+			apiKey.RevokedAt = revoked.Time.Format(time.RFC3339)
+		}
+		apiKeys = append(apiKeys, &apiKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListApiKeysResponse{ApiKeys: apiKeys}, nil
+}
+
+func (s *usersServer) RevokeApiKey(ctx context.Context, req *pb.RevokeApiKeyRequest) (*pb.RevokeApiKeyResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "key id is required")
+	}
+
+	query := `UPDATE api_key_data SET revoked_at = current_timestamp WHERE id = $1 AND uuid = $2 AND revoked_at IS NULL`
+	res, err := s.db.ExecContext(ctx, query, req.GetId(), userId)
+	if err != nil {
+		log.Printf("Failed to revoke API key: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "API key not found")
+	}
+
+	return &pb.RevokeApiKeyResponse{Message: "API key revoked"}, nil
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"log"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// Credential scopes. Session tokens are always read-write; API keys carry
+// the scope they were created with.
+const (
+	scopeRead      = "read"
+	scopeReadWrite = "read_write"
+)
+
+const (
+	credentialSession = "session"
+	credentialAPIKey  = "api_key"
+)
+
+// User roles stored in user_data.role.
+const (
+	roleUser            = "user"
+	roleAdmin           = "admin"
+	roleSupportReadonly = "support-readonly"
+)
+
+// identity is the authenticated caller attached to the request context.
+// ImpersonatorID is set when a support agent acts on behalf of the user.
+type identity struct {
+	UserID         string
+	Role           string
+	Scope          string
+	CredentialType string
+	ImpersonatorID string
+}
+
+type identityKey struct{}
+
+func withIdentity(ctx context.Context, id *identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// identityFromContext returns the caller authenticated by the interceptors,
+// or nil when the request carried no credentials.
+func identityFromContext(ctx context.Context) *identity {
+	id, _ := ctx.Value(identityKey{}).(*identity)
+	return id
+}
+
+// callerID returns the id of the authenticated user. Handlers must use it
+// rather than any user id supplied in the request.
+func callerID(ctx context.Context) (string, error) {
+	id := identityFromContext(ctx)
+	if id == nil {
+		return "", status.Error(codes.Unauthenticated, "authentication required")
+	}
+	return id.UserID, nil
+}
+
+// publicMethods can be called without credentials. They either establish a
+// session or validate a credential passed in the request itself.
+var publicMethods = map[string]bool{
+	pb.UsersService_CreateUser_FullMethodName:     true,
+	pb.UsersService_GetUser_FullMethodName:        true,
+	pb.UsersService_VerifyMfa_FullMethodName:      true,
+	pb.UsersService_OidcLogin_FullMethodName:      true,
+	pb.UsersService_VerifyEmail_FullMethodName:    true,
+	pb.UsersService_CheckAuthToken_FullMethodName: true,
+}
+
+// readOnlyMethods may be called with a read-only API key.
+var readOnlyMethods = map[string]bool{
+	pb.ExpensesService_GetHeatMapData_FullMethodName:            true,
+	pb.ExpensesService_CompareSpending_FullMethodName:           true,
+	pb.ExpensesService_GetSpendingTypes_FullMethodName:          true,
+	pb.ExpensesService_ListExpenses_FullMethodName:              true,
+	pb.ExpensesService_ExportExpenses_FullMethodName:            true,
+	pb.ExpensesService_ListTags_FullMethodName:                  true,
+	pb.ExpensesService_ListCustomFields_FullMethodName:          true,
+	pb.ExpensesService_SearchExpenses_FullMethodName:            true,
+	pb.CategorizationService_ListRules_FullMethodName:           true,
+	pb.CategorizationService_ListCategories_FullMethodName:      true,
+	pb.CategorizationService_PredictCategory_FullMethodName:     true,
+	pb.MerchantsService_ListMerchants_FullMethodName:            true,
+	pb.MerchantsService_GetTopMerchants_FullMethodName:          true,
+	pb.ImportService_ListImportProfiles_FullMethodName:          true,
+	pb.GroupsService_ListGroups_FullMethodName:                  true,
+	pb.GroupsService_GetGroup_FullMethodName:                    true,
+	pb.GroupsService_ListInvitations_FullMethodName:             true,
+	pb.GroupsService_GetBalances_FullMethodName:                 true,
+	pb.GroupsService_SuggestItemAssignments_FullMethodName:      true,
+	pb.ExpenseReportsService_ListExpenseReports_FullMethodName:  true,
+	pb.ExpenseReportsService_GetExpenseReport_FullMethodName:    true,
+	pb.ExpenseReportsService_GetReportReceipt_FullMethodName:    true,
+	pb.ExpenseReportsService_ExportExpenseReport_FullMethodName: true,
+	pb.UsersService_GetProfile_FullMethodName:                   true,
+	pb.UsersService_ExportMyData_FullMethodName:                 true,
+	pb.IngestionService_ListIngestionConsents_FullMethodName:    true,
+	pb.IngestionService_GetEmailIngestion_FullMethodName:        true,
+	pb.TripsService_ListTrips_FullMethodName:                    true,
+	pb.TripsService_GetTripSummary_FullMethodName:               true,
+}
+
+// sessionOnlyMethods manage the account's credentials and consents and
+// cannot be reached with an API key of any scope.
+var sessionOnlyMethods = map[string]bool{
+	pb.UsersService_UpdateProfile_FullMethodName:           true,
+	pb.UsersService_DeleteAccount_FullMethodName:           true,
+	pb.UsersService_CancelAccountDeletion_FullMethodName:   true,
+	pb.UsersService_EnrollTotp_FullMethodName:              true,
+	pb.UsersService_ConfirmTotp_FullMethodName:             true,
+	pb.UsersService_DisableTotp_FullMethodName:             true,
+	pb.UsersService_RegenerateRecoveryCodes_FullMethodName: true,
+	pb.UsersService_LinkIdentity_FullMethodName:            true,
+	pb.UsersService_ListIdentities_FullMethodName:          true,
+	pb.UsersService_UnlinkIdentity_FullMethodName:          true,
+	pb.UsersService_CreateApiKey_FullMethodName:            true,
+	pb.UsersService_ListApiKeys_FullMethodName:             true,
+	pb.UsersService_RevokeApiKey_FullMethodName:            true,
+	pb.IngestionService_SetIngestionConsent_FullMethodName: true,
+	pb.IngestionService_ConnectMailbox_FullMethodName:      true,
+	pb.IngestionService_DisconnectMailbox_FullMethodName:   true,
+}
+
+// methodRoles restricts methods to the listed roles. Methods that are not
+// listed are open to every authenticated user.
+var methodRoles = map[string][]string{
+	pb.AdminService_ListUsers_FullMethodName:             {roleAdmin, roleSupportReadonly},
+	pb.AdminService_GetUsageStats_FullMethodName:         {roleAdmin, roleSupportReadonly},
+	pb.AdminService_ImpersonateForSupport_FullMethodName: {roleAdmin, roleSupportReadonly},
+	pb.AdminService_DisableUser_FullMethodName:           {roleAdmin},
+	pb.AdminService_EnableUser_FullMethodName:            {roleAdmin},
+	pb.AdminService_SetUserRole_FullMethodName:           {roleAdmin},
+	pb.AdminService_RevokeUserTokens_FullMethodName:      {roleAdmin},
+}
+
+// authenticate resolves a session token or an API key to the identity it
+// belongs to. It returns nil without an error when the credential is unknown,
+// expired or revoked.
+func authenticate(ctx context.Context, db *sql.DB, credential string) (*identity, error) {
+	credential = strings.TrimSpace(strings.TrimPrefix(credential, "Bearer "))
+	if credential == "" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(credential, apiKeyPrefix) {
+		return authenticateAPIKey(ctx, db, credential)
+	}
+
+	id := identity{CredentialType: credentialSession}
+	var impersonator sql.NullString
+	query := `
+		SELECT t.uuid, u.role, t.scope, t.impersonator FROM token_data t JOIN user_data u ON u.uuid = t.uuid
+		WHERE t.token = $1 AND t.expires_at > current_timestamp AND u.disabled_at IS NULL`
+	err := db.QueryRowContext(ctx, query, credential).Scan(&id.UserID, &id.Role, &id.Scope, &impersonator)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	id.ImpersonatorID = impersonator.String
+	return &id, nil
+}
+
+func authenticateAPIKey(ctx context.Context, db *sql.DB, key string) (*identity, error) {
+	prefix, ok := apiKeyLookupPrefix(key)
+	if !ok {
+		return nil, nil
+	}
+
+	var id, userId, role, hash, scope string
+	query := `
+		SELECT k.id, k.uuid, u.role, k.key_hash, k.scope FROM api_key_data k JOIN user_data u ON u.uuid = k.uuid
+		WHERE k.prefix = $1 AND k.revoked_at IS NULL AND u.disabled_at IS NULL`
+	err := db.QueryRowContext(ctx, query, prefix).Scan(&id, &userId, &role, &hash, &scope)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(hashAPIKey(key))) != 1 {
+		return nil, nil
+	}
+
+	// Only touch last_used_at once a minute to keep hot keys from writing
+	// on every request.
+	query = `UPDATE api_key_data SET last_used_at = current_timestamp WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < current_timestamp - interval '1 minute')`
+	if _, err := db.ExecContext(ctx, query, id); err != nil {
+		log.Printf("Failed to update API key usage: %v", err)
+	}
+	return &identity{UserID: userId, Role: role, Scope: scope, CredentialType: credentialAPIKey}, nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// authorizeMethod checks whether id may call fullMethod.
+func authorizeMethod(id *identity, fullMethod string) error {
+	if id.CredentialType == credentialAPIKey && sessionOnlyMethods[fullMethod] {
+		return status.Error(codes.PermissionDenied, "API keys cannot manage account credentials")
+	}
+	if id.Scope != scopeReadWrite && !readOnlyMethods[fullMethod] {
+		return status.Error(codes.PermissionDenied, "credential is read-only")
+	}
+	if roles, restricted := methodRoles[fullMethod]; restricted {
+		if id.CredentialType != credentialSession || id.ImpersonatorID != "" {
+			return status.Error(codes.PermissionDenied, "administrative calls require a personal session")
+		}
+		if !slices.Contains(roles, id.Role) {
+			return status.Error(codes.PermissionDenied, "insufficient role")
+		}
+	}
+	return nil
+}
+
+// authContext authenticates the credential in the "authorization" metadata
+// and returns ctx with the caller's identity attached. Public methods are
+// passed through untouched.
+func authContext(ctx context.Context, db *sql.DB, fullMethod string) (context.Context, error) {
+	if publicMethods[fullMethod] {
+		return ctx, nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	id, err := authenticate(ctx, db, md.Get("authorization")[0])
+	if err != nil {
+		log.Printf("Failed to authenticate request: %v", err)
+		return nil, status.Error(codes.Internal, "failed to authenticate request")
+	}
+	if id == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired credentials")
+	}
+	if err := authorizeMethod(id, fullMethod); err != nil {
+		return nil, err
+	}
+	if id.ImpersonatorID != "" {
+		recordAdminAudit(ctx, db, id.ImpersonatorID, adminActionImpersonatedCall, id.UserID, "", fullMethod)
+	}
+	return withIdentity(ctx, id), nil
+}
+
+func unaryAuthInterceptor(db *sql.DB) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authContext(ctx, db, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuthInterceptor(db *sql.DB) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authContext(ss.Context(), db, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream overrides the context of a server stream so handlers
+// see the caller's identity.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
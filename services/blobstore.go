@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BlobStore keeps binary objects such as receipt images outside the database.
+// Keys are slash separated paths, e.g. "<user uuid>/<receipt id>".
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStore returns a filesystem backed store rooted at RECEIPT_STORAGE_DIR.
+func NewBlobStore() (BlobStore, error) {
+	root := os.Getenv("RECEIPT_STORAGE_DIR")
+	if root == "" {
+		root = filepath.Join("data", "receipts")
+	}
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob storage directory: %w", err)
+	}
+	return &fileBlobStore{root: root}, nil
+}
+
+type fileBlobStore struct {
+	root string
+}
+
+func (f *fileBlobStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if clean == "." || filepath.IsAbs(clean) || strings.HasPrefix(clean, "..") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(f.root, clean), nil
+}
+
+func (f *fileBlobStore) Put(ctx context.Context, key string, data []byte) error {
+	p, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0o640)
+}
+
+func (f *fileBlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	p, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(p)
+}
+
+func (f *fileBlobStore) Delete(ctx context.Context, key string) error {
+	p, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	maxCategoriesPerUser   = 100
+	maxSynonymsPerCategory = 20
+	maxCategoryNameLength  = 50
+)
+
+// fallbackCategory is the system category expenses are filed under when
+// their free-text category matches nothing in the taxonomy.
+const fallbackCategory = "Other"
+
+var colourPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// rowQueryer is satisfied by both *sql.DB and *sql.Tx.
+type rowQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// resolveCategory maps a free-text category onto the taxonomy. The user's
+// own categories win over system ones and names over synonyms. It returns
+// the category id and the name to store, which is the canonical name on a
+// match and the text itself when it falls back to "Other".
+func resolveCategory(ctx context.Context, q rowQueryer, userId, text string) (string, string, error) {
+	text = strings.TrimSpace(text)
+	query := `
+		SELECT c.id, c.name FROM category_data c
+		WHERE (c.uuid = $1 OR c.uuid IS NULL)
+			AND (lower(c.name) = lower($2::text) OR EXISTS (
+				SELECT 1 FROM category_synonym_data s WHERE s.category_id = c.id AND lower(s.synonym) = lower($2::text)))
+		ORDER BY c.uuid IS NULL, lower(c.name) <> lower($2::text)
+		LIMIT 1`
+	var id, name string
+	err := q.QueryRowContext(ctx, query, userId, text).Scan(&id, &name)
+	if err == nil {
+		return id, name, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", "", err
+	}
+
+	err = q.QueryRowContext(ctx, `SELECT id FROM category_data WHERE uuid IS NULL AND name = $1`, fallbackCategory).Scan(&id)
+	if err != nil {
+		return "", "", err
+	}
+	if text == "" {
+		text = fallbackCategory
+	}
+	return id, text, nil
+}
+
+// validateCategory checks a category from a request and returns its trimmed
+// name and normalized synonyms.
+func validateCategory(category *pb.Category) (string, []string, error) {
+	if category == nil {
+		return "", nil, status.Error(codes.InvalidArgument, "category is required")
+	}
+	name := strings.TrimSpace(category.GetName())
+	if name == "" || len(name) > maxCategoryNameLength {
+		return "", nil, status.Errorf(codes.InvalidArgument, "name must be between 1 and %d characters", maxCategoryNameLength)
+	}
+	if len(category.GetIcon()) > 50 {
+		return "", nil, status.Error(codes.InvalidArgument, "icon must be at most 50 characters")
+	}
+	if category.GetColour() != "" && !colourPattern.MatchString(category.GetColour()) {
+		return "", nil, status.Error(codes.InvalidArgument, "colour must be a hex colour such as #1A2B3C")
+	}
+
+	seen := map[string]bool{strings.ToLower(name): true}
+	var synonyms []string
+	for _, synonym := range category.GetSynonyms() {
+		synonym = strings.ToLower(strings.TrimSpace(synonym))
+		if synonym == "" || seen[synonym] {
+			continue
+		}
+		if len(synonym) > maxCategoryNameLength {
+			return "", nil, status.Errorf(codes.InvalidArgument, "synonyms must be at most %d characters", maxCategoryNameLength)
+		}
+		seen[synonym] = true
+		synonyms = append(synonyms, synonym)
+	}
+	if len(synonyms) > maxSynonymsPerCategory {
+		return "", nil, status.Errorf(codes.InvalidArgument, "at most %d synonyms are allowed", maxSynonymsPerCategory)
+	}
+	return name, synonyms, nil
+}
+
+// checkCategoryParent verifies that parentId names a top-level category the
+// user can see. The taxonomy is two levels deep, so a category that has
+// children of its own cannot be given a parent.
+func checkCategoryParent(ctx context.Context, tx *sql.Tx, userId, parentId, categoryId string) error {
+	if parentId == "" {
+		return nil
+	}
+	if _, err := uuid.Parse(parentId); err != nil {
+		return status.Error(codes.InvalidArgument, "invalid parent id")
+	}
+	if parentId == categoryId {
+		return status.Error(codes.InvalidArgument, "a category cannot be its own parent")
+	}
+	var topLevel bool
+	err := tx.QueryRowContext(ctx, `SELECT parent_id IS NULL FROM category_data WHERE id = $1 AND (uuid = $2 OR uuid IS NULL)`,
+		parentId, userId).Scan(&topLevel)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, "parent category not found")
+	}
+	if err != nil {
+		return err
+	}
+	if !topLevel {
+		return status.Error(codes.InvalidArgument, "parent must be a top-level category")
+	}
+	if categoryId != "" {
+		var children bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM category_data WHERE parent_id = $1)`, categoryId).Scan(&children); err != nil {
+			return err
+		}
+		if children {
+			return status.Error(codes.InvalidArgument, "a category with sub-categories cannot have a parent")
+		}
+	}
+	return nil
+}
+
+// storeCategorySynonyms replaces the synonyms of a category and files the
+// user's expenses whose free-text category matches the name or a synonym
+// under it.
+func storeCategorySynonyms(ctx context.Context, tx *sql.Tx, userId, categoryId, name string, synonyms []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM category_synonym_data WHERE category_id = $1`, categoryId); err != nil {
+		return err
+	}
+	for _, synonym := range synonyms {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO category_synonym_data (category_id, synonym) VALUES ($1, $2)`, categoryId, synonym); err != nil {
+			return err
+		}
+	}
+	query := `
+		UPDATE expense_data SET category_id = $1, category = $2
+		WHERE uuid = $3 AND (category_id = $1 OR lower(category) = lower($2) OR lower(category) = ANY($4))`
+	_, err := tx.ExecContext(ctx, query, categoryId, name, userId, pq.Array(synonyms))
+	return err
+}
+
+// checkSystemName rejects names that belong to a system category, which
+// would otherwise shadow it for the user.
+func checkSystemName(ctx context.Context, tx *sql.Tx, name string) error {
+	var taken bool
+	err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM category_data WHERE uuid IS NULL AND lower(name) = lower($1::text))`, name).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return status.Error(codes.AlreadyExists, "a system category already has this name")
+	}
+	return nil
+}
+
+const categoryColumns = `c.id, c.name, coalesce(c.parent_id::text, ''), coalesce(c.icon, ''), coalesce(c.colour, ''), c.uuid IS NOT NULL,
+	coalesce((SELECT array_agg(synonym ORDER BY synonym) FROM category_synonym_data WHERE category_id = c.id), '{}')`
+
+func scanCategory(row interface{ Scan(...any) error }) (*pb.Category, error) {
+	var category pb.Category
+	err := row.Scan(&category.Id, &category.Name, &category.ParentId, &category.Icon, &category.Colour,
+		&category.Custom, pq.Array(&category.Synonyms))
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (s *categorizationServer) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + categoryColumns + ` FROM category_data c
+		LEFT JOIN category_data p ON p.id = c.parent_id
+		WHERE c.uuid IS NULL OR c.uuid = $1
+		ORDER BY lower(coalesce(p.name, c.name)), c.parent_id IS NOT NULL, lower(c.name)`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		log.Printf("Failed to list categories: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*pb.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListCategoriesResponse{Categories: categories}, nil
+}
+
+func (s *categorizationServer) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name, synonyms, err := validateCategory(req.GetCategory())
+	if err != nil {
+		return nil, err
+	}
+	c := req.GetCategory()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM category_data WHERE uuid = $1`, userId).Scan(&count); err != nil {
+		return nil, err
+	}
+	if count >= maxCategoriesPerUser {
+		return nil, status.Errorf(codes.ResourceExhausted, "at most %d custom categories are allowed", maxCategoriesPerUser)
+	}
+	if err := checkSystemName(ctx, tx, name); err != nil {
+		return nil, err
+	}
+	if err := checkCategoryParent(ctx, tx, userId, c.GetParentId(), ""); err != nil {
+		return nil, err
+	}
+
+	var categoryId string
+	query := `INSERT INTO category_data (uuid, parent_id, name, icon, colour) VALUES ($1, nullif($2, '')::uuid, $3, $4, $5) RETURNING id`
+	err = tx.QueryRowContext(ctx, query, userId, c.GetParentId(), name, nullIfEmpty(c.GetIcon()), nullIfEmpty(c.GetColour())).Scan(&categoryId)
+	if isUniqueViolation(err) {
+		return nil, status.Error(codes.AlreadyExists, "a category with this name already exists")
+	}
+	if err != nil {
+		log.Printf("Failed to create category: %v", err)
+		return nil, err
+	}
+	if err := storeCategorySynonyms(ctx, tx, userId, categoryId, name, synonyms); err != nil {
+		log.Printf("Failed to store category synonyms: %v", err)
+		return nil, err
+	}
+
+	category, err := scanCategory(tx.QueryRowContext(ctx, `SELECT `+categoryColumns+` FROM category_data c WHERE c.id = $1`, categoryId))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateCategoryResponse{Category: category}, nil
+}
+
+func (s *categorizationServer) UpdateCategory(ctx context.Context, req *pb.UpdateCategoryRequest) (*pb.UpdateCategoryResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name, synonyms, err := validateCategory(req.GetCategory())
+	if err != nil {
+		return nil, err
+	}
+	c := req.GetCategory()
+	if _, err := uuid.Parse(c.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid category id")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := checkSystemName(ctx, tx, name); err != nil {
+		return nil, err
+	}
+	if err := checkCategoryParent(ctx, tx, userId, c.GetParentId(), c.GetId()); err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE category_data SET parent_id = nullif($1, '')::uuid, name = $2, icon = $3, colour = $4
+		WHERE id = $5 AND uuid = $6`
+	res, err := tx.ExecContext(ctx, query, c.GetParentId(), name, nullIfEmpty(c.GetIcon()), nullIfEmpty(c.GetColour()), c.GetId(), userId)
+	if isUniqueViolation(err) {
+		return nil, status.Error(codes.AlreadyExists, "a category with this name already exists")
+	}
+	if err != nil {
+		log.Printf("Failed to update category: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "custom category not found")
+	}
+	if err := storeCategorySynonyms(ctx, tx, userId, c.GetId(), name, synonyms); err != nil {
+		log.Printf("Failed to store category synonyms: %v", err)
+		return nil, err
+	}
+
+	category, err := scanCategory(tx.QueryRowContext(ctx, `SELECT `+categoryColumns+` FROM category_data c WHERE c.id = $1`, c.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateCategoryResponse{Category: category}, nil
+}
+
+func (s *categorizationServer) DeleteCategory(ctx context.Context, req *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid category id")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Sub-categories go with their parent.
+	res, err := tx.ExecContext(ctx, `DELETE FROM category_data WHERE id = $1 AND uuid = $2`, req.GetId(), userId)
+	if err != nil {
+		log.Printf("Failed to delete category: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "custom category not found")
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, category FROM expense_data WHERE uuid = $1 AND category_id IS NULL`, userId)
+	if err != nil {
+		return nil, err
+	}
+	orphans := map[string]string{}
+	for rows.Next() {
+		var expenseId, category string
+		if err := rows.Scan(&expenseId, &category); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		orphans[expenseId] = category
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for expenseId, text := range orphans {
+		categoryId, category, err := resolveCategory(ctx, tx, userId, text)
+		if err != nil {
+			log.Printf("Failed to resolve category: %v", err)
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE expense_data SET category_id = $1, category = $2 WHERE id = $3`, categoryId, category, expenseId); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteCategoryResponse{Message: "Category deleted"}, nil
+}
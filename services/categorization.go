@@ -0,0 +1,504 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	defaultRulePriority = 100
+	maxRulesPerUser     = 200
+	maxTagsPerExpense   = 20
+	maxTagLength        = 50
+)
+
+// ruleOrderKey sorts rules in the order loadRules runs them, by priority and
+// then creation time. Both go into one key, the priority shifted to be
+// non-negative and zero-padded followed by the time in microseconds, which
+// sorts the same way byte by byte.
+const ruleOrderKey = `((to_char(priority::bigint + 2147483648, 'FM0000000000') ||
+	to_char(created_at AT TIME ZONE 'UTC', 'YYYYMMDDHH24MISSUS')) COLLATE "C")`
+
+var ruleOrders = map[string]pageOrder{
+	"priority asc": {column: ruleOrderKey, cast: "text", id: "id", idCast: "uuid"},
+}
+
+type categorizationServer struct {
+	pb.UnimplementedCategorizationServiceServer
+	db *sql.DB
+}
+
+// categorizationRule is a rule compiled for matching.
+type categorizationRule struct {
+	merchantContains string
+	merchantRegex    *regexp.Regexp
+	minAmount        sql.NullFloat64
+	maxAmount        sql.NullFloat64
+	paymentMethod    string
+	category         string
+	tags             []string
+}
+
+func (r *categorizationRule) matches(place string, amount float64, paymentMethod string) bool {
+	if r.merchantContains != "" && !strings.Contains(strings.ToLower(place), strings.ToLower(r.merchantContains)) {
+		return false
+	}
+	if r.merchantRegex != nil && !r.merchantRegex.MatchString(place) {
+		return false
+	}
+	if r.minAmount.Valid && amount < r.minAmount.Float64 {
+		return false
+	}
+	if r.maxAmount.Valid && amount > r.maxAmount.Float64 {
+		return false
+	}
+	if r.paymentMethod != "" && !strings.EqualFold(r.paymentMethod, strings.TrimSpace(paymentMethod)) {
+		return false
+	}
+	return true
+}
+
+// loadRules returns the user's enabled rules in the order they run.
+func loadRules(ctx context.Context, db *sql.DB, userId string) ([]categorizationRule, error) {
+	query := `
+		SELECT id, coalesce(merchant_contains, ''), coalesce(merchant_regex, ''), min_amount, max_amount,
+			coalesce(payment_method, ''), coalesce(category, ''), tags
+		FROM categorization_rule_data WHERE uuid = $1 AND enabled
+		ORDER BY priority, created_at, id`
+	rows, err := db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []categorizationRule
+	for rows.Next() {
+		var id, pattern string
+		var rule categorizationRule
+		if err := rows.Scan(&id, &rule.merchantContains, &pattern, &rule.minAmount, &rule.maxAmount,
+			&rule.paymentMethod, &rule.category, pq.Array(&rule.tags)); err != nil {
+			return nil, err
+		}
+		if pattern != "" {
+			rule.merchantRegex, err = regexp.Compile("(?i)" + pattern)
+			if err != nil {
+				log.Printf("Skipping categorization rule %s with invalid regex: %v", id, err)
+				continue
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// categorize runs the rules over an expense. It returns the category of the
+// first matching rule that sets one, or "", and the tags of all matching
+// rules.
+func categorize(rules []categorizationRule, place string, amount float64, paymentMethod string) (string, []string) {
+	var category string
+	var tags []string
+	for i := range rules {
+		if !rules[i].matches(place, amount, paymentMethod) {
+			continue
+		}
+		if category == "" {
+			category = rules[i].category
+		}
+		tags = append(tags, rules[i].tags...)
+	}
+	return category, normalizeTags(tags)
+}
+
+// normalizeTags lower-cases, trims and de-duplicates tags.
+func normalizeTags(tags []string) []string {
+	seen := map[string]bool{}
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+func validateTags(tags []string) error {
+	if len(tags) > maxTagsPerExpense {
+		return status.Errorf(codes.InvalidArgument, "at most %d tags are allowed", maxTagsPerExpense)
+	}
+	for _, tag := range tags {
+		if len(tag) > maxTagLength {
+			return status.Errorf(codes.InvalidArgument, "tags must be at most %d characters", maxTagLength)
+		}
+	}
+	return nil
+}
+
+// addExpenseTags attaches tags to an expense, keeping the ones it has.
+func addExpenseTags(ctx context.Context, tx *sql.Tx, expenseId string, tags []string) error {
+	query := `INSERT INTO expense_tag_data (expense_id, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, query, expenseId, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateExpenseTags adds and removes tags on an expense, keeping it within
+// the tag limit.
+func updateExpenseTags(ctx context.Context, tx *sql.Tx, expenseId string, add, remove []string) error {
+	add = normalizeTags(add)
+	if err := validateTags(add); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM expense_tag_data WHERE expense_id = $1 AND tag = ANY($2)`, expenseId, pq.Array(normalizeTags(remove))); err != nil {
+		return err
+	}
+	if err := addExpenseTags(ctx, tx, expenseId, add); err != nil {
+		return err
+	}
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM expense_tag_data WHERE expense_id = $1`, expenseId).Scan(&count); err != nil {
+		return err
+	}
+	if count > maxTagsPerExpense {
+		return status.Errorf(codes.InvalidArgument, "at most %d tags are allowed", maxTagsPerExpense)
+	}
+	return nil
+}
+
+// validateRule checks a rule from a request and returns its normalized tags.
+func validateRule(rule *pb.CategorizationRule) ([]string, error) {
+	if rule == nil {
+		return nil, status.Error(codes.InvalidArgument, "rule is required")
+	}
+	name := strings.TrimSpace(rule.GetName())
+	if name == "" || len(name) > 100 {
+		return nil, status.Error(codes.InvalidArgument, "name must be between 1 and 100 characters")
+	}
+	if rule.GetMerchantRegex() != "" {
+		if _, err := regexp.Compile("(?i)" + rule.GetMerchantRegex()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid merchant regex: %v", err)
+		}
+	}
+	if len(strings.TrimSpace(rule.GetCategory())) > 50 {
+		return nil, status.Error(codes.InvalidArgument, "category must be at most 50 characters")
+	}
+	if rule.MinAmount != nil && rule.MaxAmount != nil && rule.GetMinAmount() > rule.GetMaxAmount() {
+		return nil, status.Error(codes.InvalidArgument, "min amount must not exceed max amount")
+	}
+	tags := normalizeTags(rule.GetTags())
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(rule.GetCategory()) == "" && len(tags) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a rule must set a category or tags")
+	}
+	if rule.GetMerchantContains() == "" && rule.GetMerchantRegex() == "" && rule.MinAmount == nil &&
+		rule.MaxAmount == nil && rule.GetPaymentMethod() == "" {
+		return nil, status.Error(codes.InvalidArgument, "a rule needs at least one condition")
+	}
+	return tags, nil
+}
+
+// nullIfEmpty stores empty optional text columns as NULL.
+func nullIfEmpty(value string) any {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil
+	}
+	return value
+}
+
+const ruleColumns = `id, name, priority, coalesce(merchant_contains, ''), coalesce(merchant_regex, ''), min_amount, max_amount,
+	coalesce(payment_method, ''), coalesce(category, ''), tags, enabled, created_at`
+
+func scanRule(row interface{ Scan(...any) error }) (*pb.CategorizationRule, error) {
+	var rule pb.CategorizationRule
+	var minAmount, maxAmount sql.NullFloat64
+	var enabled bool
+	var createdAt time.Time
+	err := row.Scan(&rule.Id, &rule.Name, &rule.Priority, &rule.MerchantContains, &rule.MerchantRegex, &minAmount, &maxAmount,
+		&rule.PaymentMethod, &rule.Category, pq.Array(&rule.Tags), &enabled, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	rule.Enabled = &enabled
+	if minAmount.Valid {
+		rule.MinAmount = &minAmount.Float64
+	}
+	if maxAmount.Valid {
+		rule.MaxAmount = &maxAmount.Float64
+	}
+	rule.CreatedAt = createdAt.Format(time.RFC3339)
+	return &rule, nil
+}
+
+func (s *categorizationServer) CreateRule(ctx context.Context, req *pb.CreateRuleRequest) (*pb.CreateRuleResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := validateRule(req.GetRule())
+	if err != nil {
+		return nil, err
+	}
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM categorization_rule_data WHERE uuid = $1`, userId).Scan(&count); err != nil {
+		return nil, err
+	}
+	if count >= maxRulesPerUser {
+		return nil, status.Errorf(codes.ResourceExhausted, "at most %d rules are allowed", maxRulesPerUser)
+	}
+
+	r := req.GetRule()
+	priority := r.GetPriority()
+	if priority == 0 {
+		priority = defaultRulePriority
+	}
+	query := `
+		INSERT INTO categorization_rule_data
+			(uuid, name, priority, merchant_contains, merchant_regex, min_amount, max_amount, payment_method, category, tags, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, true)
+		RETURNING ` + ruleColumns
+	rule, err := scanRule(s.db.QueryRowContext(ctx, query, userId, strings.TrimSpace(r.GetName()), priority,
+		nullIfEmpty(r.GetMerchantContains()), nullIfEmpty(r.GetMerchantRegex()), r.MinAmount, r.MaxAmount,
+		nullIfEmpty(r.GetPaymentMethod()), nullIfEmpty(r.GetCategory()), pq.Array(tags)))
+	if err != nil {
+		log.Printf("Failed to create categorization rule: %v", err)
+		return nil, err
+	}
+
+	return &pb.CreateRuleResponse{Rule: rule}, nil
+}
+
+func (s *categorizationServer) ListRules(ctx context.Context, req *pb.ListRulesRequest) (*pb.ListRulesResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := newPage(req, ruleOrders, "priority asc", "", maxRulesPerUser, maxRulesPerUser)
+	if err != nil {
+		return nil, err
+	}
+	var total int32
+	if req.GetIncludeTotalCount() {
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM categorization_rule_data WHERE uuid = $1`, userId).Scan(&total); err != nil {
+			log.Printf("Failed to count categorization rules: %v", err)
+			return nil, err
+		}
+	}
+
+	after, args := page.condition([]any{userId})
+	query := `SELECT ` + ruleColumns + `, ` + page.keyColumns() + ` FROM categorization_rule_data WHERE uuid = $1 AND ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Failed to list categorization rules: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*pb.CategorizationRule
+	for rows.Next() {
+		rule, err := scanRule(withKey{rows, page})
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	next, n := page.nextToken()
+	return &pb.ListRulesResponse{Rules: rules[:n], NextPageToken: next, TotalCount: total}, nil
+}
+
+func (s *categorizationServer) UpdateRule(ctx context.Context, req *pb.UpdateRuleRequest) (*pb.UpdateRuleResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := validateRule(req.GetRule())
+	if err != nil {
+		return nil, err
+	}
+	r := req.GetRule()
+	if _, err := uuid.Parse(r.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid rule id")
+	}
+	priority := r.GetPriority()
+	if priority == 0 {
+		priority = defaultRulePriority
+	}
+
+	query := `
+		UPDATE categorization_rule_data SET name = $1, priority = $2, merchant_contains = $3, merchant_regex = $4,
+			min_amount = $5, max_amount = $6, payment_method = $7, category = $8, tags = $9, enabled = coalesce($10, enabled),
+			updated_at = current_timestamp
+		WHERE id = $11 AND uuid = $12
+		RETURNING ` + ruleColumns
+	rule, err := scanRule(s.db.QueryRowContext(ctx, query, strings.TrimSpace(r.GetName()), priority,
+		nullIfEmpty(r.GetMerchantContains()), nullIfEmpty(r.GetMerchantRegex()), r.MinAmount, r.MaxAmount,
+		nullIfEmpty(r.GetPaymentMethod()), nullIfEmpty(r.GetCategory()), pq.Array(tags), r.Enabled, r.GetId(), userId))
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "rule not found")
+	}
+	if err != nil {
+		log.Printf("Failed to update categorization rule: %v", err)
+		return nil, err
+	}
+
+	return &pb.UpdateRuleResponse{Rule: rule}, nil
+}
+
+func (s *categorizationServer) DeleteRule(ctx context.Context, req *pb.DeleteRuleRequest) (*pb.DeleteRuleResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid rule id")
+	}
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM categorization_rule_data WHERE id = $1 AND uuid = $2`, req.GetId(), userId)
+	if err != nil {
+		log.Printf("Failed to delete categorization rule: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "rule not found")
+	}
+
+	return &pb.DeleteRuleResponse{Message: "Rule deleted"}, nil
+}
+
+func (s *categorizationServer) ApplyRulesToHistory(ctx context.Context, req *pb.ApplyRulesToHistoryRequest) (*pb.ApplyRulesToHistoryResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var since time.Time
+	if req.GetSince() != "" {
+		if since, err = time.Parse(time.RFC3339, req.GetSince()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "since must be an RFC 3339 timestamp")
+		}
+	}
+
+	rules, err := loadRules(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Failed to load categorization rules: %v", err)
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Expenses claimed on a report that is under review or decided stay as
+	// the approver saw them.
+	query := `
+		SELECT e.id, e.place, e.amount, e.mode_of_payment, e.category, coalesce(e.category_source, '') FROM expense_data e
+		WHERE e.uuid = $1 AND e.date_and_time >= $2
+			AND NOT EXISTS (
+				SELECT 1 FROM expense_report_line_data l
+				JOIN expense_report_data r ON r.id = l.report_id
+				WHERE l.expense_id = e.id AND r.status NOT IN ($3, $4))
+		FOR UPDATE OF e`
+	rows, err := tx.QueryContext(ctx, query, userId, since, reportStatusDraft, reportStatusRejected)
+	if err != nil {
+		log.Printf("Failed to load expenses: %v", err)
+		return nil, err
+	}
+	type change struct {
+		id       string
+		current  string
+		category string
+		tags     []string
+	}
+	var changes []change
+	var scanned int32
+	for rows.Next() {
+		var id, place, paymentMethod, current, source string
+		var amount float64
+		if err := rows.Scan(&id, &place, &amount, &paymentMethod, &current, &source); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		scanned++
+		// A category the user picked by hand is what the classifier learns
+		// from; rules never override it.
+		if source == categorySourceUser {
+			continue
+		}
+		category, tags := categorize(rules, place, amount, paymentMethod)
+		if category == current {
+			category = ""
+		}
+		if category != "" || len(tags) > 0 {
+			changes = append(changes, change{id, current, category, tags})
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var updated int32
+	for _, c := range changes {
+		changed := false
+		if c.category != "" {
+			categoryId, category, err := resolveCategory(ctx, tx, userId, c.category)
+			if err != nil {
+				return nil, err
+			}
+			if category != c.current {
+				query := `UPDATE expense_data SET category = $1, category_id = $2, category_source = $3, category_confidence = NULL WHERE id = $4`
+				if _, err := tx.ExecContext(ctx, query, category, categoryId, categorySourceRule, c.id); err != nil {
+					return nil, err
+				}
+				changed = true
+			}
+		}
+		for _, tag := range c.tags {
+			res, err := tx.ExecContext(ctx, `INSERT INTO expense_tag_data (expense_id, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING`, c.id, tag)
+			if err != nil {
+				return nil, err
+			}
+			if n, _ := res.RowsAffected(); n > 0 {
+				changed = true
+			}
+		}
+		if changed {
+			updated++
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Re-applied %d categorization rules for user %s: %d of %d expenses updated", len(rules), userId, updated, scanned)
+	return &pb.ApplyRulesToHistoryResponse{
+		Scanned: scanned,
+		Updated: updated,
+	}, nil
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	// classifierVersion is bumped whenever the features change; stored
+	// models of another version are rebuilt from the corrections.
+	classifierVersion = 1
+
+	// The classifier only overrides the extracted category once it has
+	// learned from enough corrections and is confident about the result.
+	minClassifierExamples    = 5
+	minClassifierConfidence  = 0.6
+	maxClassifierPredictions = 5
+)
+
+// Values of expense_data.category_source.
+const (
+	categorySourceGenAI      = "genai"
+	categorySourceClassifier = "classifier"
+	categorySourceMerchant   = "merchant"
+	categorySourceRule       = "rule"
+	categorySourceUser       = "user"
+)
+
+// categoryModel is a multinomial naive Bayes classifier over the features of
+// an expense. It is updated one correction at a time.
+type categoryModel struct {
+	Examples   int                       `json:"examples"`
+	Classes    map[string]int            `json:"classes"`
+	Counts     map[string]map[string]int `json:"counts"`
+	Totals     map[string]int            `json:"totals"`
+	Vocabulary map[string]int            `json:"vocabulary"`
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+type categoryPrediction struct {
+	category   string
+	confidence float64
+}
+
+func newCategoryModel() *categoryModel {
+	return &categoryModel{
+		Classes:    map[string]int{},
+		Counts:     map[string]map[string]int{},
+		Totals:     map[string]int{},
+		Vocabulary: map[string]int{},
+	}
+}
+
+// expenseFeatures turns an expense into the tokens the classifier counts:
+// the merchant and its words, an amount bucket, the time of day, weekday or
+// weekend and the payment method.
+func expenseFeatures(place string, amount float64, paymentMethod string, date time.Time) []string {
+	var features []string
+	if merchant := normalizeItemName(place); merchant != "" {
+		features = append(features, "merchant:"+merchant)
+		for _, word := range strings.Fields(merchant) {
+			features = append(features, "word:"+word)
+		}
+	}
+	features = append(features, "amount:"+strconv.Itoa(int(math.Log2(math.Abs(amount)+1))))
+	if !date.IsZero() {
+		switch hour := date.Hour(); {
+		case hour < 6:
+			features = append(features, "time:night")
+		case hour < 12:
+			features = append(features, "time:morning")
+		case hour < 18:
+			features = append(features, "time:afternoon")
+		default:
+			features = append(features, "time:evening")
+		}
+		if day := date.Weekday(); day == time.Saturday || day == time.Sunday {
+			features = append(features, "day:weekend")
+		} else {
+			features = append(features, "day:weekday")
+		}
+	}
+	if payment := strings.ToLower(strings.TrimSpace(paymentMethod)); payment != "" {
+		features = append(features, "payment:"+payment)
+	}
+	return features
+}
+
+// learn adds an example to the model, or removes one when weight is -1.
+func (m *categoryModel) learn(features []string, category string, weight int) {
+	m.Examples += weight
+	m.Classes[category] += weight
+	if m.Classes[category] <= 0 {
+		delete(m.Classes, category)
+	}
+	counts := m.Counts[category]
+	if counts == nil {
+		counts = map[string]int{}
+		m.Counts[category] = counts
+	}
+	for _, feature := range features {
+		counts[feature] += weight
+		if counts[feature] <= 0 {
+			delete(counts, feature)
+		}
+		m.Totals[category] += weight
+		m.Vocabulary[feature] += weight
+		if m.Vocabulary[feature] <= 0 {
+			delete(m.Vocabulary, feature)
+		}
+	}
+	if m.Totals[category] <= 0 {
+		delete(m.Totals, category)
+	}
+	if len(counts) == 0 {
+		delete(m.Counts, category)
+	}
+}
+
+// predict returns the categories ranked by their posterior probability,
+// using Laplace smoothing.
+func (m *categoryModel) predict(features []string) []categoryPrediction {
+	if m.Examples <= 0 || len(m.Classes) == 0 {
+		return nil
+	}
+	vocabulary := float64(len(m.Vocabulary) + 1)
+	scores := map[string]float64{}
+	best := math.Inf(-1)
+	for category, examples := range m.Classes {
+		score := math.Log(float64(examples) / float64(m.Examples))
+		denominator := float64(m.Totals[category]) + vocabulary
+		for _, feature := range features {
+			score += math.Log((float64(m.Counts[category][feature]) + 1) / denominator)
+		}
+		scores[category] = score
+		best = max(best, score)
+	}
+
+	var sum float64
+	for _, score := range scores {
+		sum += math.Exp(score - best)
+	}
+	predictions := make([]categoryPrediction, 0, len(scores))
+	for category, score := range scores {
+		predictions = append(predictions, categoryPrediction{category, math.Exp(score-best) / sum})
+	}
+	sort.Slice(predictions, func(i, j int) bool {
+		if predictions[i].confidence != predictions[j].confidence {
+			return predictions[i].confidence > predictions[j].confidence
+		}
+		return predictions[i].category < predictions[j].category
+	})
+	return predictions
+}
+
+// loadCategoryModel returns the user's model, rebuilding it from the
+// corrections when it was stored by another classifier version. Pass a
+// transaction to lock the model for an update.
+func loadCategoryModel(ctx context.Context, db *sql.DB, tx *sql.Tx, userId string) (*categoryModel, error) {
+	var q rowQueryer = db
+	query := `SELECT version, model FROM category_model_data WHERE uuid = $1`
+	if tx != nil {
+		q = tx
+		query += ` FOR UPDATE`
+	}
+	var version int
+	var stored []byte
+	err := q.QueryRowContext(ctx, query, userId).Scan(&version, &stored)
+	if err == sql.ErrNoRows {
+		return newCategoryModel(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if version == classifierVersion {
+		m := newCategoryModel()
+		if err := json.Unmarshal(stored, m); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	if tx == nil {
+		// Rebuilt models are only stored under the lock of an update.
+		return trainCategoryModel(ctx, db, userId)
+	}
+	m, err := trainCategoryModel(ctx, tx, userId)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Rebuilt category model for user %s from %d corrections", userId, m.Examples)
+	return m, saveCategoryModel(ctx, tx, userId, m)
+}
+
+// trainCategoryModel builds a model from the user's corrections, counting
+// only the latest correction of each expense.
+func trainCategoryModel(ctx context.Context, q queryer, userId string) (*categoryModel, error) {
+	query := `
+		SELECT DISTINCT ON (coalesce(expense_id, id)) place, amount, mode_of_payment, date_and_time, to_category
+		FROM category_correction_data WHERE uuid = $1
+		ORDER BY coalesce(expense_id, id), created_at DESC`
+	rows, err := q.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	m := newCategoryModel()
+	for rows.Next() {
+		var place, paymentMethod, category string
+		var amount float64
+		var date time.Time
+		if err := rows.Scan(&place, &amount, &paymentMethod, &date, &category); err != nil {
+			return nil, err
+		}
+		m.learn(expenseFeatures(place, amount, paymentMethod, date), category, 1)
+	}
+	return m, rows.Err()
+}
+
+func saveCategoryModel(ctx context.Context, tx *sql.Tx, userId string, m *categoryModel) error {
+	stored, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO category_model_data (uuid, version, model, examples) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (uuid) DO UPDATE SET version = $2, model = $3, examples = $4, updated_at = current_timestamp`
+	_, err = tx.ExecContext(ctx, query, userId, classifierVersion, stored, m.Examples)
+	return err
+}
+
+// correctedExpense is the state of an expense when its category was
+// corrected.
+type correctedExpense struct {
+	id            string
+	place         string
+	amount        float64
+	paymentMethod string
+	date          time.Time
+}
+
+// recordCorrection stores a category correction and trains the user's model
+// on it. An earlier correction of the same expense is unlearned first, so
+// that each expense counts once.
+func recordCorrection(ctx context.Context, tx *sql.Tx, userId string, expense correctedExpense, from, to string) error {
+	m, err := loadCategoryModel(ctx, nil, tx, userId)
+	if err != nil {
+		return err
+	}
+
+	var previous correctedExpense
+	var previousCategory string
+	query := `
+		SELECT place, amount, mode_of_payment, date_and_time, to_category FROM category_correction_data
+		WHERE expense_id = $1 ORDER BY created_at DESC LIMIT 1`
+	err = tx.QueryRowContext(ctx, query, expense.id).Scan(&previous.place, &previous.amount, &previous.paymentMethod,
+		&previous.date, &previousCategory)
+	if err == nil {
+		m.learn(expenseFeatures(previous.place, previous.amount, previous.paymentMethod, previous.date), previousCategory, -1)
+	} else if err != sql.ErrNoRows {
+		return err
+	}
+
+	query = `
+		INSERT INTO category_correction_data (uuid, expense_id, place, amount, mode_of_payment, date_and_time, from_category, to_category)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	if _, err := tx.ExecContext(ctx, query, userId, expense.id, expense.place, expense.amount, expense.paymentMethod,
+		expense.date, from, to); err != nil {
+		return err
+	}
+	m.learn(expenseFeatures(expense.place, expense.amount, expense.paymentMethod, expense.date), to, 1)
+	return saveCategoryModel(ctx, tx, userId, m)
+}
+
+// classifyExpense returns the category the user's model is confident about,
+// if any.
+func classifyExpense(ctx context.Context, db *sql.DB, userId, place string, amount float64, paymentMethod string, date time.Time) (categoryPrediction, bool, error) {
+	m, err := loadCategoryModel(ctx, db, nil, userId)
+	if err != nil {
+		return categoryPrediction{}, false, err
+	}
+	prediction, ok := m.classify(place, amount, paymentMethod, date)
+	return prediction, ok, nil
+}
+
+// classify returns the model's prediction when it has seen enough examples
+// and is confident.
+func (m *categoryModel) classify(place string, amount float64, paymentMethod string, date time.Time) (categoryPrediction, bool) {
+	if m.Examples < minClassifierExamples {
+		return categoryPrediction{}, false
+	}
+	predictions := m.predict(expenseFeatures(place, amount, paymentMethod, date))
+	if len(predictions) == 0 || predictions[0].confidence < minClassifierConfidence {
+		return categoryPrediction{}, false
+	}
+	return predictions[0], true
+}
+
+func (s *categorizationServer) PredictCategory(ctx context.Context, req *pb.PredictCategoryRequest) (*pb.PredictCategoryResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var date time.Time
+	if req.GetDateAndTime() != "" {
+		if date, err = time.Parse(time.RFC3339, req.GetDateAndTime()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "date_and_time must be an RFC 3339 timestamp")
+		}
+	}
+
+	m, err := loadCategoryModel(ctx, s.db, nil, userId)
+	if err != nil {
+		log.Printf("Failed to load category model: %v", err)
+		return nil, err
+	}
+	predictions := m.predict(expenseFeatures(req.GetPlace(), req.GetAmount(), req.GetModeOfPayment(), date))
+	if len(predictions) > maxClassifierPredictions {
+		predictions = predictions[:maxClassifierPredictions]
+	}
+
+	res := &pb.PredictCategoryResponse{
+		Examples: int32(m.Examples),
+		Applied:  m.Examples >= minClassifierExamples && len(predictions) > 0 && predictions[0].confidence >= minClassifierConfidence,
+	}
+	for _, prediction := range predictions {
+		res.Predictions = append(res.Predictions, &pb.CategoryPrediction{
+			Category:   prediction.category,
+			Confidence: prediction.confidence,
+		})
+	}
+	return res, nil
+}
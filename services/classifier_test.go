@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExpenseFeatures(t *testing.T) {
+	saturdayMorning := time.Date(2025, 3, 8, 9, 30, 0, 0, time.UTC)
+	mondayNight := time.Date(2025, 3, 10, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		place         string
+		amount        float64
+		paymentMethod string
+		date          time.Time
+		want          []string
+	}{
+		{"everything", "BLUE TOKAI #12", 180, " UPI ", saturdayMorning,
+			[]string{"merchant:blue tokai", "word:blue", "word:tokai", "amount:7", "time:morning", "day:weekend", "payment:upi"}},
+		{"refund in the same bucket", "Blue Tokai", -180, "upi", mondayNight,
+			[]string{"merchant:blue tokai", "word:blue", "word:tokai", "amount:7", "time:night", "day:weekday", "payment:upi"}},
+		{"no date, merchant or payment", "1234", 0.5, "", time.Time{}, []string{"amount:0"}},
+		{"afternoon", "Pret", 1, "", time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC),
+			[]string{"merchant:pret", "word:pret", "amount:1", "time:afternoon", "day:weekday"}},
+		{"evening", "Pret", 1000, "", time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC),
+			[]string{"merchant:pret", "word:pret", "amount:9", "time:evening", "day:weekday"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expenseFeatures(tt.place, tt.amount, tt.paymentMethod, tt.date)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("features = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryModelLearn(t *testing.T) {
+	coffee := []string{"merchant:blue tokai", "word:blue", "word:tokai", "amount:7"}
+	taxi := []string{"merchant:uber", "word:uber", "amount:7"}
+
+	m := newCategoryModel()
+	m.learn(coffee, "Food", 1)
+	m.learn(coffee, "Food", 1)
+	m.learn(taxi, "Transport", 1)
+	if m.Examples != 3 || m.Classes["Food"] != 2 || m.Classes["Transport"] != 1 {
+		t.Errorf("examples = %d, classes = %v", m.Examples, m.Classes)
+	}
+	if m.Counts["Food"]["word:blue"] != 2 || m.Totals["Food"] != 8 || m.Vocabulary["amount:7"] != 3 {
+		t.Errorf("counts = %v, totals = %v, vocabulary = %v", m.Counts, m.Totals, m.Vocabulary)
+	}
+
+	// A model survives being stored and loaded.
+	stored, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := newCategoryModel()
+	if err := json.Unmarshal(stored, loaded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, m) {
+		t.Errorf("loaded model = %+v, want %+v", loaded, m)
+	}
+
+	// Unlearning every example leaves an empty model, with no zero counts
+	// left behind to widen the vocabulary.
+	m.learn(coffee, "Food", -1)
+	m.learn(taxi, "Transport", -1)
+	m.learn(coffee, "Food", -1)
+	if !reflect.DeepEqual(m, newCategoryModel()) {
+		t.Errorf("model after unlearning = %+v, want an empty model", m)
+	}
+	if predictions := m.predict(coffee); predictions != nil {
+		t.Errorf("empty model predicted %v", predictions)
+	}
+}
+
+func TestCategoryModelPredict(t *testing.T) {
+	m := newCategoryModel()
+	m.learn([]string{"a"}, "A", 1)
+	m.learn([]string{"b"}, "B", 1)
+
+	// With a vocabulary of two features plus one and Laplace smoothing, A
+	// scores 1/2 * 2/4 and B 1/2 * 1/4 for feature a.
+	tests := []struct {
+		name     string
+		features []string
+		want     []categoryPrediction
+	}{
+		{"seen feature", []string{"a"}, []categoryPrediction{{"A", 2.0 / 3}, {"B", 1.0 / 3}}},
+		{"other feature", []string{"b"}, []categoryPrediction{{"B", 2.0 / 3}, {"A", 1.0 / 3}}},
+		{"tie is ordered by name", []string{"unseen"}, []categoryPrediction{{"A", 0.5}, {"B", 0.5}}},
+		{"no features", nil, []categoryPrediction{{"A", 0.5}, {"B", 0.5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.predict(tt.features)
+			if len(got) != len(tt.want) {
+				t.Fatalf("predictions = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].category != tt.want[i].category || math.Abs(got[i].confidence-tt.want[i].confidence) > 1e-9 {
+					t.Errorf("predictions = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCategoryModelClassify(t *testing.T) {
+	at := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	m := newCategoryModel()
+	train := func(place string, amount float64, category string) {
+		m.learn(expenseFeatures(place, amount, "card", at), category, 1)
+	}
+
+	for range minClassifierExamples - 1 {
+		train("Blue Tokai", 180, "Coffee")
+	}
+	if _, ok := m.classify("Blue Tokai", 180, "card", at); ok {
+		t.Errorf("classified with %d examples, want at least %d", m.Examples, minClassifierExamples)
+	}
+
+	train("Ola Cabs", 350, "Transport")
+	prediction, ok := m.classify("Blue Tokai", 200, "card", at)
+	if !ok || prediction.category != "Coffee" || prediction.confidence < minClassifierConfidence {
+		t.Errorf("classify = %+v, %v; want a confident Coffee", prediction, ok)
+	}
+
+	// With as many examples of each, a merchant and amount the model has
+	// never seen only share the time, day and payment features with both.
+	for range 3 {
+		train("Ola Cabs", 350, "Transport")
+	}
+	if prediction, ok := m.classify("Croma", 45000, "card", at); ok {
+		t.Errorf("classify = %+v for an unseen merchant, want no prediction", prediction)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	defaultCompareLimit = 10
+	maxCompareLimit     = 50
+)
+
+// spendingPeriods returns the bounds of the current and previous periods of
+// a comparison, with the ends exclusive.
+func (s *expenseServer) spendingPeriods(ctx context.Context, req *pb.CompareSpendingRequest) ([4]time.Time, error) {
+	var bounds [4]time.Time
+	if req.GetPeriod() != "" {
+		if req.GetCurrentFrom() != "" || req.GetCurrentTo() != "" || req.GetPreviousFrom() != "" || req.GetPreviousTo() != "" {
+			return bounds, status.Error(codes.InvalidArgument, "period cannot be combined with explicit ranges")
+		}
+		userId, err := callerID(ctx)
+		if err != nil {
+			return bounds, err
+		}
+		var timeZone string
+		if err := s.db.QueryRowContext(ctx, `SELECT time_zone FROM user_data WHERE uuid = $1`, userId).Scan(&timeZone); err != nil {
+			return bounds, err
+		}
+		loc, err := time.LoadLocation(timeZone)
+		if err != nil {
+			loc = time.UTC
+		}
+		now := time.Now().In(loc)
+		switch req.GetPeriod() {
+		case "month":
+			start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+			return [4]time.Time{start, start.AddDate(0, 1, 0), start.AddDate(0, -1, 0), start}, nil
+		case "year":
+			start := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, loc)
+			return [4]time.Time{start, start.AddDate(1, 0, 0), start.AddDate(-1, 0, 0), start}, nil
+		}
+		return bounds, status.Error(codes.InvalidArgument, "period must be month or year")
+	}
+
+	values := []string{req.GetCurrentFrom(), req.GetCurrentTo(), req.GetPreviousFrom(), req.GetPreviousTo()}
+	names := []string{"current_from", "current_to", "previous_from", "previous_to"}
+	for i, value := range values {
+		t, err := parseExportTime(value, i%2 == 1)
+		if err != nil {
+			return bounds, status.Errorf(codes.InvalidArgument, "%s must be an RFC 3339 time or a YYYY-MM-DD date", names[i])
+		}
+		bounds[i] = t
+	}
+	if !bounds[1].After(bounds[0]) || !bounds[3].After(bounds[2]) {
+		return bounds, status.Error(codes.InvalidArgument, "the end of a period must not be before its start")
+	}
+	return bounds, nil
+}
+
+// percentChange returns the change from previous to current in percent,
+// or nil when previous is 0.
+func percentChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	percent := math.Round((current-previous)/previous*10000) / 100
+	return &percent
+}
+
+// compareSpendingBy totals each period by groupBy with spendingQuery and
+// returns the limit entries that changed the most.
+func (s *expenseServer) compareSpendingBy(ctx context.Context, groupBy, scope string, args []any, byParent bool, bounds [4]time.Time, limit int) ([]*pb.SpendingChange, error) {
+	var changes []*pb.SpendingChange
+	index := map[string]*pb.SpendingChange{}
+	for period := 0; period < 2; period++ {
+		n := len(args)
+		periodScope := scope + " AND date_and_time >= $" + strconv.Itoa(n+1) + " AND date_and_time < $" + strconv.Itoa(n+2)
+		periodArgs := append(append([]any{}, args...), bounds[2*period], bounds[2*period+1])
+		query, queryArgs, err := spendingQuery(groupBy, periodScope, periodArgs, byParent, "")
+		if err != nil {
+			return nil, err
+		}
+		rows, err := s.db.QueryContext(ctx, query, queryArgs...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var categoryId, name, icon, colour string
+			var spent float64
+			if err := rows.Scan(&categoryId, &name, &icon, &colour, &spent); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			key := categoryId
+			if key == "" {
+				key = strings.ToLower(name)
+			}
+			change := index[key]
+			if change == nil {
+				change = &pb.SpendingChange{Name: name, CategoryId: categoryId, Icon: icon, Colour: colour}
+				index[key] = change
+				changes = append(changes, change)
+			}
+			if period == 0 {
+				change.Current += spent
+			} else {
+				change.Previous += spent
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, change := range changes {
+		change.Current, change.Previous = roundCents(change.Current), roundCents(change.Previous)
+		change.Change = roundCents(change.Current - change.Previous)
+		change.PercentChange = percentChange(change.Current, change.Previous)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := math.Abs(changes[i].Change), math.Abs(changes[j].Change)
+		if a != b {
+			return a > b
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes[:min(len(changes), limit)], nil
+}
+
+func (s *expenseServer) CompareSpending(ctx context.Context, req *pb.CompareSpendingRequest) (*pb.CompareSpendingResponse, error) {
+	scope, owner, err := s.expenseScope(ctx, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
+	bounds, err := s.spendingPeriods(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultCompareLimit
+	}
+	limit = min(limit, maxCompareLimit)
+
+	// A period still under way is compared so far, with as much of the
+	// previous period.
+	response := &pb.CompareSpendingResponse{}
+	now := time.Now()
+	if !bounds[0].Before(now) {
+		return nil, status.Error(codes.InvalidArgument, "the current period has not started yet")
+	}
+	if bounds[1].After(now) {
+		elapsed := now.Sub(bounds[0])
+		bounds[1] = now
+		if end := bounds[2].Add(elapsed); end.Before(bounds[3]) {
+			bounds[3] = end
+		}
+		response.Partial = true
+	}
+
+	args := []any{owner}
+	if req.GetCurrency() != "" {
+		currency := strings.ToUpper(strings.TrimSpace(req.GetCurrency()))
+		if len(currency) != 3 {
+			return nil, status.Error(codes.InvalidArgument, "currency must be a three-letter code")
+		}
+		scope += " AND currency = $2"
+		args = append(args, currency)
+	}
+
+	periods := make([]*pb.SpendingPeriod, 2)
+	for i := range periods {
+		n := len(args)
+		query := `SELECT coalesce(sum(amount), 0), count(*) FROM expense_data WHERE ` + scope +
+			` AND date_and_time >= $` + strconv.Itoa(n+1) + ` AND date_and_time < $` + strconv.Itoa(n+2)
+		period := &pb.SpendingPeriod{From: bounds[2*i].Format(time.RFC3339), To: bounds[2*i+1].Format(time.RFC3339)}
+		if err := s.db.QueryRowContext(ctx, query, append(args, bounds[2*i], bounds[2*i+1])...).Scan(&period.Total, &period.ExpenseCount); err != nil {
+			log.Printf("Error totalling spending: %v", err)
+			return nil, err
+		}
+		period.Total = roundCents(period.Total)
+		periods[i] = period
+	}
+	response.Current, response.Previous = periods[0], periods[1]
+	response.Change = roundCents(response.Current.Total - response.Previous.Total)
+	response.PercentChange = percentChange(response.Current.Total, response.Previous.Total)
+
+	if response.Categories, err = s.compareSpendingBy(ctx, "category", scope, args, req.GetByParent(), bounds, limit); err != nil {
+		log.Printf("Error comparing spending by category: %v", err)
+		return nil, err
+	}
+	if response.Merchants, err = s.compareSpendingBy(ctx, "merchant", scope, args, false, bounds, limit); err != nil {
+		log.Printf("Error comparing spending by merchant: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
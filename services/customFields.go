@@ -0,0 +1,438 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// Types of a custom field, stored in custom_field_data.field_type.
+const (
+	customFieldText   = "text"
+	customFieldNumber = "number"
+	customFieldDate   = "date"
+	customFieldEnum   = "enum"
+)
+
+const (
+	maxCustomFields           = 50
+	maxCustomFieldNameLength  = 50
+	maxCustomFieldOptions     = 100
+	maxCustomFieldValueLength = 200
+	maxNotesLength            = 2000
+)
+
+// customFieldValuesColumn selects the custom fields of an expense_data row
+// as a JSON array of CustomFieldValue.
+const customFieldValuesColumn = `coalesce((
+	SELECT json_agg(json_build_object('field_id', f.id, 'name', f.name, 'type', f.field_type, 'value', v.value) ORDER BY f.name)
+	FROM expense_custom_field_data v JOIN custom_field_data f ON f.id = v.field_id
+	WHERE v.expense_id = expense_data.id), '[]')`
+
+var customFieldOrders = map[string]pageOrder{
+	"name asc":        {column: "lower(f.name)", cast: "text", id: "f.id", idCast: "uuid"},
+	"created_at asc":  {column: "f.created_at", cast: "timestamptz", id: "f.id", idCast: "uuid"},
+	"created_at desc": {column: "f.created_at", cast: "timestamptz", desc: true, id: "f.id", idCast: "uuid", idDesc: true},
+}
+
+var tagOrders = map[string]pageOrder{
+	"expense_count desc": {column: "count(*)", cast: "bigint", desc: true, id: "t.tag", idCast: "text"},
+	"tag asc":            {column: "t.tag", cast: "text", id: "t.tag", idCast: "text"},
+}
+
+type customField struct {
+	id        string
+	name      string
+	fieldType string
+	options   []string
+}
+
+// normalize checks a value against the field's type and returns it in the
+// form it is stored in.
+func (f *customField) normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch f.fieldType {
+	case customFieldNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "%s must be a number", f.name)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case customFieldDate:
+		d, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", status.Errorf(codes.InvalidArgument, "%s must be a YYYY-MM-DD date", f.name)
+		}
+		return d.Format("2006-01-02"), nil
+	case customFieldEnum:
+		for _, option := range f.options {
+			if strings.EqualFold(option, value) {
+				return option, nil
+			}
+		}
+		return "", status.Errorf(codes.InvalidArgument, "%s must be one of %s", f.name, strings.Join(f.options, ", "))
+	}
+	if len(value) > maxCustomFieldValueLength {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", f.name, maxCustomFieldValueLength)
+	}
+	return value, nil
+}
+
+func loadCustomFields(ctx context.Context, q queryer, userId string) ([]*customField, error) {
+	rows, err := q.QueryContext(ctx, `SELECT id, name, field_type, options FROM custom_field_data WHERE uuid = $1`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var fields []*customField
+	for rows.Next() {
+		var field customField
+		if err := rows.Scan(&field.id, &field.name, &field.fieldType, pq.Array(&field.options)); err != nil {
+			return nil, err
+		}
+		fields = append(fields, &field)
+	}
+	return fields, rows.Err()
+}
+
+// resolveCustomFieldValues finds the caller's fields named in values and
+// returns their normalized values by field id. Empty values are kept when
+// keepEmpty is set, to clear a field or to match any value.
+func resolveCustomFieldValues(ctx context.Context, q queryer, userId string, values []*pb.CustomFieldValue, keepEmpty bool) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	fields, err := loadCustomFields(ctx, q, userId)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[string]string, len(values))
+	for _, value := range values {
+		var field *customField
+		for _, f := range fields {
+			if (value.GetFieldId() != "" && f.id == value.GetFieldId()) ||
+				(value.GetFieldId() == "" && strings.EqualFold(f.name, strings.TrimSpace(value.GetName()))) {
+				field = f
+			}
+		}
+		if field == nil {
+			return nil, status.Errorf(codes.NotFound, "custom field %s not found", value.GetFieldId()+value.GetName())
+		}
+		if strings.TrimSpace(value.GetValue()) == "" {
+			if keepEmpty {
+				resolved[field.id] = ""
+			}
+			continue
+		}
+		normalized, err := field.normalize(value.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		resolved[field.id] = normalized
+	}
+	return resolved, nil
+}
+
+// setCustomFieldValues stores the values on an expense, clearing the
+// fields whose value is empty.
+func setCustomFieldValues(ctx context.Context, tx *sql.Tx, expenseId string, values map[string]string) error {
+	for fieldId, value := range values {
+		var err error
+		if value == "" {
+			_, err = tx.ExecContext(ctx, `DELETE FROM expense_custom_field_data WHERE expense_id = $1 AND field_id = $2`, expenseId, fieldId)
+		} else {
+			query := `
+				INSERT INTO expense_custom_field_data (expense_id, field_id, value) VALUES ($1, $2, $3)
+				ON CONFLICT (expense_id, field_id) DO UPDATE SET value = excluded.value`
+			_, err = tx.ExecContext(ctx, query, expenseId, fieldId, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCustomFieldDefinition checks a field's name and options and
+// returns them cleaned up.
+func validateCustomFieldDefinition(name, fieldType string, options []string) (string, []string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxCustomFieldNameLength {
+		return "", nil, status.Errorf(codes.InvalidArgument, "name must be between 1 and %d characters", maxCustomFieldNameLength)
+	}
+	if fieldType != customFieldEnum {
+		if len(options) > 0 {
+			return "", nil, status.Error(codes.InvalidArgument, "only enum fields have options")
+		}
+		return name, []string{}, nil
+	}
+	var cleaned []string
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" || len(option) > maxCustomFieldValueLength {
+			return "", nil, status.Errorf(codes.InvalidArgument, "options must be between 1 and %d characters", maxCustomFieldValueLength)
+		}
+		if !slices.ContainsFunc(cleaned, func(o string) bool { return strings.EqualFold(o, option) }) {
+			cleaned = append(cleaned, option)
+		}
+	}
+	if len(cleaned) == 0 || len(cleaned) > maxCustomFieldOptions {
+		return "", nil, status.Errorf(codes.InvalidArgument, "an enum field needs between 1 and %d options", maxCustomFieldOptions)
+	}
+	return name, cleaned, nil
+}
+
+func loadCustomField(ctx context.Context, q rowQueryer, userId, fieldId string) (*pb.CustomField, error) {
+	var field pb.CustomField
+	var createdAt time.Time
+	query := `
+		SELECT f.id, f.name, f.field_type, f.options,
+			(SELECT count(*) FROM expense_custom_field_data WHERE field_id = f.id), f.created_at
+		FROM custom_field_data f WHERE f.id = $1 AND f.uuid = $2`
+	err := q.QueryRowContext(ctx, query, fieldId, userId).Scan(&field.Id, &field.Name, &field.Type, pq.Array(&field.Options), &field.ExpenseCount, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "custom field not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	field.CreatedAt = createdAt.Format(time.RFC3339)
+	return &field, nil
+}
+
+func (s *expenseServer) CreateCustomField(ctx context.Context, req *pb.CreateCustomFieldRequest) (*pb.CustomFieldResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fieldType := strings.ToLower(strings.TrimSpace(req.GetType()))
+	switch fieldType {
+	case customFieldText, customFieldNumber, customFieldDate, customFieldEnum:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "type must be text, number, date or enum")
+	}
+	name, options, err := validateCustomFieldDefinition(req.GetName(), fieldType, req.GetOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM custom_field_data WHERE uuid = $1`, userId).Scan(&count); err != nil {
+		return nil, err
+	}
+	if count >= maxCustomFields {
+		return nil, status.Errorf(codes.ResourceExhausted, "at most %d custom fields are allowed", maxCustomFields)
+	}
+
+	var fieldId string
+	query := `INSERT INTO custom_field_data (uuid, name, field_type, options) VALUES ($1, $2, $3, $4) RETURNING id`
+	err = s.db.QueryRowContext(ctx, query, userId, name, fieldType, pq.Array(options)).Scan(&fieldId)
+	if isUniqueViolation(err) {
+		return nil, status.Error(codes.AlreadyExists, "a custom field with this name already exists")
+	}
+	if err != nil {
+		log.Printf("Failed to create custom field: %v", err)
+		return nil, err
+	}
+	field, err := loadCustomField(ctx, s.db, userId, fieldId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CustomFieldResponse{Field: field}, nil
+}
+
+func (s *expenseServer) UpdateCustomField(ctx context.Context, req *pb.UpdateCustomFieldRequest) (*pb.CustomFieldResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid custom field id")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	existing, err := loadCustomField(ctx, tx, userId, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	name := req.GetName()
+	if strings.TrimSpace(name) == "" {
+		name = existing.Name
+	}
+	options := req.GetOptions()
+	if existing.Type == customFieldEnum && len(options) == 0 {
+		options = existing.Options
+	}
+	name, options, err = validateCustomFieldDefinition(name, existing.Type, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.Type == customFieldEnum {
+		var inUse []string
+		query := `SELECT DISTINCT value FROM expense_custom_field_data WHERE field_id = $1 AND NOT (value = ANY($2)) ORDER BY value`
+		rows, err := tx.QueryContext(ctx, query, req.GetId(), pq.Array(options))
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var value string
+			if err := rows.Scan(&value); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			inUse = append(inUse, value)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		if len(inUse) > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "options still in use cannot be removed: %s", strings.Join(inUse, ", "))
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE custom_field_data SET name = $1, options = $2 WHERE id = $3`, name, pq.Array(options), req.GetId())
+	if isUniqueViolation(err) {
+		return nil, status.Error(codes.AlreadyExists, "a custom field with this name already exists")
+	}
+	if err != nil {
+		log.Printf("Failed to update custom field: %v", err)
+		return nil, err
+	}
+	field, err := loadCustomField(ctx, tx, userId, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.CustomFieldResponse{Field: field}, nil
+}
+
+func (s *expenseServer) DeleteCustomField(ctx context.Context, req *pb.DeleteCustomFieldRequest) (*pb.DeleteCustomFieldResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid custom field id")
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM custom_field_data WHERE id = $1 AND uuid = $2`, req.GetId(), userId)
+	if err != nil {
+		log.Printf("Failed to delete custom field: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "custom field not found")
+	}
+	return &pb.DeleteCustomFieldResponse{Message: "Custom field deleted"}, nil
+}
+
+func (s *expenseServer) ListCustomFields(ctx context.Context, req *pb.ListCustomFieldsRequest) (*pb.ListCustomFieldsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, err := newPage(req, customFieldOrders, "name asc", "", maxCustomFields, maxCustomFields)
+	if err != nil {
+		return nil, err
+	}
+	var total int32
+	if req.GetIncludeTotalCount() {
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM custom_field_data WHERE uuid = $1`, userId).Scan(&total); err != nil {
+			return nil, err
+		}
+	}
+
+	after, args := page.condition([]any{userId})
+	query := `
+		SELECT f.id, f.name, f.field_type, f.options,
+			(SELECT count(*) FROM expense_custom_field_data WHERE field_id = f.id), f.created_at, ` + page.keyColumns() + `
+		FROM custom_field_data f WHERE f.uuid = $1 AND ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Failed to list custom fields: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	var fields []*pb.CustomField
+	for rows.Next() {
+		var field pb.CustomField
+		var createdAt time.Time
+		key, id := page.scanKey()
+		if err := rows.Scan(&field.Id, &field.Name, &field.Type, pq.Array(&field.Options), &field.ExpenseCount, &createdAt, key, id); err != nil {
+			return nil, err
+		}
+		field.CreatedAt = createdAt.Format(time.RFC3339)
+		fields = append(fields, &field)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	next, n := page.nextToken()
+	return &pb.ListCustomFieldsResponse{Fields: fields[:n], NextPageToken: next, TotalCount: total}, nil
+}
+
+func (s *expenseServer) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	scope, owner, err := s.expenseScope(ctx, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
+	page, err := newPage(req, tagOrders, "expense_count desc", pageFilter(req.GetGroupId()), defaultPageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	var total int32
+	if req.GetIncludeTotalCount() {
+		query := `SELECT count(DISTINCT t.tag) FROM expense_tag_data t JOIN expense_data ON expense_data.id = t.expense_id WHERE ` + scope
+		if err := s.db.QueryRowContext(ctx, query, owner).Scan(&total); err != nil {
+			log.Printf("Failed to count tags: %v", err)
+			return nil, err
+		}
+	}
+
+	after, args := page.condition([]any{owner})
+	query := `
+		SELECT t.tag, count(*), ` + page.keyColumns() + `
+		FROM expense_tag_data t JOIN expense_data ON expense_data.id = t.expense_id
+		WHERE ` + scope + `
+		GROUP BY t.tag
+		HAVING ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Failed to list tags: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	var tags []*pb.TagCount
+	for rows.Next() {
+		var tag pb.TagCount
+		key, id := page.scanKey()
+		if err := rows.Scan(&tag.Tag, &tag.ExpenseCount, key, id); err != nil {
+			return nil, err
+		}
+		tags = append(tags, &tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	next, n := page.nextToken()
+	return &pb.ListTagsResponse{Tags: tags[:n], NextPageToken: next, TotalCount: total}, nil
+}
@@ -0,0 +1,646 @@
+package main
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+const (
+	channelEmail = "email"
+
+	defaultImapPort     = 993
+	maxEmailsPerPoll    = 200
+	mailboxBackfill     = 30 * 24 * time.Hour
+	inboundTokenBytes   = 10
+	inboundAddressLocal = "receipts"
+)
+
+// emailPrompt asks the extraction model to read an order confirmation or
+// e-receipt from the text of an email.
+const emailPrompt = `
+	You are a helpful assistant. The text below is an email. If it is an order confirmation, invoice or e-receipt for a
+		completed purchase, extract the following details and return them as a JSON object. Do not include any extra text
+		before or after the JSON.
+
+		Fields to extract:
+		- "transaction_id": The order or invoice number.
+		- "merchant_details":
+		- "name": The name of the store or service.
+		- "transaction_details":
+		- "date_and_time" : The date and time of the purchase in ISO 8601 format (e.g., "2023-10-01T12:00:00Z").
+		- "payment_method": The payment method used (e.g., "Credit Card", "UPI", "Debit Card").
+		- "total_amount": The total amount charged, as a float.
+		- "currency": The currency of the total amount (e.g., "USD", "EUR").
+		- "items": An array of objects, where each object has:
+		- "item_name": The name of the product or service.
+		- "price": The item's price as a float.
+		- "quantity": The number of units purchased.
+		- "category": A classification of the item (e.g., "Groceries", "Household", "Dining").
+		- "spending_category": A top-level classification for the entire order (e.g., "Groceries", "Dining Out", "Utilities").
+
+		If the email is not such a receipt, for example a newsletter, a shipping update or an order that was not paid,
+		return {}. Return only the JSON object.
+	`
+
+// mailboxCipher seals mailbox passwords with MAILBOX_SECRET_KEY, 32 bytes
+// in hex. Mailboxes cannot be connected without it.
+func mailboxCipher() (cipher.AEAD, error) {
+	key, err := hex.DecodeString(os.Getenv("MAILBOX_SECRET_KEY"))
+	if err != nil || len(key) != 32 {
+		return nil, status.Error(codes.FailedPrecondition, "mailbox connections are not configured on this server")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func sealMailboxPassword(password string) (string, error) {
+	aead, err := mailboxCipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nonce, nonce, []byte(password), nil)), nil
+}
+
+func openMailboxPassword(secret string) (string, error) {
+	aead, err := mailboxCipher()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("mailbox password is corrupt")
+	}
+	password, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("mailbox password cannot be opened: %w", err)
+	}
+	return string(password), nil
+}
+
+// mailboxAllowInsecure reports whether MAILBOX_ALLOW_INSECURE lets users
+// connect mailboxes without TLS or on internal addresses, such as a local
+// IMAP test server. It must stay off wherever users are not trusted, as it
+// lets them reach services on the server's own network.
+func mailboxAllowInsecure() bool {
+	allow, _ := strconv.ParseBool(os.Getenv("MAILBOX_ALLOW_INSECURE"))
+	return allow
+}
+
+// internalPrefixes are address ranges not on the public internet besides
+// those netip classifies.
+var internalPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// isInternalAddr reports whether ip is this machine or on a private or
+// link-local network.
+func isInternalAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return true
+	}
+	for _, prefix := range internalPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMailboxHost resolves host and rejects it when any of its addresses
+// is internal. The dialer checks again when connecting, as the name may
+// resolve differently by then.
+func checkMailboxHost(ctx context.Context, host string) error {
+	if mailboxAllowInsecure() {
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return status.Error(codes.InvalidArgument, "host could not be resolved")
+	}
+	for _, addr := range addrs {
+		if isInternalAddr(addr) {
+			return status.Error(codes.InvalidArgument, "host must be a public address")
+		}
+	}
+	return nil
+}
+
+// mailboxDialControl refuses connections to internal addresses unless
+// MAILBOX_ALLOW_INSECURE is set.
+func mailboxDialControl(network, address string, _ syscall.RawConn) error {
+	if mailboxAllowInsecure() {
+		return nil
+	}
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || isInternalAddr(addrPort.Addr()) {
+		return fmt.Errorf("refusing to connect to internal address %s", address)
+	}
+	return nil
+}
+
+// mailboxRow is a mailbox_data row.
+type mailboxRow struct {
+	host           string
+	port           int
+	username       string
+	passwordSecret string
+	mailbox        string
+	insecure       bool
+	uidValidity    int64
+	lastUid        int64
+	connectedAt    time.Time
+	lastPolledAt   sql.NullTime
+	lastError      sql.NullString
+}
+
+const mailboxColumns = `host, port, username, password_secret, mailbox, insecure, uid_validity, last_uid, connected_at, last_polled_at, last_error`
+
+func scanMailbox(row interface{ Scan(...any) error }) (*mailboxRow, error) {
+	var m mailboxRow
+	err := row.Scan(&m.host, &m.port, &m.username, &m.passwordSecret, &m.mailbox, &m.insecure,
+		&m.uidValidity, &m.lastUid, &m.connectedAt, &m.lastPolledAt, &m.lastError)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (m *mailboxRow) proto() *pb.Mailbox {
+	mailbox := &pb.Mailbox{
+		Host:        m.host,
+		Port:        int32(m.port),
+		Username:    m.username,
+		Mailbox:     m.mailbox,
+		Insecure:    m.insecure,
+		ConnectedAt: m.connectedAt.Format(time.RFC3339),
+		LastError:   m.lastError.String,
+	}
+	if m.lastPolledAt.Valid {
+		mailbox.LastPolledAt = m.lastPolledAt.Time.Format(time.RFC3339)
+	}
+	return mailbox
+}
+
+// openMailbox logs in to the mailbox and opens it, returning its
+// UIDVALIDITY.
+func openMailbox(ctx context.Context, m *mailboxRow, password string) (*imapClient, int64, error) {
+	client, err := dialImap(ctx, m.host, m.port, m.insecure)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := client.Login(m.username, password); err != nil {
+		client.Close()
+		return nil, 0, err
+	}
+	uidValidity, err := client.Examine(m.mailbox)
+	if err != nil {
+		client.Close()
+		return nil, 0, err
+	}
+	return client, uidValidity, nil
+}
+
+func (s *ingestionServer) ConnectMailbox(ctx context.Context, req *pb.ConnectMailboxRequest) (*pb.ConnectMailboxResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := &mailboxRow{
+		host:     strings.TrimSpace(req.GetHost()),
+		port:     int(req.GetPort()),
+		username: strings.TrimSpace(req.GetUsername()),
+		mailbox:  strings.TrimSpace(req.GetMailbox()),
+		insecure: req.GetInsecure(),
+	}
+	if m.port == 0 {
+		m.port = defaultImapPort
+	}
+	if m.mailbox == "" {
+		m.mailbox = "INBOX"
+	}
+	if m.host == "" || len(m.host) > 255 || strings.ContainsAny(m.host, " /") {
+		return nil, status.Error(codes.InvalidArgument, "host must be a host name or address")
+	}
+	if m.port < 1 || m.port > 65535 {
+		return nil, status.Error(codes.InvalidArgument, "port must be between 1 and 65535")
+	}
+	if m.username == "" || len(m.username) > 255 || req.GetPassword() == "" || len(m.mailbox) > 255 {
+		return nil, status.Error(codes.InvalidArgument, "username and password are required")
+	}
+	if m.insecure && !mailboxAllowInsecure() {
+		return nil, status.Error(codes.InvalidArgument, "mailboxes cannot be read without TLS on this server")
+	}
+	if err := checkMailboxHost(ctx, m.host); err != nil {
+		return nil, err
+	}
+	if err := requireConsent(ctx, s.db, userId, channelEmail); err != nil {
+		return nil, err
+	}
+	if m.passwordSecret, err = sealMailboxPassword(req.GetPassword()); err != nil {
+		return nil, err
+	}
+
+	// Check the credentials now rather than on the first poll.
+	client, uidValidity, err := openMailbox(ctx, m, req.GetPassword())
+	if err != nil {
+		log.Printf("Failed to open mailbox %s for user %s: %v", m.host, userId, err)
+		return nil, status.Error(codes.FailedPrecondition, "could not open the mailbox with these details")
+	}
+	client.Close()
+
+	query := `
+		INSERT INTO mailbox_data (uuid, host, port, username, password_secret, mailbox, insecure, uid_validity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (uuid) DO UPDATE SET host = EXCLUDED.host, port = EXCLUDED.port, username = EXCLUDED.username,
+			password_secret = EXCLUDED.password_secret, mailbox = EXCLUDED.mailbox, insecure = EXCLUDED.insecure,
+			uid_validity = EXCLUDED.uid_validity, last_uid = 0, connected_at = current_timestamp,
+			last_polled_at = NULL, last_error = NULL
+		RETURNING ` + mailboxColumns
+	m, err = scanMailbox(s.db.QueryRowContext(ctx, query, userId, m.host, m.port, m.username, m.passwordSecret, m.mailbox, m.insecure, uidValidity))
+	if err != nil {
+		log.Printf("Failed to store mailbox: %v", err)
+		return nil, err
+	}
+
+	log.Printf("User %s connected mailbox %s@%s", userId, m.username, m.host)
+	return &pb.ConnectMailboxResponse{Mailbox: m.proto()}, nil
+}
+
+func (s *ingestionServer) DisconnectMailbox(ctx context.Context, req *pb.DisconnectMailboxRequest) (*pb.DisconnectMailboxResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM mailbox_data WHERE uuid = $1`, userId)
+	if err != nil {
+		log.Printf("Failed to disconnect mailbox: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "no mailbox is connected")
+	}
+	log.Printf("User %s disconnected their mailbox", userId)
+	return &pb.DisconnectMailboxResponse{}, nil
+}
+
+// inboundAddress returns the address the user's receipts can be forwarded
+// to, creating its token on first use. It is empty when INBOUND_MAIL_DOMAIN
+// is not set.
+func inboundAddress(ctx context.Context, db *sql.DB, userId string) (string, error) {
+	domain := os.Getenv("INBOUND_MAIL_DOMAIN")
+	if domain == "" {
+		return "", nil
+	}
+	random := make([]byte, inboundTokenBytes)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	var token string
+	query := `
+		INSERT INTO inbound_address_data (uuid, token) VALUES ($1, $2)
+		ON CONFLICT (uuid) DO UPDATE SET token = inbound_address_data.token
+		RETURNING token`
+	if err := db.QueryRowContext(ctx, query, userId, hex.EncodeToString(random)).Scan(&token); err != nil {
+		return "", err
+	}
+	return inboundAddressLocal + "+" + token + "@" + domain, nil
+}
+
+func (s *ingestionServer) GetEmailIngestion(ctx context.Context, req *pb.GetEmailIngestionRequest) (*pb.GetEmailIngestionResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.GetEmailIngestionResponse{}
+	if res.InboundAddress, err = inboundAddress(ctx, s.db, userId); err != nil {
+		log.Printf("Failed to create inbound address: %v", err)
+		return nil, err
+	}
+
+	m, err := scanMailbox(s.db.QueryRowContext(ctx, `SELECT `+mailboxColumns+` FROM mailbox_data WHERE uuid = $1`, userId))
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("Failed to load mailbox: %v", err)
+		return nil, err
+	}
+	if m != nil {
+		res.Mailbox = m.proto()
+	}
+	return res, nil
+}
+
+func (s *ingestionServer) PollMailbox(ctx context.Context, req *pb.PollMailboxRequest) (*pb.PollMailboxResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireConsent(ctx, s.db, userId, channelEmail); err != nil {
+		return nil, err
+	}
+	return s.pollMailbox(ctx, userId)
+}
+
+// pollMailbox reads the mail that arrived since the last poll. The first
+// poll after connecting reads back mailboxBackfill from the connection time.
+// A message that should be retried stops the poll so it is read again next
+// time.
+func (s *ingestionServer) pollMailbox(ctx context.Context, userId string) (*pb.PollMailboxResponse, error) {
+	if _, busy := s.polling.LoadOrStore(userId, true); busy {
+		return nil, status.Error(codes.Aborted, "the mailbox is already being read")
+	}
+	defer s.polling.Delete(userId)
+
+	m, err := scanMailbox(s.db.QueryRowContext(ctx, `SELECT `+mailboxColumns+` FROM mailbox_data WHERE uuid = $1`, userId))
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "no mailbox is connected")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.PollMailboxResponse{}
+	lastUid, uidValidity, pollErr := s.readMailbox(ctx, userId, m, res)
+	lastError := ""
+	if pollErr != nil {
+		lastError = pollErr.Error()
+		log.Printf("Failed to poll mailbox for user %s: %v", userId, pollErr)
+	}
+	query := `UPDATE mailbox_data SET last_uid = $2, uid_validity = $3, last_polled_at = current_timestamp, last_error = nullif($4, '') WHERE uuid = $1`
+	if _, err := s.db.ExecContext(ctx, query, userId, lastUid, uidValidity, lastError); err != nil {
+		return nil, err
+	}
+	if pollErr != nil && len(res.Results) == 0 {
+		return nil, status.Error(codes.Unavailable, "the mailbox could not be read")
+	}
+
+	log.Printf("Polled mailbox for user %s: %d messages, %d expenses created", userId, len(res.Results), res.Created)
+	return res, nil
+}
+
+// searchCriteria returns the last UID already read from the mailbox, now
+// that it has uidValidity, and the UID SEARCH criteria for the messages
+// after it. UIDs mean nothing across a change of UIDVALIDITY, so the
+// mailbox is then read again from the backfill date; Message-IDs keep the
+// second read from recording anything twice.
+func (m *mailboxRow) searchCriteria(uidValidity int64) (int64, string) {
+	if uidValidity != m.uidValidity || m.lastUid == 0 {
+		return 0, "SINCE " + imapDate(m.connectedAt.Add(-mailboxBackfill))
+	}
+	return m.lastUid, fmt.Sprintf("UID %d:*", m.lastUid+1)
+}
+
+// readMailbox ingests new messages into res and returns how far it got.
+func (s *ingestionServer) readMailbox(ctx context.Context, userId string, m *mailboxRow, res *pb.PollMailboxResponse) (int64, int64, error) {
+	password, err := openMailboxPassword(m.passwordSecret)
+	if err != nil {
+		return m.lastUid, m.uidValidity, err
+	}
+	client, uidValidity, err := openMailbox(ctx, m, password)
+	if err != nil {
+		return m.lastUid, m.uidValidity, err
+	}
+	defer client.Close()
+
+	lastUid, criteria := m.searchCriteria(uidValidity)
+	uids, err := client.SearchUids(criteria)
+	if err != nil {
+		return lastUid, uidValidity, err
+	}
+
+	for _, uid := range uids {
+		// "n:*" always matches the newest message, even below n.
+		if uid <= lastUid {
+			continue
+		}
+		if len(res.Results) >= maxEmailsPerPoll {
+			break
+		}
+		raw, err := client.FetchMessage(uid)
+		if err != nil {
+			return lastUid, uidValidity, err
+		}
+		result, err := s.ingestEmail(ctx, userId, raw)
+		if err != nil {
+			return lastUid, uidValidity, err
+		}
+		result.Index = int32(len(res.Results))
+		res.Results = append(res.Results, result)
+		if result.Status == messageCreated {
+			res.Created++
+		}
+		lastUid = uid
+	}
+	return lastUid, uidValidity, nil
+}
+
+// pollMailboxes polls every connected mailbox whose owner still consents,
+// once per interval.
+func pollMailboxes(ctx context.Context, s *ingestionServer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := pollDueMailboxes(ctx, s); err != nil {
+			log.Printf("Failed to poll mailboxes: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func pollDueMailboxes(ctx context.Context, s *ingestionServer) error {
+	query := `
+		SELECT m.uuid FROM mailbox_data m
+		JOIN message_consent_data c ON c.uuid = m.uuid AND c.channel = $1 AND c.revoked_at IS NULL`
+	rows, err := s.db.QueryContext(ctx, query, channelEmail)
+	if err != nil {
+		return err
+	}
+	var userIds []string
+	for rows.Next() {
+		var userId string
+		if err := rows.Scan(&userId); err != nil {
+			rows.Close()
+			return err
+		}
+		userIds = append(userIds, userId)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, userId := range userIds {
+		if _, err := s.pollMailbox(ctx, userId); err != nil && status.Code(err) != codes.Aborted {
+			log.Printf("Failed to poll mailbox for user %s: %v", userId, err)
+		}
+	}
+	return nil
+}
+
+func mailboxPollInterval() time.Duration {
+	if v := os.Getenv("MAILBOX_POLL_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			return d
+		}
+	}
+	return 5 * time.Minute
+}
+
+// ingestEmail records the receipt in one raw message, if it has one. It
+// returns errIngestRetry, or a storage error, when the message should be
+// read again later.
+func (s *ingestionServer) ingestEmail(ctx context.Context, userId string, raw []byte) (*pb.IngestedMessage, error) {
+	result := &pb.IngestedMessage{}
+	if raw == nil {
+		result.Status = messageFailed
+		result.Reason = "the message is larger than 25 MB"
+		return result, nil
+	}
+	email, err := parseEmail(raw)
+	if err != nil {
+		result.Status = messageFailed
+		result.Reason = "the message could not be read"
+		return result, nil
+	}
+	result.MessageId = email.messageId
+
+	key := email.messageId
+	if key == "" || len(key) > 255 {
+		key = messageKey(email.from, email.date.UTC().Format(time.RFC3339), email.subject, string(raw))
+	}
+	seen, err := alreadyIngested(ctx, s.db, userId, channelEmail, key)
+	if err != nil {
+		return nil, err
+	}
+	if seen {
+		result.Status = messageDuplicate
+		return result, nil
+	}
+
+	expense, parser, document, err := s.readEmail(ctx, email)
+	if err != nil {
+		log.Printf("Error extracting email with the model: %v", err)
+		return nil, errIngestRetry
+	}
+	result.Parser = parser
+	result.Status = messageNotTransaction
+	var record *models.Transaction
+	if parser != "" {
+		expense.UUID = userId
+		expense.Source = expenseSourceEmail
+		if expense.TransactionDetails.DateTime.IsZero() {
+			expense.TransactionDetails.DateTime = email.date
+		}
+		if expense.TransactionDetails.DateTime.IsZero() {
+			expense.TransactionDetails.DateTime = time.Now()
+		}
+		if expense.TransactionDetails.Currency == "" {
+			if err := s.db.QueryRowContext(ctx, `SELECT default_currency FROM user_data WHERE uuid = $1`, userId).Scan(&expense.TransactionDetails.Currency); err != nil {
+				return nil, err
+			}
+		}
+		expense = s.expenses.categorizeTransaction(ctx, expense)
+		record = &expense
+		result.Status = messageCreated
+	}
+
+	// The IMAP poller and the LMTP listener can see the same message at
+	// once; the claim lets only one of them record it.
+	expenseId, recorded, err := s.recordIngested(ctx, userId, channelEmail, key, result.Status, record)
+	if err != nil {
+		log.Printf("Failed to record email: %v", err)
+		return nil, err
+	}
+	if !recorded {
+		result.Status = messageDuplicate
+		return result, nil
+	}
+	result.ExpenseId = expenseId
+	if document != nil && expenseId != "" {
+		if err := s.expenses.saveReceipt(ctx, userId, expenseId, document); err != nil {
+			log.Printf("Error storing emailed receipt: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// readEmail hands the message's PDF attachments, then its body, to the
+// extraction model until one of them reads as a receipt. It returns the
+// part that was read, and the PDF itself so it can be kept as the receipt.
+// parser is empty when the message holds no receipt.
+func (s *ingestionServer) readEmail(ctx context.Context, email *emailMessage) (models.Transaction, string, []byte, error) {
+	for _, pdf := range email.pdfs {
+		answer, err := s.expenses.extractor.Extract(ctx, pdf, "application/pdf", receiptPrompt)
+		if err != nil {
+			return models.Transaction{}, "", nil, err
+		}
+		if expense, ok := emailTransaction(answer); ok {
+			return expense, "pdf", pdf, nil
+		}
+	}
+
+	if email.text == "" || !receiptEmailPattern.MatchString(email.subject+"\n"+email.text) {
+		return models.Transaction{}, "", nil, nil
+	}
+	text := "Subject: " + email.subject + "\nFrom: " + email.from + "\n\n" + email.text
+	answer, err := s.expenses.extractor.Extract(ctx, []byte(text), "text/plain", emailPrompt)
+	if err != nil {
+		return models.Transaction{}, "", nil, err
+	}
+	if expense, ok := emailTransaction(answer); ok {
+		parser := "text"
+		if email.html {
+			parser = "html"
+		}
+		return expense, parser, nil, nil
+	}
+	return models.Transaction{}, "", nil, nil
+}
+
+// emailTransaction reads a model answer, rejecting answers that are not a
+// purchase.
+func emailTransaction(answer string) (models.Transaction, bool) {
+	expense, err := parseTransaction(answer)
+	if err != nil || expense.TransactionDetails.TotalAmount <= 0 || strings.TrimSpace(expense.MerchantDetails.Name) == "" {
+		return models.Transaction{}, false
+	}
+	expense.TransactionDetails.Currency = strings.ToUpper(strings.TrimSpace(expense.TransactionDetails.Currency))
+	if len(expense.TransactionDetails.Currency) != 3 {
+		expense.TransactionDetails.Currency = ""
+	}
+	return expense, true
+}
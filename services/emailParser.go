@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+const (
+	maxEmailSize       = 25 << 20
+	maxEmailPdfs       = 3
+	maxEmailTextLength = 20000
+	maxMimeDepth       = 10
+)
+
+// emailMessage is the part of a message that receipts are read from. text is
+// the HTML body reduced to text, or the plain text body when there is no
+// HTML one.
+type emailMessage struct {
+	messageId string
+	from      string
+	subject   string
+	date      time.Time
+	text      string
+	html      bool
+	pdfs      [][]byte
+}
+
+var (
+	htmlDropPattern  = regexp.MustCompile(`(?is)<(script|style|head|title)\b.*?</(?:script|style|head|title)\s*>|<!--.*?-->`)
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(?:p|div|tr|li|h[1-6]|table|section)\s*>`)
+	htmlCellPattern  = regexp.MustCompile(`(?i)</t[dh]\s*>`)
+	htmlTagPattern   = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLinePattern = regexp.MustCompile(`\n\s*\n+`)
+	spacePattern     = regexp.MustCompile(`[ \t\x{a0}]+`)
+
+	// receiptEmailPattern decides whether a message without a PDF is worth
+	// handing to the extraction model at all.
+	receiptEmailPattern = regexp.MustCompile(`(?i)\b(?:order|receipt|invoice|payment|paid|purchase|booking|bill|charged|total)\b`)
+)
+
+// parseEmail reads the headers, body and PDF attachments of a raw RFC 5322
+// message.
+func parseEmail(raw []byte) (*emailMessage, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+
+	var decoder mime.WordDecoder
+	email := &emailMessage{
+		messageId: strings.Trim(strings.TrimSpace(msg.Header.Get("Message-Id")), "<>"),
+		from:      msg.Header.Get("From"),
+	}
+	if subject, err := decoder.DecodeHeader(msg.Header.Get("Subject")); err == nil {
+		email.subject = subject
+	} else {
+		email.subject = msg.Header.Get("Subject")
+	}
+	if address, err := mail.ParseAddress(email.from); err == nil {
+		email.from = address.Address
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		email.date = date
+	}
+
+	var plain string
+	err = walkMimePart(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Header.Get("Content-Disposition"), msg.Body, 0,
+		func(mediaType string, params map[string]string, attachment bool, body []byte) {
+			switch {
+			case mediaType == "application/pdf" || (attachment && mediaType == "application/octet-stream" && strings.HasSuffix(strings.ToLower(params["name"]), ".pdf")):
+				if len(email.pdfs) < maxEmailPdfs {
+					email.pdfs = append(email.pdfs, body)
+				}
+			case attachment:
+			case mediaType == "text/html" && !email.html:
+				email.text = htmlToText(decodeCharset(body, params["charset"]))
+				email.html = true
+			case mediaType == "text/plain" && plain == "":
+				plain = strings.TrimSpace(decodeCharset(body, params["charset"]))
+			}
+		})
+	if err != nil {
+		return nil, err
+	}
+	if !email.html {
+		email.text = plain
+	}
+	if len(email.text) > maxEmailTextLength {
+		email.text = email.text[:maxEmailTextLength]
+	}
+	return email, nil
+}
+
+// walkMimePart calls visit for every leaf part of a message body, decoded
+// from its transfer encoding.
+func walkMimePart(contentType, encoding, disposition string, body io.Reader, depth int, visit func(string, map[string]string, bool, []byte)) error {
+	if depth > maxMimeDepth {
+		return fmt.Errorf("message is nested too deeply")
+	}
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", map[string]string{}
+	}
+	mediaType = strings.ToLower(mediaType)
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read message part: %w", err)
+			}
+			err = walkMimePart(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part.Header.Get("Content-Disposition"), part, depth+1, visit)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, &newlineStripper{r: body})
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	content, err := io.ReadAll(io.LimitReader(body, maxEmailSize))
+	if err != nil {
+		return fmt.Errorf("failed to decode message part: %w", err)
+	}
+
+	attachment := false
+	if disposition, dispositionParams, err := mime.ParseMediaType(disposition); err == nil {
+		attachment = strings.EqualFold(disposition, "attachment")
+		if params["name"] == "" {
+			params["name"] = dispositionParams["filename"]
+		}
+	}
+	visit(mediaType, params, attachment, content)
+	return nil
+}
+
+// newlineStripper drops line breaks, which the base64 decoder rejects.
+type newlineStripper struct {
+	r io.Reader
+}
+
+func (n *newlineStripper) Read(p []byte) (int, error) {
+	for {
+		count, err := n.r.Read(p)
+		kept := 0
+		for _, b := range p[:count] {
+			if b != '\r' && b != '\n' && b != ' ' && b != '\t' {
+				p[kept] = b
+				kept++
+			}
+		}
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+// decodeCharset converts text in a declared charset to UTF-8, returning it
+// unchanged when the charset is unknown.
+func decodeCharset(body []byte, charset string) string {
+	charset = strings.ToLower(strings.TrimSpace(charset))
+	if charset == "" || charset == "utf-8" || charset == "us-ascii" {
+		return string(body)
+	}
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return string(body)
+	}
+	decoded, err := encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		return string(body)
+	}
+	return string(decoded)
+}
+
+// htmlToText keeps the visible text of an HTML body, one block per line.
+func htmlToText(body string) string {
+	body = htmlDropPattern.ReplaceAllString(body, "")
+	body = htmlBreakPattern.ReplaceAllString(body, "\n")
+	body = htmlCellPattern.ReplaceAllString(body, " ")
+	body = htmlTagPattern.ReplaceAllString(body, "")
+	body = html.UnescapeString(body)
+	body = spacePattern.ReplaceAllString(body, " ")
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(blankLinePattern.ReplaceAllString(strings.Join(lines, "\n"), "\n"))
+}
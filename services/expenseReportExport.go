@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// reportReceipt is a stored receipt of an expense on a report.
+type reportReceipt struct {
+	id          string
+	storageKey  string
+	contentType string
+}
+
+// GetReportReceipt is a server-streaming RPC that sends a receipt of an
+// expense on the report, so that the approver can see it too.
+func (s *expenseReportsServer) GetReportReceipt(req *pb.GetReportReceiptRequest, stream pb.ExpenseReportsService_GetReportReceiptServer) error {
+	ctx := stream.Context()
+	userId, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := loadReportAccess(ctx, s.db, req.GetReportId(), userId, false); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(req.GetReceiptId()); err != nil {
+		return status.Error(codes.InvalidArgument, "invalid receipt id")
+	}
+
+	var receipt reportReceipt
+	query := `
+		SELECT r.id, r.storage_key, r.content_type
+		FROM receipt_data r JOIN expense_report_line_data l ON l.expense_id = r.expense_id
+		WHERE r.id = $1 AND l.report_id = $2`
+	err = s.db.QueryRowContext(ctx, query, req.GetReceiptId(), req.GetReportId()).Scan(&receipt.id, &receipt.storageKey, &receipt.contentType)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, "receipt not found")
+	}
+	if err != nil {
+		return err
+	}
+	data, err := s.blobs.Get(ctx, receipt.storageKey)
+	if err != nil {
+		log.Printf("Failed to read receipt %s: %v", receipt.id, err)
+		return err
+	}
+
+	out := &exportStream{
+		send:        reportFileSender(stream),
+		contentType: receipt.contentType,
+		filename:    "receipt-" + receipt.id + extensionFor(receipt.contentType),
+	}
+	for len(data) > 0 {
+		n := min(len(data), exportChunkSize)
+		if _, err := out.Write(data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return out.Close()
+}
+
+func reportFileSender(stream interface{ Send(*pb.ReportFile) error }) func([]byte, string, string) error {
+	return func(chunk []byte, contentType, filename string) error {
+		return stream.Send(&pb.ReportFile{Chunks: chunk, ContentType: contentType, Filename: filename})
+	}
+}
+
+// ExportExpenseReport is a server-streaming RPC that streams the report as
+// CSV, one row per line, or as a PDF with the receipts attached.
+func (s *expenseReportsServer) ExportExpenseReport(req *pb.ExportExpenseReportRequest, stream pb.ExpenseReportsService_ExportExpenseReportServer) error {
+	ctx := stream.Context()
+	userId, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(strings.TrimSpace(req.GetFormat()))
+	if format == "" {
+		format = exportPdf
+	}
+	if format != exportCsv && format != exportPdf {
+		return status.Error(codes.InvalidArgument, "format must be csv or pdf")
+	}
+	if _, err := loadReportAccess(ctx, s.db, req.GetReportId(), userId, false); err != nil {
+		return err
+	}
+	report, err := loadExpenseReport(ctx, s.db, req.GetReportId())
+	if err != nil {
+		return err
+	}
+
+	out := &exportStream{
+		send:        reportFileSender(stream),
+		contentType: "text/csv",
+		filename:    fmt.Sprintf("expense-report-%s.%s", report.Id[:8], format),
+	}
+	buf := bufio.NewWriterSize(out, exportChunkSize)
+	if format == exportCsv {
+		err = writeReportCsv(buf, report)
+	} else {
+		out.contentType = "application/pdf"
+		err = s.writeReportPdf(ctx, buf, report)
+	}
+	if err != nil {
+		log.Printf("Error exporting expense report %s: %v", report.Id, err)
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// lineComments groups the comments on the report's lines by line, in the
+// order they were made.
+func lineComments(report *pb.ExpenseReport) map[string][]*pb.ReportComment {
+	comments := map[string][]*pb.ReportComment{}
+	for _, comment := range report.Comments {
+		comments[comment.LineId] = append(comments[comment.LineId], comment)
+	}
+	return comments
+}
+
+func writeReportCsv(w io.Writer, report *pb.ExpenseReport) error {
+	cw := csv.NewWriter(w)
+	header := []string{"line_id", "expense_id", "date_and_time", "place", "category", "mode_of_payment", "amount", "currency", "status", "receipts", "comments"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	comments := lineComments(report)
+	for _, line := range report.Lines {
+		var notes []string
+		for _, comment := range comments[line.Id] {
+			notes = append(notes, comment.Author+": "+comment.Body)
+		}
+		record := []string{
+			line.Id,
+			line.ExpenseId,
+			line.DateAndTime,
+			spreadsheetSafe(line.Place),
+			spreadsheetSafe(line.Category),
+			spreadsheetSafe(line.ModeOfPayment),
+			strconv.FormatFloat(line.Amount, 'f', 2, 64),
+			line.Currency,
+			line.Status,
+			strconv.Itoa(len(line.ReceiptIds)),
+			spreadsheetSafe(strings.Join(notes, " | ")),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// reportDate formats an RFC 3339 time from a report for people.
+func reportDate(value string, withTime bool) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	if withTime {
+		return t.UTC().Format("2 Jan 2006 15:04 MST")
+	}
+	return t.Format("2 Jan 2006")
+}
+
+// writeReportPdf writes the report for filing: its details, the lines with
+// their decisions and comments, the totals, and then every receipt image on
+// a page of its own.
+func (s *expenseReportsServer) writeReportPdf(ctx context.Context, w io.Writer, report *pb.ExpenseReport) error {
+	p := &pdfReport{}
+	p.newPage()
+	p.y -= 20
+	p.text(pdfMargin, p.y, pdfFit(report.Title, true, 18, pdfPageWidth-2*pdfMargin), true, 18)
+	p.y -= 6
+
+	details := [][2]string{
+		{"Submitted by", report.Submitter},
+		{"Approver", report.Approver},
+		{"Status", report.Status},
+		{"Created", reportDate(report.CreatedAt, true)},
+	}
+	if report.SubmittedAt != "" {
+		details = append(details, [2]string{"Submitted", reportDate(report.SubmittedAt, true)})
+	}
+	if report.DecidedAt != "" {
+		details = append(details, [2]string{"Decided", reportDate(report.DecidedAt, true)})
+	}
+	if report.ReimbursedAt != "" {
+		details = append(details, [2]string{"Reimbursed", reportDate(report.ReimbursedAt, true)})
+	}
+	if report.ReimbursementReference != "" {
+		details = append(details, [2]string{"Reference", report.ReimbursementReference})
+	}
+	for _, detail := range details {
+		if detail[1] == "" {
+			continue
+		}
+		p.y -= 14
+		p.text(pdfMargin, p.y, detail[0], true, 9)
+		p.text(pdfMargin+90, p.y, detail[1], false, 9)
+	}
+	if report.Purpose != "" {
+		p.y -= 14
+		p.text(pdfMargin, p.y, "Purpose", true, 9)
+		for i, text := range pdfWrap(report.Purpose, false, 9, pdfPageWidth-2*pdfMargin-90) {
+			if i > 0 {
+				p.need(12)
+				p.y -= 12
+			}
+			p.text(pdfMargin+90, p.y, text, false, 9)
+		}
+	}
+
+	p.heading("Expenses")
+	columns := func(bold bool, date, place, category, amount, currency, status string) {
+		p.text(pdfMargin, p.y, date, bold, 9)
+		p.text(pdfMargin+62, p.y, pdfFit(place, bold, 9, 185), bold, 9)
+		p.text(pdfMargin+252, p.y, pdfFit(category, bold, 9, 95), bold, 9)
+		p.textRight(pdfMargin+420, p.y, amount, bold, 9)
+		p.text(pdfMargin+426, p.y, currency, bold, 9)
+		p.text(pdfMargin+455, p.y, status, bold, 9)
+	}
+	p.need(16)
+	p.y -= 16
+	columns(true, "Date", "Description", "Category", "Amount", "", "Status")
+	comments := lineComments(report)
+	for _, line := range report.Lines {
+		p.need(14)
+		p.y -= 14
+		columns(false, reportDate(line.DateAndTime, false), line.Place, line.Category, formatAmount(line.Amount), line.Currency, line.Status)
+		for _, comment := range comments[line.Id] {
+			for _, text := range pdfWrap(comment.Author+": "+comment.Body, false, 7.5, pdfPageWidth-2*pdfMargin-62) {
+				p.need(10)
+				p.y -= 10
+				fmt.Fprint(p.page, "0.35 g\n")
+				p.text(pdfMargin+62, p.y, text, false, 7.5)
+				fmt.Fprint(p.page, "0 g\n")
+			}
+		}
+	}
+
+	p.heading("Totals")
+	approved := map[string]float64{}
+	for _, amount := range report.Approved {
+		approved[amount.Currency] = amount.Amount
+	}
+	p.need(16)
+	p.y -= 16
+	p.text(pdfMargin, p.y, "Currency", true, 9)
+	p.textRight(pdfMargin+200, p.y, "Claimed", true, 9)
+	p.textRight(pdfMargin+300, p.y, "Approved", true, 9)
+	for _, claimed := range report.Claimed {
+		p.need(14)
+		p.y -= 14
+		p.text(pdfMargin, p.y, claimed.Currency, false, 9)
+		p.textRight(pdfMargin+200, p.y, formatAmount(claimed.Amount), false, 9)
+		p.textRight(pdfMargin+300, p.y, formatAmount(approved[claimed.Currency]), false, 9)
+	}
+
+	if len(comments[""]) > 0 {
+		p.heading("Comments")
+		for _, comment := range comments[""] {
+			p.need(26)
+			p.y -= 14
+			p.text(pdfMargin, p.y, comment.Author+", "+reportDate(comment.CreatedAt, true), true, 9)
+			for _, text := range pdfWrap(comment.Body, false, 9, pdfPageWidth-2*pdfMargin) {
+				p.need(12)
+				p.y -= 12
+				p.text(pdfMargin, p.y, text, false, 9)
+			}
+		}
+	}
+
+	var missing []string
+	for _, line := range report.Lines {
+		receipts, err := s.lineReceipts(ctx, line.ExpenseId)
+		if err != nil {
+			return err
+		}
+		for _, receipt := range receipts {
+			caption := fmt.Sprintf("Receipt: %s, %s, %s %s", line.Place, reportDate(line.DateAndTime, false), line.Currency, formatAmount(line.Amount))
+			img, err := s.receiptImage(ctx, receipt)
+			if err != nil {
+				missing = append(missing, fmt.Sprintf("%s (%s): %v", caption, receipt.id, err))
+				continue
+			}
+			p.newPage()
+			p.y -= 12
+			p.text(pdfMargin, p.y, pdfFit(caption, true, 11, pdfPageWidth-2*pdfMargin), true, 11)
+			p.image(img, pdfMargin, pdfMargin, pdfPageWidth-2*pdfMargin, p.y-12-pdfMargin)
+		}
+	}
+	if len(missing) > 0 {
+		p.heading("Receipts not included")
+		for _, text := range missing {
+			for _, wrapped := range pdfWrap(text, false, 8, pdfPageWidth-2*pdfMargin) {
+				p.need(11)
+				p.y -= 11
+				p.text(pdfMargin, p.y, wrapped, false, 8)
+			}
+		}
+	}
+
+	for i, page := range p.pages {
+		p.page = page
+		p.textRight(pdfPageWidth-pdfMargin, pdfMargin/2, fmt.Sprintf("Page %d of %d", i+1, len(p.pages)), false, 8)
+	}
+	return p.writeTo(w)
+}
+
+func (s *expenseReportsServer) lineReceipts(ctx context.Context, expenseId string) ([]reportReceipt, error) {
+	query := `SELECT id, storage_key, content_type FROM receipt_data WHERE expense_id = $1 ORDER BY created_at`
+	rows, err := s.db.QueryContext(ctx, query, expenseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var receipts []reportReceipt
+	for rows.Next() {
+		var receipt reportReceipt
+		if err := rows.Scan(&receipt.id, &receipt.storageKey, &receipt.contentType); err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, rows.Err()
+}
+
+// receiptImage loads a receipt for the PDF. Receipts that are not images,
+// such as PDF attachments, are left to be downloaded on their own.
+func (s *expenseReportsServer) receiptImage(ctx context.Context, receipt reportReceipt) (*pdfImage, error) {
+	if !pdfImageSupported(receipt.contentType) {
+		return nil, fmt.Errorf("%s receipts are not embedded, download it separately", receipt.contentType)
+	}
+	data, err := s.blobs.Get(ctx, receipt.storageKey)
+	if err != nil {
+		log.Printf("Failed to read receipt %s: %v", receipt.id, err)
+		return nil, fmt.Errorf("the receipt could not be read")
+	}
+	img, err := newPdfImage(data)
+	if err != nil {
+		return nil, fmt.Errorf("the image could not be decoded")
+	}
+	return img, nil
+}
@@ -0,0 +1,853 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// Statuses of an expense report, stored in expense_report_data.status.
+const (
+	reportStatusDraft      = "draft"
+	reportStatusSubmitted  = "submitted"
+	reportStatusApproved   = "approved"
+	reportStatusRejected   = "rejected"
+	reportStatusReimbursed = "reimbursed"
+)
+
+// Statuses of a report line, stored in expense_report_line_data.status.
+const (
+	lineStatusPending  = "pending"
+	lineStatusApproved = "approved"
+	lineStatusRejected = "rejected"
+)
+
+const (
+	maxReportTitleLength     = 200
+	maxReportPurposeLength   = 2000
+	maxReportCommentLength   = 2000
+	maxReportReferenceLength = 100
+	maxReportLines           = 500
+)
+
+type expenseReportsServer struct {
+	pb.UnimplementedExpenseReportsServiceServer
+	db     *sql.DB
+	blobs  BlobStore
+	mailer Mailer
+}
+
+// reportAccess is what a caller may do with a report.
+type reportAccess struct {
+	submitter string
+	approver  string
+	status    string
+}
+
+func (a *reportAccess) editable() bool {
+	return a.status == reportStatusDraft || a.status == reportStatusRejected
+}
+
+// loadReportAccess returns the report if the caller submitted it or it was
+// submitted to them. Anyone else gets NotFound so report ids cannot be
+// probed. The row is locked when q is a transaction.
+func loadReportAccess(ctx context.Context, q reportQueryer, reportId, userId string, lock bool) (*reportAccess, error) {
+	if _, err := uuid.Parse(reportId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid report id")
+	}
+	query := `SELECT uuid, coalesce(approver::text, ''), status FROM expense_report_data WHERE id = $1`
+	if lock {
+		query += ` FOR UPDATE`
+	}
+	var access reportAccess
+	err := q.QueryRowContext(ctx, query, reportId).Scan(&access.submitter, &access.approver, &access.status)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "expense report not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if access.submitter != userId && (access.approver != userId || access.status == reportStatusDraft) {
+		return nil, status.Error(codes.NotFound, "expense report not found")
+	}
+	return &access, nil
+}
+
+// checkExpenseUnclaimed returns FailedPrecondition when the expense is on a
+// report that has been submitted and not sent back, as changing it would
+// change what the approver decided on. In a transaction the report is
+// locked against being submitted until the change is committed.
+func checkExpenseUnclaimed(ctx context.Context, q rowQueryer, expenseId string) error {
+	var reportStatus string
+	query := `
+		SELECT r.status FROM expense_report_line_data l
+		JOIN expense_report_data r ON r.id = l.report_id
+		WHERE l.expense_id = $1
+		FOR SHARE OF r`
+	err := q.QueryRowContext(ctx, query, expenseId).Scan(&reportStatus)
+	if err == sql.ErrNoRows || reportStatus == reportStatusDraft || reportStatus == reportStatusRejected {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return status.Errorf(codes.FailedPrecondition, "the expense is on a %s expense report and cannot be changed", reportStatus)
+}
+
+// reportQueryer is satisfied by both *sql.DB and *sql.Tx.
+type reportQueryer interface {
+	queryer
+	rowQueryer
+}
+
+func formatNullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+var expenseReportOrders = map[string]pageOrder{
+	"created_at desc": {column: "r.created_at", cast: "timestamptz", desc: true, id: "r.id", idCast: "uuid", idDesc: true},
+	"created_at asc":  {column: "r.created_at", cast: "timestamptz", id: "r.id", idCast: "uuid"},
+	"title asc":       {column: "lower(r.title)", cast: "text", id: "r.id", idCast: "uuid"},
+}
+
+const expenseReportColumns = `
+	r.id, r.title, coalesce(r.purpose, ''), r.status, r.uuid, s.username,
+	coalesce(r.approver::text, ''), coalesce(a.username, ''),
+	(SELECT count(*) FROM expense_report_line_data WHERE report_id = r.id),
+	r.created_at, r.submitted_at, r.decided_at, r.reimbursed_at, coalesce(r.reimbursement_reference, '')`
+
+const expenseReportFrom = `
+	FROM expense_report_data r
+	JOIN user_data s ON s.uuid = r.uuid
+	LEFT JOIN user_data a ON a.uuid = r.approver`
+
+func scanExpenseReport(row interface{ Scan(...any) error }) (*pb.ExpenseReport, error) {
+	var report pb.ExpenseReport
+	var createdAt time.Time
+	var submittedAt, decidedAt, reimbursedAt sql.NullTime
+	err := row.Scan(&report.Id, &report.Title, &report.Purpose, &report.Status, &report.SubmitterId, &report.Submitter,
+		&report.ApproverId, &report.Approver, &report.LineCount,
+		&createdAt, &submittedAt, &decidedAt, &reimbursedAt, &report.ReimbursementReference)
+	if err != nil {
+		return nil, err
+	}
+	report.CreatedAt = createdAt.Format(time.RFC3339)
+	report.SubmittedAt = formatNullTime(submittedAt)
+	report.DecidedAt = formatNullTime(decidedAt)
+	report.ReimbursedAt = formatNullTime(reimbursedAt)
+	return &report, nil
+}
+
+// loadReportTotals fills in the claimed and approved totals of the reports.
+func loadReportTotals(ctx context.Context, q reportQueryer, reports []*pb.ExpenseReport) error {
+	if len(reports) == 0 {
+		return nil
+	}
+	byId := make(map[string]*pb.ExpenseReport, len(reports))
+	ids := make([]string, 0, len(reports))
+	for _, report := range reports {
+		byId[report.Id] = report
+		ids = append(ids, report.Id)
+	}
+	query := `
+		SELECT l.report_id, coalesce(l.currency, e.currency),
+			sum(coalesce(l.amount, e.amount)),
+			coalesce(sum(coalesce(l.amount, e.amount)) FILTER (WHERE l.status = $2), 0)
+		FROM expense_report_line_data l JOIN expense_data e ON e.id = l.expense_id
+		WHERE l.report_id = ANY($1::uuid[])
+		GROUP BY 1, 2
+		ORDER BY 2`
+	rows, err := q.QueryContext(ctx, query, pq.Array(ids), lineStatusApproved)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var reportId, currency string
+		var claimed, approved float64
+		if err := rows.Scan(&reportId, &currency, &claimed, &approved); err != nil {
+			return err
+		}
+		report := byId[reportId]
+		report.Claimed = append(report.Claimed, &pb.ReportAmount{Currency: currency, Amount: claimed})
+		if approved != 0 {
+			report.Approved = append(report.Approved, &pb.ReportAmount{Currency: currency, Amount: approved})
+		}
+	}
+	return rows.Err()
+}
+
+// loadExpenseReport returns the report with its lines, their receipts and
+// the comments.
+func loadExpenseReport(ctx context.Context, q reportQueryer, reportId string) (*pb.ExpenseReport, error) {
+	report, err := scanExpenseReport(q.QueryRowContext(ctx, `SELECT `+expenseReportColumns+expenseReportFrom+` WHERE r.id = $1`, reportId))
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "expense report not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := loadReportTotals(ctx, q, []*pb.ExpenseReport{report}); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT l.id, l.expense_id, e.date_and_time, e.place, coalesce(e.category, ''), coalesce(e.mode_of_payment, ''),
+			coalesce(l.amount, e.amount), coalesce(l.currency, e.currency), l.status,
+			coalesce((SELECT array_agg(id::text ORDER BY created_at) FROM receipt_data WHERE expense_id = e.id), '{}')
+		FROM expense_report_line_data l JOIN expense_data e ON e.id = l.expense_id
+		WHERE l.report_id = $1
+		ORDER BY e.date_and_time, l.id`
+	rows, err := q.QueryContext(ctx, query, reportId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var line pb.ExpenseReportLine
+		var date time.Time
+		err := rows.Scan(&line.Id, &line.ExpenseId, &date, &line.Place, &line.Category, &line.ModeOfPayment,
+			&line.Amount, &line.Currency, &line.Status, pq.Array(&line.ReceiptIds))
+		if err != nil {
+			return nil, err
+		}
+		line.DateAndTime = date.Format(time.RFC3339)
+		report.Lines = append(report.Lines, &line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT c.id, coalesce(c.line_id::text, ''), coalesce(c.author::text, ''), coalesce(u.username, ''), c.body, c.created_at
+		FROM expense_report_comment_data c LEFT JOIN user_data u ON u.uuid = c.author
+		WHERE c.report_id = $1
+		ORDER BY c.created_at, c.id`
+	rows, err = q.QueryContext(ctx, query, reportId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var comment pb.ReportComment
+		var createdAt time.Time
+		if err := rows.Scan(&comment.Id, &comment.LineId, &comment.AuthorId, &comment.Author, &comment.Body, &createdAt); err != nil {
+			return nil, err
+		}
+		comment.CreatedAt = createdAt.Format(time.RFC3339)
+		report.Comments = append(report.Comments, &comment)
+	}
+	return report, rows.Err()
+}
+
+// addReportLines claims the caller's expenses on the report. Expenses that
+// are already on it are skipped.
+func addReportLines(ctx context.Context, tx *sql.Tx, reportId, userId string, expenseIds []string) error {
+	for _, expenseId := range expenseIds {
+		if _, err := uuid.Parse(expenseId); err != nil {
+			return status.Error(codes.InvalidArgument, "invalid expense id")
+		}
+		var owner, claimedOn string
+		query := `
+			SELECT e.uuid, coalesce(l.report_id::text, '')
+			FROM expense_data e LEFT JOIN expense_report_line_data l ON l.expense_id = e.id
+			WHERE e.id = $1`
+		err := tx.QueryRowContext(ctx, query, expenseId).Scan(&owner, &claimedOn)
+		if err == sql.ErrNoRows || (err == nil && owner != userId) {
+			return status.Errorf(codes.NotFound, "expense %s not found", expenseId)
+		}
+		if err != nil {
+			return err
+		}
+		if claimedOn == reportId {
+			continue
+		}
+		if claimedOn != "" {
+			return status.Errorf(codes.FailedPrecondition, "expense %s is already on another report", expenseId)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO expense_report_line_data (report_id, expense_id) VALUES ($1, $2)`, reportId, expenseId); err != nil {
+			return err
+		}
+	}
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM expense_report_line_data WHERE report_id = $1`, reportId).Scan(&count); err != nil {
+		return err
+	}
+	if count > maxReportLines {
+		return status.Errorf(codes.InvalidArgument, "a report can hold at most %d expenses", maxReportLines)
+	}
+	return nil
+}
+
+func validateReportText(title, purpose string) error {
+	if len(title) > maxReportTitleLength {
+		return status.Errorf(codes.InvalidArgument, "title must be at most %d characters", maxReportTitleLength)
+	}
+	if len(purpose) > maxReportPurposeLength {
+		return status.Errorf(codes.InvalidArgument, "purpose must be at most %d characters", maxReportPurposeLength)
+	}
+	return nil
+}
+
+func (s *expenseReportsServer) CreateExpenseReport(ctx context.Context, req *pb.CreateExpenseReportRequest) (*pb.ExpenseReportResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(req.GetTitle())
+	purpose := strings.TrimSpace(req.GetPurpose())
+	if title == "" {
+		return nil, status.Error(codes.InvalidArgument, "title is required")
+	}
+	if err := validateReportText(title, purpose); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var reportId string
+	query := `INSERT INTO expense_report_data (uuid, title, purpose) VALUES ($1, $2, $3) RETURNING id`
+	if err := tx.QueryRowContext(ctx, query, userId, title, nullIfEmpty(purpose)).Scan(&reportId); err != nil {
+		log.Printf("Failed to create expense report: %v", err)
+		return nil, err
+	}
+	if err := addReportLines(ctx, tx, reportId, userId, req.GetExpenseIds()); err != nil {
+		return nil, err
+	}
+	report, err := loadExpenseReport(ctx, tx, reportId)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	log.Printf("User %s created expense report %s", userId, reportId)
+	return &pb.ExpenseReportResponse{Report: report}, nil
+}
+
+func (s *expenseReportsServer) UpdateExpenseReport(ctx context.Context, req *pb.UpdateExpenseReportRequest) (*pb.ExpenseReportResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(req.GetTitle())
+	purpose := strings.TrimSpace(req.GetPurpose())
+	if err := validateReportText(title, purpose); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	access, err := loadReportAccess(ctx, tx, req.GetReportId(), userId, true)
+	if err != nil {
+		return nil, err
+	}
+	if access.submitter != userId {
+		return nil, status.Error(codes.PermissionDenied, "only the submitter can change the report")
+	}
+	if !access.editable() {
+		return nil, status.Errorf(codes.FailedPrecondition, "a %s report cannot be changed", access.status)
+	}
+
+	query := `
+		UPDATE expense_report_data SET
+			title = coalesce($2, title),
+			purpose = coalesce($3, purpose),
+			updated_at = current_timestamp
+		WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, req.GetReportId(), nullIfEmpty(title), nullIfEmpty(purpose)); err != nil {
+		log.Printf("Failed to update expense report: %v", err)
+		return nil, err
+	}
+	for _, expenseId := range req.GetRemoveExpenseIds() {
+		if _, err := uuid.Parse(expenseId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid expense id")
+		}
+		query := `DELETE FROM expense_report_line_data WHERE report_id = $1 AND expense_id = $2`
+		if _, err := tx.ExecContext(ctx, query, req.GetReportId(), expenseId); err != nil {
+			return nil, err
+		}
+	}
+	if err := addReportLines(ctx, tx, req.GetReportId(), userId, req.GetAddExpenseIds()); err != nil {
+		return nil, err
+	}
+	report, err := loadExpenseReport(ctx, tx, req.GetReportId())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.ExpenseReportResponse{Report: report}, nil
+}
+
+func (s *expenseReportsServer) DeleteExpenseReport(ctx context.Context, req *pb.DeleteExpenseReportRequest) (*pb.DeleteExpenseReportResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	access, err := loadReportAccess(ctx, tx, req.GetReportId(), userId, true)
+	if err != nil {
+		return nil, err
+	}
+	if access.submitter != userId {
+		return nil, status.Error(codes.PermissionDenied, "only the submitter can delete the report")
+	}
+	if !access.editable() {
+		return nil, status.Errorf(codes.FailedPrecondition, "a %s report cannot be deleted", access.status)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM expense_report_data WHERE id = $1`, req.GetReportId()); err != nil {
+		log.Printf("Failed to delete expense report: %v", err)
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteExpenseReportResponse{Message: "Expense report deleted"}, nil
+}
+
+func (s *expenseReportsServer) ListExpenseReports(ctx context.Context, req *pb.ListExpenseReportsRequest) (*pb.ListExpenseReportsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var where string
+	switch req.GetRole() {
+	case "", "submitter":
+		where = `r.uuid = $1`
+	case "approver":
+		where = `r.approver = $1 AND r.status <> '` + reportStatusDraft + `'`
+	default:
+		return nil, status.Error(codes.InvalidArgument, `role must be "submitter" or "approver"`)
+	}
+	switch req.GetStatus() {
+	case "", reportStatusDraft, reportStatusSubmitted, reportStatusApproved, reportStatusRejected, reportStatusReimbursed:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid status")
+	}
+
+	page, err := newPage(req, expenseReportOrders, "created_at desc", pageFilter(req.GetRole(), req.GetStatus()), defaultPageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	where += ` AND ($2 = '' OR r.status = $2)`
+	var total int32
+	if req.GetIncludeTotalCount() {
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM expense_report_data r WHERE `+where, userId, req.GetStatus()).Scan(&total); err != nil {
+			log.Printf("Failed to count expense reports: %v", err)
+			return nil, err
+		}
+	}
+
+	after, args := page.condition([]any{userId, req.GetStatus()})
+	query := `SELECT ` + expenseReportColumns + `, ` + page.keyColumns() + expenseReportFrom + `
+		WHERE ` + where + ` AND ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Failed to list expense reports: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	var reports []*pb.ExpenseReport
+	for rows.Next() {
+		report, err := scanExpenseReport(withKey{rows, page})
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	next, n := page.nextToken()
+	reports = reports[:n]
+	if err := loadReportTotals(ctx, s.db, reports); err != nil {
+		return nil, err
+	}
+	return &pb.ListExpenseReportsResponse{Reports: reports, NextPageToken: next, TotalCount: total}, nil
+}
+
+func (s *expenseReportsServer) GetExpenseReport(ctx context.Context, req *pb.GetExpenseReportRequest) (*pb.ExpenseReportResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := loadReportAccess(ctx, s.db, req.GetReportId(), userId, false); err != nil {
+		return nil, err
+	}
+	report, err := loadExpenseReport(ctx, s.db, req.GetReportId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ExpenseReportResponse{Report: report}, nil
+}
+
+func (s *expenseReportsServer) SubmitExpenseReport(ctx context.Context, req *pb.SubmitExpenseReportRequest) (*pb.ExpenseReportResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	username := strings.TrimSpace(req.GetApproverUsername())
+	email := strings.TrimSpace(req.GetApproverEmail())
+	if (username == "") == (email == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of approver_username or approver_email is required")
+	}
+
+	var approver string
+	if username != "" {
+		err = s.db.QueryRowContext(ctx, `SELECT uuid FROM user_data WHERE username = $1 AND disabled_at IS NULL`, username).Scan(&approver)
+	} else {
+		err = s.db.QueryRowContext(ctx, `SELECT uuid FROM user_data WHERE lower(email) = lower($1) AND email_verified AND disabled_at IS NULL`, email).Scan(&approver)
+	}
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "approver not found")
+	}
+	if err != nil {
+		log.Printf("Failed to look up approver: %v", err)
+		return nil, err
+	}
+	if approver == userId {
+		return nil, status.Error(codes.InvalidArgument, "you cannot approve your own report")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	access, err := loadReportAccess(ctx, tx, req.GetReportId(), userId, true)
+	if err != nil {
+		return nil, err
+	}
+	if access.submitter != userId {
+		return nil, status.Error(codes.PermissionDenied, "only the submitter can submit the report")
+	}
+	if !access.editable() {
+		return nil, status.Errorf(codes.FailedPrecondition, "a %s report cannot be submitted", access.status)
+	}
+
+	// Fix the claimed amounts and start every line over, which also reopens
+	// the lines of a rejected report.
+	query := `
+		UPDATE expense_report_line_data l SET amount = e.amount, currency = e.currency, status = $2, decided_at = NULL
+		FROM expense_data e
+		WHERE e.id = l.expense_id AND l.report_id = $1`
+	res, err := tx.ExecContext(ctx, query, req.GetReportId(), lineStatusPending)
+	if err != nil {
+		log.Printf("Failed to submit expense report lines: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "add expenses to the report before submitting it")
+	}
+	query = `
+		UPDATE expense_report_data SET status = $2, approver = $3, submitted_at = current_timestamp,
+			decided_at = NULL, updated_at = current_timestamp
+		WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, req.GetReportId(), reportStatusSubmitted, approver); err != nil {
+		log.Printf("Failed to submit expense report: %v", err)
+		return nil, err
+	}
+	report, err := loadExpenseReport(ctx, tx, req.GetReportId())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf("%s submitted the expense report %q for your approval. It claims %s over %d expenses.\n\nSign in at %s to review it.",
+		report.Submitter, report.Title, formatReportAmounts(report.Claimed), report.LineCount, appBaseURL())
+	s.mailUser(ctx, approver, "Expense report awaiting your approval", body)
+	log.Printf("User %s submitted expense report %s to %s", userId, report.Id, approver)
+	return &pb.ExpenseReportResponse{Report: report}, nil
+}
+
+func (s *expenseReportsServer) ReviewExpenseReport(ctx context.Context, req *pb.ReviewExpenseReportRequest) (*pb.ExpenseReportResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lineStatus := func(decision string) (string, error) {
+		switch decision {
+		case "approve":
+			return lineStatusApproved, nil
+		case "reject":
+			return lineStatusRejected, nil
+		}
+		return "", status.Error(codes.InvalidArgument, `decision must be "approve" or "reject"`)
+	}
+	comment := strings.TrimSpace(req.GetComment())
+	if len(comment) > maxReportCommentLength {
+		return nil, status.Errorf(codes.InvalidArgument, "comments must be at most %d characters", maxReportCommentLength)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	access, err := loadReportAccess(ctx, tx, req.GetReportId(), userId, true)
+	if err != nil {
+		return nil, err
+	}
+	if access.approver != userId {
+		return nil, status.Error(codes.PermissionDenied, "only the approver can review the report")
+	}
+	if access.status != reportStatusSubmitted {
+		return nil, status.Errorf(codes.FailedPrecondition, "a %s report cannot be reviewed", access.status)
+	}
+
+	for _, decision := range req.GetDecisions() {
+		if _, err := uuid.Parse(decision.GetLineId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid line id")
+		}
+		lineStatus, err := lineStatus(decision.GetDecision())
+		if err != nil {
+			return nil, err
+		}
+		query := `UPDATE expense_report_line_data SET status = $3, decided_at = current_timestamp WHERE id = $1 AND report_id = $2`
+		res, err := tx.ExecContext(ctx, query, decision.GetLineId(), req.GetReportId(), lineStatus)
+		if err != nil {
+			log.Printf("Failed to review expense report line: %v", err)
+			return nil, err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil, status.Errorf(codes.NotFound, "line %s not found", decision.GetLineId())
+		}
+		if err := addReportComment(ctx, tx, req.GetReportId(), decision.GetLineId(), userId, decision.GetComment()); err != nil {
+			return nil, err
+		}
+	}
+	if req.GetDecision() != "" {
+		lineStatus, err := lineStatus(req.GetDecision())
+		if err != nil {
+			return nil, err
+		}
+		query := `UPDATE expense_report_line_data SET status = $3, decided_at = current_timestamp WHERE report_id = $1 AND status = $2`
+		if _, err := tx.ExecContext(ctx, query, req.GetReportId(), lineStatusPending, lineStatus); err != nil {
+			log.Printf("Failed to review expense report lines: %v", err)
+			return nil, err
+		}
+	}
+	if err := addReportComment(ctx, tx, req.GetReportId(), "", userId, comment); err != nil {
+		return nil, err
+	}
+
+	// The report is decided once no line is left pending.
+	query := `
+		UPDATE expense_report_data SET
+			status = CASE WHEN EXISTS (SELECT 1 FROM expense_report_line_data WHERE report_id = $1 AND status = $3) THEN $4 ELSE $5 END,
+			decided_at = current_timestamp, updated_at = current_timestamp
+		WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM expense_report_line_data WHERE report_id = $1 AND status = $2)`
+	res, err := tx.ExecContext(ctx, query, req.GetReportId(), lineStatusPending, lineStatusApproved, reportStatusApproved, reportStatusRejected)
+	if err != nil {
+		log.Printf("Failed to decide expense report: %v", err)
+		return nil, err
+	}
+	decided, _ := res.RowsAffected()
+	report, err := loadExpenseReport(ctx, tx, req.GetReportId())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if decided > 0 {
+		body := fmt.Sprintf("%s %s your expense report %q.", report.Approver, report.Status, report.Title)
+		if report.Status == reportStatusApproved {
+			body += fmt.Sprintf(" %s of the %s claimed was approved.", formatReportAmounts(report.Approved), formatReportAmounts(report.Claimed))
+		}
+		if comment != "" {
+			body += "\n\n" + comment
+		}
+		body += fmt.Sprintf("\n\nSign in at %s to see the details.", appBaseURL())
+		s.mailUser(ctx, report.SubmitterId, "Your expense report was "+report.Status, body)
+		log.Printf("User %s %s expense report %s", userId, report.Status, report.Id)
+	}
+	return &pb.ExpenseReportResponse{Report: report}, nil
+}
+
+// addReportComment adds a comment unless body is empty. lineId may be empty
+// for a comment on the whole report.
+func addReportComment(ctx context.Context, tx *sql.Tx, reportId, lineId, userId, body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil
+	}
+	if len(body) > maxReportCommentLength {
+		return status.Errorf(codes.InvalidArgument, "comments must be at most %d characters", maxReportCommentLength)
+	}
+	query := `INSERT INTO expense_report_comment_data (report_id, line_id, author, body) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.ExecContext(ctx, query, reportId, nullIfEmpty(lineId), userId, body); err != nil {
+		log.Printf("Failed to add expense report comment: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *expenseReportsServer) CommentOnExpenseReport(ctx context.Context, req *pb.CommentOnExpenseReportRequest) (*pb.ExpenseReportResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.GetBody()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "body is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := loadReportAccess(ctx, tx, req.GetReportId(), userId, true); err != nil {
+		return nil, err
+	}
+	if req.GetLineId() != "" {
+		if _, err := uuid.Parse(req.GetLineId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid line id")
+		}
+		var exists bool
+		query := `SELECT EXISTS (SELECT 1 FROM expense_report_line_data WHERE id = $1 AND report_id = $2)`
+		if err := tx.QueryRowContext(ctx, query, req.GetLineId(), req.GetReportId()).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, status.Error(codes.NotFound, "line not found")
+		}
+	}
+	if err := addReportComment(ctx, tx, req.GetReportId(), req.GetLineId(), userId, req.GetBody()); err != nil {
+		return nil, err
+	}
+	report, err := loadExpenseReport(ctx, tx, req.GetReportId())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.ExpenseReportResponse{Report: report}, nil
+}
+
+func (s *expenseReportsServer) MarkReportReimbursed(ctx context.Context, req *pb.MarkReportReimbursedRequest) (*pb.ExpenseReportResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reference := strings.TrimSpace(req.GetReference())
+	if len(reference) > maxReportReferenceLength {
+		return nil, status.Errorf(codes.InvalidArgument, "reference must be at most %d characters", maxReportReferenceLength)
+	}
+	reimbursedAt := time.Now()
+	if req.GetReimbursedAt() != "" {
+		if reimbursedAt, err = parseExportTime(req.GetReimbursedAt(), false); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "reimbursed_at must be an RFC 3339 time or a YYYY-MM-DD date")
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	access, err := loadReportAccess(ctx, tx, req.GetReportId(), userId, true)
+	if err != nil {
+		return nil, err
+	}
+	if access.approver != userId {
+		return nil, status.Error(codes.PermissionDenied, "only the approver can mark the report reimbursed")
+	}
+	if access.status != reportStatusApproved {
+		return nil, status.Errorf(codes.FailedPrecondition, "a %s report cannot be reimbursed", access.status)
+	}
+	query := `
+		UPDATE expense_report_data SET status = $2, reimbursed_at = $3, reimbursement_reference = $4, updated_at = current_timestamp
+		WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, req.GetReportId(), reportStatusReimbursed, reimbursedAt, nullIfEmpty(reference)); err != nil {
+		log.Printf("Failed to mark expense report reimbursed: %v", err)
+		return nil, err
+	}
+	report, err := loadExpenseReport(ctx, tx, req.GetReportId())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf("%s of your expense report %q was reimbursed.", formatReportAmounts(report.Approved), report.Title)
+	if reference != "" {
+		body += " Reference: " + reference + "."
+	}
+	s.mailUser(ctx, report.SubmitterId, "Your expense report was reimbursed", body)
+	return &pb.ExpenseReportResponse{Report: report}, nil
+}
+
+// mailUser emails a user about one of their reports. Failures are only
+// logged, as the report itself has already been updated.
+func (s *expenseReportsServer) mailUser(ctx context.Context, userId, subject, body string) {
+	var email string
+	if err := s.db.QueryRowContext(ctx, `SELECT email FROM user_data WHERE uuid = $1`, userId).Scan(&email); err != nil {
+		log.Printf("Failed to look up email for expense report notification: %v", err)
+		return
+	}
+	if err := s.mailer.Send(email, subject, body); err != nil {
+		log.Printf("Failed to send expense report notification: %v", err)
+	}
+}
+
+func appBaseURL() string {
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	return strings.TrimRight(baseURL, "/")
+}
+
+// formatReportAmounts writes totals such as "USD 12.50 and EUR 3.00".
+func formatReportAmounts(amounts []*pb.ReportAmount) string {
+	if len(amounts) == 0 {
+		return "nothing"
+	}
+	parts := make([]string, len(amounts))
+	for i, amount := range amounts {
+		parts[i] = amount.Currency + " " + formatAmount(amount.Amount)
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
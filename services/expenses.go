@@ -0,0 +1,450 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+// uncategorized is stored when neither the user nor a rule picked a category.
+const uncategorized = "Uncategorized"
+
+const expenseColumns = `id, uuid, group_id, date_and_time, place, amount, currency, category, mode_of_payment,
+	coalesce((SELECT array_agg(tag ORDER BY tag) FROM expense_tag_data WHERE expense_id = expense_data.id), '{}'),
+	coalesce(category_source, ''), coalesce(category_confidence, 0), coalesce(source, ''), coalesce(notes, ''),
+	` + customFieldValuesColumn + `, coalesce(trip_id::text, '')`
+
+func scanExpense(row interface{ Scan(...any) error }) (*pb.Expense, error) {
+	var expense pb.Expense
+	var userId, groupId sql.NullString
+	var date time.Time
+	var customFields []byte
+	if err := row.Scan(&expense.Id, &userId, &groupId, &date, &expense.Place, &expense.Amount,
+		&expense.Currency, &expense.Category, &expense.ModeOfPayment, pq.Array(&expense.Tags),
+		&expense.CategorySource, &expense.CategoryConfidence, &expense.Source, &expense.Notes, &customFields, &expense.TripId); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(customFields, &expense.CustomFields); err != nil {
+		return nil, err
+	}
+	expense.UserId = userId.String
+	expense.GroupId = groupId.String
+	expense.DateAndTime = date.Format(time.RFC3339)
+	return &expense, nil
+}
+
+// expenseScope returns the expense_data condition and its argument that
+// select the caller's own expenses or, when groupId is set, the expenses of
+// a group the caller belongs to.
+func (s *expenseServer) expenseScope(ctx context.Context, groupId string) (string, string, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if groupId == "" {
+		return "uuid = $1", userId, nil
+	}
+	if _, err := groupRole(ctx, s.db, groupId, userId); err != nil {
+		return "", "", err
+	}
+	return "group_id = $1", groupId, nil
+}
+
+func (s *expenseServer) ListExpenses(ctx context.Context, req *pb.ListExpensesRequest) (*pb.ListExpensesResponse, error) {
+	scope, owner, err := s.expenseScope(ctx, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every listed tag and custom field value has to match.
+	args := []any{owner}
+	filters := ""
+	tags := normalizeTags(req.GetTags())
+	if len(tags) > 0 {
+		args = append(args, pq.Array(tags))
+		filters += fmt.Sprintf(` AND $%d::text[] <@ ARRAY(SELECT tag FROM expense_tag_data WHERE expense_id = expense_data.id)`, len(args))
+	}
+	if req.GetTripId() != "" {
+		if _, err := uuid.Parse(req.GetTripId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid trip id")
+		}
+		args = append(args, req.GetTripId())
+		filters += fmt.Sprintf(` AND trip_id = $%d`, len(args))
+	}
+	customFields, err := resolveCustomFieldValues(ctx, s.db, userId, req.GetCustomFields(), true)
+	if err != nil {
+		return nil, err
+	}
+	for fieldId, value := range customFields {
+		args = append(args, fieldId, value)
+		filters += fmt.Sprintf(` AND EXISTS (SELECT 1 FROM expense_custom_field_data
+			WHERE expense_id = expense_data.id AND field_id = $%d AND ($%d = '' OR value = $%d))`, len(args)-1, len(args), len(args))
+	}
+
+	page, err := newPage(req, expenseOrders, "date_and_time desc", pageFilter(req.GetGroupId(), tags, customFields, req.GetTripId()), defaultPageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	var total int32
+	if req.GetIncludeTotalCount() {
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM expense_data WHERE `+scope+filters, args...).Scan(&total); err != nil {
+			log.Printf("Error counting expenses: %v", err)
+			return nil, err
+		}
+	}
+
+	after, args := page.condition(args)
+	query := `SELECT ` + expenseColumns + `, ` + page.keyColumns() + ` FROM expense_data
+		WHERE ` + scope + filters + ` AND ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Error listing expenses: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expenses []*pb.Expense
+	for rows.Next() {
+		expense, err := scanExpense(withKey{rows, page})
+		if err != nil {
+			log.Printf("Error scanning expense: %v", err)
+			return nil, err
+		}
+		expenses = append(expenses, expense)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	next, n := page.nextToken()
+	return &pb.ListExpensesResponse{Expenses: expenses[:n], NextPageToken: next, TotalCount: total}, nil
+}
+
+func (s *expenseServer) SetExpenseGroup(ctx context.Context, req *pb.SetExpenseGroupRequest) (*pb.SetExpenseGroupResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetExpenseId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid expense id")
+	}
+	if req.GetGroupId() != "" {
+		if _, err := groupRole(ctx, s.db, req.GetGroupId(), userId); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Only the member who recorded an expense can move it between groups.
+	var current sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT group_id FROM expense_data WHERE id = $1 AND uuid = $2 FOR UPDATE`, req.GetExpenseId(), userId).Scan(&current)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "expense not found")
+	}
+	if err != nil {
+		log.Printf("Error loading expense: %v", err)
+		return nil, err
+	}
+	if err := checkExpenseUnclaimed(ctx, tx, req.GetExpenseId()); err != nil {
+		return nil, err
+	}
+
+	// Splits and trips only make sense within the group they were made in.
+	if current.String != req.GetGroupId() {
+		query := `
+			UPDATE expense_data SET group_id = nullif($1, '')::uuid, paid_by = NULL, split_method = NULL,
+				trip_id = NULL, trip_manual = false
+			WHERE id = $2`
+		if _, err := tx.ExecContext(ctx, query, req.GetGroupId(), req.GetExpenseId()); err != nil {
+			log.Printf("Error setting expense group: %v", err)
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM expense_split_data WHERE expense_id = $1`, req.GetExpenseId()); err != nil {
+			return nil, err
+		}
+		if err := assignTrips(ctx, tx, "e.id = $1", req.GetExpenseId()); err != nil {
+			log.Printf("Error assigning expense to trip: %v", err)
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	if req.GetGroupId() == "" {
+		return &pb.SetExpenseGroupResponse{Message: "Expense moved to personal expenses"}, nil
+	}
+	return &pb.SetExpenseGroupResponse{Message: "Expense moved to group"}, nil
+}
+
+func (s *expenseServer) AddExpense(ctx context.Context, req *pb.AddExpenseRequest) (*pb.AddExpenseResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	place := strings.TrimSpace(req.GetPlace())
+	if place == "" || len(place) > 100 {
+		return nil, status.Error(codes.InvalidArgument, "place must be between 1 and 100 characters")
+	}
+	if req.GetAmount() == 0 || math.Abs(req.GetAmount()) >= 1e8 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be non-zero and below 100000000")
+	}
+	currency := strings.ToUpper(strings.TrimSpace(req.GetCurrency()))
+	if len(currency) != 3 {
+		return nil, status.Error(codes.InvalidArgument, "currency must be a three-letter code")
+	}
+	date := time.Now()
+	if req.GetDateAndTime() != "" {
+		if date, err = time.Parse(time.RFC3339, req.GetDateAndTime()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "date_and_time must be an RFC 3339 timestamp")
+		}
+	}
+	if req.GetGroupId() != "" {
+		if _, err := groupRole(ctx, s.db, req.GetGroupId(), userId); err != nil {
+			return nil, err
+		}
+	}
+
+	rules, err := loadRules(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Error loading categorization rules: %v", err)
+		return nil, err
+	}
+	var expense models.Transaction
+	category, tags := categorize(rules, place, req.GetAmount(), req.GetModeOfPayment())
+	expense.CategorySource = categorySourceRule
+	if explicit := strings.TrimSpace(req.GetCategory()); explicit != "" {
+		category = explicit
+		expense.CategorySource = categorySourceUser
+	}
+	if category == "" {
+		prediction, ok, err := classifyExpense(ctx, s.db, userId, place, req.GetAmount(), req.GetModeOfPayment(), date)
+		if err != nil {
+			log.Printf("Error running category classifier: %v", err)
+			return nil, err
+		}
+		if ok {
+			category = prediction.category
+			expense.CategorySource = categorySourceClassifier
+			expense.CategoryConfidence = prediction.confidence
+		}
+	}
+	if category == "" {
+		category = uncategorized
+		expense.CategorySource = ""
+	}
+	if len(category) > 50 {
+		return nil, status.Error(codes.InvalidArgument, "category must be at most 50 characters")
+	}
+	tags = normalizeTags(append(tags, req.GetTags()...))
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+	notes := strings.TrimSpace(req.GetNotes())
+	if len(notes) > maxNotesLength {
+		return nil, status.Errorf(codes.InvalidArgument, "notes must be at most %d characters", maxNotesLength)
+	}
+	customFields, err := resolveCustomFieldValues(ctx, s.db, userId, req.GetCustomFields(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	expense.UUID = userId
+	expense.GroupID = req.GetGroupId()
+	expense.Source = expenseSourceManual
+	expense.MerchantDetails.Name = place
+	expense.TransactionDetails.DateTime = date
+	expense.TransactionDetails.PaymentMethod = strings.TrimSpace(req.GetModeOfPayment())
+	expense.TransactionDetails.TotalAmount = req.GetAmount()
+	expense.TransactionDetails.Currency = currency
+	expense.SpendingCategory = category
+	expense.Tags = tags
+	expense.Notes = notes
+	expense.CustomFields = customFields
+	expenseId, err := s.WriteExpenseToDB(expense)
+	if err != nil {
+		log.Printf("Error writing expense to database: %v", err)
+		return nil, err
+	}
+
+	added, err := scanExpense(s.db.QueryRowContext(ctx, `SELECT `+expenseColumns+` FROM expense_data WHERE id = $1`, expenseId))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AddExpenseResponse{Expense: added}, nil
+}
+
+func (s *expenseServer) UpdateExpense(ctx context.Context, req *pb.UpdateExpenseRequest) (*pb.UpdateExpenseResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetExpenseId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid expense id")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Only the member who recorded an expense can edit it.
+	var expense correctedExpense
+	var currency, category string
+	query := `SELECT place, amount, mode_of_payment, date_and_time, currency, category FROM expense_data WHERE id = $1 AND uuid = $2 FOR UPDATE`
+	err = tx.QueryRowContext(ctx, query, req.GetExpenseId(), userId).Scan(&expense.place, &expense.amount,
+		&expense.paymentMethod, &expense.date, &currency, &category)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "expense not found")
+	}
+	if err != nil {
+		log.Printf("Error loading expense: %v", err)
+		return nil, err
+	}
+	expense.id = req.GetExpenseId()
+	if err := checkExpenseUnclaimed(ctx, tx, expense.id); err != nil {
+		return nil, err
+	}
+	amountChanged := false
+
+	if req.Place != nil {
+		expense.place = strings.TrimSpace(req.GetPlace())
+		if expense.place == "" || len(expense.place) > 100 {
+			return nil, status.Error(codes.InvalidArgument, "place must be between 1 and 100 characters")
+		}
+	}
+	if req.Amount != nil {
+		if req.GetAmount() == 0 || math.Abs(req.GetAmount()) >= 1e8 {
+			return nil, status.Error(codes.InvalidArgument, "amount must be non-zero and below 100000000")
+		}
+		amountChanged = req.GetAmount() != expense.amount
+		expense.amount = req.GetAmount()
+	}
+	if req.Currency != nil {
+		currency = strings.ToUpper(strings.TrimSpace(req.GetCurrency()))
+		if len(currency) != 3 {
+			return nil, status.Error(codes.InvalidArgument, "currency must be a three-letter code")
+		}
+	}
+	if req.DateAndTime != nil {
+		if expense.date, err = time.Parse(time.RFC3339, req.GetDateAndTime()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "date_and_time must be an RFC 3339 timestamp")
+		}
+	}
+	if req.ModeOfPayment != nil {
+		expense.paymentMethod = strings.TrimSpace(req.GetModeOfPayment())
+		if len(expense.paymentMethod) > 50 {
+			return nil, status.Error(codes.InvalidArgument, "mode_of_payment must be at most 50 characters")
+		}
+	}
+
+	query = `UPDATE expense_data SET place = $1, amount = $2, mode_of_payment = $3, date_and_time = $4, currency = $5 WHERE id = $6`
+	if _, err := tx.ExecContext(ctx, query, expense.place, expense.amount, expense.paymentMethod, expense.date, currency, expense.id); err != nil {
+		log.Printf("Error updating expense: %v", err)
+		return nil, err
+	}
+	if req.Place != nil {
+		merchantId, _, err := resolveMerchant(ctx, tx, userId, expense.place)
+		if err != nil {
+			log.Printf("Error resolving merchant: %v", err)
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE expense_data SET merchant_id = nullif($1, '')::uuid WHERE id = $2`, merchantId, expense.id); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Category != nil {
+		text := strings.TrimSpace(req.GetCategory())
+		if text == "" || len(text) > 50 {
+			return nil, status.Error(codes.InvalidArgument, "category must be between 1 and 50 characters")
+		}
+		categoryId, corrected, err := resolveCategory(ctx, tx, userId, text)
+		if err != nil {
+			log.Printf("Error resolving category: %v", err)
+			return nil, err
+		}
+		if corrected != category {
+			query := `UPDATE expense_data SET category = $1, category_id = $2, category_source = $3, category_confidence = NULL WHERE id = $4`
+			if _, err := tx.ExecContext(ctx, query, corrected, categoryId, categorySourceUser, expense.id); err != nil {
+				return nil, err
+			}
+			if err := recordCorrection(ctx, tx, userId, expense, category, corrected); err != nil {
+				log.Printf("Error recording category correction: %v", err)
+				return nil, err
+			}
+		}
+	}
+
+	if req.Notes != nil {
+		notes := strings.TrimSpace(req.GetNotes())
+		if len(notes) > maxNotesLength {
+			return nil, status.Errorf(codes.InvalidArgument, "notes must be at most %d characters", maxNotesLength)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE expense_data SET notes = nullif($1, '') WHERE id = $2`, notes, expense.id); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.GetAddTags()) > 0 || len(req.GetRemoveTags()) > 0 {
+		if err := updateExpenseTags(ctx, tx, expense.id, req.GetAddTags(), req.GetRemoveTags()); err != nil {
+			return nil, err
+		}
+	}
+	customFields, err := resolveCustomFieldValues(ctx, tx, userId, req.GetCustomFields(), true)
+	if err != nil {
+		return nil, err
+	}
+	if err := setCustomFieldValues(ctx, tx, expense.id, customFields); err != nil {
+		log.Printf("Error setting custom fields: %v", err)
+		return nil, err
+	}
+
+	if req.DateAndTime != nil {
+		if err := assignTrips(ctx, tx, "e.id = $1", expense.id); err != nil {
+			log.Printf("Error assigning expense to trip: %v", err)
+			return nil, err
+		}
+	}
+
+	// A split no longer adds up once the amount changes.
+	if amountChanged {
+		if _, err := tx.ExecContext(ctx, `UPDATE expense_data SET paid_by = NULL, split_method = NULL WHERE id = $1`, expense.id); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM expense_split_data WHERE expense_id = $1`, expense.id); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := scanExpense(tx.QueryRowContext(ctx, `SELECT `+expenseColumns+` FROM expense_data WHERE id = $1`, expense.id))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateExpenseResponse{Expense: updated}, nil
+}
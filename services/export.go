@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// Export formats.
+const (
+	exportCsv    = "csv"
+	exportNdjson = "ndjson"
+	exportXlsx   = "xlsx"
+	exportPdf    = "pdf"
+)
+
+// exportRow is an expense as it is exported.
+type exportRow struct {
+	ID             string    `json:"id"`
+	DateTime       time.Time `json:"date_and_time"`
+	Place          string    `json:"place"`
+	Merchant       string    `json:"merchant"`
+	Category       string    `json:"category"`
+	ParentCategory string    `json:"parent_category"`
+	Amount         float64   `json:"amount"`
+	Currency       string    `json:"currency"`
+	PaymentMethod  string    `json:"mode_of_payment"`
+	Tags           []string  `json:"tags"`
+	Source         string    `json:"source"`
+	GroupID        string    `json:"group_id,omitempty"`
+}
+
+var exportHeader = []string{"id", "date_and_time", "place", "merchant", "category", "parent_category", "amount", "currency", "mode_of_payment", "tags", "source", "group_id"}
+
+// exportFilter is the parsed filter of an export. to is exclusive.
+type exportFilter struct {
+	from       time.Time
+	to         time.Time
+	categoryId string
+	merchantId string
+}
+
+// parseExportTime reads an RFC 3339 time or a date. A date used as the end
+// of a range covers the whole day.
+func parseExportTime(value string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+func parseExportFilter(req *pb.ExportExpensesRequest) (exportFilter, error) {
+	var filter exportFilter
+	var err error
+	if req.GetFrom() != "" {
+		if filter.from, err = parseExportTime(req.GetFrom(), false); err != nil {
+			return filter, status.Error(codes.InvalidArgument, "from must be an RFC 3339 time or a YYYY-MM-DD date")
+		}
+	}
+	if req.GetTo() != "" {
+		if filter.to, err = parseExportTime(req.GetTo(), true); err != nil {
+			return filter, status.Error(codes.InvalidArgument, "to must be an RFC 3339 time or a YYYY-MM-DD date")
+		}
+		if req.GetFrom() != "" && !filter.to.After(filter.from) {
+			return filter, status.Error(codes.InvalidArgument, "to must not be before from")
+		}
+	}
+	if req.GetCategoryId() != "" {
+		if _, err := uuid.Parse(req.GetCategoryId()); err != nil {
+			return filter, status.Error(codes.InvalidArgument, "invalid category id")
+		}
+		filter.categoryId = req.GetCategoryId()
+	}
+	if req.GetMerchantId() != "" {
+		if _, err := uuid.Parse(req.GetMerchantId()); err != nil {
+			return filter, status.Error(codes.InvalidArgument, "invalid merchant id")
+		}
+		filter.merchantId = req.GetMerchantId()
+	}
+	return filter, nil
+}
+
+// exportStream forwards everything written to it as chunks of a file,
+// announcing the content type and file name with the first one.
+type exportStream struct {
+	send        func(chunk []byte, contentType, filename string) error
+	contentType string
+	filename    string
+	sent        bool
+}
+
+func (w *exportStream) Write(p []byte) (int, error) {
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+	var contentType, filename string
+	if !w.sent {
+		contentType, filename = w.contentType, w.filename
+		w.sent = true
+	}
+	if err := w.send(chunk, contentType, filename); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close announces the file if nothing was written, so that an empty file
+// still has a type.
+func (w *exportStream) Close() error {
+	if w.sent {
+		return nil
+	}
+	w.sent = true
+	return w.send(nil, w.contentType, w.filename)
+}
+
+// ExportExpenses is a server-streaming RPC that streams the expenses
+// matching the filter as a file in the requested format.
+func (s *expenseServer) ExportExpenses(req *pb.ExportExpensesRequest, stream pb.ExpensesService_ExportExpensesServer) error {
+	ctx := stream.Context()
+	scope, owner, err := s.expenseScope(ctx, req.GetGroupId())
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(strings.TrimSpace(req.GetFormat()))
+	if format == "" {
+		format = exportCsv
+	}
+	contentType, ok := map[string]string{
+		exportCsv:    "text/csv",
+		exportNdjson: "application/x-ndjson",
+		exportXlsx:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		exportPdf:    "application/pdf",
+	}[format]
+	if !ok {
+		return status.Error(codes.InvalidArgument, "format must be csv, ndjson, xlsx or pdf")
+	}
+	filter, err := parseExportFilter(req)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT e.id, e.date_and_time, e.place, coalesce(m.name, ''), e.category, coalesce(p.name, ''), e.amount, e.currency,
+			coalesce(e.mode_of_payment, ''),
+			coalesce((SELECT array_agg(tag ORDER BY tag) FROM expense_tag_data WHERE expense_id = e.id), '{}'),
+			coalesce(e.source, ''), coalesce(e.group_id::text, '')
+		FROM expense_data e
+		LEFT JOIN merchant_data m ON m.id = e.merchant_id
+		LEFT JOIN category_data c ON c.id = e.category_id
+		LEFT JOIN category_data p ON p.id = c.parent_id
+		WHERE e.` + scope + `
+			AND ($2::timestamptz IS NULL OR e.date_and_time >= $2)
+			AND ($3::timestamptz IS NULL OR e.date_and_time < $3)
+			AND ($4::uuid IS NULL OR e.category_id = $4 OR c.parent_id = $4)
+			AND ($5::uuid IS NULL OR e.merchant_id = $5)
+		ORDER BY e.date_and_time, e.id`
+	var from, to any
+	if !filter.from.IsZero() {
+		from = filter.from
+	}
+	if !filter.to.IsZero() {
+		to = filter.to
+	}
+	rows, err := s.db.QueryContext(ctx, query, owner, from, to, nullIfEmpty(filter.categoryId), nullIfEmpty(filter.merchantId))
+	if err != nil {
+		log.Printf("Error querying expenses for export: %v", err)
+		return err
+	}
+	defer rows.Close()
+	next := func() (*exportRow, error) {
+		if !rows.Next() {
+			return nil, rows.Err()
+		}
+		var row exportRow
+		err := rows.Scan(&row.ID, &row.DateTime, &row.Place, &row.Merchant, &row.Category, &row.ParentCategory, &row.Amount,
+			&row.Currency, &row.PaymentMethod, pq.Array(&row.Tags), &row.Source, &row.GroupID)
+		return &row, err
+	}
+
+	out := &exportStream{
+		send: func(chunk []byte, contentType, filename string) error {
+			return stream.Send(&pb.ExportExpensesResponse{Chunks: chunk, ContentType: contentType, Filename: filename})
+		},
+		contentType: contentType,
+		filename:    fmt.Sprintf("expenses-%s.%s", time.Now().Format("2006-01-02"), format),
+	}
+	buf := bufio.NewWriterSize(out, exportChunkSize)
+	switch format {
+	case exportCsv:
+		err = writeExportCsv(buf, next)
+	case exportNdjson:
+		err = writeExportNdjson(buf, next)
+	case exportXlsx:
+		err = writeExportXlsx(buf, next)
+	case exportPdf:
+		err = writeExportPdf(buf, next, filter, req.GetGroupId() != "")
+	}
+	if err != nil {
+		log.Printf("Error exporting expenses: %v", err)
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// spreadsheetSafe keeps text that a spreadsheet would run as a formula,
+// such as a merchant name read from a hostile email, from being one.
+func spreadsheetSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func writeExportCsv(w io.Writer, next func() (*exportRow, error)) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportHeader); err != nil {
+		return err
+	}
+	for {
+		row, err := next()
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			break
+		}
+		record := []string{
+			row.ID,
+			row.DateTime.Format(time.RFC3339),
+			spreadsheetSafe(row.Place),
+			spreadsheetSafe(row.Merchant),
+			spreadsheetSafe(row.Category),
+			spreadsheetSafe(row.ParentCategory),
+			strconv.FormatFloat(row.Amount, 'f', 2, 64),
+			row.Currency,
+			spreadsheetSafe(row.PaymentMethod),
+			spreadsheetSafe(strings.Join(row.Tags, ";")),
+			row.Source,
+			row.GroupID,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeExportNdjson(w io.Writer, next func() (*exportRow, error)) error {
+	enc := json.NewEncoder(w)
+	for {
+		row, err := next()
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			return nil
+		}
+		if row.Tags == nil {
+			row.Tags = []string{}
+		}
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+	}
+}
+
+// exportTotal is the sum and count of a group of expenses.
+type exportTotal struct {
+	Key      string
+	Currency string
+	Count    int
+	Amount   float64
+}
+
+// exportSummary totals exported expenses per currency, since amounts in
+// different currencies are never added up.
+type exportSummary struct {
+	count      int
+	first      time.Time
+	last       time.Time
+	currencies map[string]*exportTotal
+	categories map[string]*exportTotal
+	months     map[string]*exportTotal
+	merchants  map[string]*exportTotal
+}
+
+func newExportSummary() *exportSummary {
+	return &exportSummary{
+		currencies: map[string]*exportTotal{},
+		categories: map[string]*exportTotal{},
+		months:     map[string]*exportTotal{},
+		merchants:  map[string]*exportTotal{},
+	}
+}
+
+func addExportTotal(totals map[string]*exportTotal, key, currency string, amount float64) {
+	total := totals[currency+"\x00"+key]
+	if total == nil {
+		total = &exportTotal{Key: key, Currency: currency}
+		totals[currency+"\x00"+key] = total
+	}
+	total.Count++
+	total.Amount += amount
+}
+
+func (s *exportSummary) add(row *exportRow) {
+	if s.count == 0 || row.DateTime.Before(s.first) {
+		s.first = row.DateTime
+	}
+	if row.DateTime.After(s.last) {
+		s.last = row.DateTime
+	}
+	s.count++
+	addExportTotal(s.currencies, row.Currency, row.Currency, row.Amount)
+	addExportTotal(s.categories, row.Category, row.Currency, row.Amount)
+	addExportTotal(s.months, row.DateTime.UTC().Format("2006-01"), row.Currency, row.Amount)
+	merchant := row.Merchant
+	if merchant == "" {
+		merchant = row.Place
+	}
+	addExportTotal(s.merchants, merchant, row.Currency, row.Amount)
+}
+
+// sortedTotals returns totals by currency and then by amount, largest first,
+// or by key when byKey is set. An empty currency returns every currency.
+func sortedTotals(totals map[string]*exportTotal, currency string, byKey bool) []*exportTotal {
+	var sorted []*exportTotal
+	for _, total := range totals {
+		if currency == "" || total.Currency == currency {
+			sorted = append(sorted, total)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		if !byKey && a.Amount != b.Amount {
+			return a.Amount > b.Amount
+		}
+		return a.Key < b.Key
+	})
+	return sorted
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+// Values of expense_data.source.
+const (
+	expenseSourceReceipt = "receipt"
+	expenseSourceManual  = "manual"
+	expenseSourceSms     = "sms"
+	expenseSourceEmail   = "email"
+	expenseSourceImport  = "import"
+)
+
+// Extractor runs the extraction model over a receipt image or message text
+// and returns its raw answer to the prompt.
+type Extractor interface {
+	Extract(ctx context.Context, content []byte, mimeType, prompt string) (string, error)
+}
+
+// geminiExtractor extracts with the Gemini API.
+type geminiExtractor struct{}
+
+func (geminiExtractor) Extract(ctx context.Context, content []byte, mimeType, prompt string) (string, error) {
+	return genAI(content, mimeType, prompt)
+}
+
+// parseTransaction reads the JSON transaction out of a model answer, which
+// may be wrapped in a Markdown code fence.
+func parseTransaction(answer string) (models.Transaction, error) {
+	answer = strings.Replace(answer, "```json\n", "", -1)
+	answer = strings.Replace(answer, "```", "", -1)
+
+	var expense models.Transaction
+	err := json.Unmarshal([]byte(strings.TrimSpace(answer)), &expense)
+	return expense, err
+}
@@ -7,12 +7,17 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"google.golang.org/genai"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	// Replace with your actual proto package import
 	pb "github.com/barathsurya2004/expenses/proto"
@@ -23,17 +28,49 @@ const (
 	port = ":50051"
 )
 
+// receiptPrompt asks the extraction model for a models.Transaction.
+const receiptPrompt = `
+	You are a helpful assistant. Extract the following details from the receipt image and return them as a JSON object.
+		Do not include any extra text before or after the JSON.
+
+		Fields to extract:
+		- "transaction_id": A unique identifier for the receipt.
+		- "merchant_details":
+		- "name": The name of the store or service.
+		- "transaction_details":
+		- "date_and_time" : The date and time of the transaction in ISO 8601 format (e.g., "2023-10-01T12:00:00Z").
+		- "payment_method": The payment method used (e.g., "Credit Card", "Cash", "Debit Card").
+		- "total_amount": The total amount spent, as a float.
+		- "currency": The currency of the total amount (e.g., "USD", "EUR").
+		- "items": An array of objects, where each object has:
+		- "item_name": The name of the product or service.
+		- "price": The item's price as a float.
+		- "quantity": The number of units purchased.
+		- "category": A classification of the item (e.g., "Groceries", "Household", "Dining").
+		- "spending_category": A top-level classification for the entire receipt (e.g., "Groceries", "Dining Out", "Utilities").
+
+		if there are some data missing from the receipt, you can leave them empty.
+		Return only the JSON object.
+	`
+
 // server implements the gRPC ExpensesServiceServer interface.
 type expenseServer struct {
 	pb.UnimplementedExpensesServiceServer
-	db *sql.DB
+	db        *sql.DB
+	blobs     BlobStore
+	extractor Extractor
 }
 
 // CreateExpense is a client-streaming RPC that receives an image and processes it.
 func (s *expenseServer) CreateExpense(stream pb.ExpensesService_CreateExpenseServer) error {
+	userId, err := callerID(stream.Context())
+	if err != nil {
+		return err
+	}
 	log.Println("Receiving image chunks from client...")
 
 	var imageBytes []byte
+	var groupId string
 
 	// Read image chunks from the client stream.
 	for {
@@ -46,64 +83,51 @@ func (s *expenseServer) CreateExpense(stream pb.ExpensesService_CreateExpenseSer
 			return err
 		}
 
+		if groupId == "" {
+			groupId = chunk.GetGroupId()
+		}
 		imageBytes = append(imageBytes, chunk.Chunks...)
 	}
 	log.Println("Image chunks received and saved successfully.")
 
-	prompt := `
-	You are a helpful assistant. Extract the following details from the receipt image and return them as a JSON object.
-		Do not include any extra text before or after the JSON.
-
-		Fields to extract:
-		- "transaction_id": A unique identifier for the receipt.
-		- "merchant_details":
-		- "name": The name of the store or service.
-		- "transaction_details":
-		- "date_and_time" : The date and time of the transaction in ISO 8601 format (e.g., "2023-10-01T12:00:00Z").
-		- "payment_method": The payment method used (e.g., "Credit Card", "Cash", "Debit Card").
-		- "total_amount": The total amount spent, as a float.
-		- "currency": The currency of the total amount (e.g., "USD", "EUR").
-		- "items": An array of objects, where each object has:
-		- "item_name": The name of the product or service.
-		- "price": The item's price as a float.
-		- "quantity": The number of units purchased.
-		- "category": A classification of the item (e.g., "Groceries", "Household", "Dining").
-		- "spending_category": A top-level classification for the entire receipt (e.g., "Groceries", "Dining Out", "Utilities").
-
-		if there are some data missing from the receipt, you can leave them empty.
-		Return only the JSON object.
-	`
+	if groupId != "" {
+		if _, err := groupRole(stream.Context(), s.db, groupId, userId); err != nil {
+			return err
+		}
+	}
 
-	responseText, err := genAI(imageBytes, prompt)
+	responseText, err := s.extractor.Extract(stream.Context(), imageBytes, "image/jpeg", receiptPrompt)
 	if err != nil {
 		return err
 	}
 
 	log.Println("Successfully processed image with GenAI. Sending response to client.")
 
-	jsonParsed := strings.Replace(responseText, "```json\n", "", -1)
-	jsonParsed = strings.Replace(jsonParsed, "```", "", -1)
-	responseText = strings.TrimSpace(jsonParsed)
-
-	// Parse the JSON response into the Expenses model.
-	var expense models.Transaction
-	err = json.Unmarshal([]byte(responseText), &expense)
+	expense, err := parseTransaction(responseText)
 	if err != nil {
 		log.Printf("Error parsing JSON response: %v", err)
 		return err
 	}
 
 	// Write the expense data to the database.
-	err = s.WriteExpenseToDB(expense)
+	expense.UUID = userId
+	expense.GroupID = groupId
+	expense.Source = expenseSourceReceipt
+	expense, expenseId, err := s.recordTransaction(stream.Context(), expense)
+	if categorized, err := json.Marshal(expense); err == nil {
+		responseText = string(categorized)
+	}
 	if err != nil {
 		log.Printf("Error writing expense to database: %v", err)
+	} else if err := s.saveReceipt(stream.Context(), expense.UUID, expenseId, imageBytes); err != nil {
+		log.Printf("Error storing receipt image: %v", err)
 	}
 
 	// Send the final JSON response back to the client and close the stream.
 	return stream.SendAndClose(&pb.CreateExpenseResponse{Status: responseText})
 }
 
-func genAI(image []byte, prompt string) (string, error) {
+func genAI(content []byte, mimeType, prompt string) (string, error) {
 	err := godotenv.Load()
 	if err != nil {
 		return "", err
@@ -118,7 +142,11 @@ func genAI(image []byte, prompt string) (string, error) {
 
 	parts := []*genai.Part{
 		genai.NewPartFromText(strings.TrimSpace(prompt)),
-		genai.NewPartFromBytes(image, "image/jpeg"),
+	}
+	if strings.HasPrefix(mimeType, "text/") {
+		parts = append(parts, genai.NewPartFromText(string(content)))
+	} else {
+		parts = append(parts, genai.NewPartFromBytes(content, mimeType))
 	}
 	contents := []*genai.Content{
 		genai.NewContentFromParts(parts, genai.RoleUser),
@@ -137,7 +165,44 @@ func genAI(image []byte, prompt string) (string, error) {
 	return result.Text(), nil
 }
 
-func (s *expenseServer) WriteExpenseToDB(expense models.Transaction) error {
+// recordTransaction categorizes an extracted transaction and stores it. The
+// user's personal classifier overrides the extraction model once it is
+// confident, and their rules override both. The categorized transaction is
+// returned even when storing it fails.
+func (s *expenseServer) recordTransaction(ctx context.Context, expense models.Transaction) (models.Transaction, string, error) {
+	expense = s.categorizeTransaction(ctx, expense)
+	expenseId, err := s.WriteExpenseToDB(expense)
+	return expense, expenseId, err
+}
+
+// categorizeTransaction picks the category and tags of an extracted
+// transaction with the classifier and the user's rules.
+func (s *expenseServer) categorizeTransaction(ctx context.Context, expense models.Transaction) models.Transaction {
+	rules, err := loadRules(ctx, s.db, expense.UUID)
+	if err != nil {
+		log.Printf("Error loading categorization rules: %v", err)
+	}
+	expense.CategorySource = categorySourceGenAI
+	prediction, ok, err := classifyExpense(ctx, s.db, expense.UUID, expense.MerchantDetails.Name,
+		expense.TransactionDetails.TotalAmount, expense.TransactionDetails.PaymentMethod, expense.TransactionDetails.DateTime)
+	if err != nil {
+		log.Printf("Error running category classifier: %v", err)
+	} else if ok {
+		expense.SpendingCategory = prediction.category
+		expense.CategorySource = categorySourceClassifier
+		expense.CategoryConfidence = prediction.confidence
+	}
+	category, tags := categorize(rules, expense.MerchantDetails.Name, expense.TransactionDetails.TotalAmount, expense.TransactionDetails.PaymentMethod)
+	if category != "" {
+		expense.SpendingCategory = category
+		expense.CategorySource = categorySourceRule
+		expense.CategoryConfidence = 0
+	}
+	expense.Tags = normalizeTags(append(expense.Tags, tags...))
+	return expense
+}
+
+func (s *expenseServer) WriteExpenseToDB(expense models.Transaction) (string, error) {
 	fmt.Println(
 		expense.TransactionDetails.TotalAmount,
 		expense.MerchantDetails.Name,
@@ -147,30 +212,133 @@ func (s *expenseServer) WriteExpenseToDB(expense models.Transaction) error {
 		expense.SpendingCategory,
 	)
 
-	query := `INSERT INTO expense_data (uuid,date_and_time, place, mode_of_payment, amount, currency, category) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	expenseId, err := writeExpense(ctx, tx, expense)
+	if err != nil {
+		return "", err
+	}
+	return expenseId, tx.Commit()
+}
 
-	uuid := "01f07c5a-f6c2-652b-9f5a-00155d4c4438"
+// writeExpense stores an expense with its items, tags and custom fields
+// inside tx.
+func writeExpense(ctx context.Context, tx *sql.Tx, expense models.Transaction) (string, error) {
+	// A merchant's default category beats the extracted one but not the
+	// user's own choices.
+	merchantId, defaultCategory, err := resolveMerchant(ctx, tx, expense.UUID, expense.MerchantDetails.Name)
+	if err != nil {
+		return "", err
+	}
+	if defaultCategory != "" && (expense.CategorySource == categorySourceGenAI || expense.CategorySource == "") {
+		expense.SpendingCategory = defaultCategory
+		expense.CategorySource = categorySourceMerchant
+	}
+
+	categoryId, category, err := resolveCategory(ctx, tx, expense.UUID, expense.SpendingCategory)
+	if err != nil {
+		return "", err
+	}
+
+	var confidence sql.NullFloat64
+	if expense.CategorySource == categorySourceClassifier {
+		confidence = sql.NullFloat64{Float64: expense.CategoryConfidence, Valid: true}
+	}
 
-	_, err := s.db.ExecContext(context.Background(), query,
-		uuid,
+	query := `INSERT INTO expense_data (uuid,date_and_time, place, mode_of_payment, amount, currency, category, group_id, category_id, category_source, category_confidence, merchant_id, source, import_key, notes) VALUES ($1, $2, $3, $4, $5, $6, $7, nullif($8, '')::uuid, $9, $10, $11, nullif($12, '')::uuid, $13, $14, $15) RETURNING id`
+
+	var expenseId string
+	err = tx.QueryRowContext(ctx, query,
+		expense.UUID,
 		expense.TransactionDetails.DateTime,
 		expense.MerchantDetails.Name,
 		expense.TransactionDetails.PaymentMethod,
 		expense.TransactionDetails.TotalAmount,
 		expense.TransactionDetails.Currency,
-		expense.SpendingCategory,
-	)
+		category,
+		expense.GroupID,
+		categoryId,
+		nullIfEmpty(expense.CategorySource),
+		confidence,
+		merchantId,
+		nullIfEmpty(expense.Source),
+		nullIfEmpty(expense.ImportKey),
+		nullIfEmpty(expense.Notes),
+	).Scan(&expenseId)
+	if err != nil {
+		return "", err
+	}
+
+	itemQuery := `INSERT INTO expense_item_data (expense_id, item_name, price, quantity, category) VALUES ($1, $2, $3, $4, $5)`
+	for _, item := range expense.Items {
+		quantity := item.Quantity
+		if quantity <= 0 {
+			quantity = 1
+		}
+		if _, err := tx.ExecContext(ctx, itemQuery, expenseId, item.ItemName, item.Price, quantity, item.Category); err != nil {
+			return "", err
+		}
+	}
+	if err := addExpenseTags(ctx, tx, expenseId, expense.Tags); err != nil {
+		return "", err
+	}
+	if err := setCustomFieldValues(ctx, tx, expenseId, expense.CustomFields); err != nil {
+		return "", err
+	}
+	if err := assignTrips(ctx, tx, "e.id = $1", expenseId); err != nil {
+		return "", err
+	}
+	return expenseId, nil
+}
 
-	return err
+// saveReceipt stores the uploaded receipt image in blob storage and links it
+// to the expense it was extracted from.
+func (s *expenseServer) saveReceipt(ctx context.Context, userId, expenseId string, image []byte) error {
+	receiptId := uuid.NewString()
+	key := userId + "/" + receiptId
+	if err := s.blobs.Put(ctx, key, image); err != nil {
+		return err
+	}
 
+	query := `INSERT INTO receipt_data (id, expense_id, uuid, storage_key, content_type, size_bytes) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := s.db.ExecContext(ctx, query, receiptId, expenseId, userId, key, http.DetectContentType(image), len(image))
+	if err != nil {
+		if delErr := s.blobs.Delete(ctx, key); delErr != nil {
+			log.Printf("Error removing orphaned receipt %s: %v", key, delErr)
+		}
+		return err
+	}
+	return nil
 }
 
 func (s *expenseServer) GetHeatMapData(ctx context.Context, req *pb.GetHeatMapDataRequest) (*pb.GetHeatMapDataResponse, error) {
+	scope, owner, err := s.expenseScope(ctx, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
 	log.Println("Fetching heat map data...")
 
-	query := `SELECT date_and_time, amount, currency FROM expense_data WHERE uuid = $1`
+	page, err := newPage(req, expenseOrders, "date_and_time desc", pageFilter(req.GetGroupId()), maxPageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	var total int32
+	if req.GetIncludeTotalCount() {
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM expense_data WHERE `+scope, owner).Scan(&total); err != nil {
+			log.Printf("Error counting heat map data: %v", err)
+			return nil, err
+		}
+	}
+
+	after, args := page.condition([]any{owner})
+	query := `SELECT date_and_time, amount, currency, ` + page.keyColumns() + ` FROM expense_data WHERE ` + scope + ` AND ` + after + page.orderLimit()
 
-	rows, err := s.db.QueryContext(ctx, query, req.GetUserId())
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Printf("Error querying heat map data: %v", err)
 		return nil, err
@@ -181,7 +349,8 @@ func (s *expenseServer) GetHeatMapData(ctx context.Context, req *pb.GetHeatMapDa
 		var date time.Time
 		var amount float64
 		var currency string
-		if err := rows.Scan(&date, &amount, &currency); err != nil {
+		key, id := page.scanKey()
+		if err := rows.Scan(&date, &amount, &currency, key, id); err != nil {
 			log.Printf("Error scanning heat map data: %v", err)
 			return nil, err
 		}
@@ -200,20 +369,84 @@ func (s *expenseServer) GetHeatMapData(ctx context.Context, req *pb.GetHeatMapDa
 	}
 	log.Println("Heat map data fetched successfully.")
 
+	next, n := page.nextToken()
 	response := &pb.GetHeatMapDataResponse{
-		HeatMapData: heatMapData,
+		HeatMapData:   heatMapData[:n],
+		NextPageToken: next,
+		TotalCount:    total,
 	}
 
 	log.Println("Heat map data fetched successfully.")
 	return response, nil
 }
 
+// spendingQuery totals the expenses matching scope, whose arguments are
+// args, by groupBy. Each row has the category id, the name of what was
+// totalled, the category's icon and colour, and total_spent.
+func spendingQuery(groupBy, scope string, args []any, byParent bool, customFieldId string) (string, []any, error) {
+	param := "$" + strconv.Itoa(len(args)+1)
+	switch groupBy {
+	case "", "category":
+		// Expenses outside the taxonomy are grouped by their free-text category.
+		query := `
+			SELECT coalesce(k.id::text, ''), coalesce(k.name, e.category), coalesce(k.icon, ''), coalesce(k.colour, ''), SUM(e.amount) as total_spent
+			FROM (SELECT category, category_id, amount FROM expense_data WHERE ` + scope + `) e
+			LEFT JOIN category_data c ON c.id = e.category_id
+			LEFT JOIN category_data k ON k.id = CASE WHEN ` + param + ` THEN coalesce(c.parent_id, c.id) ELSE c.id END
+			GROUP BY 1, 2, 3, 4`
+		return query, append(args, byParent), nil
+	case "tag":
+		query := `
+			SELECT '', coalesce(t.tag, 'Untagged'), '', '', SUM(e.amount) as total_spent
+			FROM (SELECT id, amount FROM expense_data WHERE ` + scope + `) e
+			LEFT JOIN expense_tag_data t ON t.expense_id = e.id
+			GROUP BY 1, 2, 3, 4`
+		return query, args, nil
+	case "merchant":
+		// Expenses not matched to a merchant are grouped by their place.
+		query := `
+			SELECT '', coalesce(m.name, e.place), '', '', SUM(e.amount) as total_spent
+			FROM (SELECT place, merchant_id, amount FROM expense_data WHERE ` + scope + `) e
+			LEFT JOIN merchant_data m ON m.id = e.merchant_id
+			GROUP BY 1, 2, 3, 4`
+		return query, args, nil
+	case "custom_field":
+		query := `
+			SELECT '', coalesce(v.value, 'Not set'), '', '', SUM(e.amount) as total_spent
+			FROM (SELECT id, amount FROM expense_data WHERE ` + scope + `) e
+			LEFT JOIN expense_custom_field_data v ON v.expense_id = e.id AND v.field_id = ` + param + `
+			GROUP BY 1, 2, 3, 4`
+		return query, append(args, customFieldId), nil
+	}
+	return "", nil, status.Error(codes.InvalidArgument, "group_by must be category, tag, merchant or custom_field")
+}
+
 func (s *expenseServer) GetSpendingTypes(ctx context.Context, req *pb.GetSpendingTypesRequest) (*pb.GetSpendingTypesResponse, error) {
+	scope, owner, err := s.expenseScope(ctx, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
 	log.Println("Fetching spending types data...")
 
-	query := `SELECT category, SUM(amount) as total_spent FROM expense_data WHERE uuid = $1 GROUP BY category ORDER BY total_spent DESC LIMIT 5`
+	if req.GetGroupBy() == "custom_field" {
+		userId, err := callerID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := uuid.Parse(req.GetCustomFieldId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid custom field id")
+		}
+		if _, err := loadCustomField(ctx, s.db, userId, req.GetCustomFieldId()); err != nil {
+			return nil, err
+		}
+	}
+	query, args, err := spendingQuery(req.GetGroupBy(), scope, []any{owner}, req.GetByParent(), req.GetCustomFieldId())
+	if err != nil {
+		return nil, err
+	}
+	query += ` ORDER BY total_spent DESC LIMIT 5`
 
-	rows, err := s.db.QueryContext(ctx, query, req.GetUserId())
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Printf("Error querying spending types data: %v", err)
 		return nil, err
@@ -222,16 +455,19 @@ func (s *expenseServer) GetSpendingTypes(ctx context.Context, req *pb.GetSpendin
 
 	var spendingTypes []*pb.SpendingType
 	for rows.Next() {
-		var category string
+		var categoryId, category, icon, colour string
 		var totalSpent float64
-		if err := rows.Scan(&category, &totalSpent); err != nil {
+		if err := rows.Scan(&categoryId, &category, &icon, &colour, &totalSpent); err != nil {
 			log.Printf("Error scanning spending types data: %v", err)
 			return nil, err
 		}
 
 		spendingTypes = append(spendingTypes, &pb.SpendingType{
-			Type:  category,
-			Spent: totalSpent,
+			Type:       category,
+			Spent:      totalSpent,
+			CategoryId: categoryId,
+			Icon:       icon,
+			Colour:     colour,
 		})
 	}
 	if err := rows.Err(); err != nil {
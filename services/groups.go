@@ -0,0 +1,519 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// Roles within a group, stored in group_member_data.role.
+const (
+	groupRoleOwner  = "owner"
+	groupRoleAdmin  = "admin"
+	groupRoleMember = "member"
+)
+
+const (
+	groupInvitationTTL = 14 * 24 * time.Hour
+	maxGroupNameLength = 100
+)
+
+var groupOrders = map[string]pageOrder{
+	"name asc":        {column: "lower(g.name)", cast: "text", id: "g.id", idCast: "uuid"},
+	"created_at asc":  {column: "g.created_at", cast: "timestamptz", id: "g.id", idCast: "uuid"},
+	"created_at desc": {column: "g.created_at", cast: "timestamptz", desc: true, id: "g.id", idCast: "uuid", idDesc: true},
+}
+
+type groupsServer struct {
+	pb.UnimplementedGroupsServiceServer
+	db     *sql.DB
+	mailer Mailer
+}
+
+// groupRole returns the role of userId in the group. Callers that are not
+// members get NotFound so group ids cannot be probed.
+func groupRole(ctx context.Context, db *sql.DB, groupId, userId string) (string, error) {
+	if _, err := uuid.Parse(groupId); err != nil {
+		return "", status.Error(codes.InvalidArgument, "invalid group id")
+	}
+	if _, err := uuid.Parse(userId); err != nil {
+		return "", status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	var role string
+	err := db.QueryRowContext(ctx, `SELECT role FROM group_member_data WHERE group_id = $1 AND uuid = $2`, groupId, userId).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", status.Error(codes.NotFound, "group not found")
+	}
+	if err != nil {
+		return "", err
+	}
+	return role, nil
+}
+
+func loadGroup(ctx context.Context, db *sql.DB, groupId, userId string) (*pb.Group, error) {
+	var group pb.Group
+	var createdAt time.Time
+	query := `
+		SELECT g.id, g.name, m.role, (SELECT count(*) FROM group_member_data WHERE group_id = g.id), g.created_at
+		FROM group_data g JOIN group_member_data m ON m.group_id = g.id AND m.uuid = $2
+		WHERE g.id = $1`
+	err := db.QueryRowContext(ctx, query, groupId, userId).Scan(&group.Id, &group.Name, &group.Role, &group.MemberCount, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "group not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	group.CreatedAt = createdAt.Format(time.RFC3339)
+	return &group, nil
+}
+
+func (s *groupsServer) CreateGroup(ctx context.Context, req *pb.CreateGroupRequest) (*pb.CreateGroupResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.GetName())
+	if name == "" || len(name) > maxGroupNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be between 1 and %d characters", maxGroupNameLength)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var groupId string
+	if err := tx.QueryRowContext(ctx, `INSERT INTO group_data (name, created_by) VALUES ($1, $2) RETURNING id`, name, userId).Scan(&groupId); err != nil {
+		log.Printf("Failed to create group: %v", err)
+		return nil, err
+	}
+	query := `INSERT INTO group_member_data (group_id, uuid, role) VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, query, groupId, userId, groupRoleOwner); err != nil {
+		log.Printf("Failed to add group owner: %v", err)
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	group, err := loadGroup(ctx, s.db, groupId, userId)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("User %s created group %s", userId, groupId)
+	return &pb.CreateGroupResponse{Group: group}, nil
+}
+
+func (s *groupsServer) ListGroups(ctx context.Context, req *pb.ListGroupsRequest) (*pb.ListGroupsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := newPage(req, groupOrders, "name asc", "", defaultPageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	var total int32
+	if req.GetIncludeTotalCount() {
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM group_member_data WHERE uuid = $1`, userId).Scan(&total); err != nil {
+			log.Printf("Failed to count groups: %v", err)
+			return nil, err
+		}
+	}
+
+	after, args := page.condition([]any{userId})
+	query := `
+		SELECT g.id, g.name, m.role, (SELECT count(*) FROM group_member_data WHERE group_id = g.id), g.created_at, ` + page.keyColumns() + `
+		FROM group_data g JOIN group_member_data m ON m.group_id = g.id
+		WHERE m.uuid = $1 AND ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Failed to list groups: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []*pb.Group
+	for rows.Next() {
+		var group pb.Group
+		var createdAt time.Time
+		if err := (withKey{rows, page}).Scan(&group.Id, &group.Name, &group.Role, &group.MemberCount, &createdAt); err != nil {
+			return nil, err
+		}
+		group.CreatedAt = createdAt.Format(time.RFC3339)
+		groups = append(groups, &group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	next, n := page.nextToken()
+	return &pb.ListGroupsResponse{Groups: groups[:n], NextPageToken: next, TotalCount: total}, nil
+}
+
+func (s *groupsServer) GetGroup(ctx context.Context, req *pb.GetGroupRequest) (*pb.GetGroupResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := groupRole(ctx, s.db, req.GetGroupId(), userId); err != nil {
+		return nil, err
+	}
+	group, err := loadGroup(ctx, s.db, req.GetGroupId(), userId)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT u.uuid, u.username, coalesce(u.first_name, ''), coalesce(u.last_name, ''), m.role, m.joined_at
+		FROM group_member_data m JOIN user_data u ON u.uuid = m.uuid
+		WHERE m.group_id = $1
+		ORDER BY m.joined_at, u.uuid`
+	rows, err := s.db.QueryContext(ctx, query, req.GetGroupId())
+	if err != nil {
+		log.Printf("Failed to list group members: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*pb.GroupMember
+	for rows.Next() {
+		var member pb.GroupMember
+		var joinedAt time.Time
+		if err := rows.Scan(&member.UserId, &member.Username, &member.FirstName, &member.LastName, &member.Role, &joinedAt); err != nil {
+			return nil, err
+		}
+		member.JoinedAt = joinedAt.Format(time.RFC3339)
+		members = append(members, &member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.GetGroupResponse{
+		Group:   group,
+		Members: members,
+	}, nil
+}
+
+func (s *groupsServer) InviteToGroup(ctx context.Context, req *pb.InviteToGroupRequest) (*pb.InviteToGroupResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role, err := groupRole(ctx, s.db, req.GetGroupId(), userId)
+	if err != nil {
+		return nil, err
+	}
+	if role != groupRoleOwner && role != groupRoleAdmin {
+		return nil, status.Error(codes.PermissionDenied, "only group owners and admins can invite members")
+	}
+	inviteRole := req.GetRole()
+	if inviteRole == "" {
+		inviteRole = groupRoleMember
+	}
+	if inviteRole != groupRoleMember && inviteRole != groupRoleAdmin {
+		return nil, status.Errorf(codes.InvalidArgument, "role must be %q or %q", groupRoleMember, groupRoleAdmin)
+	}
+
+	username := strings.TrimSpace(req.GetUsername())
+	email := strings.TrimSpace(req.GetEmail())
+	if (username == "") == (email == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of username or email is required")
+	}
+
+	// Resolve the invitee to an account when there is one. Email invitations
+	// for unknown addresses stay open until someone verifies that address.
+	var invitee sql.NullString
+	var inviteeEmail string
+	if username != "" {
+		err = s.db.QueryRowContext(ctx, `SELECT uuid, email FROM user_data WHERE username = $1`, username).Scan(&invitee, &inviteeEmail)
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+	} else {
+		inviteeEmail = email
+		err = s.db.QueryRowContext(ctx, `SELECT uuid FROM user_data WHERE lower(email) = lower($1) AND email_verified`, email).Scan(&invitee)
+		if err == sql.ErrNoRows {
+			err = nil
+		}
+	}
+	if err != nil {
+		log.Printf("Failed to look up invitee: %v", err)
+		return nil, err
+	}
+
+	if invitee.Valid {
+		if _, err := groupRole(ctx, s.db, req.GetGroupId(), invitee.String); err == nil {
+			return nil, status.Error(codes.AlreadyExists, "user is already a member of this group")
+		}
+	}
+
+	var pending bool
+	query := `
+		SELECT EXISTS (SELECT 1 FROM group_invitation_data
+		WHERE group_id = $1 AND accepted_at IS NULL AND expires_at > current_timestamp
+		AND (invitee = $2 OR (invitee IS NULL AND lower(email) = lower($3))))`
+	if err := s.db.QueryRowContext(ctx, query, req.GetGroupId(), invitee, inviteeEmail).Scan(&pending); err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, status.Error(codes.AlreadyExists, "an invitation is already pending")
+	}
+
+	var invitationId string
+	var storedEmail any
+	if email != "" {
+		storedEmail = email
+	}
+	query = `
+		INSERT INTO group_invitation_data (group_id, invited_by, invitee, email, role, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	err = s.db.QueryRowContext(ctx, query, req.GetGroupId(), userId, invitee, storedEmail, inviteRole, time.Now().Add(groupInvitationTTL)).Scan(&invitationId)
+	if err != nil {
+		log.Printf("Failed to create group invitation: %v", err)
+		return nil, err
+	}
+
+	if inviteeEmail != "" {
+		if err := s.sendGroupInvitation(ctx, req.GetGroupId(), userId, inviteeEmail); err != nil {
+			log.Printf("Failed to send group invitation email: %v", err)
+		}
+	}
+
+	return &pb.InviteToGroupResponse{
+		InvitationId: invitationId,
+		Message:      "Invitation sent",
+	}, nil
+}
+
+func (s *groupsServer) sendGroupInvitation(ctx context.Context, groupId, inviterId, email string) error {
+	var groupName, inviter string
+	query := `SELECT g.name, u.username FROM group_data g, user_data u WHERE g.id = $1 AND u.uuid = $2`
+	if err := s.db.QueryRowContext(ctx, query, groupId, inviterId).Scan(&groupName, &inviter); err != nil {
+		return err
+	}
+
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	body := fmt.Sprintf("%s invited you to share expenses in the group %q.\n\nSign in at %s to accept the invitation. It expires in %s.",
+		inviter, groupName, strings.TrimRight(baseURL, "/"), groupInvitationTTL)
+	return s.mailer.Send(email, "You have been invited to a group", body)
+}
+
+// pendingInvitationsQuery matches invitations addressed to the caller ($1),
+// either directly or through their verified email address.
+const pendingInvitationsQuery = `
+	FROM group_invitation_data i JOIN group_data g ON g.id = i.group_id
+	LEFT JOIN user_data inviter ON inviter.uuid = i.invited_by
+	WHERE i.accepted_at IS NULL AND i.expires_at > current_timestamp
+	AND (i.invitee = $1 OR (i.invitee IS NULL AND lower(i.email) = (
+		SELECT lower(email) FROM user_data WHERE uuid = $1 AND email_verified)))`
+
+func (s *groupsServer) ListInvitations(ctx context.Context, req *pb.ListInvitationsRequest) (*pb.ListInvitationsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT i.id, g.id, g.name, coalesce(inviter.username, ''), i.role, i.created_at, i.expires_at` +
+		pendingInvitationsQuery + ` ORDER BY i.created_at`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		log.Printf("Failed to list group invitations: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*pb.GroupInvitation
+	for rows.Next() {
+		var invitation pb.GroupInvitation
+		var createdAt, expiresAt time.Time
+		if err := rows.Scan(&invitation.Id, &invitation.GroupId, &invitation.GroupName, &invitation.InvitedBy,
+			&invitation.Role, &createdAt, &expiresAt); err != nil {
+			return nil, err
+		}
+		invitation.CreatedAt = createdAt.Format(time.RFC3339)
+		invitation.ExpiresAt = expiresAt.Format(time.RFC3339)
+		invitations = append(invitations, &invitation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &pb.ListInvitationsResponse{Invitations: invitations}, nil
+}
+
+func (s *groupsServer) AcceptInvitation(ctx context.Context, req *pb.AcceptInvitationRequest) (*pb.AcceptInvitationResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetInvitationId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid invitation id")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var groupId, role string
+	query := `SELECT g.id, i.role` + pendingInvitationsQuery + ` AND i.id = $2 FOR UPDATE OF i`
+	err = tx.QueryRowContext(ctx, query, userId, req.GetInvitationId()).Scan(&groupId, &role)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "invitation not found or expired")
+	}
+	if err != nil {
+		log.Printf("Failed to load group invitation: %v", err)
+		return nil, err
+	}
+
+	query = `INSERT INTO group_member_data (group_id, uuid, role) VALUES ($1, $2, $3) ON CONFLICT (group_id, uuid) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, groupId, userId, role); err != nil {
+		log.Printf("Failed to add group member: %v", err)
+		return nil, err
+	}
+	query = `UPDATE group_invitation_data SET accepted_at = current_timestamp, invitee = $1 WHERE id = $2`
+	if _, err := tx.ExecContext(ctx, query, userId, req.GetInvitationId()); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	group, err := loadGroup(ctx, s.db, groupId, userId)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("User %s joined group %s", userId, groupId)
+	return &pb.AcceptInvitationResponse{Group: group}, nil
+}
+
+func (s *groupsServer) SetMemberRole(ctx context.Context, req *pb.SetMemberRoleRequest) (*pb.SetMemberRoleResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newRole := req.GetRole()
+	if newRole != groupRoleOwner && newRole != groupRoleAdmin && newRole != groupRoleMember {
+		return nil, status.Errorf(codes.InvalidArgument, "role must be %q, %q or %q", groupRoleOwner, groupRoleAdmin, groupRoleMember)
+	}
+	callerRole, err := groupRole(ctx, s.db, req.GetGroupId(), userId)
+	if err != nil {
+		return nil, err
+	}
+	targetRole, err := groupRole(ctx, s.db, req.GetGroupId(), req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "member not found")
+	}
+
+	// Admins manage members and other admins; only owners can grant,
+	// revoke or change ownership.
+	switch {
+	case callerRole == groupRoleOwner:
+	case callerRole == groupRoleAdmin && targetRole != groupRoleOwner && newRole != groupRoleOwner:
+	default:
+		return nil, status.Error(codes.PermissionDenied, "insufficient group role")
+	}
+	if targetRole == groupRoleOwner && newRole != groupRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, req.GetGroupId(), req.GetUserId()); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `UPDATE group_member_data SET role = $1 WHERE group_id = $2 AND uuid = $3`
+	if _, err := s.db.ExecContext(ctx, query, newRole, req.GetGroupId(), req.GetUserId()); err != nil {
+		log.Printf("Failed to set group member role: %v", err)
+		return nil, err
+	}
+
+	return &pb.SetMemberRoleResponse{Message: "Role updated"}, nil
+}
+
+func (s *groupsServer) RemoveMember(ctx context.Context, req *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	callerRole, err := groupRole(ctx, s.db, req.GetGroupId(), userId)
+	if err != nil {
+		return nil, err
+	}
+	targetId := req.GetUserId()
+	if targetId == "" {
+		targetId = userId
+	}
+	targetRole, err := groupRole(ctx, s.db, req.GetGroupId(), targetId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "member not found")
+	}
+
+	leaving := targetId == userId
+	switch {
+	case leaving:
+	case callerRole == groupRoleOwner:
+	case callerRole == groupRoleAdmin && targetRole == groupRoleMember:
+	default:
+		return nil, status.Error(codes.PermissionDenied, "insufficient group role")
+	}
+
+	var members int
+	if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM group_member_data WHERE group_id = $1`, req.GetGroupId()).Scan(&members); err != nil {
+		return nil, err
+	}
+	// The last member leaving dissolves the group. Its expenses fall back
+	// to the members who recorded them.
+	if members == 1 {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM group_data WHERE id = $1`, req.GetGroupId()); err != nil {
+			log.Printf("Failed to delete group: %v", err)
+			return nil, err
+		}
+		log.Printf("Group %s deleted after its last member left", req.GetGroupId())
+		return &pb.RemoveMemberResponse{Message: "Group deleted"}, nil
+	}
+	if targetRole == groupRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, req.GetGroupId(), targetId); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM group_member_data WHERE group_id = $1 AND uuid = $2`, req.GetGroupId(), targetId); err != nil {
+		log.Printf("Failed to remove group member: %v", err)
+		return nil, err
+	}
+
+	if leaving {
+		return &pb.RemoveMemberResponse{Message: "You left the group"}, nil
+	}
+	return &pb.RemoveMemberResponse{Message: "Member removed"}, nil
+}
+
+// ensureAnotherOwner keeps a group from losing its last owner.
+func (s *groupsServer) ensureAnotherOwner(ctx context.Context, groupId, userId string) error {
+	var owners int
+	query := `SELECT count(*) FROM group_member_data WHERE group_id = $1 AND role = $2 AND uuid <> $3`
+	if err := s.db.QueryRowContext(ctx, query, groupId, groupRoleOwner, userId).Scan(&owners); err != nil {
+		return err
+	}
+	if owners == 0 {
+		return status.Error(codes.FailedPrecondition, "a group needs at least one owner, transfer ownership first")
+	}
+	return nil
+}
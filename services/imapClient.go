@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const imapTimeout = 2 * time.Minute
+
+var (
+	imapLiteralPattern     = regexp.MustCompile(`\{(\d+)\}$`)
+	imapUidValidityPattern = regexp.MustCompile(`(?i)\[UIDVALIDITY (\d+)\]`)
+)
+
+// imapResponse is an untagged response line. Literals the server sent
+// inside the line are collected in order.
+type imapResponse struct {
+	line     string
+	literals [][]byte
+}
+
+// imapClient speaks the few IMAP4rev1 commands needed to read new mail:
+// LOGIN, EXAMINE, UID SEARCH and UID FETCH.
+type imapClient struct {
+	conn net.Conn
+	r    *bufio.Reader
+	tag  int
+}
+
+// dialImap connects to an IMAP server over TLS, or in plain text when
+// insecure is set, and reads its greeting. Plain text and internal
+// addresses are refused unless MAILBOX_ALLOW_INSECURE is set.
+func dialImap(ctx context.Context, host string, port int, insecure bool) (*imapClient, error) {
+	if insecure && !mailboxAllowInsecure() {
+		return nil, fmt.Errorf("mailboxes cannot be read without TLS on this server")
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	dialer := &net.Dialer{Timeout: 30 * time.Second, Control: mailboxDialControl}
+	var conn net.Conn
+	var err error
+	if insecure {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host}}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	return newImapClient(conn)
+}
+
+// newImapClient reads the greeting of the server on conn.
+func newImapClient(conn net.Conn) (*imapClient, error) {
+	c := &imapClient{conn: conn, r: bufio.NewReader(conn)}
+	conn.SetDeadline(time.Now().Add(imapTimeout))
+	greeting, err := c.readLine()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !strings.HasPrefix(strings.ToUpper(greeting), "* OK") {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected IMAP greeting: %s", greeting)
+	}
+	return c, nil
+}
+
+func (c *imapClient) Close() error {
+	c.conn.SetDeadline(time.Now().Add(10 * time.Second))
+	c.command("LOGOUT")
+	return c.conn.Close()
+}
+
+func (c *imapClient) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read IMAP response: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// command sends a tagged command and returns the untagged responses that
+// preceded its completion. Literals larger than maxEmailSize are discarded
+// and returned as nil.
+func (c *imapClient) command(format string, args ...any) ([]imapResponse, error) {
+	c.tag++
+	tag := fmt.Sprintf("A%03d", c.tag)
+	c.conn.SetDeadline(time.Now().Add(imapTimeout))
+	if _, err := fmt.Fprintf(c.conn, "%s %s\r\n", tag, fmt.Sprintf(format, args...)); err != nil {
+		return nil, fmt.Errorf("failed to send IMAP command: %w", err)
+	}
+
+	var responses []imapResponse
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(line, tag+" ") {
+			result := strings.TrimPrefix(line, tag+" ")
+			if !strings.HasPrefix(strings.ToUpper(result), "OK") {
+				return nil, fmt.Errorf("IMAP command failed: %s", result)
+			}
+			return responses, nil
+		}
+
+		response := imapResponse{line: line}
+		for {
+			match := imapLiteralPattern.FindStringSubmatch(line)
+			if match == nil {
+				break
+			}
+			size, err := strconv.ParseInt(match[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IMAP literal: %s", match[0])
+			}
+			var literal []byte
+			if size <= maxEmailSize {
+				literal = make([]byte, size)
+				_, err = io.ReadFull(c.r, literal)
+			} else {
+				_, err = io.CopyN(io.Discard, c.r, size)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read IMAP literal: %w", err)
+			}
+			response.literals = append(response.literals, literal)
+			if line, err = c.readLine(); err != nil {
+				return nil, err
+			}
+			response.line += " " + line
+		}
+		responses = append(responses, response)
+	}
+}
+
+// imapQuote quotes a string argument.
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func (c *imapClient) Login(username, password string) error {
+	if strings.ContainsAny(username+password, "\r\n") {
+		return fmt.Errorf("credentials must not contain line breaks")
+	}
+	_, err := c.command("LOGIN %s %s", imapQuote(username), imapQuote(password))
+	return err
+}
+
+// Examine opens a mailbox read-only, so that reading leaves messages unseen,
+// and returns its UIDVALIDITY.
+func (c *imapClient) Examine(mailbox string) (int64, error) {
+	responses, err := c.command("EXAMINE %s", imapQuote(mailbox))
+	if err != nil {
+		return 0, err
+	}
+	for _, response := range responses {
+		if match := imapUidValidityPattern.FindStringSubmatch(response.line); match != nil {
+			return strconv.ParseInt(match[1], 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("server did not report UIDVALIDITY")
+}
+
+// SearchUids returns the UIDs matching criteria in ascending order.
+func (c *imapClient) SearchUids(criteria string) ([]int64, error) {
+	responses, err := c.command("UID SEARCH %s", criteria)
+	if err != nil {
+		return nil, err
+	}
+	var uids []int64
+	for _, response := range responses {
+		fields := strings.Fields(response.line)
+		if len(fields) < 2 || !strings.EqualFold(fields[1], "SEARCH") {
+			continue
+		}
+		for _, field := range fields[2:] {
+			if uid, err := strconv.ParseInt(field, 10, 64); err == nil {
+				uids = append(uids, uid)
+			}
+		}
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	return uids, nil
+}
+
+// FetchMessage returns the raw message with the UID, or nil when it is
+// larger than maxEmailSize.
+func (c *imapClient) FetchMessage(uid int64) ([]byte, error) {
+	responses, err := c.command("UID FETCH %d BODY.PEEK[]", uid)
+	if err != nil {
+		return nil, err
+	}
+	for _, response := range responses {
+		if strings.Contains(strings.ToUpper(response.line), "FETCH") && len(response.literals) > 0 {
+			return response.literals[0], nil
+		}
+	}
+	return nil, fmt.Errorf("message %d was not returned", uid)
+}
+
+// imapDate formats a date for SEARCH SINCE.
+func imapDate(t time.Time) string {
+	return t.Format("02-Jan-2006")
+}
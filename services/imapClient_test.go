@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeImapServer answers the client on the other end of a pipe. respond is
+// given each command without its tag and writes the untagged responses; the
+// tagged OK is sent after it unless respond returns a result of its own.
+func fakeImapServer(t *testing.T, respond func(command string, w io.Writer) string) (*imapClient, *[]string) {
+	t.Helper()
+	client, server := net.Pipe()
+	var commands []string
+	go func() {
+		defer server.Close()
+		r := bufio.NewReader(server)
+		fmt.Fprintf(server, "* OK fake IMAP ready\r\n")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			tag, command, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+			commands = append(commands, command)
+			result := ""
+			if command != "LOGOUT" {
+				result = respond(command, server)
+			}
+			if result == "" {
+				result = "OK done"
+			}
+			fmt.Fprintf(server, "%s %s\r\n", tag, result)
+		}
+	}()
+
+	c, err := newImapClient(client)
+	if err != nil {
+		t.Fatalf("newImapClient: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c, &commands
+}
+
+func TestImapClientExamine(t *testing.T) {
+	c, commands := fakeImapServer(t, func(command string, w io.Writer) string {
+		if strings.HasPrefix(command, "EXAMINE") {
+			fmt.Fprintf(w, "* 3 EXISTS\r\n* OK [UIDVALIDITY 1700000042] UIDs valid\r\n")
+		}
+		return ""
+	})
+	if err := c.Login("me@example.com", `pa"ss\word`); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	uidValidity, err := c.Examine("INBOX")
+	if err != nil {
+		t.Fatalf("Examine: %v", err)
+	}
+	if uidValidity != 1700000042 {
+		t.Errorf("UIDVALIDITY = %d, want 1700000042", uidValidity)
+	}
+	if want := `LOGIN "me@example.com" "pa\"ss\\word"`; (*commands)[0] != want {
+		t.Errorf("sent %s, want %s", (*commands)[0], want)
+	}
+}
+
+func TestImapClientExamineWithoutUidValidity(t *testing.T) {
+	c, _ := fakeImapServer(t, func(string, io.Writer) string { return "" })
+	if _, err := c.Examine("INBOX"); err == nil {
+		t.Error("Examine succeeded without UIDVALIDITY")
+	}
+}
+
+func TestImapClientLoginRejectsLineBreaks(t *testing.T) {
+	c, commands := fakeImapServer(t, func(string, io.Writer) string { return "" })
+	if err := c.Login("me", "secret\r\nA999 DELETE INBOX"); err == nil {
+		t.Error("Login accepted a password with a line break")
+	}
+	if len(*commands) != 0 {
+		t.Errorf("commands were sent: %q", *commands)
+	}
+}
+
+func TestImapClientCommandFailure(t *testing.T) {
+	c, _ := fakeImapServer(t, func(string, io.Writer) string { return "NO [AUTHENTICATIONFAILED] invalid credentials" })
+	if err := c.Login("me", "wrong"); err == nil || !strings.Contains(err.Error(), "AUTHENTICATIONFAILED") {
+		t.Errorf("Login error = %v, want the server's NO", err)
+	}
+}
+
+func TestImapClientSearchUids(t *testing.T) {
+	c, commands := fakeImapServer(t, func(command string, w io.Writer) string {
+		fmt.Fprintf(w, "* SEARCH 12 4 9\r\n* SEARCH 15\r\n")
+		return ""
+	})
+	uids, err := c.SearchUids("UID 4:*")
+	if err != nil {
+		t.Fatalf("SearchUids: %v", err)
+	}
+	if fmt.Sprint(uids) != "[4 9 12 15]" {
+		t.Errorf("uids = %v, want [4 9 12 15]", uids)
+	}
+	if (*commands)[0] != "UID SEARCH UID 4:*" {
+		t.Errorf("sent %s", (*commands)[0])
+	}
+}
+
+func TestImapClientFetchLiteral(t *testing.T) {
+	// The literal holds what would otherwise end the response or look like
+	// another literal.
+	message := "Subject: Receipt\r\n\r\nTotal {12}\r\nA001 OK not really)\r\n"
+	c, _ := fakeImapServer(t, func(command string, w io.Writer) string {
+		fmt.Fprintf(w, "* 1 FETCH (UID 7 BODY[] {%d}\r\n%s FLAGS (\\Seen))\r\n", len(message), message)
+		return ""
+	})
+	raw, err := c.FetchMessage(7)
+	if err != nil {
+		t.Fatalf("FetchMessage: %v", err)
+	}
+	if string(raw) != message {
+		t.Errorf("message = %q, want %q", raw, message)
+	}
+}
+
+func TestImapClientFetchMissing(t *testing.T) {
+	c, _ := fakeImapServer(t, func(string, io.Writer) string { return "" })
+	if _, err := c.FetchMessage(7); err == nil {
+		t.Error("FetchMessage succeeded without a FETCH response")
+	}
+}
+
+func TestImapClientFetchOversized(t *testing.T) {
+	c, _ := fakeImapServer(t, func(command string, w io.Writer) string {
+		if strings.HasPrefix(command, "UID FETCH 8 ") {
+			fmt.Fprintf(w, "* 1 FETCH (UID 8 BODY[] {%d}\r\n", maxEmailSize+1)
+			io.Copy(w, io.LimitReader(repeatReader('x'), maxEmailSize+1))
+			fmt.Fprintf(w, ")\r\n")
+			return ""
+		}
+		fmt.Fprintf(w, "* 2 FETCH (UID 9 BODY[] {5}\r\nsmall)\r\n")
+		return ""
+	})
+	raw, err := c.FetchMessage(8)
+	if err != nil {
+		t.Fatalf("FetchMessage: %v", err)
+	}
+	if raw != nil {
+		t.Errorf("oversized message returned %d bytes, want nil", len(raw))
+	}
+
+	// The oversized literal was read past, so the next command lines up.
+	raw, err = c.FetchMessage(9)
+	if err != nil || string(raw) != "small" {
+		t.Errorf("next FetchMessage = %q, %v; want small", raw, err)
+	}
+}
+
+func TestMailboxSearchCriteria(t *testing.T) {
+	connectedAt := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	since := "SINCE " + imapDate(connectedAt.Add(-mailboxBackfill))
+	tests := []struct {
+		name        string
+		lastUid     int64
+		uidValidity int64
+		wantUid     int64
+		want        string
+	}{
+		{"first poll", 0, 42, 0, since},
+		{"next poll", 17, 42, 17, "UID 18:*"},
+		{"uidvalidity changed", 17, 43, 0, since},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mailboxRow{uidValidity: 42, lastUid: tt.lastUid, connectedAt: connectedAt}
+			lastUid, criteria := m.searchCriteria(tt.uidValidity)
+			if lastUid != tt.wantUid || criteria != tt.want {
+				t.Errorf("searchCriteria = %d, %q; want %d, %q", lastUid, criteria, tt.wantUid, tt.want)
+			}
+		})
+	}
+}
+
+// repeatReader is an endless stream of one byte.
+type repeatReader byte
+
+func (r repeatReader) Read(p []byte) (int, error) {
+	copy(p, bytes.Repeat([]byte{byte(r)}, len(p)))
+	return len(p), nil
+}
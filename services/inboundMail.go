@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	maxInboundRecipients = 10
+	inboundMailTimeout   = 5 * time.Minute
+)
+
+// serveInboundMail accepts mail for users' inbound addresses over LMTP, or
+// plain SMTP when the client greets with HELO or EHLO. It does no
+// authentication and is meant to sit behind the mail server that receives
+// for INBOUND_MAIL_DOMAIN, or to be reached directly by a local test
+// client.
+func serveInboundMail(ctx context.Context, listener net.Listener, sink inboundMailSink) {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	log.Println("Accepting inbound mail on ", listener.Addr())
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			log.Printf("Failed to accept inbound mail connection: %v", err)
+			continue
+		}
+		go handleInboundMail(ctx, conn, sink)
+	}
+}
+
+// inboundRecipient is a user that a message is being delivered to.
+type inboundRecipient struct {
+	address string
+	userId  string
+}
+
+// inboundMailSink looks up and delivers to the recipients of inbound mail.
+// The ingestion server is the only one outside of tests.
+type inboundMailSink interface {
+	inboundRecipientUser(ctx context.Context, address string) (string, error)
+	deliverInboundMail(ctx context.Context, recipients []inboundRecipient, raw []byte) []string
+}
+
+func handleInboundMail(ctx context.Context, conn net.Conn, sink inboundMailSink) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	hostname, _ := os.Hostname()
+
+	conn.SetDeadline(time.Now().Add(inboundMailTimeout))
+	text.PrintfLine("220 %s LMTP receipt intake ready", hostname)
+
+	lmtp := false
+	sender := false
+	var recipients []inboundRecipient
+	for {
+		conn.SetDeadline(time.Now().Add(inboundMailTimeout))
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "LHLO", "EHLO", "HELO":
+			lmtp = strings.EqualFold(verb, "LHLO")
+			sender, recipients = false, nil
+			if strings.EqualFold(verb, "HELO") {
+				text.PrintfLine("250 %s", hostname)
+			} else {
+				text.PrintfLine("250-%s", hostname)
+				text.PrintfLine("250-8BITMIME")
+				text.PrintfLine("250 SIZE %d", maxEmailSize)
+			}
+		case "MAIL":
+			if !strings.HasPrefix(strings.ToUpper(arg), "FROM:") {
+				text.PrintfLine("501 5.5.4 Syntax: MAIL FROM:<address>")
+				continue
+			}
+			sender, recipients = true, nil
+			text.PrintfLine("250 2.1.0 OK")
+		case "RCPT":
+			if !sender {
+				text.PrintfLine("503 5.5.1 MAIL first")
+				continue
+			}
+			if !strings.HasPrefix(strings.ToUpper(arg), "TO:") {
+				text.PrintfLine("501 5.5.4 Syntax: RCPT TO:<address>")
+				continue
+			}
+			if len(recipients) >= maxInboundRecipients {
+				text.PrintfLine("452 4.5.3 Too many recipients")
+				continue
+			}
+			address := inboundMailPath(arg[len("TO:"):])
+			userId, err := sink.inboundRecipientUser(ctx, address)
+			switch {
+			case err != nil:
+				log.Printf("Failed to look up inbound recipient: %v", err)
+				text.PrintfLine("451 4.3.0 Temporary failure")
+			case userId == "":
+				text.PrintfLine("550 5.1.1 No such recipient")
+			default:
+				recipients = append(recipients, inboundRecipient{address: address, userId: userId})
+				text.PrintfLine("250 2.1.5 OK")
+			}
+		case "DATA":
+			if len(recipients) == 0 {
+				text.PrintfLine("503 5.5.1 RCPT first")
+				continue
+			}
+			text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			conn.SetDeadline(time.Now().Add(inboundMailTimeout))
+			dot := text.DotReader()
+			raw, err := io.ReadAll(io.LimitReader(dot, maxEmailSize+1))
+			if err != nil {
+				return
+			}
+			// Drain whatever is left of an oversized message so the
+			// connection stays in step.
+			if _, err := io.Copy(io.Discard, dot); err != nil {
+				return
+			}
+			var replies []string
+			if len(raw) > maxEmailSize {
+				for range recipients {
+					replies = append(replies, "552 5.3.4 Message too big")
+				}
+			} else {
+				replies = sink.deliverInboundMail(ctx, recipients, raw)
+			}
+			if lmtp {
+				for _, reply := range replies {
+					text.PrintfLine("%s", reply)
+				}
+			} else {
+				text.PrintfLine("%s", smtpReply(replies))
+			}
+			sender, recipients = false, nil
+		case "RSET":
+			sender, recipients = false, nil
+			text.PrintfLine("250 2.0.0 OK")
+		case "NOOP":
+			text.PrintfLine("250 2.0.0 OK")
+		case "QUIT":
+			text.PrintfLine("221 2.0.0 Bye")
+			return
+		default:
+			text.PrintfLine("502 5.5.2 Command not recognised")
+		}
+	}
+}
+
+// deliverInboundMail ingests the message for every recipient and returns one
+// reply per recipient, as LMTP requires.
+func (s *ingestionServer) deliverInboundMail(ctx context.Context, recipients []inboundRecipient, raw []byte) []string {
+	replies := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		result, err := s.ingestEmail(ctx, recipient.userId, raw)
+		if err != nil {
+			log.Printf("Failed to ingest inbound mail for %s: %v", recipient.address, err)
+			replies = append(replies, "451 4.3.0 Temporary failure, try again later")
+			continue
+		}
+		log.Printf("Inbound mail for user %s: %s", recipient.userId, result.Status)
+		replies = append(replies, "250 2.0.0 OK "+result.Status)
+	}
+	return replies
+}
+
+// smtpReply folds per-recipient replies into the single reply SMTP allows:
+// success if any recipient took the message, else the first failure.
+func smtpReply(replies []string) string {
+	for _, reply := range replies {
+		if strings.HasPrefix(reply, "250") {
+			return reply
+		}
+	}
+	return replies[0]
+}
+
+// inboundMailPath reads the address out of a "<address> PARAMS" path.
+func inboundMailPath(path string) string {
+	path = strings.TrimSpace(path)
+	if start := strings.Index(path, "<"); start >= 0 {
+		if end := strings.Index(path[start:], ">"); end > 0 {
+			return strings.ToLower(path[start+1 : start+end])
+		}
+	}
+	address, _, _ := strings.Cut(path, " ")
+	return strings.ToLower(address)
+}
+
+// inboundRecipientUser returns the user an inbound address belongs to, or
+// "" when it belongs to no one or its owner does not consent to email
+// ingestion. The token may follow a "+" in the local part or make up the
+// whole of it.
+func (s *ingestionServer) inboundRecipientUser(ctx context.Context, address string) (string, error) {
+	inboundDomain := os.Getenv("INBOUND_MAIL_DOMAIN")
+	local, domain, ok := strings.Cut(address, "@")
+	if !ok || inboundDomain == "" || !strings.EqualFold(domain, inboundDomain) {
+		return "", nil
+	}
+	if i := strings.LastIndex(local, "+"); i >= 0 {
+		local = local[i+1:]
+	}
+	if local == "" {
+		return "", nil
+	}
+
+	var userId string
+	query := `
+		SELECT a.uuid FROM inbound_address_data a
+		JOIN message_consent_data c ON c.uuid = a.uuid AND c.channel = $2 AND c.revoked_at IS NULL
+		JOIN user_data u ON u.uuid = a.uuid AND u.disabled_at IS NULL
+		WHERE a.token = $1`
+	err := s.db.QueryRowContext(ctx, query, local, channelEmail).Scan(&userId)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return userId, err
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeMailSink accepts mail for the addresses in users.
+type fakeMailSink struct {
+	users     map[string]string
+	mu        sync.Mutex
+	delivered []string
+}
+
+func (f *fakeMailSink) inboundRecipientUser(_ context.Context, address string) (string, error) {
+	return f.users[address], nil
+}
+
+func (f *fakeMailSink) deliverInboundMail(_ context.Context, recipients []inboundRecipient, raw []byte) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var replies []string
+	for _, recipient := range recipients {
+		f.delivered = append(f.delivered, recipient.userId+":"+string(raw))
+		replies = append(replies, "250 2.0.0 OK created")
+	}
+	return replies
+}
+
+// dialFakeInbound runs handleInboundMail on one end of a pipe and returns
+// the other, past the greeting.
+func dialFakeInbound(t *testing.T, sink *fakeMailSink) *textproto.Conn {
+	t.Helper()
+	client, server := net.Pipe()
+	go handleInboundMail(context.Background(), server, sink)
+	text := textproto.NewConn(client)
+	t.Cleanup(func() { text.Close() })
+	if _, _, err := text.ReadResponse(220); err != nil {
+		t.Fatalf("greeting: %v", err)
+	}
+	return text
+}
+
+// expect sends a command and checks the code of the reply.
+func expect(t *testing.T, text *textproto.Conn, code int, format string, args ...any) string {
+	t.Helper()
+	if err := text.PrintfLine(format, args...); err != nil {
+		t.Fatalf("send %q: %v", fmt.Sprintf(format, args...), err)
+	}
+	_, message, err := text.ReadResponse(code)
+	if err != nil {
+		t.Fatalf("%q: %v", fmt.Sprintf(format, args...), err)
+	}
+	return message
+}
+
+func newFakeMailSink() *fakeMailSink {
+	return &fakeMailSink{users: map[string]string{
+		"receipts+alice@in.example.com": "alice",
+		"receipts+bob@in.example.com":   "bob",
+	}}
+}
+
+func TestInboundMailLmtpDelivery(t *testing.T) {
+	sink := newFakeMailSink()
+	text := dialFakeInbound(t, sink)
+
+	expect(t, text, 250, "LHLO test")
+	expect(t, text, 250, "MAIL FROM:<shop@example.com>")
+	expect(t, text, 250, "RCPT TO:<receipts+alice@in.example.com>")
+	expect(t, text, 550, "RCPT TO:<nobody@in.example.com>")
+	expect(t, text, 250, "RCPT TO:<Receipts+Bob@in.example.com> NOTIFY=NEVER")
+	expect(t, text, 354, "DATA")
+
+	// The dot writer stuffs the leading dots; the listener has to remove
+	// them again.
+	body := "Subject: Receipt\r\n\r\n.hidden line\r\n..two dots\r\nTotal 12.50\r\n"
+	w := text.DotWriter()
+	io.WriteString(w, body)
+	w.Close()
+
+	// LMTP replies once per accepted recipient.
+	for _, user := range []string{"alice", "bob"} {
+		if _, _, err := text.ReadResponse(250); err != nil {
+			t.Fatalf("reply for %s: %v", user, err)
+		}
+	}
+	expect(t, text, 221, "QUIT")
+
+	if len(sink.delivered) != 2 {
+		t.Fatalf("delivered %d messages, want 2", len(sink.delivered))
+	}
+	// Lines come out of the dot reader ending in LF alone.
+	unstuffed := strings.ReplaceAll(body, "\r\n", "\n")
+	for i, user := range []string{"alice", "bob"} {
+		if want := user + ":" + unstuffed; sink.delivered[i] != want {
+			t.Errorf("delivered %q, want %q", sink.delivered[i], want)
+		}
+	}
+}
+
+func TestInboundMailSmtpSingleReply(t *testing.T) {
+	sink := newFakeMailSink()
+	text := dialFakeInbound(t, sink)
+
+	expect(t, text, 250, "EHLO test")
+	expect(t, text, 250, "MAIL FROM:<shop@example.com>")
+	expect(t, text, 250, "RCPT TO:<receipts+alice@in.example.com>")
+	expect(t, text, 250, "RCPT TO:<receipts+bob@in.example.com>")
+	expect(t, text, 354, "DATA")
+	w := text.DotWriter()
+	io.WriteString(w, "Subject: hi\r\n\r\nbody\r\n")
+	w.Close()
+	if _, _, err := text.ReadResponse(250); err != nil {
+		t.Fatalf("DATA reply: %v", err)
+	}
+	// Only one reply was sent, so the next command gets the next one.
+	expect(t, text, 250, "NOOP")
+}
+
+func TestInboundMailCommandOrder(t *testing.T) {
+	text := dialFakeInbound(t, newFakeMailSink())
+
+	expect(t, text, 250, "LHLO test")
+	expect(t, text, 503, "RCPT TO:<receipts+alice@in.example.com>")
+	expect(t, text, 503, "DATA")
+	expect(t, text, 501, "MAIL <shop@example.com>")
+	expect(t, text, 250, "MAIL FROM:<shop@example.com>")
+	expect(t, text, 501, "RCPT <receipts+alice@in.example.com>")
+	expect(t, text, 250, "RSET")
+	expect(t, text, 503, "RCPT TO:<receipts+alice@in.example.com>")
+	expect(t, text, 502, "VRFY alice")
+}
+
+func TestInboundMailTooManyRecipients(t *testing.T) {
+	sink := newFakeMailSink()
+	for i := 0; i <= maxInboundRecipients; i++ {
+		sink.users[fmt.Sprintf("r%d@in.example.com", i)] = fmt.Sprint(i)
+	}
+	text := dialFakeInbound(t, sink)
+
+	expect(t, text, 250, "LHLO test")
+	expect(t, text, 250, "MAIL FROM:<shop@example.com>")
+	for i := 0; i < maxInboundRecipients; i++ {
+		expect(t, text, 250, "RCPT TO:<r%d@in.example.com>", i)
+	}
+	expect(t, text, 452, "RCPT TO:<r%d@in.example.com>", maxInboundRecipients)
+}
+
+func TestInboundMailOversized(t *testing.T) {
+	sink := newFakeMailSink()
+	text := dialFakeInbound(t, sink)
+
+	expect(t, text, 250, "LHLO test")
+	expect(t, text, 250, "MAIL FROM:<shop@example.com>")
+	expect(t, text, 250, "RCPT TO:<receipts+alice@in.example.com>")
+	expect(t, text, 354, "DATA")
+	w := text.DotWriter()
+	// Sizes are counted once the line endings are down to LF.
+	line := strings.Repeat("x", 998) + "\r\n"
+	for written := 0; written <= maxEmailSize; written += len(line) - 1 {
+		io.WriteString(w, line)
+	}
+	w.Close()
+	if _, message, err := text.ReadResponse(552); err != nil {
+		t.Fatalf("DATA reply: %v (%s)", err, message)
+	}
+	if len(sink.delivered) != 0 {
+		t.Errorf("oversized message was delivered")
+	}
+	// The rest of the message was drained, so the session carries on.
+	expect(t, text, 250, "NOOP")
+}
+
+func TestInboundMailPath(t *testing.T) {
+	tests := map[string]string{
+		"<Receipts+Abc@In.Example.com>": "receipts+abc@in.example.com",
+		" <a@example.com> SIZE=100":     "a@example.com",
+		"a@example.com NOTIFY=NEVER":    "a@example.com",
+		"<>":                            "",
+		"<\"quoted local\"@example.com> BODY=8BITMIME": "\"quoted local\"@example.com",
+	}
+	for path, want := range tests {
+		if got := inboundMailPath(path); got != want {
+			t.Errorf("inboundMailPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
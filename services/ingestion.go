@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+const (
+	channelSms = "sms"
+
+	maxMessagesPerRequest = 500
+	maxSmsLength          = 2000
+)
+
+// smsPrompt asks the extraction model to read a message no template knows.
+const smsPrompt = `
+	You are a helpful assistant. The text below is an SMS message. If it reports a completed payment, purchase,
+		withdrawal or refund, return it as a JSON object. Do not include any extra text before or after the JSON.
+
+		Fields to extract:
+		- "merchant_details":
+		- "name": The merchant, payee or payer.
+		- "transaction_details":
+		- "payment_method": The payment method (e.g., "UPI", "Credit Card", "Debit Card", "Bank Transfer").
+		- "total_amount": The amount as a float. Use a negative amount for money received.
+		- "currency": The ISO 4217 code of the currency, if stated.
+		- "spending_category": A top-level classification (e.g., "Groceries", "Dining Out", "Utilities").
+
+		If the message is not such a transaction, for example an OTP, a balance or a promotion, return {}.
+		Return only the JSON object.
+	`
+
+type ingestionServer struct {
+	pb.UnimplementedIngestionServiceServer
+	db       *sql.DB
+	expenses *expenseServer
+
+	// polling holds the users whose mailbox is being read.
+	polling sync.Map
+}
+
+func validChannel(channel string) bool {
+	return channel == channelSms || channel == channelEmail
+}
+
+func scanConsent(row interface{ Scan(...any) error }) (*pb.IngestionConsent, error) {
+	var consent pb.IngestionConsent
+	var grantedAt time.Time
+	var revokedAt sql.NullTime
+	if err := row.Scan(&consent.Channel, &grantedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	consent.Granted = !revokedAt.Valid
+	consent.GrantedAt = grantedAt.Format(time.RFC3339)
+	if revokedAt.Valid {
+		consent.RevokedAt = revokedAt.Time.Format(time.RFC3339)
+	}
+	return &consent, nil
+}
+
+func (s *ingestionServer) SetIngestionConsent(ctx context.Context, req *pb.SetIngestionConsentRequest) (*pb.SetIngestionConsentResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !validChannel(req.GetChannel()) {
+		return nil, status.Error(codes.InvalidArgument, "channel must be sms or email")
+	}
+
+	var query string
+	if req.GetGranted() {
+		query = `
+			INSERT INTO message_consent_data (uuid, channel) VALUES ($1, $2)
+			ON CONFLICT (uuid, channel) DO UPDATE SET granted_at = current_timestamp, revoked_at = NULL
+			RETURNING channel, granted_at, revoked_at`
+	} else {
+		query = `
+			UPDATE message_consent_data SET revoked_at = coalesce(revoked_at, current_timestamp)
+			WHERE uuid = $1 AND channel = $2
+			RETURNING channel, granted_at, revoked_at`
+	}
+	consent, err := scanConsent(s.db.QueryRowContext(ctx, query, userId, req.GetChannel()))
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "consent was never granted")
+	}
+	if err != nil {
+		log.Printf("Failed to record ingestion consent: %v", err)
+		return nil, err
+	}
+
+	log.Printf("User %s set %s ingestion consent to %v", userId, req.GetChannel(), req.GetGranted())
+	return &pb.SetIngestionConsentResponse{Consent: consent}, nil
+}
+
+func (s *ingestionServer) ListIngestionConsents(ctx context.Context, req *pb.ListIngestionConsentsRequest) (*pb.ListIngestionConsentsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT channel, granted_at, revoked_at FROM message_consent_data WHERE uuid = $1 ORDER BY channel`, userId)
+	if err != nil {
+		log.Printf("Failed to list ingestion consents: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var consents []*pb.IngestionConsent
+	for rows.Next() {
+		consent, err := scanConsent(rows)
+		if err != nil {
+			return nil, err
+		}
+		consents = append(consents, consent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &pb.ListIngestionConsentsResponse{Consents: consents}, nil
+}
+
+// requireConsent fails unless the user currently consents to the channel.
+func requireConsent(ctx context.Context, db *sql.DB, userId, channel string) error {
+	var granted bool
+	query := `SELECT EXISTS (SELECT 1 FROM message_consent_data WHERE uuid = $1 AND channel = $2 AND revoked_at IS NULL)`
+	if err := db.QueryRowContext(ctx, query, userId, channel).Scan(&granted); err != nil {
+		return err
+	}
+	if !granted {
+		return status.Errorf(codes.FailedPrecondition, "%s ingestion requires consent", channel)
+	}
+	return nil
+}
+
+// messageKey identifies a message for de-duplication without storing it.
+func messageKey(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// errIngestRetry marks a message that could not be read for a reason that
+// may go away, such as the extraction model being unavailable. Such
+// messages are not recorded as ingested, so they can be sent again.
+var errIngestRetry = errors.New("the extraction model is unavailable")
+
+// alreadyIngested reports whether the message has been read before. It
+// saves reading a message again; recordIngested has the final say.
+func alreadyIngested(ctx context.Context, db *sql.DB, userId, channel, key string) (bool, error) {
+	var seen bool
+	query := `SELECT EXISTS (SELECT 1 FROM ingested_message_data WHERE uuid = $1 AND channel = $2 AND message_key = $3)`
+	err := db.QueryRowContext(ctx, query, userId, channel, key).Scan(&seen)
+	return seen, err
+}
+
+// recordIngested claims the message's key with its outcome and, for a
+// message that created an expense, writes the expense in the same
+// transaction. A copy of the message being recorded at the same time waits
+// for the claim and then finds it taken, so only one expense is created.
+// It returns false when the message had already been recorded.
+func (s *ingestionServer) recordIngested(ctx context.Context, userId, channel, key, outcome string, expense *models.Transaction) (string, bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", false, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO ingested_message_data (uuid, channel, message_key, status)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING`
+	res, err := tx.ExecContext(ctx, query, userId, channel, key, outcome)
+	if err != nil {
+		return "", false, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return "", false, nil
+	}
+
+	var expenseId string
+	if expense != nil {
+		if expenseId, err = writeExpense(ctx, tx, *expense); err != nil {
+			return "", false, err
+		}
+		query := `UPDATE ingested_message_data SET expense_id = $4 WHERE uuid = $1 AND channel = $2 AND message_key = $3`
+		if _, err := tx.ExecContext(ctx, query, userId, channel, key, expenseId); err != nil {
+			return "", false, err
+		}
+	}
+	return expenseId, true, tx.Commit()
+}
+
+func (s *ingestionServer) IngestMessages(ctx context.Context, req *pb.IngestMessagesRequest) (*pb.IngestMessagesResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.GetMessages()) > maxMessagesPerRequest {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d messages are allowed per request", maxMessagesPerRequest)
+	}
+	if err := requireConsent(ctx, s.db, userId, channelSms); err != nil {
+		return nil, err
+	}
+
+	var defaultCurrency string
+	if err := s.db.QueryRowContext(ctx, `SELECT default_currency FROM user_data WHERE uuid = $1`, userId).Scan(&defaultCurrency); err != nil {
+		return nil, err
+	}
+
+	res := &pb.IngestMessagesResponse{}
+	for i, message := range req.GetMessages() {
+		result := &pb.IngestedMessage{Index: int32(i)}
+		res.Results = append(res.Results, result)
+
+		body := strings.TrimSpace(message.GetBody())
+		if body == "" || len(body) > maxSmsLength {
+			result.Status = messageFailed
+			result.Reason = "body must be between 1 and 2000 characters"
+			continue
+		}
+		receivedAt := time.Now()
+		if message.GetReceivedAt() != "" {
+			if receivedAt, err = time.Parse(time.RFC3339, message.GetReceivedAt()); err != nil {
+				result.Status = messageFailed
+				result.Reason = "received_at must be an RFC 3339 timestamp"
+				continue
+			}
+		}
+
+		key := messageKey(message.GetSender(), receivedAt.UTC().Format(time.RFC3339), body)
+		seen, err := alreadyIngested(ctx, s.db, userId, channelSms, key)
+		if err != nil {
+			return nil, err
+		}
+		if seen {
+			result.Status = messageDuplicate
+			continue
+		}
+
+		expense, parser, outcome, err := s.readSms(ctx, message.GetSender(), body)
+		result.Parser = parser
+		if err != nil {
+			result.Status = messageFailed
+			result.Reason = "the message could not be read now, send it again later"
+			continue
+		}
+		var record *models.Transaction
+		if outcome == messageCreated {
+			expense.UUID = userId
+			expense.Source = expenseSourceSms
+			expense.TransactionDetails.DateTime = receivedAt
+			if expense.TransactionDetails.Currency == "" {
+				expense.TransactionDetails.Currency = defaultCurrency
+			}
+			expense = s.expenses.categorizeTransaction(ctx, expense)
+			record = &expense
+		}
+		expenseId, recorded, err := s.recordIngested(ctx, userId, channelSms, key, outcome, record)
+		if err != nil {
+			log.Printf("Failed to record SMS: %v", err)
+			result.Status = messageFailed
+			result.Reason = "the expense could not be stored"
+			continue
+		}
+		if !recorded {
+			result.Status = messageDuplicate
+			continue
+		}
+		result.Status = outcome
+		result.ExpenseId = expenseId
+		if outcome == messageCreated {
+			res.Created++
+		}
+	}
+
+	log.Printf("Ingested %d SMS messages for user %s: %d expenses created", len(req.GetMessages()), userId, res.Created)
+	return res, nil
+}
+
+// readSms turns a message into a transaction. OTPs and promotions are
+// dropped, bank templates are tried next and the extraction model reads
+// whatever still looks like a transaction. It returns errIngestRetry when
+// the model could not be reached.
+func (s *ingestionServer) readSms(ctx context.Context, sender, body string) (models.Transaction, string, string, error) {
+	var expense models.Transaction
+	if isOtp(body) {
+		return expense, "", messageOtp, nil
+	}
+	if promotionalSenderPattern.MatchString(strings.TrimSpace(sender)) {
+		return expense, "", messagePromotional, nil
+	}
+
+	if parsed, ok := parseSms(sender, body); ok {
+		expense.MerchantDetails.Name = parsed.merchant
+		expense.TransactionDetails.PaymentMethod = parsed.method
+		expense.TransactionDetails.TotalAmount = parsed.amount
+		if parsed.credit {
+			expense.TransactionDetails.TotalAmount = -parsed.amount
+		}
+		expense.TransactionDetails.Currency = parsed.currency
+		return expense, parsed.template, messageCreated, nil
+	}
+
+	if promotionalPattern.MatchString(body) {
+		return expense, "", messagePromotional, nil
+	}
+	if !transactionPattern.MatchString(body) || !amountPattern.MatchString(body) {
+		return expense, "", messageNotTransaction, nil
+	}
+
+	answer, err := s.expenses.extractor.Extract(ctx, []byte(body), "text/plain", smsPrompt)
+	if err != nil {
+		log.Printf("Error extracting SMS with the model: %v", err)
+		return expense, "model", messageFailed, errIngestRetry
+	}
+	expense, err = parseTransaction(answer)
+	if err != nil || expense.TransactionDetails.TotalAmount == 0 || strings.TrimSpace(expense.MerchantDetails.Name) == "" {
+		return models.Transaction{}, "model", messageNotTransaction, nil
+	}
+	expense.MerchantDetails.Name = cleanSmsMerchant(expense.MerchantDetails.Name)
+	expense.TransactionDetails.Currency = strings.ToUpper(strings.TrimSpace(expense.TransactionDetails.Currency))
+	if len(expense.TransactionDetails.Currency) != 3 {
+		expense.TransactionDetails.Currency = ""
+	}
+	expense.Items = nil
+	return expense, "model", messageCreated, nil
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	minSuggestionConfidence = 0.2
+	maxSuggestionsPerItem   = 3
+)
+
+type expenseItem struct {
+	id       string
+	name     string
+	price    float64
+	quantity int32
+}
+
+func (s *groupsServer) expenseItems(ctx context.Context, expenseId string) ([]expenseItem, error) {
+	query := `SELECT id, item_name, price, quantity FROM expense_item_data WHERE expense_id = $1 ORDER BY item_name, id`
+	rows, err := s.db.QueryContext(ctx, query, expenseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []expenseItem
+	for rows.Next() {
+		var item expenseItem
+		if err := rows.Scan(&item.id, &item.name, &item.price, &item.quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (s *groupsServer) AssignExpenseItems(ctx context.Context, req *pb.AssignExpenseItemsRequest) (*pb.SplitExpenseResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	expense, err := s.loadSplittableExpense(ctx, req.GetExpenseId(), userId)
+	if err != nil {
+		return nil, err
+	}
+	paidBy, err := s.payer(ctx, expense, req.GetPaidBy(), userId)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.expenseItems(ctx, expense.id)
+	if err != nil {
+		log.Printf("Failed to load expense items: %v", err)
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "expense has no line items")
+	}
+
+	known := map[string]bool{}
+	for _, item := range items {
+		known[item.id] = true
+	}
+	members := map[string]bool{}
+	var participants []string
+	assignments := map[string][]string{}
+	for _, assignment := range req.GetAssignments() {
+		if !known[assignment.GetItemId()] {
+			return nil, status.Errorf(codes.InvalidArgument, "item %s does not belong to this expense", assignment.GetItemId())
+		}
+		if _, dup := assignments[assignment.GetItemId()]; dup {
+			return nil, status.Error(codes.InvalidArgument, "each item can only be assigned once")
+		}
+		var assignees []string
+		for _, member := range assignment.GetUserIds() {
+			if !members[member] {
+				if _, err := groupRole(ctx, s.db, expense.groupId, member); err != nil {
+					return nil, status.Error(codes.InvalidArgument, "items can only be assigned to group members")
+				}
+				members[member] = true
+				participants = append(participants, member)
+			}
+			if !slices.Contains(assignees, member) {
+				assignees = append(assignees, member)
+			}
+		}
+		if len(assignees) > 0 {
+			assignments[assignment.GetItemId()] = assignees
+		}
+	}
+	if len(participants) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one item must be assigned")
+	}
+
+	index := map[string]int{}
+	for i, participant := range participants {
+		index[participant] = i
+	}
+	subtotals := make([]int64, len(participants))
+	var itemsTotal int64
+	for _, item := range items {
+		line := toCents(item.price * float64(max(item.quantity, 1)))
+		itemsTotal += line
+		assignees, ok := assignments[item.id]
+		if !ok {
+			assignees = participants
+		}
+		parts, err := splitAmounts(line, splitEqual, make([]float64, len(assignees)))
+		if err != nil {
+			return nil, err
+		}
+		for i, member := range assignees {
+			subtotals[index[member]] += parts[i]
+		}
+	}
+
+	// Spread tax, tips and discounts in proportion to what each member had,
+	// or equally when the subtotals cannot serve as weights.
+	method := splitShares
+	weights := make([]float64, len(participants))
+	for i, subtotal := range subtotals {
+		if subtotal <= 0 {
+			method = splitEqual
+		}
+		weights[i] = float64(subtotal)
+	}
+	residual, err := splitAmounts(toCents(expense.amount)-itemsTotal, method, weights)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subtotals {
+		subtotals[i] += residual[i]
+	}
+
+	return s.storeSplit(ctx, expense, paidBy, splitItems, participants, subtotals, assignments)
+}
+
+// normalizeItemName reduces a receipt line to lower-case words so that
+// "OAT MILK 1L" and "Oat milk" are treated as the same item.
+func normalizeItemName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool { return !unicode.IsLetter(r) })
+	kept := words[:0]
+	for _, word := range words {
+		if len(word) > 1 {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// itemSimilarity is the Jaccard similarity of the words of two normalized
+// item names.
+func itemSimilarity(a, b string) float64 {
+	wordsA := strings.Fields(a)
+	wordsB := map[string]bool{}
+	for _, word := range strings.Fields(b) {
+		wordsB[word] = true
+	}
+	union := len(wordsB)
+	shared := 0
+	seen := map[string]bool{}
+	for _, word := range wordsA {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		if wordsB[word] {
+			shared++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+func (s *groupsServer) SuggestItemAssignments(ctx context.Context, req *pb.SuggestItemAssignmentsRequest) (*pb.SuggestItemAssignmentsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetExpenseId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid expense id")
+	}
+
+	var groupId sql.NullString
+	err = s.db.QueryRowContext(ctx, `SELECT group_id FROM expense_data WHERE id = $1`, req.GetExpenseId()).Scan(&groupId)
+	if err == sql.ErrNoRows || (err == nil && !groupId.Valid) {
+		return nil, status.Error(codes.NotFound, "group expense not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := groupRole(ctx, s.db, groupId.String, userId); err != nil {
+		return nil, status.Error(codes.NotFound, "group expense not found")
+	}
+
+	items, err := s.expenseItems(ctx, req.GetExpenseId())
+	if err != nil {
+		log.Printf("Failed to load expense items: %v", err)
+		return nil, err
+	}
+
+	// Past assignments of the group's current members, one entry per
+	// assigned receipt line.
+	type pastItem struct {
+		name      string
+		assignees []string
+	}
+	query := `
+		SELECT i.id, i.item_name, a.uuid
+		FROM expense_item_assignment_data a
+		JOIN expense_item_data i ON i.id = a.item_id
+		JOIN expense_data e ON e.id = i.expense_id
+		JOIN group_member_data m ON m.group_id = e.group_id AND m.uuid = a.uuid
+		WHERE e.group_id = $1 AND e.id <> $2`
+	rows, err := s.db.QueryContext(ctx, query, groupId.String, req.GetExpenseId())
+	if err != nil {
+		log.Printf("Failed to load item assignment history: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	history := map[string]*pastItem{}
+	for rows.Next() {
+		var itemId, name, member string
+		if err := rows.Scan(&itemId, &name, &member); err != nil {
+			return nil, err
+		}
+		past, ok := history[itemId]
+		if !ok {
+			past = &pastItem{name: normalizeItemName(name)}
+			history[itemId] = past
+		}
+		past.assignees = append(past.assignees, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	current := map[string][]string{}
+	query = `
+		SELECT a.item_id, a.uuid FROM expense_item_assignment_data a
+		JOIN expense_item_data i ON i.id = a.item_id WHERE i.expense_id = $1`
+	rows, err = s.db.QueryContext(ctx, query, req.GetExpenseId())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var itemId, member string
+		if err := rows.Scan(&itemId, &member); err != nil {
+			return nil, err
+		}
+		current[itemId] = append(current[itemId], member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var suggested []string
+	res := &pb.SuggestItemAssignmentsResponse{}
+	for _, item := range items {
+		name := normalizeItemName(item.name)
+
+		// Identical names decide on their own; otherwise similar names vote
+		// with their similarity as weight.
+		scores := map[string]float64{}
+		occurrences := map[string]int32{}
+		var total float64
+		for _, exactOnly := range []bool{true, false} {
+			if name == "" {
+				break
+			}
+			for _, past := range history {
+				weight := 1.0
+				if past.name != name {
+					if exactOnly {
+						continue
+					}
+					weight = itemSimilarity(name, past.name)
+				}
+				if weight == 0 {
+					continue
+				}
+				total += weight
+				for _, member := range past.assignees {
+					scores[member] += weight
+					occurrences[member]++
+				}
+			}
+			if total > 0 {
+				break
+			}
+		}
+
+		suggestion := &pb.ExpenseItemSuggestion{
+			ItemId:          item.id,
+			ItemName:        item.name,
+			Price:           item.price,
+			Quantity:        item.quantity,
+			AssignedUserIds: current[item.id],
+		}
+		for member, score := range scores {
+			if confidence := score / total; confidence >= minSuggestionConfidence {
+				suggestion.Suggestions = append(suggestion.Suggestions, &pb.AssignmentSuggestion{
+					UserId:      member,
+					Confidence:  confidence,
+					Occurrences: occurrences[member],
+				})
+			}
+		}
+		sort.Slice(suggestion.Suggestions, func(i, j int) bool {
+			a, b := suggestion.Suggestions[i], suggestion.Suggestions[j]
+			if a.Confidence != b.Confidence {
+				return a.Confidence > b.Confidence
+			}
+			return a.UserId < b.UserId
+		})
+		if len(suggestion.Suggestions) > maxSuggestionsPerItem {
+			suggestion.Suggestions = suggestion.Suggestions[:maxSuggestionsPerItem]
+		}
+		for _, candidate := range suggestion.Suggestions {
+			suggested = append(suggested, candidate.UserId)
+		}
+		res.Items = append(res.Items, suggestion)
+	}
+
+	usernames, err := s.usernames(ctx, suggested)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range res.Items {
+		for _, candidate := range item.Suggestions {
+			candidate.Username = usernames[candidate.UserId]
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/netip"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/barathsurya2004/expenses/services/trustedproxy"
+)
+
+// Failed logins are counted per username and per source IP. Once a key
+// reaches its threshold every further failure doubles the lockout, starting
+// at baseLockout and capped at maxLockout. Failures older than failureWindow
+// are forgotten.
+const (
+	usernameFailureThreshold = 5
+	ipFailureThreshold       = 20
+	baseLockout              = time.Second
+	maxLockout               = 15 * time.Minute
+	failureWindow            = time.Hour
+)
+
+// Auth audit event names stored in auth_event_data.
+const (
+	authEventLogin       = "login"
+	authEventLoginLocked = "login_locked"
+)
+
+func usernameThrottleKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
+
+func ipThrottleKey(ip string) string {
+	return "ip:" + ip
+}
+
+// lockoutFor returns how long a key stays locked after its n-th failure.
+func lockoutFor(failures, threshold int) time.Duration {
+	if failures < threshold {
+		return 0
+	}
+	lockout := baseLockout
+	for i := threshold; i < failures && lockout < maxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > maxLockout {
+		lockout = maxLockout
+	}
+	return lockout
+}
+
+// checkLoginThrottle returns a ResourceExhausted status when any of the keys
+// is currently locked.
+func checkLoginThrottle(ctx context.Context, db *sql.DB, keys ...string) error {
+	for _, key := range keys {
+		var lockedUntil sql.NullTime
+		err := db.QueryRowContext(ctx, `SELECT locked_until FROM login_throttle_data WHERE key = $1`, key).Scan(&lockedUntil)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if lockedUntil.Valid && lockedUntil.Time.After(time.Now()) {
+			retry := time.Until(lockedUntil.Time).Round(time.Second)
+			if retry < time.Second {
+				retry = time.Second
+			}
+			return status.Errorf(codes.ResourceExhausted, "too many failed login attempts, retry in %s", retry)
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure bumps the failure counter of key and locks it when the
+// threshold is reached.
+func recordLoginFailure(ctx context.Context, db *sql.DB, key string, threshold int) error {
+	query := `
+		INSERT INTO login_throttle_data (key, failures, last_failure_at)
+		VALUES ($1, 1, current_timestamp)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_throttle_data.last_failure_at < current_timestamp - $2::interval
+				THEN 1 ELSE login_throttle_data.failures + 1 END,
+			last_failure_at = current_timestamp
+		RETURNING failures`
+
+	var failures int
+	if err := db.QueryRowContext(ctx, query, key, failureWindow.String()).Scan(&failures); err != nil {
+		return err
+	}
+
+	if lockout := lockoutFor(failures, threshold); lockout > 0 {
+		_, err := db.ExecContext(ctx, `UPDATE login_throttle_data SET locked_until = $1 WHERE key = $2`, time.Now().Add(lockout), key)
+		return err
+	}
+	return nil
+}
+
+func resetLoginThrottle(ctx context.Context, db *sql.DB, key string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM login_throttle_data WHERE key = $1`, key)
+	return err
+}
+
+// recordAuthEvent writes an entry to the auth audit table. Failures are only
+// logged so auditing never blocks a login.
+func recordAuthEvent(ctx context.Context, db *sql.DB, userId, username, ip, event string, success bool, detail string) {
+	query := `INSERT INTO auth_event_data (uuid, username, ip_address, event, success, detail) VALUES ($1, $2, $3, $4, $5, $6)`
+	var uid any
+	if userId != "" {
+		uid = userId
+	}
+	if _, err := db.ExecContext(ctx, query, uid, username, ip, event, success, detail); err != nil {
+		log.Printf("Failed to record auth event: %v", err)
+	}
+}
+
+// trustedProxies are the peers, normally the HTTP gateway, whose
+// x-client-ip metadata is believed.
+var trustedProxies = sync.OnceValue(trustedproxy.Load)
+
+// clientIP returns the address of the end user. The HTTP gateway passes it
+// in the x-client-ip metadata, which is only read when the peer is one of
+// TRUSTED_PROXIES; anyone else is identified by their own address.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	addr := trustedproxy.Host(p.Addr.String())
+	if !trustedProxies().Contains(addr) {
+		return addr
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-client-ip"); len(values) == 1 {
+			if ip, err := netip.ParseAddr(strings.TrimSpace(values[0])); err == nil {
+				return ip.Unmap().String()
+			}
+		}
+	}
+	return addr
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+	"strconv"
+)
+
+// Mailer delivers transactional email such as verification links.
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// NewMailer returns an SMTP mailer when SMTP_HOST is configured and a mailer
+// that only logs the message otherwise, which is handy during development.
+func NewMailer() Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		logBody, _ := strconv.ParseBool(os.Getenv("MAIL_LOG_BODY"))
+		return logMailer{logBody: logBody}
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	return &smtpMailer{
+		addr:     host + ":" + port,
+		host:     host,
+		username: os.Getenv("SMTP_USERNAME"),
+		password: os.Getenv("SMTP_PASSWORD"),
+		from:     os.Getenv("SMTP_FROM"),
+	}
+}
+
+// logMailer logs the recipient and subject of each message. Bodies carry
+// verification links and expense details, so they are only logged when
+// MAIL_LOG_BODY is set on a development machine.
+type logMailer struct {
+	logBody bool
+}
+
+func (m logMailer) Send(to, subject, body string) error {
+	if m.logBody {
+		log.Printf("Mail to %s: %s\n%s", to, subject, body)
+		return nil
+	}
+	log.Printf("Mail to %s: %s (not sent, SMTP_HOST is not set)", to, subject)
+	return nil
+}
+
+type smtpMailer struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+}
+
+func (m *smtpMailer) Send(to, subject, body string) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", m.from, to, subject, body)
+	if err := smtp.SendMail(m.addr, auth, m.from, []string{to}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
@@ -1,15 +1,18 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"net"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 
 	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/sso"
 )
 
 func main() {
@@ -34,11 +37,66 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer dbConn.Close()
-	s := grpc.NewServer()
-	pb.RegisterExpensesServiceServer(s, &expenseServer{
+	blobs, err := NewBlobStore()
+	if err != nil {
+		log.Fatalf("Failed to open blob storage: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go purgeDeletedAccounts(ctx, dbConn, blobs, time.Hour)
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryAuthInterceptor(dbConn)),
+		grpc.ChainStreamInterceptor(streamAuthInterceptor(dbConn)),
+	)
+	expenses := &expenseServer{
+		db:        dbConn,
+		blobs:     blobs,
+		extractor: geminiExtractor{},
+	}
+	pb.RegisterExpensesServiceServer(s, expenses)
+	pb.RegisterUsersServiceServer(s, &usersServer{
+		db:     dbConn,
+		mailer: NewMailer(),
+		blobs:  blobs,
+		oidc:   newOidcVerifiers(sso.LoadProviders()),
+	})
+	pb.RegisterAdminServiceServer(s, &adminServer{
 		db: dbConn,
 	})
-	pb.RegisterUsersServiceServer(s, &usersServer{
+	pb.RegisterCategorizationServiceServer(s, &categorizationServer{
+		db: dbConn,
+	})
+	pb.RegisterMerchantsServiceServer(s, &merchantsServer{
+		db: dbConn,
+	})
+	ingestion := &ingestionServer{
+		db:       dbConn,
+		expenses: expenses,
+	}
+	pb.RegisterIngestionServiceServer(s, ingestion)
+	go pollMailboxes(ctx, ingestion, mailboxPollInterval())
+	if addr := os.Getenv("INBOUND_MAIL_ADDR"); addr != "" {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			log.Fatalf("Failed to listen for inbound mail on %s: %v", addr, err)
+		}
+		go serveInboundMail(ctx, listener, ingestion)
+	}
+	pb.RegisterImportServiceServer(s, &importServer{
+		db: dbConn,
+	})
+	pb.RegisterGroupsServiceServer(s, &groupsServer{
+		db:     dbConn,
+		mailer: NewMailer(),
+	})
+	pb.RegisterExpenseReportsServiceServer(s, &expenseReportsServer{
+		db:     dbConn,
+		blobs:  blobs,
+		mailer: NewMailer(),
+	})
+	pb.RegisterTripsServiceServer(s, &tripsServer{
 		db: dbConn,
 	})
 
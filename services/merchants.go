@@ -0,0 +1,481 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	// merchantMatchThreshold is the trigram similarity from which a new
+	// place is taken to be a known merchant.
+	merchantMatchThreshold = 0.6
+	// minMerchantPrefixLength keeps short names such as "the" from matching
+	// every merchant that starts with them.
+	minMerchantPrefixLength = 5
+
+	defaultTopMerchants = 10
+	maxTopMerchants     = 100
+)
+
+// storeNumberPattern matches the branch numbers receipts append to merchant
+// names, as in "STARBUCKS #1234".
+var storeNumberPattern = regexp.MustCompile(`(\s*#\s*\d+|\s+\d{3,})\s*$`)
+
+type merchantsServer struct {
+	pb.UnimplementedMerchantsServiceServer
+	db *sql.DB
+}
+
+// normalizeMerchant reduces a place to lower-case letters and single spaces,
+// so that "STARBUCKS #1234" and "Starbucks" share the alias "starbucks".
+func normalizeMerchant(place string) string {
+	words := strings.FieldsFunc(strings.ToLower(place), func(r rune) bool { return !unicode.IsLetter(r) })
+	return strings.Join(words, " ")
+}
+
+// merchantPrefixes returns the leading words of alias that are long enough
+// to stand for the whole name, so that "starbucks coffee" can match the
+// known alias "starbucks".
+func merchantPrefixes(alias string) []string {
+	var prefixes []string
+	for i, r := range alias {
+		if r == ' ' && i >= minMerchantPrefixLength {
+			prefixes = append(prefixes, alias[:i])
+		}
+	}
+	return prefixes
+}
+
+// resolveMerchant links a place to one of the user's merchants, learning it
+// as a new alias when it only matches fuzzily and creating a merchant when
+// nothing matches. It returns the merchant id, or "" for a place without
+// letters, and the merchant's default category.
+func resolveMerchant(ctx context.Context, tx *sql.Tx, userId, place string) (string, string, error) {
+	alias := normalizeMerchant(place)
+	if alias == "" {
+		return "", "", nil
+	}
+
+	var merchantId, defaultCategory string
+	query := `
+		SELECT m.id, coalesce(m.default_category, '') FROM merchant_alias_data a
+		JOIN merchant_data m ON m.id = a.merchant_id
+		WHERE a.uuid = $1 AND a.alias = $2`
+	err := tx.QueryRowContext(ctx, query, userId, alias).Scan(&merchantId, &defaultCategory)
+	if err == nil {
+		return merchantId, defaultCategory, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", "", err
+	}
+
+	// Known aliases that alias starts with, or that start with alias, count
+	// as a match even when they share too few trigrams.
+	longer := ""
+	if len(alias) >= minMerchantPrefixLength {
+		longer = alias + " %"
+	}
+	query = `
+		SELECT merchant_id FROM merchant_alias_data
+		WHERE uuid = $1 AND ((alias % $2 AND similarity(alias, $2) >= $5) OR alias = ANY($3) OR alias LIKE $4)
+		ORDER BY greatest(similarity(alias, $2), CASE WHEN alias = ANY($3) OR alias LIKE $4 THEN $5 ELSE 0 END) DESC, alias
+		LIMIT 1`
+	err = tx.QueryRowContext(ctx, query, userId, alias, pq.Array(merchantPrefixes(alias)), longer, merchantMatchThreshold).Scan(&merchantId)
+	if err != nil && err != sql.ErrNoRows {
+		return "", "", err
+	}
+
+	if merchantId == "" {
+		name := strings.TrimSpace(storeNumberPattern.ReplaceAllString(place, ""))
+		if name == "" {
+			name = strings.TrimSpace(place)
+		}
+		if err := tx.QueryRowContext(ctx, `INSERT INTO merchant_data (uuid, name) VALUES ($1, $2) RETURNING id`, userId, name).Scan(&merchantId); err != nil {
+			return "", "", err
+		}
+	} else {
+		err := tx.QueryRowContext(ctx, `SELECT coalesce(default_category, '') FROM merchant_data WHERE id = $1`, merchantId).Scan(&defaultCategory)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	query = `INSERT INTO merchant_alias_data (merchant_id, uuid, alias) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, merchantId, userId, alias); err != nil {
+		return "", "", err
+	}
+	return merchantId, defaultCategory, nil
+}
+
+var merchantOrders = map[string]pageOrder{
+	"name asc":        {column: "lower(m.name)", cast: "text", id: "m.id", idCast: "uuid"},
+	"created_at asc":  {column: "m.created_at", cast: "timestamptz", id: "m.id", idCast: "uuid"},
+	"created_at desc": {column: "m.created_at", cast: "timestamptz", desc: true, id: "m.id", idCast: "uuid", idDesc: true},
+}
+
+const merchantColumns = `m.id, m.name, coalesce(m.location, ''), coalesce(m.default_category, ''),
+	coalesce((SELECT array_agg(alias ORDER BY alias) FROM merchant_alias_data WHERE merchant_id = m.id), '{}'),
+	(SELECT count(*) FROM expense_data WHERE merchant_id = m.id), m.created_at`
+
+func scanMerchant(row interface{ Scan(...any) error }) (*pb.Merchant, error) {
+	var merchant pb.Merchant
+	var createdAt time.Time
+	err := row.Scan(&merchant.Id, &merchant.Name, &merchant.Location, &merchant.DefaultCategory,
+		pq.Array(&merchant.Aliases), &merchant.ExpenseCount, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	merchant.CreatedAt = createdAt.Format(time.RFC3339)
+	return &merchant, nil
+}
+
+func loadMerchant(ctx context.Context, q rowQueryer, merchantId string) (*pb.Merchant, error) {
+	return scanMerchant(q.QueryRowContext(ctx, `SELECT `+merchantColumns+` FROM merchant_data m WHERE m.id = $1`, merchantId))
+}
+
+// lockMerchant checks that the user owns the merchant and locks it for the
+// rest of the transaction.
+func lockMerchant(ctx context.Context, tx *sql.Tx, userId, merchantId string) error {
+	if _, err := uuid.Parse(merchantId); err != nil {
+		return status.Error(codes.InvalidArgument, "invalid merchant id")
+	}
+	var id string
+	err := tx.QueryRowContext(ctx, `SELECT id FROM merchant_data WHERE id = $1 AND uuid = $2 FOR UPDATE`, merchantId, userId).Scan(&id)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, "merchant not found")
+	}
+	return err
+}
+
+func validateMerchantName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if normalizeMerchant(name) == "" || len(name) > 100 {
+		return "", status.Error(codes.InvalidArgument, "name must be between 1 and 100 characters and contain letters")
+	}
+	return name, nil
+}
+
+func (s *merchantsServer) ListMerchants(ctx context.Context, req *pb.ListMerchantsRequest) (*pb.ListMerchantsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	search := strings.TrimSpace(req.GetQuery())
+	page, err := newPage(req, merchantOrders, "name asc", pageFilter(search), defaultPageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	scope := `m.uuid = $1 AND ($2::text = '' OR strpos(lower(m.name), lower($2::text)) > 0 OR EXISTS (
+			SELECT 1 FROM merchant_alias_data a WHERE a.merchant_id = m.id AND strpos(a.alias, lower($2::text)) > 0))`
+	var total int32
+	if req.GetIncludeTotalCount() {
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM merchant_data m WHERE `+scope, userId, search).Scan(&total); err != nil {
+			log.Printf("Failed to count merchants: %v", err)
+			return nil, err
+		}
+	}
+
+	after, args := page.condition([]any{userId, search})
+	query := `SELECT ` + merchantColumns + `, ` + page.keyColumns() + ` FROM merchant_data m
+		WHERE ` + scope + ` AND ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Failed to list merchants: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var merchants []*pb.Merchant
+	for rows.Next() {
+		merchant, err := scanMerchant(withKey{rows, page})
+		if err != nil {
+			return nil, err
+		}
+		merchants = append(merchants, merchant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	next, n := page.nextToken()
+	return &pb.ListMerchantsResponse{Merchants: merchants[:n], NextPageToken: next, TotalCount: total}, nil
+}
+
+func (s *merchantsServer) UpdateMerchant(ctx context.Context, req *pb.UpdateMerchantRequest) (*pb.UpdateMerchantResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := req.GetMerchant()
+	if m == nil {
+		return nil, status.Error(codes.InvalidArgument, "merchant is required")
+	}
+	name, err := validateMerchantName(m.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(m.GetLocation())) > 200 {
+		return nil, status.Error(codes.InvalidArgument, "location must be at most 200 characters")
+	}
+	if len(strings.TrimSpace(m.GetDefaultCategory())) > 50 {
+		return nil, status.Error(codes.InvalidArgument, "default category must be at most 50 characters")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockMerchant(ctx, tx, userId, m.GetId()); err != nil {
+		return nil, err
+	}
+	query := `UPDATE merchant_data SET name = $1, location = $2, default_category = $3, updated_at = current_timestamp WHERE id = $4`
+	if _, err := tx.ExecContext(ctx, query, name, nullIfEmpty(m.GetLocation()), nullIfEmpty(m.GetDefaultCategory()), m.GetId()); err != nil {
+		log.Printf("Failed to update merchant: %v", err)
+		return nil, err
+	}
+	// The canonical name always resolves to the merchant unless another
+	// merchant already owns it.
+	query = `INSERT INTO merchant_alias_data (merchant_id, uuid, alias) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, m.GetId(), userId, normalizeMerchant(name)); err != nil {
+		return nil, err
+	}
+
+	merchant, err := loadMerchant(ctx, tx, m.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateMerchantResponse{Merchant: merchant}, nil
+}
+
+func (s *merchantsServer) MergeMerchants(ctx context.Context, req *pb.MergeMerchantsRequest) (*pb.MergeMerchantsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var sources []string
+	for _, source := range req.GetSourceIds() {
+		if _, err := uuid.Parse(source); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid merchant id")
+		}
+		if source == req.GetTargetId() {
+			return nil, status.Error(codes.InvalidArgument, "a merchant cannot be merged into itself")
+		}
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one source merchant is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockMerchant(ctx, tx, userId, req.GetTargetId()); err != nil {
+		return nil, err
+	}
+	var owned int
+	query := `SELECT count(*) FROM merchant_data WHERE id = ANY($1::uuid[]) AND uuid = $2`
+	if err := tx.QueryRowContext(ctx, query, pq.Array(sources), userId).Scan(&owned); err != nil {
+		return nil, err
+	}
+	distinct := map[string]bool{}
+	for _, source := range sources {
+		distinct[source] = true
+	}
+	if owned != len(distinct) {
+		return nil, status.Error(codes.NotFound, "merchant not found")
+	}
+
+	for _, query := range []string{
+		`UPDATE merchant_alias_data SET merchant_id = $1 WHERE merchant_id = ANY($2::uuid[])`,
+		`UPDATE expense_data SET merchant_id = $1 WHERE merchant_id = ANY($2::uuid[])`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, req.GetTargetId(), pq.Array(sources)); err != nil {
+			log.Printf("Failed to merge merchants: %v", err)
+			return nil, err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM merchant_data WHERE id = ANY($1::uuid[])`, pq.Array(sources)); err != nil {
+		return nil, err
+	}
+
+	merchant, err := loadMerchant(ctx, tx, req.GetTargetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.MergeMerchantsResponse{Merchant: merchant}, nil
+}
+
+func (s *merchantsServer) SplitMerchant(ctx context.Context, req *pb.SplitMerchantRequest) (*pb.SplitMerchantResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name, err := validateMerchantName(req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	moved := map[string]bool{}
+	var aliases []string
+	for _, alias := range req.GetAliases() {
+		if alias = normalizeMerchant(alias); alias != "" && !moved[alias] {
+			moved[alias] = true
+			aliases = append(aliases, alias)
+		}
+	}
+	if len(aliases) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one alias is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := lockMerchant(ctx, tx, userId, req.GetMerchantId()); err != nil {
+		return nil, err
+	}
+	var matching, total int
+	query := `SELECT count(*) FILTER (WHERE alias = ANY($2)), count(*) FROM merchant_alias_data WHERE merchant_id = $1`
+	if err := tx.QueryRowContext(ctx, query, req.GetMerchantId(), pq.Array(aliases)).Scan(&matching, &total); err != nil {
+		return nil, err
+	}
+	if matching != len(aliases) {
+		return nil, status.Error(codes.InvalidArgument, "every alias must belong to the merchant")
+	}
+	if matching == total {
+		return nil, status.Error(codes.InvalidArgument, "at least one alias must stay with the merchant")
+	}
+
+	var splitId string
+	query = `
+		INSERT INTO merchant_data (uuid, name, default_category)
+		SELECT uuid, $2, default_category FROM merchant_data WHERE id = $1
+		RETURNING id`
+	if err := tx.QueryRowContext(ctx, query, req.GetMerchantId(), name).Scan(&splitId); err != nil {
+		log.Printf("Failed to split merchant: %v", err)
+		return nil, err
+	}
+	query = `UPDATE merchant_alias_data SET merchant_id = $1 WHERE merchant_id = $2 AND alias = ANY($3)`
+	if _, err := tx.ExecContext(ctx, query, splitId, req.GetMerchantId(), pq.Array(aliases)); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, place FROM expense_data WHERE merchant_id = $1`, req.GetMerchantId())
+	if err != nil {
+		return nil, err
+	}
+	var expenses []string
+	for rows.Next() {
+		var expenseId, place string
+		if err := rows.Scan(&expenseId, &place); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if moved[normalizeMerchant(place)] {
+			expenses = append(expenses, expenseId)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE expense_data SET merchant_id = $1 WHERE id = ANY($2::uuid[])`, splitId, pq.Array(expenses)); err != nil {
+		return nil, err
+	}
+
+	merchant, err := loadMerchant(ctx, tx, req.GetMerchantId())
+	if err != nil {
+		return nil, err
+	}
+	split, err := loadMerchant(ctx, tx, splitId)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.SplitMerchantResponse{Merchant: merchant, Split: split}, nil
+}
+
+func (s *merchantsServer) GetTopMerchants(ctx context.Context, req *pb.GetTopMerchantsRequest) (*pb.GetTopMerchantsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var since, until sql.NullTime
+	if req.GetSince() != "" {
+		if since.Time, err = time.Parse(time.RFC3339, req.GetSince()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "since must be an RFC 3339 timestamp")
+		}
+		since.Valid = true
+	}
+	if req.GetUntil() != "" {
+		if until.Time, err = time.Parse(time.RFC3339, req.GetUntil()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "until must be an RFC 3339 timestamp")
+		}
+		until.Valid = true
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultTopMerchants
+	}
+	if limit > maxTopMerchants {
+		limit = maxTopMerchants
+	}
+
+	query := `
+		SELECT m.id, m.name, SUM(e.amount) AS total_spent, count(*), max(e.date_and_time)
+		FROM expense_data e
+		JOIN merchant_data m ON m.id = e.merchant_id
+		WHERE e.uuid = $1
+			AND ($2::timestamp with time zone IS NULL OR e.date_and_time >= $2)
+			AND ($3::timestamp with time zone IS NULL OR e.date_and_time < $3)
+		GROUP BY m.id, m.name
+		ORDER BY total_spent DESC, m.name
+		LIMIT $4`
+	rows, err := s.db.QueryContext(ctx, query, userId, since, until, limit)
+	if err != nil {
+		log.Printf("Failed to query top merchants: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := &pb.GetTopMerchantsResponse{}
+	for rows.Next() {
+		var merchant pb.TopMerchant
+		var lastVisit time.Time
+		if err := rows.Scan(&merchant.MerchantId, &merchant.Name, &merchant.Spent, &merchant.ExpenseCount, &lastVisit); err != nil {
+			return nil, err
+		}
+		merchant.LastVisit = lastVisit.Format(time.RFC3339)
+		res.Merchants = append(res.Merchants, &merchant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
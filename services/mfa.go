@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/hex"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	mfaChallengeTTL   = 5 * time.Minute
+	recoveryCodeCount = 10
+)
+
+// Auth audit event names for the second login step.
+const (
+	authEventMfaChallenge = "mfa_challenge"
+	authEventMfaVerify    = "mfa_verify"
+	authEventMfaEnabled   = "mfa_enabled"
+	authEventMfaDisabled  = "mfa_disabled"
+)
+
+func mfaThrottleKey(userId string) string {
+	return "mfa:" + userId
+}
+
+func (s *usersServer) EnrollTotp(ctx context.Context, req *pb.EnrollTotpRequest) (*pb.EnrollTotpResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reauthenticate(ctx, userId, req); err != nil {
+		return nil, err
+	}
+
+	enabled, err := mfaEnabled(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Failed to check MFA status: %v", err)
+		return nil, err
+	}
+	if enabled {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		log.Printf("Failed to generate TOTP secret: %v", err)
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO mfa_data (uuid, totp_secret) VALUES ($1, $2)
+		ON CONFLICT (uuid) DO UPDATE SET totp_secret = EXCLUDED.totp_secret, last_used_step = 0, created_at = current_timestamp
+		WHERE mfa_data.enabled = false`
+	if _, err := s.db.ExecContext(ctx, query, userId, secret); err != nil {
+		log.Printf("Failed to store TOTP secret: %v", err)
+		return nil, err
+	}
+
+	var username string
+	if err := s.db.QueryRowContext(ctx, `SELECT username FROM user_data WHERE uuid = $1`, userId).Scan(&username); err != nil {
+		return nil, err
+	}
+
+	issuer := os.Getenv("MFA_ISSUER")
+	if issuer == "" {
+		issuer = "Expenses"
+	}
+
+	return &pb.EnrollTotpResponse{
+		Secret:     secret,
+		OtpauthUri: totpURI(issuer, username, secret),
+	}, nil
+}
+
+func (s *usersServer) ConfirmTotp(ctx context.Context, req *pb.ConfirmTotpRequest) (*pb.ConfirmTotpResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret string
+	err = s.db.QueryRowContext(ctx, `SELECT totp_secret FROM mfa_data WHERE uuid = $1 AND enabled = false`, userId).Scan(&secret)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.FailedPrecondition, "no pending two-factor enrollment")
+	}
+	if err != nil {
+		log.Printf("Failed to load TOTP secret: %v", err)
+		return nil, err
+	}
+
+	step, ok := verifyTOTP(secret, req.GetCode(), time.Now())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid verification code")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE mfa_data SET enabled = true, enabled_at = current_timestamp, last_used_step = $1 WHERE uuid = $2`
+	if _, err := tx.ExecContext(ctx, query, step, userId); err != nil {
+		log.Printf("Failed to enable MFA: %v", err)
+		return nil, err
+	}
+	recoveryCodes, err := replaceRecoveryCodes(ctx, tx, userId)
+	if err != nil {
+		log.Printf("Failed to store recovery codes: %v", err)
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	recordAuthEvent(ctx, s.db, userId, "", clientIP(ctx), authEventMfaEnabled, true, "")
+	return &pb.ConfirmTotpResponse{
+		RecoveryCodes: recoveryCodes,
+		Message:       "Two-factor authentication enabled",
+	}, nil
+}
+
+func (s *usersServer) DisableTotp(ctx context.Context, req *pb.DisableTotpRequest) (*pb.DisableTotpResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reauthenticate(ctx, userId, req); err != nil {
+		return nil, err
+	}
+	if err := s.checkSecondFactor(ctx, userId, req.GetCode()); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_code_data WHERE uuid = $1`, userId); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_data WHERE uuid = $1`, userId); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	recordAuthEvent(ctx, s.db, userId, "", clientIP(ctx), authEventMfaDisabled, true, "")
+	return &pb.DisableTotpResponse{Message: "Two-factor authentication disabled"}, nil
+}
+
+func (s *usersServer) RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RegenerateRecoveryCodesResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSecondFactor(ctx, userId, req.GetCode()); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	recoveryCodes, err := replaceRecoveryCodes(ctx, tx, userId)
+	if err != nil {
+		log.Printf("Failed to store recovery codes: %v", err)
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &pb.RegenerateRecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *usersServer) VerifyMfa(ctx context.Context, req *pb.VerifyMfaRequest) (*pb.GetUserResponse, error) {
+	if req.GetMfaToken() == "" || req.GetCode() == "" {
+		return nil, status.Error(codes.InvalidArgument, "mfa token and code are required")
+	}
+
+	var userId string
+	query := `SELECT uuid FROM mfa_challenge_data WHERE token = $1 AND expires_at > current_timestamp`
+	err := s.db.QueryRowContext(ctx, query, req.GetMfaToken()).Scan(&userId)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired mfa token")
+	}
+	if err != nil {
+		log.Printf("Failed to load MFA challenge: %v", err)
+		return nil, err
+	}
+
+	ip := clientIP(ctx)
+	if err := s.checkSecondFactor(ctx, userId, req.GetCode()); err != nil {
+		recordAuthEvent(ctx, s.db, userId, "", ip, authEventMfaVerify, false, err.Error())
+		return nil, err
+	}
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM mfa_challenge_data WHERE uuid = $1`, userId); err != nil {
+		log.Printf("Failed to delete MFA challenge: %v", err)
+	}
+	recordAuthEvent(ctx, s.db, userId, "", ip, authEventMfaVerify, true, "")
+
+	authToken, err := GenToken(userId, s.db)
+	if err != nil {
+		log.Printf("Failed to generate auth token: %v", err)
+		return nil, err
+	}
+	profile, err := loadProfile(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Failed to load profile: %v", err)
+		return nil, err
+	}
+
+	return &pb.GetUserResponse{
+		UserId:    userId,
+		AuthToken: authToken,
+		Profile:   profile,
+	}, nil
+}
+
+// newMfaChallenge issues the short-lived token GetUser hands out in place of
+// an auth token when the second step is still outstanding.
+func newMfaChallenge(ctx context.Context, db *sql.DB, userId string) (string, error) {
+	token, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	query := `INSERT INTO mfa_challenge_data (token, uuid, expires_at) VALUES ($1, $2, $3)`
+	if _, err := db.ExecContext(ctx, query, token.String(), userId, time.Now().Add(mfaChallengeTTL)); err != nil {
+		return "", err
+	}
+	return token.String(), nil
+}
+
+func mfaEnabled(ctx context.Context, db *sql.DB, userId string) (bool, error) {
+	var enabled bool
+	err := db.QueryRowContext(ctx, `SELECT enabled FROM mfa_data WHERE uuid = $1`, userId).Scan(&enabled)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return enabled, err
+}
+
+// checkSecondFactor accepts either a TOTP code that has not been used yet or
+// an unused recovery code, which is consumed. Failures count towards the same
+// lockout as password failures.
+func (s *usersServer) checkSecondFactor(ctx context.Context, userId, code string) error {
+	key := mfaThrottleKey(userId)
+	if err := checkLoginThrottle(ctx, s.db, key); err != nil {
+		return err
+	}
+
+	var secret string
+	var lastStep int64
+	err := s.db.QueryRowContext(ctx, `SELECT totp_secret, last_used_step FROM mfa_data WHERE uuid = $1 AND enabled = true`, userId).Scan(&secret, &lastStep)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+	if err != nil {
+		return err
+	}
+
+	accepted := false
+	if step, ok := verifyTOTP(secret, code, time.Now()); ok && step > lastStep {
+		res, err := s.db.ExecContext(ctx, `UPDATE mfa_data SET last_used_step = $1 WHERE uuid = $2 AND last_used_step < $1`, step, userId)
+		if err != nil {
+			return err
+		}
+		n, _ := res.RowsAffected()
+		accepted = n == 1
+	}
+	if !accepted {
+		query := `UPDATE mfa_recovery_code_data SET used_at = current_timestamp WHERE uuid = $1 AND code_hash = $2 AND used_at IS NULL`
+		res, err := s.db.ExecContext(ctx, query, userId, hashRecoveryCode(code))
+		if err != nil {
+			return err
+		}
+		n, _ := res.RowsAffected()
+		accepted = n > 0
+		if accepted {
+			log.Printf("Recovery code used for user %s", userId)
+		}
+	}
+
+	if !accepted {
+		if err := recordLoginFailure(ctx, s.db, key, usernameFailureThreshold); err != nil {
+			log.Printf("Failed to record MFA failure: %v", err)
+		}
+		return status.Error(codes.Unauthenticated, "invalid verification code")
+	}
+	if err := resetLoginThrottle(ctx, s.db, key); err != nil {
+		log.Printf("Failed to reset MFA throttle: %v", err)
+	}
+	return nil
+}
+
+// replaceRecoveryCodes invalidates the user's recovery codes and returns a
+// fresh set in plain text. Only their hashes are stored.
+func replaceRecoveryCodes(ctx context.Context, tx *sql.Tx, userId string) ([]string, error) {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_code_data WHERE uuid = $1`, userId); err != nil {
+		return nil, err
+	}
+
+	recovery := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 8)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		encoded := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw))[:10]
+		code := encoded[:5] + "-" + encoded[5:]
+
+		query := `INSERT INTO mfa_recovery_code_data (uuid, code_hash) VALUES ($1, $2)`
+		if _, err := tx.ExecContext(ctx, query, userId, hashRecoveryCode(code)); err != nil {
+			return nil, err
+		}
+		recovery = append(recovery, code)
+	}
+	return recovery, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
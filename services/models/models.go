@@ -16,14 +16,225 @@ type GetUserRequest struct {
 	Password string `json:"password"`
 }
 
+// UpdateProfileRequest mirrors pb.UpdateProfileRequest; nil fields are left
+// unchanged.
+type UpdateProfileRequest struct {
+	Username        *string `json:"username"`
+	Email           *string `json:"email"`
+	FirstName       *string `json:"first_name"`
+	LastName        *string `json:"last_name"`
+	AvatarURL       *string `json:"avatar_url"`
+	DefaultCurrency *string `json:"default_currency"`
+	TimeZone        *string `json:"time_zone"`
+	Locale          *string `json:"locale"`
+	WeekStartDay    *string `json:"week_start_day"`
+}
+
+// DeleteAccountRequest and TotpRequest confirm the caller with the password,
+// or for accounts without one, with the fields returned by the OIDC callback
+// in reauth mode.
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+	Provider string `json:"provider,omitempty"`
+	IDToken  string `json:"id_token,omitempty"`
+	Nonce    string `json:"nonce,omitempty"`
+}
+
+type VerifyMfaRequest struct {
+	MfaToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+// TotpRequest carries the confirmation fields used by the TOTP routes.
+type TotpRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+	Provider string `json:"provider,omitempty"`
+	IDToken  string `json:"id_token,omitempty"`
+	Nonce    string `json:"nonce,omitempty"`
+}
+
+// LinkIdentityRequest is returned by the OIDC callback in link and reauth
+// mode. In link mode it is posted back, together with the account password,
+// to /link-identity.
+type LinkIdentityRequest struct {
+	Provider string `json:"provider"`
+	IDToken  string `json:"id_token"`
+	Nonce    string `json:"nonce"`
+	Password string `json:"password,omitempty"`
+}
+
+type CreateApiKeyRequest struct {
+	Name  string `json:"name"`
+	Scope string `json:"scope"`
+}
+
+type RevokeApiKeyRequest struct {
+	ID string `json:"id"`
+}
+
+type AddExpenseRequest struct {
+	DateAndTime   string             `json:"date_and_time"`
+	Place         string             `json:"place"`
+	Amount        float64            `json:"amount"`
+	Currency      string             `json:"currency"`
+	Category      string             `json:"category"`
+	ModeOfPayment string             `json:"mode_of_payment"`
+	GroupID       string             `json:"group_id"`
+	Tags          []string           `json:"tags"`
+	Notes         string             `json:"notes"`
+	CustomFields  []CustomFieldValue `json:"custom_fields"`
+}
+
+// CustomFieldValue names a custom field by field_id or by name.
+type CustomFieldValue struct {
+	FieldID string `json:"field_id"`
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+}
+
+type CustomFieldRequest struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+}
+
+// UpdateExpenseRequest mirrors pb.UpdateExpenseRequest; nil fields are left
+// unchanged.
+type UpdateExpenseRequest struct {
+	ExpenseID     string             `json:"expense_id"`
+	DateAndTime   *string            `json:"date_and_time"`
+	Place         *string            `json:"place"`
+	Amount        *float64           `json:"amount"`
+	Currency      *string            `json:"currency"`
+	Category      *string            `json:"category"`
+	ModeOfPayment *string            `json:"mode_of_payment"`
+	Notes         *string            `json:"notes"`
+	AddTags       []string           `json:"add_tags"`
+	RemoveTags    []string           `json:"remove_tags"`
+	CustomFields  []CustomFieldValue `json:"custom_fields"`
+}
+
+type CategorizationRule struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Priority         int32    `json:"priority"`
+	MerchantContains string   `json:"merchant_contains"`
+	MerchantRegex    string   `json:"merchant_regex"`
+	MinAmount        *float64 `json:"min_amount"`
+	MaxAmount        *float64 `json:"max_amount"`
+	PaymentMethod    string   `json:"payment_method"`
+	Category         string   `json:"category"`
+	Tags             []string `json:"tags"`
+	Enabled          *bool    `json:"enabled"`
+}
+
+type ApplyRulesRequest struct {
+	Since string `json:"since"`
+}
+
+// MerchantRequest is the body of /update-merchant.
+type MerchantRequest struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Location        string `json:"location"`
+	DefaultCategory string `json:"default_category"`
+}
+
+type MergeMerchantsRequest struct {
+	TargetID  string   `json:"target_id"`
+	SourceIDs []string `json:"source_ids"`
+}
+
+type SplitMerchantRequest struct {
+	MerchantID string   `json:"merchant_id"`
+	Aliases    []string `json:"aliases"`
+	Name       string   `json:"name"`
+}
+
+type Category struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	ParentID string   `json:"parent_id"`
+	Icon     string   `json:"icon"`
+	Colour   string   `json:"colour"`
+	Synonyms []string `json:"synonyms"`
+}
+
+// GroupRequest is the body of the group routes. Each route reads the fields
+// it needs.
+type GroupRequest struct {
+	GroupID      string `json:"group_id"`
+	Name         string `json:"name"`
+	UserID       string `json:"user_id"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
+	InvitationID string `json:"invitation_id"`
+}
+
+type SplitShare struct {
+	UserID string  `json:"user_id"`
+	Value  float64 `json:"value"`
+}
+
+type SplitExpenseRequest struct {
+	ExpenseID string       `json:"expense_id"`
+	PaidBy    string       `json:"paid_by"`
+	Method    string       `json:"method"`
+	Shares    []SplitShare `json:"shares"`
+}
+
+type ItemAssignment struct {
+	ItemID  string   `json:"item_id"`
+	UserIDs []string `json:"user_ids"`
+}
+
+type AssignExpenseItemsRequest struct {
+	ExpenseID   string           `json:"expense_id"`
+	PaidBy      string           `json:"paid_by"`
+	Assignments []ItemAssignment `json:"assignments"`
+}
+
+type RecordSettlementRequest struct {
+	GroupID    string  `json:"group_id"`
+	FromUserID string  `json:"from_user_id"`
+	ToUserID   string  `json:"to_user_id"`
+	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency"`
+	Note       string  `json:"note"`
+}
+
+type SetExpenseGroupRequest struct {
+	ExpenseID string `json:"expense_id"`
+	GroupID   string `json:"group_id"`
+}
+
+// AdminUserRequest is the body of the /admin routes that act on one user.
+type AdminUserRequest struct {
+	UserID         string `json:"user_id"`
+	Reason         string `json:"reason"`
+	Role           string `json:"role"`
+	IncludeApiKeys bool   `json:"include_api_keys"`
+}
+
 // The top-level struct to hold the entire JSON object
 type Transaction struct {
 	UUID               string            `json:"uuid"`
+	GroupID            string            `json:"group_id,omitempty"`
+	Tags               []string          `json:"tags,omitempty"`
 	TransactionID      string            `json:"transaction_id"`
 	MerchantDetails    Merchant          `json:"merchant_details"`
 	TransactionDetails TransactionDetail `json:"transaction_details"`
 	Items              []Item            `json:"items"`
 	SpendingCategory   string            `json:"spending_category"`
+	CategorySource     string            `json:"category_source,omitempty"`
+	CategoryConfidence float64           `json:"category_confidence,omitempty"`
+	Source             string            `json:"source,omitempty"`
+	ImportKey          string            `json:"-"`
+	Notes              string            `json:"-"`
+	CustomFields       map[string]string `json:"-"`
 }
 
 // A nested struct to handle the "merchant_details" object
@@ -54,3 +265,109 @@ type HeatMapDay struct {
 	Amount   int    `json:"amount"`
 	Currency string `json:"currency"`
 }
+
+type IngestionConsentRequest struct {
+	Channel string `json:"channel"`
+	Granted bool   `json:"granted"`
+}
+
+type SmsMessage struct {
+	Sender     string `json:"sender"`
+	Body       string `json:"body"`
+	ReceivedAt string `json:"received_at"`
+}
+
+type IngestMessagesRequest struct {
+	Messages []SmsMessage `json:"messages"`
+}
+
+type ConnectMailboxRequest struct {
+	Host     string `json:"host"`
+	Port     int32  `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Mailbox  string `json:"mailbox"`
+	Insecure bool   `json:"insecure"`
+}
+
+type CsvMapping struct {
+	Delimiter         string `json:"delimiter"`
+	SkipRows          int32  `json:"skip_rows"`
+	NoHeader          bool   `json:"no_header"`
+	DateColumn        string `json:"date_column"`
+	DateFormat        string `json:"date_format"`
+	DescriptionColumn string `json:"description_column"`
+	AmountColumn      string `json:"amount_column"`
+	DebitColumn       string `json:"debit_column"`
+	CreditColumn      string `json:"credit_column"`
+	CurrencyColumn    string `json:"currency_column"`
+	ReferenceColumn   string `json:"reference_column"`
+	DebitsPositive    bool   `json:"debits_positive"`
+	DecimalComma      bool   `json:"decimal_comma"`
+}
+
+type ImportProfileRequest struct {
+	ID      string     `json:"id"`
+	Name    string     `json:"name"`
+	Mapping CsvMapping `json:"mapping"`
+}
+
+// ExpenseReportRequest is the body of the expense report routes. Each route
+// reads the fields it needs.
+type ExpenseReportRequest struct {
+	ReportID         string   `json:"report_id"`
+	Title            string   `json:"title"`
+	Purpose          string   `json:"purpose"`
+	ExpenseIDs       []string `json:"expense_ids"`
+	AddExpenseIDs    []string `json:"add_expense_ids"`
+	RemoveExpenseIDs []string `json:"remove_expense_ids"`
+	ApproverUsername string   `json:"approver_username"`
+	ApproverEmail    string   `json:"approver_email"`
+	LineID           string   `json:"line_id"`
+	Body             string   `json:"body"`
+	Reference        string   `json:"reference"`
+	ReimbursedAt     string   `json:"reimbursed_at"`
+}
+
+type LineDecision struct {
+	LineID   string `json:"line_id"`
+	Decision string `json:"decision"`
+	Comment  string `json:"comment"`
+}
+
+type ReviewExpenseReportRequest struct {
+	ReportID  string         `json:"report_id"`
+	Decisions []LineDecision `json:"decisions"`
+	Decision  string         `json:"decision"`
+	Comment   string         `json:"comment"`
+}
+
+// TripRequest is the body of the create and delete trip routes.
+type TripRequest struct {
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	GroupID         string  `json:"group_id"`
+	StartsOn        string  `json:"starts_on"`
+	EndsOn          string  `json:"ends_on"`
+	Budget          float64 `json:"budget"`
+	HomeCurrency    string  `json:"home_currency"`
+	ForeignCurrency string  `json:"foreign_currency"`
+	ExchangeRate    float64 `json:"exchange_rate"`
+}
+
+type UpdateTripRequest struct {
+	ID              string   `json:"id"`
+	Name            *string  `json:"name"`
+	StartsOn        *string  `json:"starts_on"`
+	EndsOn          *string  `json:"ends_on"`
+	Budget          *float64 `json:"budget"`
+	HomeCurrency    *string  `json:"home_currency"`
+	ForeignCurrency *string  `json:"foreign_currency"`
+	ExchangeRate    *float64 `json:"exchange_rate"`
+}
+
+type SetExpenseTripRequest struct {
+	ExpenseID string `json:"expense_id"`
+	TripID    string `json:"trip_id"`
+	Automatic bool   `json:"automatic"`
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/sso"
+)
+
+const authEventOidcLogin = "oidc_login"
+
+type oidcClaims struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+	GivenName         string `json:"given_name"`
+	FamilyName        string `json:"family_name"`
+	Picture           string `json:"picture"`
+	AuthTime          int64  `json:"auth_time"`
+
+	IssuedAt time.Time `json:"-"`
+}
+
+// maxReauthAge is how long ago a sign-in at the provider may have happened
+// for its ID token to confirm a sensitive change.
+const maxReauthAge = 5 * time.Minute
+
+// authenticatedAt is when the user last signed in at the provider, taken
+// from auth_time and otherwise from when the token was issued.
+func (c *oidcClaims) authenticatedAt() time.Time {
+	if c.AuthTime > 0 {
+		return time.Unix(c.AuthTime, 0)
+	}
+	return c.IssuedAt
+}
+
+// oidcVerifiers lazily runs discovery for each configured provider so the
+// server can start while a provider is unreachable.
+type oidcVerifiers struct {
+	mu        sync.Mutex
+	providers map[string]sso.ProviderConfig
+	verifiers map[string]*gooidc.IDTokenVerifier
+}
+
+func newOidcVerifiers(providers map[string]sso.ProviderConfig) *oidcVerifiers {
+	return &oidcVerifiers{
+		providers: providers,
+		verifiers: map[string]*gooidc.IDTokenVerifier{},
+	}
+}
+
+// verify checks the signature, issuer, audience, expiry and nonce of rawIDToken.
+func (v *oidcVerifiers) verify(ctx context.Context, provider, rawIDToken, nonce string) (*oidcClaims, error) {
+	v.mu.Lock()
+	verifier, ok := v.verifiers[provider]
+	if !ok {
+		cfg, configured := v.providers[provider]
+		if !configured {
+			v.mu.Unlock()
+			return nil, status.Errorf(codes.InvalidArgument, "unknown identity provider %q", provider)
+		}
+		p, err := gooidc.NewProvider(context.Background(), cfg.Issuer)
+		if err != nil {
+			v.mu.Unlock()
+			return nil, fmt.Errorf("failed to discover identity provider %s: %w", provider, err)
+		}
+		verifier = p.Verifier(&gooidc.Config{ClientID: cfg.ClientID})
+		v.verifiers[provider] = verifier
+	}
+	v.mu.Unlock()
+
+	token, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		log.Printf("ID token verification failed for provider %s: %v", provider, err)
+		return nil, status.Error(codes.Unauthenticated, "invalid id token")
+	}
+	if nonce == "" || token.Nonce != nonce {
+		return nil, status.Error(codes.Unauthenticated, "id token nonce mismatch")
+	}
+
+	var claims oidcClaims
+	if err := token.Claims(&claims); err != nil {
+		return nil, err
+	}
+	claims.Subject = token.Subject
+	claims.IssuedAt = token.IssuedAt
+	return &claims, nil
+}
+
+func (s *usersServer) OidcLogin(ctx context.Context, req *pb.OidcLoginRequest) (*pb.GetUserResponse, error) {
+	claims, err := s.oidc.verify(ctx, req.GetProvider(), req.GetIdToken(), req.GetNonce())
+	if err != nil {
+		return nil, err
+	}
+	ip := clientIP(ctx)
+
+	var userId, username string
+	query := `
+		SELECT u.uuid, u.username FROM user_identities i JOIN user_data u ON u.uuid = i.uuid
+		WHERE i.provider = $1 AND i.subject = $2`
+	err = s.db.QueryRowContext(ctx, query, req.GetProvider(), claims.Subject).Scan(&userId, &username)
+	switch {
+	case err == sql.ErrNoRows:
+		userId, username, err = s.createOidcUser(ctx, req.GetProvider(), claims)
+		if err != nil {
+			recordAuthEvent(ctx, s.db, "", "", ip, authEventOidcLogin, false, err.Error())
+			return nil, err
+		}
+		log.Printf("Created user %s from %s identity", userId, req.GetProvider())
+	case err != nil:
+		log.Printf("Failed to look up identity: %v", err)
+		return nil, err
+	}
+
+	_, err = s.db.ExecContext(ctx, `UPDATE user_identities SET last_login_at = current_timestamp WHERE provider = $1 AND subject = $2`,
+		req.GetProvider(), claims.Subject)
+	if err != nil {
+		log.Printf("Failed to update identity: %v", err)
+	}
+	recordAuthEvent(ctx, s.db, userId, username, ip, authEventOidcLogin, true, req.GetProvider())
+
+	return s.completeLogin(ctx, userId, username, ip)
+}
+
+func (s *usersServer) LinkIdentity(ctx context.Context, req *pb.LinkIdentityRequest) (*pb.LinkIdentityResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyUserPassword(ctx, userId, req.GetPassword()); err != nil {
+		return nil, err
+	}
+	claims, err := s.oidc.verify(ctx, req.GetProvider(), req.GetIdToken(), req.GetNonce())
+	if err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO user_identities (uuid, provider, subject, email) VALUES ($1, $2, $3, $4)`
+	if _, err := s.db.ExecContext(ctx, query, userId, req.GetProvider(), claims.Subject, claims.Email); err != nil {
+		if isUniqueViolation(err) {
+			return nil, status.Error(codes.AlreadyExists, "this identity or provider is already linked")
+		}
+		log.Printf("Failed to link identity: %v", err)
+		return nil, err
+	}
+
+	identities, err := s.identities(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	var linked *pb.Identity
+	for _, identity := range identities {
+		if identity.GetProvider() == req.GetProvider() {
+			linked = identity
+		}
+	}
+
+	return &pb.LinkIdentityResponse{
+		Identity: linked,
+		Message:  "Identity linked successfully",
+	}, nil
+}
+
+func (s *usersServer) UnlinkIdentity(ctx context.Context, req *pb.UnlinkIdentityRequest) (*pb.UnlinkIdentityResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetProvider() == "" {
+		return nil, status.Error(codes.InvalidArgument, "provider is required")
+	}
+
+	// Refuse to remove the last way of signing in.
+	var hasPassword bool
+	var identities int
+	query := `
+		SELECT u.password_hash IS NOT NULL, (SELECT count(*) FROM user_identities WHERE uuid = u.uuid)
+		FROM user_data u WHERE u.uuid = $1`
+	if err := s.db.QueryRowContext(ctx, query, userId).Scan(&hasPassword, &identities); err != nil {
+		return nil, err
+	}
+	if !hasPassword && identities <= 1 {
+		return nil, status.Error(codes.FailedPrecondition, "cannot unlink the only sign-in method")
+	}
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM user_identities WHERE uuid = $1 AND provider = $2`, userId, req.GetProvider())
+	if err != nil {
+		log.Printf("Failed to unlink identity: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Errorf(codes.NotFound, "no %s identity linked", req.GetProvider())
+	}
+
+	return &pb.UnlinkIdentityResponse{Message: "Identity unlinked successfully"}, nil
+}
+
+func (s *usersServer) ListIdentities(ctx context.Context, req *pb.ListIdentitiesRequest) (*pb.ListIdentitiesResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := s.identities(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to list identities: %v", err)
+		return nil, err
+	}
+	return &pb.ListIdentitiesResponse{Identities: identities}, nil
+}
+
+func (s *usersServer) identities(ctx context.Context, userId string) ([]*pb.Identity, error) {
+	query := `SELECT provider, subject, coalesce(email, ''), created_at, last_login_at FROM user_identities WHERE uuid = $1 ORDER BY created_at`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []*pb.Identity
+	for rows.Next() {
+		var identity pb.Identity
+		var createdAt time.Time
+		var lastLogin sql.NullTime
+		if err := rows.Scan(&identity.Provider, &identity.Subject, &identity.Email, &createdAt, &lastLogin); err != nil {
+			return nil, err
+		}
+		identity.CreatedAt = createdAt.Format(time.RFC3339)
+		if lastLogin.Valid {
+			identity.LastLoginAt = lastLogin.Time.Format(time.RFC3339)
+		}
+		identities = append(identities, &identity)
+	}
+	return identities, rows.Err()
+}
+
+// createOidcUser registers a password-less account for a first-time external
+// identity. An existing account with the same email is never taken over; its
+// owner has to sign in with the password and link the identity instead.
+func (s *usersServer) createOidcUser(ctx context.Context, provider string, claims *oidcClaims) (string, string, error) {
+	if claims.Email == "" {
+		return "", "", status.Error(codes.InvalidArgument, "identity provider did not share an email address")
+	}
+
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM user_data WHERE lower(email) = lower($1))`, claims.Email).Scan(&exists)
+	if err != nil {
+		return "", "", err
+	}
+	if exists {
+		return "", "", status.Error(codes.FailedPrecondition,
+			"an account with this email already exists, sign in with your password and link the identity")
+	}
+
+	userUUID, err := uuid.NewV6()
+	if err != nil {
+		return "", "", err
+	}
+	userId := userUUID.String()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback()
+
+	base := usernameFromClaims(claims)
+	username := base
+	query := `
+		INSERT INTO user_data (uuid, username, email, first_name, last_name, avatar_url, email_verified)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (username) DO NOTHING`
+	for attempt := 0; ; attempt++ {
+		if attempt == 5 {
+			return "", "", fmt.Errorf("failed to find a free username for %s", base)
+		}
+		res, err := tx.ExecContext(ctx, query, userId, username, claims.Email, claims.GivenName, claims.FamilyName, claims.Picture, claims.EmailVerified)
+		if err != nil {
+			return "", "", err
+		}
+		if n, _ := res.RowsAffected(); n == 1 {
+			break
+		}
+		suffix := make([]byte, 2)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", "", err
+		}
+		username = base + "-" + hex.EncodeToString(suffix)
+	}
+
+	query = `INSERT INTO user_identities (uuid, provider, subject, email) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.ExecContext(ctx, query, userId, provider, claims.Subject, claims.Email); err != nil {
+		return "", "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", "", err
+	}
+	return userId, username, nil
+}
+
+var usernameUnsafe = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+func usernameFromClaims(claims *oidcClaims) string {
+	name := claims.PreferredUsername
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+	name = usernameUnsafe.ReplaceAllString(strings.ToLower(name), "")
+	if name == "" {
+		name = "user"
+	}
+	if len(name) > 40 {
+		name = name[:40]
+	}
+	return name
+}
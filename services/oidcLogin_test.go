@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/barathsurya2004/expenses/services/sso"
+)
+
+// fakeOidcProvider serves discovery and the signing keys of an identity
+// provider and signs ID tokens with its key.
+type fakeOidcProvider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+}
+
+func newFakeOidcProvider(t *testing.T) *fakeOidcProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &fakeOidcProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                p.URL,
+			"authorization_endpoint":                p.URL + "/authorize",
+			"token_endpoint":                        p.URL + "/token",
+			"jwks_uri":                              p.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// signIDToken returns an RS256 ID token for claims, signed with key.
+func signIDToken(t *testing.T, key *rsa.PrivateKey, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test", "typ": "JWT"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestOidcVerify(t *testing.T) {
+	provider := newFakeOidcProvider(t)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifiers := newOidcVerifiers(map[string]sso.ProviderConfig{
+		"corp": {Name: "corp", Issuer: provider.URL, ClientID: "expenses"},
+	})
+
+	now := time.Now()
+	claims := func(change func(map[string]any)) map[string]any {
+		c := map[string]any{
+			"iss":       provider.URL,
+			"aud":       "expenses",
+			"sub":       "user-1",
+			"email":     "alice@example.com",
+			"nonce":     "n-1",
+			"iat":       now.Unix(),
+			"exp":       now.Add(time.Hour).Unix(),
+			"auth_time": now.Add(-time.Minute).Unix(),
+		}
+		if change != nil {
+			change(c)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		provider string
+		key      *rsa.PrivateKey
+		claims   map[string]any
+		nonce    string
+		want     codes.Code
+	}{
+		{"valid", "corp", provider.key, claims(nil), "n-1", codes.OK},
+		{"nonce mismatch", "corp", provider.key, claims(nil), "n-2", codes.Unauthenticated},
+		{"missing nonce", "corp", provider.key, claims(nil), "", codes.Unauthenticated},
+		{"wrong audience", "corp", provider.key, claims(func(c map[string]any) { c["aud"] = "someone-else" }), "n-1", codes.Unauthenticated},
+		{"wrong issuer", "corp", provider.key, claims(func(c map[string]any) { c["iss"] = "https://evil.example.com" }), "n-1", codes.Unauthenticated},
+		{"expired", "corp", provider.key, claims(func(c map[string]any) { c["exp"] = now.Add(-time.Minute).Unix() }), "n-1", codes.Unauthenticated},
+		{"signed by another key", "corp", otherKey, claims(nil), "n-1", codes.Unauthenticated},
+		{"unknown provider", "google", provider.key, claims(nil), "n-1", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := signIDToken(t, tt.key, tt.claims)
+			got, err := verifiers.verify(context.Background(), tt.provider, token, tt.nonce)
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("verify error = %v, want code %s", err, tt.want)
+			}
+			if tt.want != codes.OK {
+				return
+			}
+			if got.Subject != "user-1" || got.Email != "alice@example.com" {
+				t.Errorf("claims = %+v", got)
+			}
+			if at := got.authenticatedAt(); at.Unix() != now.Add(-time.Minute).Unix() {
+				t.Errorf("authenticatedAt = %s, want auth_time", at)
+			}
+		})
+	}
+}
+
+func TestOidcClaimsAuthenticatedAt(t *testing.T) {
+	issued := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		claims oidcClaims
+		want   time.Time
+	}{
+		{"auth_time", oidcClaims{AuthTime: issued.Add(-time.Hour).Unix(), IssuedAt: issued}, issued.Add(-time.Hour)},
+		{"issued at", oidcClaims{IssuedAt: issued}, issued},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.claims.authenticatedAt(); !got.Equal(tt.want) {
+				t.Errorf("authenticatedAt = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// pageOrder is a sort order that pages are read in with keyset queries:
+// each page starts after the key and id of the last row of the page before.
+type pageOrder struct {
+	column string // expression sorted by
+	cast   string // type the key in a page token is read back as
+	desc   bool
+	id     string // unique column breaking ties
+	idCast string
+	idDesc bool
+}
+
+// expenseOrders are the orders expense_data can be listed in.
+var expenseOrders = map[string]pageOrder{
+	"date_and_time desc": {column: "date_and_time", cast: "timestamptz", desc: true, id: "id", idCast: "uuid", idDesc: true},
+	"date_and_time asc":  {column: "date_and_time", cast: "timestamptz", id: "id", idCast: "uuid"},
+	"amount desc":        {column: "amount", cast: "numeric", desc: true, id: "id", idCast: "uuid", idDesc: true},
+	"amount asc":         {column: "amount", cast: "numeric", id: "id", idCast: "uuid"},
+}
+
+func sortDirection(desc bool) (string, string) {
+	if desc {
+		return "DESC", "<"
+	}
+	return "ASC", ">"
+}
+
+// pageToken is the position a page starts after. order and filter tie it to
+// the request it was issued for.
+type pageToken struct {
+	Order  string `json:"o"`
+	Filter string `json:"f"`
+	Key    string `json:"k"`
+	ID     string `json:"i"`
+}
+
+// pageRequest is implemented by every paginated request.
+type pageRequest interface {
+	GetPageSize() int32
+	GetPageToken() string
+	GetOrderBy() string
+}
+
+// page is the page of a list request being read.
+type page struct {
+	name   string
+	order  pageOrder
+	size   int
+	filter string
+	after  *pageToken
+	keys   [][2]string
+}
+
+// pageFilter fingerprints the parameters of a request besides its page, so
+// a page token cannot be reused with different ones.
+func pageFilter(params ...any) string {
+	sum := sha256.Sum256([]byte(fmt.Sprint(params...)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// newPage reads the pagination fields of req. order_by is a key of orders,
+// with the direction defaulting to asc; fallback is used when it is empty.
+func newPage(req pageRequest, orders map[string]pageOrder, fallback, filter string, defaultSize, maxSize int) (*page, error) {
+	name := strings.Join(strings.Fields(strings.ToLower(req.GetOrderBy())), " ")
+	if name == "" {
+		name = fallback
+	} else if !strings.Contains(name, " ") {
+		name += " asc"
+	}
+	order, ok := orders[name]
+	if !ok {
+		var names []string
+		for name := range orders {
+			names = append(names, `"`+name+`"`)
+		}
+		sort.Strings(names)
+		return nil, status.Errorf(codes.InvalidArgument, "order_by must be one of %s", strings.Join(names, ", "))
+	}
+
+	size := int(req.GetPageSize())
+	if size <= 0 {
+		size = defaultSize
+	}
+	p := &page{name: name, order: order, size: min(size, maxSize), filter: filter}
+
+	if req.GetPageToken() != "" {
+		data, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+		if err != nil || json.Unmarshal(data, &p.after) != nil || p.after == nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		if p.after.Order != name || p.after.Filter != filter {
+			return nil, status.Error(codes.InvalidArgument, "page_token was issued for a different order_by or filter")
+		}
+	}
+	return p, nil
+}
+
+// condition returns the condition selecting the rows after the page token,
+// with its arguments appended to args, or "TRUE" on the first page.
+func (p *page) condition(args []any) (string, []any) {
+	if p.after == nil {
+		return "TRUE", args
+	}
+	_, op := sortDirection(p.order.desc)
+	_, idOp := sortDirection(p.order.idDesc)
+	args = append(args, p.after.Key, p.after.ID)
+	key := "$" + strconv.Itoa(len(args)-1) + "::" + p.order.cast
+	id := "$" + strconv.Itoa(len(args)) + "::" + p.order.idCast
+	// A row comparison can use an index on (column, id).
+	if p.order.desc == p.order.idDesc {
+		return fmt.Sprintf("(%s, %s) %s (%s, %s)", p.order.column, p.order.id, op, key, id), args
+	}
+	return fmt.Sprintf("(%s %s %s OR (%s = %s AND %s %s %s))",
+		p.order.column, op, key, p.order.column, key, p.order.id, idOp, id), args
+}
+
+// keyColumns selects the key and id of a row as text, to be scanned with
+// scanKey.
+func (p *page) keyColumns() string {
+	return p.order.column + "::text, " + p.order.id + "::text"
+}
+
+// orderLimit orders the page and reads one row past it, to tell whether
+// another page follows.
+func (p *page) orderLimit() string {
+	dir, _ := sortDirection(p.order.desc)
+	idDir, _ := sortDirection(p.order.idDesc)
+	return fmt.Sprintf(" ORDER BY %s %s, %s %s LIMIT %d", p.order.column, dir, p.order.id, idDir, p.size+1)
+}
+
+// scanKey returns the destinations of the columns of keyColumns and records
+// them for nextToken.
+func (p *page) scanKey() (*string, *string) {
+	p.keys = append(p.keys, [2]string{})
+	key := &p.keys[len(p.keys)-1]
+	return &key[0], &key[1]
+}
+
+// nextToken returns the token of the page after this one, or "" for the
+// last page, and how many of the rows read belong to this page.
+func (p *page) nextToken() (string, int) {
+	if len(p.keys) <= p.size {
+		return "", len(p.keys)
+	}
+	last := p.keys[p.size-1]
+	data, _ := json.Marshal(pageToken{Order: p.name, Filter: p.filter, Key: last[0], ID: last[1]})
+	return base64.RawURLEncoding.EncodeToString(data), p.size
+}
+
+// withKey scans the columns of page.keyColumns that follow a row's own
+// columns.
+type withKey struct {
+	row  interface{ Scan(...any) error }
+	page *page
+}
+
+func (r withKey) Scan(dest ...any) error {
+	key, id := r.page.scanKey()
+	return r.row.Scan(append(dest, key, id)...)
+}
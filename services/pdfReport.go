@@ -0,0 +1,508 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// The report is laid out on A4 pages, in points.
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 50.0
+
+	pdfMaxCategories = 12
+	pdfMaxMonths     = 24
+	pdfMaxMerchants  = 10
+)
+
+// Widths of the printable ASCII characters in the standard Helvetica fonts,
+// in thousandths of the font size. Other characters are given the width of a
+// digit, which is close enough for the accented letters.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// pdfPalette colours the bars of the charts.
+var pdfPalette = [][3]float64{
+	{0.23, 0.47, 0.76}, {0.93, 0.55, 0.17}, {0.30, 0.65, 0.36}, {0.84, 0.27, 0.27},
+	{0.55, 0.40, 0.72}, {0.55, 0.36, 0.29}, {0.89, 0.47, 0.76}, {0.50, 0.50, 0.50},
+}
+
+func pdfTextWidth(text string, bold bool, size float64) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, r := range text {
+		if r >= 32 && r < 127 {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfFit shortens text to fit in width.
+func pdfFit(text string, bold bool, size, width float64) string {
+	if pdfTextWidth(text, bold, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdfTextWidth(string(runes)+"...", bold, size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// pdfWrap breaks text into lines that fit in width.
+func pdfWrap(text string, bold bool, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && pdfTextWidth(line+" "+word, bold, size) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// pdfString encodes text as a PDF string in the WinAnsi encoding that the
+// standard fonts use, replacing what it cannot show.
+func pdfString(text string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range text {
+		c, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok || c < 32 {
+			c = '?'
+		}
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// formatAmount writes an amount with thousands separators.
+func formatAmount(amount float64) string {
+	s := strconv.FormatFloat(amount, 'f', 2, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, cents, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, d := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String() + "." + cents
+}
+
+// pdfReport lays out the pages of a report, starting a new page whenever
+// the next block does not fit.
+type pdfReport struct {
+	pages  []*bytes.Buffer
+	page   *bytes.Buffer
+	y      float64
+	images []*pdfImage
+}
+
+// pdfImage is an image XObject. JPEG data is embedded as it is; other
+// formats are decoded and stored as compressed RGB samples.
+type pdfImage struct {
+	width      int
+	height     int
+	colorSpace string
+	filter     string
+	decode     string
+	data       []byte
+}
+
+// newPdfImage reads a JPEG, PNG or GIF image.
+func newPdfImage(data []byte) (*pdfImage, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if format == "jpeg" {
+		img := &pdfImage{width: config.Width, height: config.Height, colorSpace: "DeviceRGB", filter: "DCTDecode", data: data}
+		switch config.ColorModel {
+		case color.GrayModel:
+			img.colorSpace = "DeviceGray"
+		case color.CMYKModel:
+			// Adobe writes CMYK JPEGs inverted.
+			img.colorSpace, img.decode = "DeviceCMYK", "[1 0 1 0 1 0 1 0]"
+		}
+		return img, nil
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	// Flatten transparency onto white, as a receipt would be printed.
+	bounds := decoded.Bounds()
+	flat := image.NewRGBA(bounds)
+	draw.Draw(flat, bounds, image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, bounds, decoded, bounds.Min, draw.Over)
+	samples := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for i := 0; i < len(flat.Pix); i += 4 {
+		samples = append(samples, flat.Pix[i], flat.Pix[i+1], flat.Pix[i+2])
+	}
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(samples); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return &pdfImage{width: bounds.Dx(), height: bounds.Dy(), colorSpace: "DeviceRGB", filter: "FlateDecode", data: compressed.Bytes()}, nil
+}
+
+// pdfImageSupported reports whether newPdfImage can read a content type.
+func pdfImageSupported(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+func (p *pdfReport) newPage() {
+	p.page = &bytes.Buffer{}
+	p.pages = append(p.pages, p.page)
+	p.y = pdfPageHeight - pdfMargin
+}
+
+// need starts a new page unless height more points fit on this one.
+func (p *pdfReport) need(height float64) {
+	if p.page == nil || p.y-height < pdfMargin {
+		p.newPage()
+	}
+}
+
+func (p *pdfReport) text(x, y float64, text string, bold bool, size float64) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(p.page, "BT /%s %.1f Tf %.2f %.2f Td %s Tj ET\n", font, size, x, y, pdfString(text))
+}
+
+func (p *pdfReport) textRight(x, y float64, text string, bold bool, size float64) {
+	p.text(x-pdfTextWidth(text, bold, size), y, text, bold, size)
+}
+
+func (p *pdfReport) rect(x, y, width, height float64, colour [3]float64) {
+	fmt.Fprintf(p.page, "%.2f %.2f %.2f rg %.2f %.2f %.2f %.2f re f 0 g\n",
+		colour[0], colour[1], colour[2], x, y, width, height)
+}
+
+// image draws img scaled to fit in the box with its bottom left corner at
+// x, y, keeping its proportions.
+func (p *pdfReport) image(img *pdfImage, x, y, width, height float64) {
+	index := -1
+	for i, existing := range p.images {
+		if existing == img {
+			index = i
+		}
+	}
+	if index < 0 {
+		p.images = append(p.images, img)
+		index = len(p.images) - 1
+	}
+	scale := min(width/float64(img.width), height/float64(img.height))
+	w, h := float64(img.width)*scale, float64(img.height)*scale
+	fmt.Fprintf(p.page, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", w, h, x, y+height-h, index+1)
+}
+
+func (p *pdfReport) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(p.page, "0.75 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n", x1, y1, x2, y2)
+}
+
+func (p *pdfReport) heading(text string) {
+	p.need(40)
+	p.y -= 24
+	p.text(pdfMargin, p.y, text, true, 13)
+	p.y -= 6
+	p.line(pdfMargin, p.y, pdfPageWidth-pdfMargin, p.y)
+	p.y -= 6
+}
+
+// categoryChart draws a horizontal bar per category with its total and
+// share of the currency's spending. Categories past the largest few are
+// added up as "Other".
+func (p *pdfReport) categoryChart(totals []*exportTotal, currency string, sum float64) {
+	if len(totals) > pdfMaxCategories {
+		other := &exportTotal{Key: "Other", Currency: currency}
+		for _, total := range totals[pdfMaxCategories-1:] {
+			other.Count += total.Count
+			other.Amount += total.Amount
+		}
+		totals = append(totals[:pdfMaxCategories-1:pdfMaxCategories-1], other)
+	}
+	largest := 0.0
+	for _, total := range totals {
+		largest = max(largest, total.Amount)
+	}
+	const labelWidth, barWidth = 140.0, 220.0
+	for i, total := range totals {
+		p.need(18)
+		p.y -= 18
+		label := total.Key
+		if label == "" {
+			label = uncategorized
+		}
+		p.text(pdfMargin, p.y, pdfFit(label, false, 9, labelWidth-8), false, 9)
+		if largest > 0 && total.Amount > 0 {
+			p.rect(pdfMargin+labelWidth, p.y-2, barWidth*total.Amount/largest, 11, pdfPalette[i%len(pdfPalette)])
+		}
+		p.textRight(pdfPageWidth-pdfMargin-45, p.y, formatAmount(total.Amount), false, 9)
+		if sum > 0 {
+			p.textRight(pdfPageWidth-pdfMargin, p.y, fmt.Sprintf("%.1f%%", 100*total.Amount/sum), false, 9)
+		}
+	}
+}
+
+// monthChart draws a column per month for the latest months.
+func (p *pdfReport) monthChart(totals []*exportTotal) {
+	if len(totals) > pdfMaxMonths {
+		totals = totals[len(totals)-pdfMaxMonths:]
+	}
+	const chartHeight = 120.0
+	p.need(chartHeight + 40)
+	p.y -= 12
+	top := p.y
+	base := top - chartHeight
+	largest := 0.0
+	for _, total := range totals {
+		largest = max(largest, total.Amount)
+	}
+	p.text(pdfMargin, top, formatAmount(largest), false, 7)
+	p.line(pdfMargin, base, pdfPageWidth-pdfMargin, base)
+	slot := (pdfPageWidth - 2*pdfMargin) / float64(len(totals))
+	for i, total := range totals {
+		left := pdfMargin + slot*float64(i)
+		if largest > 0 && total.Amount > 0 {
+			p.rect(left+slot*0.15, base, slot*0.7, (chartHeight-12)*total.Amount/largest, pdfPalette[0])
+		}
+		label := total.Key
+		if month, err := time.Parse("2006-01", total.Key); err == nil {
+			label = month.Format("Jan 06")
+		}
+		p.text(left+(slot-pdfTextWidth(label, false, 6))/2, base-9, label, false, 6)
+	}
+	p.y = base - 14
+}
+
+// merchantTable lists the merchants spent at most.
+func (p *pdfReport) merchantTable(totals []*exportTotal) {
+	if len(totals) > pdfMaxMerchants {
+		totals = totals[:pdfMaxMerchants]
+	}
+	p.need(18)
+	p.y -= 16
+	p.text(pdfMargin, p.y, "Merchant", true, 9)
+	p.textRight(pdfPageWidth-pdfMargin-110, p.y, "Expenses", true, 9)
+	p.textRight(pdfPageWidth-pdfMargin, p.y, "Amount", true, 9)
+	for _, total := range totals {
+		p.need(14)
+		p.y -= 14
+		p.text(pdfMargin, p.y, pdfFit(total.Key, false, 9, 300), false, 9)
+		p.textRight(pdfPageWidth-pdfMargin-110, p.y, strconv.Itoa(total.Count), false, 9)
+		p.textRight(pdfPageWidth-pdfMargin, p.y, formatAmount(total.Amount), false, 9)
+	}
+}
+
+// writeExportPdf writes a summary report of the expenses: their totals,
+// spending by category and by month, and the top merchants. Amounts in
+// different currencies are reported separately.
+func writeExportPdf(w io.Writer, next func() (*exportRow, error), filter exportFilter, group bool) error {
+	summary := newExportSummary()
+	for {
+		row, err := next()
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			break
+		}
+		summary.add(row)
+	}
+
+	p := &pdfReport{}
+	p.newPage()
+	title := "Expense report"
+	if group {
+		title = "Group expense report"
+	}
+	p.y -= 20
+	p.text(pdfMargin, p.y, title, true, 20)
+
+	from, to := filter.from, filter.to
+	if summary.count > 0 {
+		if from.IsZero() {
+			from = summary.first
+		}
+		if to.IsZero() {
+			to = summary.last.Add(time.Second)
+		}
+	}
+	period := "All expenses"
+	switch {
+	case !from.IsZero() && !to.IsZero():
+		period = from.Format("2 Jan 2006") + " – " + to.Add(-time.Second).Format("2 Jan 2006")
+	case !from.IsZero():
+		period = "From " + from.Format("2 Jan 2006")
+	case !to.IsZero():
+		period = "Up to " + to.Add(-time.Second).Format("2 Jan 2006")
+	}
+	p.y -= 18
+	p.text(pdfMargin, p.y, period, false, 10)
+	var filters []string
+	if filter.categoryId != "" {
+		filters = append(filters, "one category")
+	}
+	if filter.merchantId != "" {
+		filters = append(filters, "one merchant")
+	}
+	if len(filters) > 0 {
+		p.y -= 14
+		p.text(pdfMargin, p.y, "Limited to "+strings.Join(filters, " and "), false, 10)
+	}
+	p.y -= 14
+	p.text(pdfMargin, p.y, "Generated "+time.Now().UTC().Format("2 Jan 2006 15:04 MST"), false, 8)
+
+	p.heading("Totals")
+	if summary.count == 0 {
+		p.y -= 16
+		p.text(pdfMargin, p.y, "No expenses match this export.", false, 10)
+	}
+	for _, total := range sortedTotals(summary.currencies, "", true) {
+		p.need(16)
+		p.y -= 16
+		p.text(pdfMargin, p.y, total.Currency, true, 10)
+		p.textRight(pdfMargin+200, p.y, formatAmount(total.Amount), false, 10)
+		p.text(pdfMargin+215, p.y, fmt.Sprintf("%d expenses", total.Count), false, 10)
+	}
+
+	for _, currency := range sortedTotals(summary.currencies, "", true) {
+		p.heading("Spending by category (" + currency.Currency + ")")
+		p.categoryChart(sortedTotals(summary.categories, currency.Currency, false), currency.Currency, currency.Amount)
+		p.heading("Spending by month (" + currency.Currency + ")")
+		p.monthChart(sortedTotals(summary.months, currency.Currency, true))
+		p.heading("Top merchants (" + currency.Currency + ")")
+		p.merchantTable(sortedTotals(summary.merchants, currency.Currency, false))
+	}
+
+	for i, page := range p.pages {
+		p.page = page
+		p.textRight(pdfPageWidth-pdfMargin, pdfMargin/2, fmt.Sprintf("Page %d of %d", i+1, len(p.pages)), false, 8)
+	}
+	return p.writeTo(w)
+}
+
+// writeTo writes the pages out as a PDF document using the standard
+// Helvetica fonts, so that nothing has to be embedded.
+func (p *pdfReport) writeTo(w io.Writer) error {
+	var objects [][]byte
+	add := func(format string, args ...any) int {
+		objects = append(objects, []byte(fmt.Sprintf(format, args...)))
+		return len(objects)
+	}
+	add("<< /Type /Catalog /Pages 2 0 R >>")
+	pagesObject := add("")
+	add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	resources := "/Font << /F1 3 0 R /F2 4 0 R >>"
+	if len(p.images) > 0 {
+		var xobjects []string
+		for i, img := range p.images {
+			dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s /Length %d",
+				img.width, img.height, img.colorSpace, img.filter, len(img.data))
+			if img.decode != "" {
+				dict += " /Decode " + img.decode
+			}
+			object := add("<< %s >>\nstream\n%s\nendstream", dict, img.data)
+			xobjects = append(xobjects, fmt.Sprintf("/Im%d %d 0 R", i+1, object))
+		}
+		resources += " /XObject << " + strings.Join(xobjects, " ") + " >>"
+	}
+	var kids []string
+	for _, page := range p.pages {
+		var content bytes.Buffer
+		zw := zlib.NewWriter(&content)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		contentObject := add("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", content.Len(), content.Bytes())
+		pageObject := add("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << %s >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, resources, contentObject)
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+	}
+	objects[pagesObject-1] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	_, err := out.WriteTo(w)
+	return err
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const emailVerificationTTL = 48 * time.Hour
+
+func (s *usersServer) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.GetProfileResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := loadProfile(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Failed to load profile: %v", err)
+		return nil, err
+	}
+
+	return &pb.GetProfileResponse{Profile: profile}, nil
+}
+
+func (s *usersServer) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := loadProfile(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Failed to load profile: %v", err)
+		return nil, err
+	}
+
+	var sets []string
+	var args []any
+	set := func(column string, value any) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if req.Username != nil {
+		username := strings.TrimSpace(req.GetUsername())
+		if username == "" || len(username) > 50 {
+			return nil, status.Error(codes.InvalidArgument, "username must be between 1 and 50 characters")
+		}
+		set("username", username)
+	}
+	if req.FirstName != nil {
+		set("first_name", strings.TrimSpace(req.GetFirstName()))
+	}
+	if req.LastName != nil {
+		set("last_name", strings.TrimSpace(req.GetLastName()))
+	}
+	if req.AvatarUrl != nil {
+		avatarURL := strings.TrimSpace(req.GetAvatarUrl())
+		if err := validateAvatarURL(avatarURL); err != nil {
+			return nil, err
+		}
+		set("avatar_url", avatarURL)
+	}
+	if req.DefaultCurrency != nil {
+		unit, err := currency.ParseISO(req.GetDefaultCurrency())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid currency %q", req.GetDefaultCurrency())
+		}
+		set("default_currency", unit.String())
+	}
+	if req.TimeZone != nil {
+		if _, err := time.LoadLocation(req.GetTimeZone()); err != nil || req.GetTimeZone() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid time zone %q", req.GetTimeZone())
+		}
+		set("time_zone", req.GetTimeZone())
+	}
+	if req.Locale != nil {
+		tag, err := language.Parse(req.GetLocale())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid locale %q", req.GetLocale())
+		}
+		set("locale", tag.String())
+	}
+	if req.WeekStartDay != nil {
+		day, err := parseWeekday(req.GetWeekStartDay())
+		if err != nil {
+			return nil, err
+		}
+		set("week_start_day", day.String())
+	}
+
+	var newEmail string
+	if req.Email != nil && !strings.EqualFold(req.GetEmail(), current.GetEmail()) {
+		addr, err := mail.ParseAddress(req.GetEmail())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid email address %q", req.GetEmail())
+		}
+		newEmail = addr.Address
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// A taken email fails the whole request, before anything else changes.
+	var token string
+	if newEmail != "" {
+		var taken bool
+		err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM user_data WHERE lower(email) = lower($1))`, newEmail).Scan(&taken)
+		if err != nil {
+			log.Printf("Failed to check email: %v", err)
+			return nil, err
+		}
+		if taken {
+			return nil, status.Error(codes.AlreadyExists, "email is already in use")
+		}
+		if token, err = storeEmailVerification(ctx, tx, userId, newEmail); err != nil {
+			log.Printf("Failed to store email verification: %v", err)
+			return nil, err
+		}
+	}
+
+	if len(sets) > 0 {
+		sets = append(sets, "updated_at = current_timestamp")
+		args = append(args, userId)
+		query := fmt.Sprintf(`UPDATE user_data SET %s WHERE uuid = $%d`, strings.Join(sets, ", "), len(args))
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			if isUniqueViolation(err) {
+				return nil, status.Error(codes.AlreadyExists, "username is already taken")
+			}
+			log.Printf("Failed to update profile: %v", err)
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	message := "Profile updated successfully"
+	if newEmail != "" {
+		if err := s.mailEmailVerification(newEmail, token); err != nil {
+			log.Printf("Failed to send email verification: %v", err)
+			return nil, err
+		}
+		message = "Profile updated successfully, verify your new email address to apply it"
+	}
+
+	profile, err := loadProfile(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Failed to load profile: %v", err)
+		return nil, err
+	}
+
+	return &pb.UpdateProfileResponse{
+		Profile: profile,
+		Message: message,
+	}, nil
+}
+
+func (s *usersServer) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	if req.GetToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "verification token is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var userId, email string
+	query := `DELETE FROM email_verification_data WHERE token = $1 AND expires_at > current_timestamp RETURNING uuid, email`
+	err = tx.QueryRowContext(ctx, query, req.GetToken()).Scan(&userId, &email)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &pb.VerifyEmailResponse{
+				Verified: false,
+				Message:  "Invalid or expired verification token",
+			}, nil
+		}
+		log.Printf("Failed to check verification token: %v", err)
+		return nil, err
+	}
+
+	query = `UPDATE user_data SET email = $1, email_verified = true, updated_at = current_timestamp WHERE uuid = $2`
+	if _, err := tx.ExecContext(ctx, query, email, userId); err != nil {
+		if isUniqueViolation(err) {
+			return nil, status.Error(codes.AlreadyExists, "email is already in use")
+		}
+		log.Printf("Failed to verify email: %v", err)
+		return nil, err
+	}
+	// Any other outstanding verification for this user is now stale.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM email_verification_data WHERE uuid = $1`, userId); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Email verified for user %s", userId)
+	return &pb.VerifyEmailResponse{
+		Verified: true,
+		Message:  "Email verified successfully",
+	}, nil
+}
+
+// sendEmailVerification stores a single-use token for email and mails the
+// verification link to that address.
+func (s *usersServer) sendEmailVerification(ctx context.Context, userId, email string) error {
+	token, err := storeEmailVerification(ctx, s.db, userId, email)
+	if err != nil {
+		return err
+	}
+	return s.mailEmailVerification(email, token)
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// storeEmailVerification stores a single-use token that verifies email for
+// the user.
+func storeEmailVerification(ctx context.Context, db execer, userId, email string) (string, error) {
+	token, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	query := `INSERT INTO email_verification_data (token, uuid, email, expires_at) VALUES ($1, $2, $3, $4)`
+	if _, err := db.ExecContext(ctx, query, token.String(), userId, email, time.Now().Add(emailVerificationTTL)); err != nil {
+		return "", err
+	}
+	return token.String(), nil
+}
+
+// mailEmailVerification mails the verification link for token to email.
+func (s *usersServer) mailEmailVerification(email, token string) error {
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	body := fmt.Sprintf("Confirm your email address by opening the link below:\n\n%s/verify-email?token=%s\n\nThe link expires in %s.",
+		strings.TrimRight(baseURL, "/"), token, emailVerificationTTL)
+	return s.mailer.Send(email, "Verify your email address", body)
+}
+
+func loadProfile(ctx context.Context, db *sql.DB, userId string) (*pb.UserProfile, error) {
+	var (
+		profile              pb.UserProfile
+		firstName, lastName  sql.NullString
+		avatarURL            sql.NullString
+		pendingEmail         sql.NullString
+		createdAt, updatedAt time.Time
+		deletionScheduledAt  sql.NullTime
+	)
+
+	query := `
+		SELECT u.uuid, u.username, u.email, u.email_verified, u.first_name, u.last_name, u.avatar_url,
+			u.default_currency, u.time_zone, u.locale, u.week_start_day, u.created_at, u.updated_at,
+			u.deletion_scheduled_at,
+			(SELECT v.email FROM email_verification_data v
+				WHERE v.uuid = u.uuid AND v.email <> u.email AND v.expires_at > current_timestamp
+				ORDER BY v.created_at DESC LIMIT 1)
+		FROM user_data u WHERE u.uuid = $1`
+	err := db.QueryRowContext(ctx, query, userId).Scan(
+		&profile.UserId, &profile.Username, &profile.Email, &profile.EmailVerified,
+		&firstName, &lastName, &avatarURL,
+		&profile.DefaultCurrency, &profile.TimeZone, &profile.Locale, &profile.WeekStartDay,
+		&createdAt, &updatedAt, &deletionScheduledAt, &pendingEmail,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, err
+	}
+
+	profile.FirstName = firstName.String
+	profile.LastName = lastName.String
+	profile.AvatarUrl = avatarURL.String
+	profile.PendingEmail = pendingEmail.String
+	profile.CreatedAt = createdAt.Format(time.RFC3339)
+	profile.UpdatedAt = updatedAt.Format(time.RFC3339)
+	if deletionScheduledAt.Valid {
+		profile.DeletionScheduledAt = deletionScheduledAt.Time.Format(time.RFC3339)
+	}
+	return &profile, nil
+}
+
+func parseWeekday(day string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), strings.TrimSpace(day)) {
+			return d, nil
+		}
+	}
+	return time.Sunday, status.Errorf(codes.InvalidArgument, "invalid week start day %q", day)
+}
+
+// validateAvatarURL accepts an empty value, which clears the avatar, or an
+// absolute http or https URL that fits the column.
+func validateAvatarURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	if len(raw) > 500 {
+		return status.Error(codes.InvalidArgument, "avatar_url must be at most 500 characters")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return status.Error(codes.InvalidArgument, "avatar_url must be an http or https URL")
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
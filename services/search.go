@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"html"
+	"log"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	defaultSearchSize = 20
+	maxSearchSize     = 100
+	maxFacetValues    = 10
+)
+
+// searchMarkStart and searchMarkStop mark the matched words in ts_headline output. The markers
+// are stripped from the text first, so that everything else can be escaped
+// before they are turned into <mark> tags.
+const (
+	searchMarkStart   = "⟦"
+	searchMarkStop    = "⟧"
+	searchHeadlineOpt = `StartSel="⟦", StopSel="⟧", MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=" … "`
+)
+
+// searchDocument is the text ts_headline picks snippets from, holding what
+// search_vector indexes.
+const searchDocument = `translate(concat_ws(' · ', place,
+	(SELECT string_agg(tag, ' ' ORDER BY tag) FROM expense_tag_data WHERE expense_id = expense_data.id),
+	(SELECT string_agg(item_name, ', ') FROM expense_item_data WHERE expense_id = expense_data.id),
+	notes), '⟦⟧', '')`
+
+// amountFacetBounds split the amount facet into ranges.
+var amountFacetBounds = []float64{10, 50, 100, 500}
+
+var searchWordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// searchQuery is a parsed search: the free text as a tsquery and the
+// operators as conditions on expense_data. Conditions number their
+// arguments after the scope's $1.
+type searchQuery struct {
+	text       []string
+	conditions []string
+	args       []any
+}
+
+func (q *searchQuery) addCondition(negated bool, condition string, args ...any) {
+	for _, arg := range args {
+		q.args = append(q.args, arg)
+		condition = strings.Replace(condition, "?", "$"+strconv.Itoa(len(q.args)+1), 1)
+	}
+	if negated {
+		condition = "NOT (" + condition + ")"
+	}
+	q.conditions = append(q.conditions, condition)
+}
+
+// splitSearchQuery splits a query on whitespace outside double quotes.
+func splitSearchQuery(query string) []string {
+	var tokens []string
+	var token strings.Builder
+	quoted := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			token.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n'):
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+		default:
+			token.WriteRune(r)
+		}
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}
+
+// likePattern matches value anywhere in a lowercased column.
+func likePattern(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(value))
+	return "%" + value + "%"
+}
+
+// parseAmountFilter reads the value of an amount: operator.
+func parseAmountFilter(value string) (string, []any, error) {
+	if low, high, ok := strings.Cut(value, ".."); ok {
+		from, err1 := strconv.ParseFloat(low, 64)
+		to, err2 := strconv.ParseFloat(high, 64)
+		if err1 != nil || err2 != nil {
+			return "", nil, status.Errorf(codes.InvalidArgument, "invalid amount range %q", value)
+		}
+		return "amount BETWEEN ? AND ?", []any{from, to}, nil
+	}
+	op := "="
+	for _, prefix := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(value, prefix) {
+			op, value = prefix, strings.TrimPrefix(value, prefix)
+			break
+		}
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return "", nil, status.Errorf(codes.InvalidArgument, "invalid amount %q", value)
+	}
+	return "amount " + op + " ?", []any{amount}, nil
+}
+
+// parseSearchQuery turns the query syntax described on
+// SearchExpensesRequest into a searchQuery. Words that are not operators are
+// searched for as text.
+func parseSearchQuery(query string) (*searchQuery, error) {
+	parsed := &searchQuery{}
+	for _, token := range splitSearchQuery(query) {
+		negated := strings.HasPrefix(token, "-") && len(token) > 1
+		if negated {
+			token = token[1:]
+		}
+
+		key, value, ok := strings.Cut(token, ":")
+		key = strings.ToLower(key)
+		value = strings.TrimSpace(strings.ReplaceAll(value, `"`, ""))
+		if ok && value != "" {
+			var err error
+			switch key {
+			case "amount":
+				var condition string
+				var args []any
+				if condition, args, err = parseAmountFilter(value); err == nil {
+					parsed.addCondition(negated, condition, args...)
+				}
+			case "after", "before", "on":
+				start, parseErr := parseExportTime(value, false)
+				end, _ := parseExportTime(value, true)
+				if parseErr != nil {
+					err = status.Errorf(codes.InvalidArgument, "%s: must be followed by a YYYY-MM-DD date", key)
+				} else if key == "after" {
+					parsed.addCondition(negated, "date_and_time >= ?", start)
+				} else if key == "before" {
+					parsed.addCondition(negated, "date_and_time < ?", start)
+				} else {
+					parsed.addCondition(negated, "date_and_time >= ? AND date_and_time < ?", start, end)
+				}
+			case "category":
+				parsed.addCondition(negated, "lower(category) = ?", strings.ToLower(value))
+			case "merchant":
+				parsed.addCondition(negated, "lower(place) LIKE ?", likePattern(value))
+			case "payment":
+				parsed.addCondition(negated, "lower(mode_of_payment) LIKE ?", likePattern(value))
+			case "currency":
+				parsed.addCondition(negated, "currency = ?", strings.ToUpper(value))
+			case "tag":
+				parsed.addCondition(negated, "EXISTS (SELECT 1 FROM expense_tag_data WHERE expense_id = expense_data.id AND tag = ?)", strings.ToLower(value))
+			default:
+				ok = false
+			}
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				continue
+			}
+		}
+
+		// Everything else is text. The words are reduced to letters and
+		// digits, so the tsquery needs no further escaping.
+		words := searchWordPattern.FindAllString(strings.ToLower(token), -1)
+		if len(words) == 0 {
+			continue
+		}
+		for i, word := range words {
+			words[i] = word + ":*"
+		}
+		term := strings.Join(words, " <-> ")
+		if len(words) > 1 {
+			term = "(" + term + ")"
+		}
+		if negated {
+			term = "!" + term
+		}
+		parsed.text = append(parsed.text, term)
+	}
+	return parsed, nil
+}
+
+// facetQuery is the operator that narrows a search to one facet value.
+func facetQuery(key, value string) string {
+	value = strings.ReplaceAll(value, `"`, "")
+	if strings.ContainsAny(value, " \t") {
+		value = `"` + value + `"`
+	}
+	return key + ":" + value
+}
+
+// amountFacet describes the range of amountFacetBounds a bucket of
+// width_bucket covers.
+func amountFacet(bucket int) (string, string) {
+	format := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	if bucket == 0 {
+		return "Under " + format(amountFacetBounds[0]), "amount:<" + format(amountFacetBounds[0])
+	}
+	low := amountFacetBounds[bucket-1]
+	if bucket == len(amountFacetBounds) {
+		return format(low) + " and over", "amount:>=" + format(low)
+	}
+	high := amountFacetBounds[bucket]
+	return format(low) + "–" + format(high), "amount:>=" + format(low) + " amount:<" + format(high)
+}
+
+// searchSnippet escapes a ts_headline fragment and marks its matches.
+func searchSnippet(headline string) string {
+	return strings.NewReplacer(searchMarkStart, "<mark>", searchMarkStop, "</mark>").Replace(html.EscapeString(headline))
+}
+
+func (s *expenseServer) SearchExpenses(ctx context.Context, req *pb.SearchExpensesRequest) (*pb.SearchExpensesResponse, error) {
+	scope, owner, err := s.expenseScope(ctx, req.GetGroupId())
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseSearchQuery(req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]any{owner}, parsed.args...)
+	from := `expense_data`
+	where := scope
+	rank, snippet := `0::float8`, `''`
+	if len(parsed.text) > 0 {
+		// A query made up of stop words only matches every expense.
+		args = append(args, strings.Join(parsed.text, " & "))
+		from += `, to_tsquery('english', $` + strconv.Itoa(len(args)) + `) q`
+		where += ` AND (numnode(q) = 0 OR search_vector @@ q)`
+		rank = `ts_rank_cd(search_vector, q)`
+		snippet = `ts_headline('english', ` + searchDocument + `, q, '` + searchHeadlineOpt + `')`
+	}
+	for _, condition := range parsed.conditions {
+		where += ` AND ` + condition
+	}
+
+	// Without free text every rank is 0, so relevance falls back to the
+	// newest expenses first.
+	orders := maps.Clone(expenseOrders)
+	orders["relevance"] = expenseOrders["date_and_time desc"]
+	if len(parsed.text) > 0 {
+		orders["relevance"] = pageOrder{column: rank, cast: "real", desc: true, id: "id", idCast: "uuid", idDesc: true}
+	}
+	page, err := newPage(req, orders, "relevance", pageFilter(req.GetGroupId(), req.GetQuery()), defaultSearchSize, maxSearchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// The snippet is only worked out for the page returned.
+	after, pageArgs := page.condition(args)
+	query := `SELECT id, ` + rank + `, ` + snippet + `, ` + page.keyColumns() + ` FROM ` + from + `
+		WHERE ` + where + ` AND ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, pageArgs...)
+	if err != nil {
+		log.Printf("Error searching expenses: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	var results []*pb.SearchResult
+	var ids []string
+	for rows.Next() {
+		var result pb.SearchResult
+		var id string
+		key, keyId := page.scanKey()
+		if err := rows.Scan(&id, &result.Rank, &result.Snippet, key, keyId); err != nil {
+			return nil, err
+		}
+		result.Snippet = searchSnippet(result.Snippet)
+		results = append(results, &result)
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	next, n := page.nextToken()
+	results, ids = results[:n], ids[:n]
+
+	if len(ids) > 0 {
+		rows, err := s.db.QueryContext(ctx, `SELECT `+expenseColumns+` FROM expense_data WHERE id = ANY($1)`, pq.Array(ids))
+		if err != nil {
+			log.Printf("Error loading search results: %v", err)
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			expense, err := scanExpense(rows)
+			if err != nil {
+				return nil, err
+			}
+			results[slices.Index(ids, expense.Id)].Expense = expense
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	facets, total, err := s.searchFacets(ctx, from, where, args)
+	if err != nil {
+		log.Printf("Error counting search facets: %v", err)
+		return nil, err
+	}
+	return &pb.SearchExpensesResponse{Results: results, TotalCount: total, Facets: facets, NextPageToken: next}, nil
+}
+
+// searchFacets counts the matches of a search by category, merchant,
+// payment method, currency and amount range.
+func (s *expenseServer) searchFacets(ctx context.Context, from, where string, args []any) (*pb.SearchFacets, int32, error) {
+	args = append(args, pq.Array(amountFacetBounds))
+	query := `
+		WITH m AS (SELECT category, place, mode_of_payment, currency, amount FROM ` + from + ` WHERE ` + where + `)
+		SELECT 'category', category, count(*) FROM m GROUP BY 2
+		UNION ALL SELECT 'merchant', place, count(*) FROM m GROUP BY 2
+		UNION ALL SELECT 'payment', mode_of_payment, count(*) FROM m GROUP BY 2
+		UNION ALL SELECT 'currency', currency, count(*) FROM m GROUP BY 2
+		UNION ALL SELECT 'amount', width_bucket(amount::float8, $` + strconv.Itoa(len(args)) + `::float8[])::text, count(*) FROM m GROUP BY 2
+		ORDER BY 1, 3 DESC, 2`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	facets := &pb.SearchFacets{}
+	amounts := make([]*pb.FacetValue, len(amountFacetBounds)+1)
+	var total int32
+	for rows.Next() {
+		var facet, value string
+		var count int32
+		if err := rows.Scan(&facet, &value, &count); err != nil {
+			return nil, 0, err
+		}
+		var values *[]*pb.FacetValue
+		switch facet {
+		case "category":
+			values = &facets.Categories
+		case "merchant":
+			values = &facets.Merchants
+		case "payment":
+			values = &facets.PaymentMethods
+		case "currency":
+			// Every expense has a currency, so these add up to the total.
+			total += count
+			values = &facets.Currencies
+		case "amount":
+			bucket, _ := strconv.Atoi(value)
+			label, query := amountFacet(bucket)
+			amounts[bucket] = &pb.FacetValue{Value: label, Count: count, Query: query}
+			continue
+		}
+		if value == "" || len(*values) >= maxFacetValues {
+			continue
+		}
+		*values = append(*values, &pb.FacetValue{Value: value, Count: count, Query: facetQuery(facet, value)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	// Amount ranges read best in order rather than by count.
+	for _, amount := range amounts {
+		if amount != nil {
+			facets.AmountRanges = append(facets.AmountRanges, amount)
+		}
+	}
+	return facets, total, nil
+}
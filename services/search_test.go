@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	march4 := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		query      string
+		text       []string
+		conditions []string
+		args       []any
+	}{
+		{"empty", "  ", nil, nil, nil},
+		{"words", "Coffee beans", []string{"coffee:*", "beans:*"}, nil, nil},
+		{"quoted phrase", `"blue tokai" -decaf`, []string{"(blue:* <-> tokai:*)", "!decaf:*"}, nil, nil},
+		{"punctuation is dropped", `o'brien's & co!`, []string{"(o:* <-> brien:* <-> s:*)", "co:*"}, nil, nil},
+		{"lone dash", "-", nil, nil, nil},
+		{"amount comparison", "amount:>=100", nil, []string{"amount >= $2"}, []any{100.0}},
+		{"amount range", "amount:10..50.5", nil, []string{"amount BETWEEN $2 AND $3"}, []any{10.0, 50.5}},
+		{"exact amount", "amount:99", nil, []string{"amount = $2"}, []any{99.0}},
+		{"dates", "after:2025-03-04 before:2025-03-04 on:2025-03-04", nil,
+			[]string{"date_and_time >= $2", "date_and_time < $3", "date_and_time >= $4 AND date_and_time < $5"},
+			[]any{march4, march4, march4, march4.AddDate(0, 0, 1)}},
+		{"operators", `Category:Food merchant:"Blue Tokai" payment:upi currency:inr tag:Work`, nil,
+			[]string{
+				"lower(category) = $2",
+				"lower(place) LIKE $3",
+				"lower(mode_of_payment) LIKE $4",
+				"currency = $5",
+				"EXISTS (SELECT 1 FROM expense_tag_data WHERE expense_id = expense_data.id AND tag = $6)",
+			},
+			[]any{"food", "%blue tokai%", "%upi%", "INR", "work"}},
+		{"like wildcards are escaped", `merchant:50%_off\`, nil, []string{"lower(place) LIKE $2"}, []any{`%50\%\_off\\%`}},
+		{"negated operator", "-tag:reimbursed", nil,
+			[]string{"NOT (EXISTS (SELECT 1 FROM expense_tag_data WHERE expense_id = expense_data.id AND tag = $2))"}, []any{"reimbursed"}},
+		{"unknown operator is text", "note:dinner", []string{"(note:* <-> dinner:*)"}, nil, nil},
+		{"operator without a value is text", "tag: lunch", []string{"tag:*", "lunch:*"}, nil, nil},
+		{"text and operators", "uber amount:<20 -pool", []string{"uber:*", "!pool:*"}, []string{"amount < $2"}, []any{20.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := parseSearchQuery(tt.query)
+			if err != nil {
+				t.Fatalf("parseSearchQuery: %v", err)
+			}
+			if fmt.Sprint(parsed.text) != fmt.Sprint(tt.text) {
+				t.Errorf("text = %q, want %q", parsed.text, tt.text)
+			}
+			if fmt.Sprint(parsed.conditions) != fmt.Sprint(tt.conditions) {
+				t.Errorf("conditions = %q, want %q", parsed.conditions, tt.conditions)
+			}
+			if fmt.Sprint(parsed.args) != fmt.Sprint(tt.args) {
+				t.Errorf("args = %v, want %v", parsed.args, tt.args)
+			}
+		})
+	}
+}
+
+func TestParseSearchQueryInvalid(t *testing.T) {
+	for _, query := range []string{"amount:lots", "amount:>=", "amount:10..", "after:yesterday", "on:04/03/2025"} {
+		if _, err := parseSearchQuery(query); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("parseSearchQuery(%q) error = %v, want InvalidArgument", query, err)
+		}
+	}
+}
+
+func TestSplitSearchQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"a  b\tc\nd", []string{"a", "b", "c", "d"}},
+		{`merchant:"blue tokai" x`, []string{`merchant:"blue tokai"`, "x"}},
+		{`"unterminated phrase`, []string{`"unterminated phrase`}},
+	}
+	for _, tt := range tests {
+		if got := splitSearchQuery(tt.query); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("splitSearchQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
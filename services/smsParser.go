@@ -0,0 +1,166 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Outcomes of reading a message, stored in ingested_message_data.status.
+const (
+	messageCreated        = "created"
+	messageDuplicate      = "duplicate"
+	messageOtp            = "otp"
+	messagePromotional    = "promotional"
+	messageNotTransaction = "not_transaction"
+	messageFailed         = "failed"
+)
+
+// smsTemplate reads one alert format. pattern must capture "amount" and
+// "merchant" and may capture "currency".
+type smsTemplate struct {
+	name    string
+	sender  *regexp.Regexp
+	method  string
+	pattern *regexp.Regexp
+	credit  bool
+}
+
+// parsedSms is a transaction read from an alert. currency is empty when the
+// alert does not state one.
+type parsedSms struct {
+	amount   float64
+	currency string
+	merchant string
+	method   string
+	credit   bool
+	template string
+}
+
+// newSmsTemplate builds a template, expanding {amount} to the currency and
+// amount and {end} to the text that ends a merchant name. An empty method is
+// guessed from the message.
+func newSmsTemplate(name, sender, method, pattern string, credit bool) smsTemplate {
+	pattern = strings.ReplaceAll(pattern, "{amount}", `(?P<currency>rs\.?|inr|₹|usd|us\$|\$|eur|€|gbp|£)\s?(?P<amount>\d[\d,]*(?:\.\d{1,2})?)`)
+	pattern = strings.ReplaceAll(pattern, "{end}", `(?:\s+(?:on|via|using|ref|refno|upi|was|has|is|avl|avbl|not you|info)\b|\s*\.(?:\s|$)|\s*[,(;]|$)`)
+	template := smsTemplate{name: name, method: method, pattern: regexp.MustCompile("(?i)" + pattern), credit: credit}
+	if sender != "" {
+		template.sender = regexp.MustCompile("(?i)" + sender)
+	}
+	return template
+}
+
+// smsTemplates are tried in order; bank templates only for their senders.
+var smsTemplates = []smsTemplate{
+	newSmsTemplate("hdfc_upi", `HDFCBK`, "UPI", `sent {amount}\s+from .*?\bto\s+(?P<merchant>.+?)\s+on\b`, false),
+	newSmsTemplate("hdfc_card", `HDFCBK`, "Card", `spent {amount}\s+on .*?card .*?\bat\s+(?P<merchant>.+?){end}`, false),
+	newSmsTemplate("icici_card", `ICICI`, "Card", `{amount}\s+spent (?:using|on) .*?card \S+ on \S+ on\s+(?P<merchant>.+?){end}`, false),
+	newSmsTemplate("sbi_upi", `SBI`, "UPI", `debited by (?P<amount>\d[\d,]*(?:\.\d{1,2})?) on date \S+ trf to\s+(?P<merchant>.+?)\s+refno`, false),
+	newSmsTemplate("axis_upi", `AXIS`, "UPI", `{amount}\s+debited\s+a/c no\. \S+ .*?upi/p2[am]/\d+/(?P<merchant>[^/\n]+?)(?:/|\s+not you|$)`, false),
+	newSmsTemplate("card_spend", ``, "Card", `{amount}\s+(?:was\s+)?spent (?:on|using|at)\b.*?\bat\s+(?P<merchant>.+?){end}`, false),
+	newSmsTemplate("card_transaction", ``, "", `(?:txn|transaction|purchase) of {amount}.*?\bat\s+(?P<merchant>.+?){end}`, false),
+	newSmsTemplate("charge", ``, "Card", `(?:you made an? |a )?(?:charge|transaction|purchase) (?:of )?{amount}\s+(?:at|with|from)\s+(?P<merchant>.+?){end}`, false),
+	newSmsTemplate("charge_made", ``, "Card", `you made an? {amount}\s+(?:transaction|purchase|charge)\s+(?:at|with)\s+(?P<merchant>.+?){end}`, false),
+	newSmsTemplate("upi_paid", ``, "", `(?:paid|payment of|sent) {amount}\s+(?:to|at)\s+(?P<merchant>.+?){end}`, false),
+	newSmsTemplate("account_debit", ``, "", `{amount}\s+(?:has been\s+|is\s+)?debited from .*?\b(?:to|towards|at|trf to|for)\s+(?:vpa\s+)?(?P<merchant>.+?){end}`, false),
+	newSmsTemplate("account_debited", ``, "", `a/c .*?debited (?:by|for|with) {amount}.*?\b(?:towards|to|at|trf to|info:?)\s+(?:vpa\s+)?(?P<merchant>.+?){end}`, false),
+	newSmsTemplate("account_credit", ``, "", `{amount}\s+(?:has been\s+|is\s+)?credited to .*?\b(?:by|from)\s+(?:vpa\s+)?(?P<merchant>.+?){end}`, true),
+	newSmsTemplate("account_credited", ``, "", `a/c .*?credited (?:by|with) {amount}.*?\b(?:by|from)\s+(?:vpa\s+)?(?P<merchant>.+?){end}`, true),
+}
+
+var (
+	// otpPattern finds one-time passwords; otpWarningPattern removes the
+	// "never share your OTP" warnings that bank alerts carry.
+	otpPattern        = regexp.MustCompile(`(?i)\b(?:otp|one[\s-]?time\s+pass(?:word|code)|verification\s+code|security\s+code)\b`)
+	otpWarningPattern = regexp.MustCompile(`(?i)\b(?:never|do\s*n[o']?t|don't)\s+share\s+(?:your\s+|the\s+|this\s+)?(?:otp|pin|password|card details)[^.]*`)
+
+	// Promotional senders carry a -P suffix; other promotions are caught by
+	// their wording when no template matches.
+	promotionalSenderPattern = regexp.MustCompile(`(?i)-P$`)
+	promotionalPattern       = regexp.MustCompile(`(?i)\b(?:offer|cashback of up to|discount|sale|pre-?approved|apply now|click|limited period|win|voucher|coupon|https?://)`)
+
+	// transactionPattern decides whether a message is worth handing to the
+	// extraction model at all.
+	transactionPattern = regexp.MustCompile(`(?i)\b(?:debited|credited|spent|paid|sent|charged?|purchase|txn|transaction|withdrawn)\b`)
+	amountPattern      = regexp.MustCompile(`\d`)
+)
+
+// isOtp reports whether a message carries a one-time password.
+func isOtp(body string) bool {
+	return otpPattern.MatchString(otpWarningPattern.ReplaceAllString(body, ""))
+}
+
+// parseSms reads a bank alert with the first template that matches. It
+// returns false when no template does.
+func parseSms(sender, body string) (parsedSms, bool) {
+	body = strings.Join(strings.Fields(body), " ")
+	for _, template := range smsTemplates {
+		if template.sender != nil && !template.sender.MatchString(sender) {
+			continue
+		}
+		match := template.pattern.FindStringSubmatch(body)
+		if match == nil {
+			continue
+		}
+		parsed := parsedSms{credit: template.credit, template: template.name, method: template.method}
+		if parsed.method == "" {
+			parsed.method = smsPaymentMethod(body)
+		}
+		for i, group := range template.pattern.SubexpNames() {
+			switch group {
+			case "amount":
+				parsed.amount, _ = strconv.ParseFloat(strings.ReplaceAll(match[i], ",", ""), 64)
+			case "currency":
+				parsed.currency = smsCurrency(match[i])
+			case "merchant":
+				parsed.merchant = cleanSmsMerchant(match[i])
+			}
+		}
+		if parsed.amount > 0 && parsed.merchant != "" {
+			return parsed, true
+		}
+	}
+	return parsedSms{}, false
+}
+
+func smsCurrency(symbol string) string {
+	switch strings.TrimSuffix(strings.ToLower(symbol), ".") {
+	case "rs", "inr", "₹":
+		return "INR"
+	case "usd", "us$", "$":
+		return "USD"
+	case "eur", "€":
+		return "EUR"
+	case "gbp", "£":
+		return "GBP"
+	}
+	return ""
+}
+
+func smsPaymentMethod(body string) string {
+	lower := strings.ToLower(body)
+	switch {
+	case strings.Contains(lower, "upi") || strings.Contains(lower, "vpa"):
+		return "UPI"
+	case strings.Contains(lower, "credit card"):
+		return "Credit Card"
+	case strings.Contains(lower, "debit card"):
+		return "Debit Card"
+	case strings.Contains(lower, "card"):
+		return "Card"
+	}
+	return "Bank Transfer"
+}
+
+// cleanSmsMerchant trims a captured merchant and reduces a UPI address such
+// as "swiggy.food@icici" to "swiggy food".
+func cleanSmsMerchant(merchant string) string {
+	merchant = strings.Trim(merchant, " .,:;-*")
+	if at := strings.Index(merchant, "@"); at > 0 && !strings.Contains(merchant, " ") {
+		merchant = strings.NewReplacer(".", " ", "_", " ", "-", " ").Replace(merchant[:at])
+	}
+	if len(merchant) > 100 {
+		merchant = merchant[:100]
+	}
+	return strings.TrimSpace(merchant)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseSmsTemplates(t *testing.T) {
+	tests := []struct {
+		sender string
+		body   string
+		want   parsedSms
+	}{
+		{"VM-HDFCBK", "Sent Rs.250.00 from HDFC Bank A/c **1234 to SWIGGY on 12/03/25 Ref 123456789012 Not You? Call 18002586161",
+			parsedSms{250, "INR", "SWIGGY", "UPI", false, "hdfc_upi"}},
+		{"VM-HDFCBK", "Spent Rs.1,499.00 On HDFC Bank Card 1234 At AMAZON PAY INDIA On 2025-03-12:10:15:00. Not You? To Block+Reissue Call 18002586161",
+			parsedSms{1499, "INR", "AMAZON PAY INDIA", "Card", false, "hdfc_card"}},
+		{"AD-ICICIT", "INR 2,350.00 spent using ICICI Bank Card XX1234 on 12-Mar-25 on IND*FLIPKART. Avl Limit: INR 1,00,000.00. If not you, call 1800 2662.",
+			parsedSms{2350, "INR", "IND*FLIPKART", "Card", false, "icici_card"}},
+		{"BZ-SBIUPI", "Dear UPI user A/C X1234 debited by 120.0 on date 12Mar25 trf to CHAI POINT Refno 123456789012. If not u? call 1800111109. -SBI",
+			parsedSms{120, "", "CHAI POINT", "UPI", false, "sbi_upi"}},
+		{"AX-AXISBK", "INR 560.00 debited A/c no. XX1234 12-03-25, 10:15:00 UPI/P2M/123456789012/ZOMATO LTD Not you? SMS BLOCKUPI Cust ID to 919951860002 - Axis Bank",
+			parsedSms{560, "INR", "ZOMATO LTD", "UPI", false, "axis_upi"}},
+		{"VM-KOTAKB", "Rs 799 spent on Kotak Debit Card XX1234 at BOOKMYSHOW on 12/03/2025. Not you? Call 18602662666",
+			parsedSms{799, "INR", "BOOKMYSHOW", "Card", false, "card_spend"}},
+		{"+15551234567", "A transaction of USD 45.20 on your credit card ending 1234 at UBER TRIP was approved.",
+			parsedSms{45.2, "USD", "UBER TRIP", "Credit Card", false, "card_transaction"}},
+		{"+15551234567", "A charge of €30.00 with NETFLIX.COM was made on your card ending 1234.",
+			parsedSms{30, "EUR", "NETFLIX.COM", "Card", false, "charge"}},
+		{"+447700900123", "You made a £8.40 purchase at PRET A MANGER on your card ending 1234.",
+			parsedSms{8.4, "GBP", "PRET A MANGER", "Card", false, "charge_made"}},
+		{"JD-PAYTMB", "Paid Rs.150 to ramesh.kumar@okaxis via UPI. Ref 123456789012",
+			parsedSms{150, "INR", "ramesh kumar", "UPI", false, "upi_paid"}},
+		{"VK-BOBSMS", "Rs 2,000.00 has been debited from your account XX1234 towards ELECTRICITY BILL on 12-03-2025.",
+			parsedSms{2000, "INR", "ELECTRICITY BILL", "Bank Transfer", false, "account_debit"}},
+		{"VK-YESBNK", "Your A/c XX1234 is debited for INR 899.00 on 12-03-25 towards NETFLIX; Avl Bal INR 10,000",
+			parsedSms{899, "INR", "NETFLIX", "Bank Transfer", false, "account_debited"}},
+		{"VK-KOTAKB", "INR 5,000.00 credited to your A/c XX1234 from ACME CORP on 12-03-25.",
+			parsedSms{5000, "INR", "ACME CORP", "Bank Transfer", true, "account_credit"}},
+		{"VK-IDFCFB", "Your a/c XX1234 is credited with Rs.300.00 on 12-03-25 by priya@okhdfcbank. Avl bal Rs.5000",
+			parsedSms{300, "INR", "priya", "Bank Transfer", true, "account_credited"}},
+	}
+
+	covered := map[string]bool{}
+	for _, tt := range tests {
+		t.Run(tt.want.template, func(t *testing.T) {
+			got, ok := parseSms(tt.sender, tt.body)
+			if !ok {
+				t.Fatalf("no template matched %q", tt.body)
+			}
+			if got != tt.want {
+				t.Errorf("parseSms = %+v, want %+v", got, tt.want)
+			}
+		})
+		covered[tt.want.template] = true
+	}
+	for _, template := range smsTemplates {
+		if !covered[template.name] {
+			t.Errorf("template %s has no sample", template.name)
+		}
+	}
+}
+
+func TestParseSmsBankTemplateNeedsSender(t *testing.T) {
+	// The SBI wording only matches its own template when SBI sent it.
+	body := "Dear UPI user A/C X1234 debited by 120.0 on date 12Mar25 trf to CHAI POINT Refno 123456789012."
+	if got, ok := parseSms("VM-OTHERB", body); ok && got.template == "sbi_upi" {
+		t.Errorf("sbi_upi matched a message from another sender")
+	}
+}
+
+func TestReadSmsFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		sender string
+		body   string
+		want   string
+	}{
+		{"otp", "VM-HDFCBK", "123456 is your OTP for a txn of Rs 500.00 at AMAZON. Valid for 10 mins.", messageOtp},
+		{"one-time password", "AD-ICICIT", "Your one-time password for the purchase of Rs 2,000 is 482913.", messageOtp},
+		{"verification code", "+15551234567", "Your verification code is 5521.", messageOtp},
+		{"otp warning only", "VM-HDFCBK", "Sent Rs.250.00 from HDFC Bank A/c **1234 to SWIGGY on 12/03/25. Never share your OTP with anyone.", messageCreated},
+		{"promotional sender", "VM-HDFCBK-P", "Sent Rs.250.00 from HDFC Bank A/c **1234 to SWIGGY on 12/03/25", messagePromotional},
+		{"promotional wording", "VM-AMAZON", "Get cashback of up to Rs 500 on your next purchase! Click https://example.com/offer", messagePromotional},
+		{"pre-approved loan", "VM-HDFCBK", "You are pre-approved for a personal loan of Rs 5,00,000. Apply now.", messagePromotional},
+		{"no transaction", "VM-HDFCBK", "Your e-statement for March is ready to view.", messageNotTransaction},
+		{"no amount", "VM-HDFCBK", "Your card has been debited.", messageNotTransaction},
+	}
+	s := &ingestionServer{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, outcome, err := s.readSms(context.Background(), tt.sender, tt.body)
+			if err != nil {
+				t.Fatalf("readSms: %v", err)
+			}
+			if outcome != tt.want {
+				t.Errorf("outcome = %s, want %s", outcome, tt.want)
+			}
+		})
+	}
+}
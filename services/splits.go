@@ -0,0 +1,541 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// Ways of dividing an expense, stored in expense_data.split_method.
+const (
+	splitEqual      = "equal"
+	splitExact      = "exact"
+	splitPercentage = "percentage"
+	splitShares     = "shares"
+	splitItems      = "items"
+)
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// splitAmounts divides total cents between the participants. Weighted splits
+// hand the cents lost to rounding to the largest remainders, so the parts
+// always add up to the total.
+func splitAmounts(total int64, method string, values []float64) ([]int64, error) {
+	if len(values) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one participant is required")
+	}
+
+	weights := make([]float64, len(values))
+	switch method {
+	case splitEqual:
+		for i := range weights {
+			weights[i] = 1
+		}
+	case splitExact:
+		parts := make([]int64, len(values))
+		var sum int64
+		for i, v := range values {
+			parts[i] = toCents(v)
+			sum += parts[i]
+		}
+		if sum != total {
+			return nil, status.Errorf(codes.InvalidArgument, "exact amounts add up to %.2f instead of %.2f", fromCents(sum), fromCents(total))
+		}
+		return parts, nil
+	case splitPercentage, splitShares:
+		var sum float64
+		for i, v := range values {
+			if v < 0 {
+				return nil, status.Error(codes.InvalidArgument, "split values cannot be negative")
+			}
+			weights[i] = v
+			sum += v
+		}
+		if sum == 0 {
+			return nil, status.Error(codes.InvalidArgument, "split values add up to zero")
+		}
+		if method == splitPercentage && math.Abs(sum-100) > 0.01 {
+			return nil, status.Errorf(codes.InvalidArgument, "percentages add up to %.2f instead of 100", sum)
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "method must be %q, %q, %q or %q", splitEqual, splitExact, splitPercentage, splitShares)
+	}
+
+	var weightSum float64
+	for _, w := range weights {
+		weightSum += w
+	}
+	sign := int64(1)
+	if total < 0 {
+		sign, total = -1, -total
+	}
+
+	parts := make([]int64, len(weights))
+	remainders := make([]float64, len(weights))
+	allocated := int64(0)
+	for i, w := range weights {
+		exact := float64(total) * w / weightSum
+		parts[i] = int64(math.Floor(exact))
+		remainders[i] = exact - float64(parts[i])
+		allocated += parts[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for i := 0; allocated < total; i++ {
+		parts[order[i%len(order)]]++
+		allocated++
+	}
+	for i := range parts {
+		parts[i] *= sign
+	}
+	return parts, nil
+}
+
+type debt struct {
+	from  string
+	to    string
+	cents int64
+}
+
+// simplifyDebts turns net balances (positive when owed money) into
+// transfers. Debtors and creditors with equal amounts are paired first, the
+// rest is settled largest against largest, so n members never need more
+// than n-1 transfers.
+func simplifyDebts(net map[string]int64) []debt {
+	type party struct {
+		id    string
+		cents int64
+	}
+	var debtors, creditors []*party
+	for id, cents := range net {
+		switch {
+		case cents < 0:
+			debtors = append(debtors, &party{id, -cents})
+		case cents > 0:
+			creditors = append(creditors, &party{id, cents})
+		}
+	}
+	byAmount := func(parties []*party) {
+		sort.Slice(parties, func(i, j int) bool {
+			if parties[i].cents != parties[j].cents {
+				return parties[i].cents > parties[j].cents
+			}
+			return parties[i].id < parties[j].id
+		})
+	}
+
+	var debts []debt
+	for len(debtors) > 0 && len(creditors) > 0 {
+		byAmount(debtors)
+		byAmount(creditors)
+
+		di, ci := 0, 0
+	exact:
+		for i, d := range debtors {
+			for j, c := range creditors {
+				if d.cents == c.cents {
+					di, ci = i, j
+					break exact
+				}
+			}
+		}
+
+		d, c := debtors[di], creditors[ci]
+		amount := min(d.cents, c.cents)
+		debts = append(debts, debt{from: d.id, to: c.id, cents: amount})
+		d.cents -= amount
+		c.cents -= amount
+		if d.cents == 0 {
+			debtors = append(debtors[:di], debtors[di+1:]...)
+		}
+		if c.cents == 0 {
+			creditors = append(creditors[:ci], creditors[ci+1:]...)
+		}
+	}
+	return debts
+}
+
+// splittableExpense is a group expense the caller may split.
+type splittableExpense struct {
+	id         string
+	recordedBy string
+	groupId    string
+	amount     float64
+	currency   string
+}
+
+// loadSplittableExpense loads a group expense and checks that the caller
+// recorded it or administers its group.
+func (s *groupsServer) loadSplittableExpense(ctx context.Context, expenseId, userId string) (*splittableExpense, error) {
+	if _, err := uuid.Parse(expenseId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid expense id")
+	}
+
+	var recordedBy, groupId sql.NullString
+	expense := splittableExpense{id: expenseId}
+	query := `SELECT uuid, group_id, amount, currency FROM expense_data WHERE id = $1`
+	err := s.db.QueryRowContext(ctx, query, expenseId).Scan(&recordedBy, &groupId, &expense.amount, &expense.currency)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "expense not found")
+	}
+	if err != nil {
+		log.Printf("Failed to load expense: %v", err)
+		return nil, err
+	}
+	expense.recordedBy = recordedBy.String
+	expense.groupId = groupId.String
+	if !groupId.Valid {
+		// Do not reveal other users' personal expenses.
+		if expense.recordedBy != userId {
+			return nil, status.Error(codes.NotFound, "expense not found")
+		}
+		return nil, status.Error(codes.FailedPrecondition, "only group expenses can be split")
+	}
+	role, err := groupRole(ctx, s.db, expense.groupId, userId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "expense not found")
+	}
+	if expense.recordedBy != userId && role != groupRoleOwner && role != groupRoleAdmin {
+		return nil, status.Error(codes.PermissionDenied, "only the member who recorded the expense or a group admin can split it")
+	}
+	return &expense, nil
+}
+
+// payer resolves the member who paid the expense, defaulting to whoever
+// recorded it.
+func (s *groupsServer) payer(ctx context.Context, expense *splittableExpense, paidBy, userId string) (string, error) {
+	if paidBy == "" {
+		paidBy = expense.recordedBy
+	}
+	if paidBy == "" {
+		paidBy = userId
+	}
+	if _, err := groupRole(ctx, s.db, expense.groupId, paidBy); err != nil {
+		return "", status.Error(codes.InvalidArgument, "the payer must be a member of the group")
+	}
+	return paidBy, nil
+}
+
+func (s *groupsServer) SplitExpense(ctx context.Context, req *pb.SplitExpenseRequest) (*pb.SplitExpenseResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	expense, err := s.loadSplittableExpense(ctx, req.GetExpenseId(), userId)
+	if err != nil {
+		return nil, err
+	}
+	paidBy, err := s.payer(ctx, expense, req.GetPaidBy(), userId)
+	if err != nil {
+		return nil, err
+	}
+
+	method := req.GetMethod()
+	if method == "" {
+		method = splitEqual
+	}
+	var participants []string
+	var values []float64
+	if len(req.GetShares()) == 0 && method == splitEqual {
+		rows, err := s.db.QueryContext(ctx, `SELECT uuid FROM group_member_data WHERE group_id = $1 ORDER BY joined_at, uuid`, expense.groupId)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var member string
+			if err := rows.Scan(&member); err != nil {
+				return nil, err
+			}
+			participants = append(participants, member)
+			values = append(values, 1)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	seen := map[string]bool{}
+	for _, share := range req.GetShares() {
+		if seen[share.GetUserId()] {
+			return nil, status.Error(codes.InvalidArgument, "each participant can only appear once")
+		}
+		seen[share.GetUserId()] = true
+		if _, err := groupRole(ctx, s.db, expense.groupId, share.GetUserId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "every participant must be a member of the group")
+		}
+		participants = append(participants, share.GetUserId())
+		values = append(values, share.GetValue())
+	}
+
+	parts, err := splitAmounts(toCents(expense.amount), method, values)
+	if err != nil {
+		return nil, err
+	}
+	return s.storeSplit(ctx, expense, paidBy, method, participants, parts, nil)
+}
+
+// storeSplit replaces the split of an expense. When assignments is not nil
+// the item assignments are replaced in the same transaction.
+func (s *groupsServer) storeSplit(ctx context.Context, expense *splittableExpense, paidBy, method string,
+	participants []string, parts []int64, assignments map[string][]string) (*pb.SplitExpenseResponse, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := checkExpenseUnclaimed(ctx, tx, expense.id); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM expense_split_data WHERE expense_id = $1`, expense.id); err != nil {
+		return nil, err
+	}
+	query := `INSERT INTO expense_split_data (expense_id, uuid, amount) VALUES ($1, $2, $3)`
+	for i, participant := range participants {
+		if _, err := tx.ExecContext(ctx, query, expense.id, participant, fromCents(parts[i])); err != nil {
+			log.Printf("Failed to store expense split: %v", err)
+			return nil, err
+		}
+	}
+	if assignments != nil {
+		query = `DELETE FROM expense_item_assignment_data WHERE item_id IN (SELECT id FROM expense_item_data WHERE expense_id = $1)`
+		if _, err := tx.ExecContext(ctx, query, expense.id); err != nil {
+			return nil, err
+		}
+		query = `INSERT INTO expense_item_assignment_data (item_id, uuid) VALUES ($1, $2)`
+		for itemId, members := range assignments {
+			for _, member := range members {
+				if _, err := tx.ExecContext(ctx, query, itemId, member); err != nil {
+					log.Printf("Failed to store item assignment: %v", err)
+					return nil, err
+				}
+			}
+		}
+	}
+	query = `UPDATE expense_data SET paid_by = $1, split_method = $2 WHERE id = $3`
+	if _, err := tx.ExecContext(ctx, query, paidBy, method, expense.id); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	usernames, err := s.usernames(ctx, participants)
+	if err != nil {
+		return nil, err
+	}
+	splits := make([]*pb.ExpenseSplit, len(participants))
+	for i, participant := range participants {
+		splits[i] = &pb.ExpenseSplit{
+			UserId:   participant,
+			Username: usernames[participant],
+			Amount:   fromCents(parts[i]),
+		}
+	}
+
+	return &pb.SplitExpenseResponse{
+		PaidBy:   paidBy,
+		Currency: expense.currency,
+		Splits:   splits,
+	}, nil
+}
+
+func (s *groupsServer) GetBalances(ctx context.Context, req *pb.GetBalancesRequest) (*pb.GetBalancesResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := groupRole(ctx, s.db, req.GetGroupId(), userId); err != nil {
+		return nil, err
+	}
+
+	// Balances are kept per currency; amounts are never converted.
+	net := map[string]map[string]int64{}
+	add := func(currency, id string, cents int64) {
+		if net[currency] == nil {
+			net[currency] = map[string]int64{}
+		}
+		net[currency][id] += cents
+	}
+
+	query := `
+		SELECT e.currency, e.paid_by, s.uuid, s.amount
+		FROM expense_split_data s JOIN expense_data e ON e.id = s.expense_id
+		WHERE e.group_id = $1 AND e.paid_by IS NOT NULL`
+	rows, err := s.db.QueryContext(ctx, query, req.GetGroupId())
+	if err != nil {
+		log.Printf("Failed to load expense splits: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var currency, paidBy, participant string
+		var amount float64
+		if err := rows.Scan(&currency, &paidBy, &participant, &amount); err != nil {
+			return nil, err
+		}
+		add(currency, paidBy, toCents(amount))
+		add(currency, participant, -toCents(amount))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	query = `SELECT currency, from_uuid, to_uuid, amount FROM settlement_data WHERE group_id = $1`
+	rows, err = s.db.QueryContext(ctx, query, req.GetGroupId())
+	if err != nil {
+		log.Printf("Failed to load settlements: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var currency, from, to string
+		var amount float64
+		if err := rows.Scan(&currency, &from, &to, &amount); err != nil {
+			return nil, err
+		}
+		add(currency, from, toCents(amount))
+		add(currency, to, -toCents(amount))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, balances := range net {
+		for id := range balances {
+			ids = append(ids, id)
+		}
+	}
+	usernames, err := s.usernames(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies := make([]string, 0, len(net))
+	for currency := range net {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	res := &pb.GetBalancesResponse{}
+	for _, currency := range currencies {
+		members := make([]string, 0, len(net[currency]))
+		for id, cents := range net[currency] {
+			if cents != 0 {
+				members = append(members, id)
+			}
+		}
+		sort.Slice(members, func(i, j int) bool { return usernames[members[i]] < usernames[members[j]] })
+		for _, id := range members {
+			res.Balances = append(res.Balances, &pb.MemberBalance{
+				UserId:   id,
+				Username: usernames[id],
+				Currency: currency,
+				Net:      fromCents(net[currency][id]),
+			})
+		}
+		for _, d := range simplifyDebts(net[currency]) {
+			res.Transfers = append(res.Transfers, &pb.Transfer{
+				FromUserId:   d.from,
+				FromUsername: usernames[d.from],
+				ToUserId:     d.to,
+				ToUsername:   usernames[d.to],
+				Amount:       fromCents(d.cents),
+				Currency:     currency,
+			})
+		}
+	}
+	return res, nil
+}
+
+func (s *groupsServer) RecordSettlement(ctx context.Context, req *pb.RecordSettlementRequest) (*pb.RecordSettlementResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	role, err := groupRole(ctx, s.db, req.GetGroupId(), userId)
+	if err != nil {
+		return nil, err
+	}
+
+	from := req.GetFromUserId()
+	if from == "" {
+		from = userId
+	}
+	to := req.GetToUserId()
+	if from == to {
+		return nil, status.Error(codes.InvalidArgument, "a settlement needs two different members")
+	}
+	if from != userId && to != userId && role != groupRoleOwner && role != groupRoleAdmin {
+		return nil, status.Error(codes.PermissionDenied, "only the members involved or a group admin can record a settlement")
+	}
+	for _, member := range []string{from, to} {
+		if _, err := groupRole(ctx, s.db, req.GetGroupId(), member); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "both members must belong to the group")
+		}
+	}
+	cents := toCents(req.GetAmount())
+	if cents <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	currency := strings.ToUpper(strings.TrimSpace(req.GetCurrency()))
+	if len(currency) != 3 {
+		return nil, status.Error(codes.InvalidArgument, "currency must be a three-letter code")
+	}
+	var note any
+	if n := strings.TrimSpace(req.GetNote()); n != "" {
+		note = n
+	}
+
+	var settlementId string
+	query := `
+		INSERT INTO settlement_data (group_id, from_uuid, to_uuid, amount, currency, note, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	err = s.db.QueryRowContext(ctx, query, req.GetGroupId(), from, to, fromCents(cents), currency, note, userId).Scan(&settlementId)
+	if err != nil {
+		log.Printf("Failed to record settlement: %v", err)
+		return nil, err
+	}
+
+	return &pb.RecordSettlementResponse{
+		SettlementId: settlementId,
+		Message:      "Settlement recorded",
+	}, nil
+}
+
+func (s *groupsServer) usernames(ctx context.Context, ids []string) (map[string]string, error) {
+	usernames := map[string]string{}
+	rows, err := s.db.QueryContext(ctx, `SELECT uuid, username FROM user_data WHERE uuid = ANY($1::uuid[])`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, username string
+		if err := rows.Scan(&id, &username); err != nil {
+			return nil, err
+		}
+		usernames[id] = username
+	}
+	return usernames, rows.Err()
+}
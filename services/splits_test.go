@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSplitAmounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		total  int64
+		method string
+		values []float64
+		want   []int64
+	}{
+		{"equal thirds", 100, splitEqual, []float64{0, 0, 0}, []int64{34, 33, 33}},
+		{"equal thirds of 10.00", 1000, splitEqual, []float64{0, 0, 0}, []int64{334, 333, 333}},
+		{"one cent", 1, splitEqual, []float64{0, 0, 0}, []int64{1, 0, 0}},
+		{"equal refund", -100, splitEqual, []float64{0, 0, 0}, []int64{-34, -33, -33}},
+		{"equal sevenths", 1000, splitEqual, []float64{0, 0, 0, 0, 0, 0, 0}, []int64{143, 143, 143, 143, 143, 143, 142}},
+		{"percentage half cent", 1001, splitPercentage, []float64{50, 25, 25}, []int64{501, 250, 250}},
+		{"percentage thirds", 1000, splitPercentage, []float64{33.33, 33.33, 33.34}, []int64{333, 333, 334}},
+		{"shares largest remainder", 1000, splitShares, []float64{1, 2}, []int64{333, 667}},
+		{"shares with zero", 500, splitShares, []float64{0, 1, 1}, []int64{0, 250, 250}},
+		{"exact", 1000, splitExact, []float64{3.33, 6.67}, []int64{333, 667}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := splitAmounts(tt.total, tt.method, tt.values)
+			if err != nil {
+				t.Fatalf("splitAmounts: %v", err)
+			}
+			if fmt.Sprint(parts) != fmt.Sprint(tt.want) {
+				t.Errorf("parts = %v, want %v", parts, tt.want)
+			}
+			var sum int64
+			for _, part := range parts {
+				sum += part
+			}
+			if sum != tt.total {
+				t.Errorf("parts add up to %d, want %d", sum, tt.total)
+			}
+		})
+	}
+}
+
+func TestSplitAmountsInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		values []float64
+	}{
+		{"no participants", splitEqual, nil},
+		{"exact does not add up", splitExact, []float64{5, 4.99}},
+		{"percentages under 100", splitPercentage, []float64{50, 40}},
+		{"negative share", splitShares, []float64{2, -1}},
+		{"zero shares", splitShares, []float64{0, 0}},
+		{"unknown method", "weighted", []float64{1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := splitAmounts(1000, tt.method, tt.values); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("error = %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestSimplifyDebts(t *testing.T) {
+	tests := []struct {
+		name string
+		net  map[string]int64
+		want []debt
+	}{
+		{"settled", map[string]int64{"a": 0, "b": 0}, nil},
+		{"one debtor, two creditors", map[string]int64{"a": -3000, "b": 1000, "c": 2000},
+			[]debt{{"a", "c", 2000}, {"a", "b", 1000}}},
+		{"member already even", map[string]int64{"a": -1000, "b": 0, "c": 1000},
+			[]debt{{"a", "c", 1000}}},
+		{"equal amounts paired first", map[string]int64{"a": -500, "b": -1500, "c": 500, "d": 1500},
+			[]debt{{"b", "d", 1500}, {"a", "c", 500}}},
+		{"largest against largest", map[string]int64{"a": -700, "b": -300, "c": 400, "d": 600},
+			[]debt{{"a", "d", 600}, {"b", "c", 300}, {"a", "c", 100}}},
+		{"two debtors, three creditors", map[string]int64{"a": -601, "b": -299, "c": 300, "d": 300, "e": 300},
+			[]debt{{"a", "c", 300}, {"a", "d", 300}, {"b", "e", 299}, {"a", "e", 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debts := simplifyDebts(tt.net)
+			if fmt.Sprint(debts) != fmt.Sprint(tt.want) {
+				t.Errorf("debts = %v, want %v", debts, tt.want)
+			}
+
+			// The transfers settle every balance, with at most n-1 of them.
+			balance := map[string]int64{}
+			for _, d := range debts {
+				balance[d.from] -= d.cents
+				balance[d.to] += d.cents
+			}
+			for id, cents := range tt.net {
+				if balance[id] != cents {
+					t.Errorf("%s ends with %d, want %d", id, balance[id], cents)
+				}
+			}
+			if len(debts) > max(len(tt.net)-1, 0) {
+				t.Errorf("%d transfers for %d members", len(debts), len(tt.net))
+			}
+		})
+	}
+}
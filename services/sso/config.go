@@ -0,0 +1,66 @@
+// Package sso holds the OpenID Connect provider configuration shared by the
+// HTTP gateway, which runs the authorization-code flow, and the services,
+// which verify the resulting ID tokens.
+package sso
+
+import (
+	"os"
+	"strings"
+)
+
+const googleIssuer = "https://accounts.google.com"
+
+type ProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// LoadProviders reads the providers listed in OIDC_PROVIDERS, e.g.
+// "google,corp". Each provider is configured through OIDC_<NAME>_ISSUER,
+// OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET and optionally
+// OIDC_<NAME>_REDIRECT_URL and OIDC_<NAME>_SCOPES. The issuer defaults to
+// Google's for the provider named "google"; any other issuer is resolved
+// through OIDC discovery. Providers without an issuer or client id are
+// skipped.
+func LoadProviders() map[string]ProviderConfig {
+	providers := map[string]ProviderConfig{}
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+
+		cfg := ProviderConfig{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       []string{"openid", "email", "profile"},
+		}
+		if cfg.Issuer == "" && name == "google" {
+			cfg.Issuer = googleIssuer
+		}
+		if cfg.RedirectURL == "" {
+			cfg.RedirectURL = strings.TrimRight(baseURL, "/") + "/auth/oidc/" + name + "/callback"
+		}
+		if scopes := os.Getenv(prefix + "SCOPES"); scopes != "" {
+			cfg.Scopes = strings.Fields(strings.ReplaceAll(scopes, ",", " "))
+		}
+		if cfg.Issuer == "" || cfg.ClientID == "" {
+			continue
+		}
+		providers[name] = cfg
+	}
+	return providers
+}
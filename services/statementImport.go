@@ -0,0 +1,468 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+	"github.com/barathsurya2004/expenses/services/models"
+)
+
+const maxStatementSize = 10 << 20
+
+// Statuses of a statement row.
+const (
+	rowNew               = "new"
+	rowCreated           = "created"
+	rowDuplicate         = "duplicate"
+	rowPossibleDuplicate = "possible_duplicate"
+	rowSkipped           = "skipped"
+	rowError             = "error"
+)
+
+type importServer struct {
+	pb.UnimplementedImportServiceServer
+	db *sql.DB
+}
+
+var importProfileOrders = map[string]pageOrder{
+	"name asc":        {column: "lower(name)", cast: "text", id: "id", idCast: "uuid"},
+	"created_at asc":  {column: "created_at", cast: "timestamptz", id: "id", idCast: "uuid"},
+	"created_at desc": {column: "created_at", cast: "timestamptz", desc: true, id: "id", idCast: "uuid", idDesc: true},
+}
+
+const importProfileColumns = `id, name, delimiter, skip_rows, no_header, date_column, coalesce(date_format, ''), description_column,
+	coalesce(amount_column, ''), coalesce(debit_column, ''), coalesce(credit_column, ''), coalesce(currency_column, ''),
+	coalesce(reference_column, ''), debits_positive, decimal_comma, created_at`
+
+func scanImportProfile(row interface{ Scan(...any) error }) (*pb.ImportProfile, error) {
+	profile := pb.ImportProfile{Mapping: &pb.CsvMapping{}}
+	mapping := profile.Mapping
+	var createdAt time.Time
+	err := row.Scan(&profile.Id, &profile.Name, &mapping.Delimiter, &mapping.SkipRows, &mapping.NoHeader, &mapping.DateColumn,
+		&mapping.DateFormat, &mapping.DescriptionColumn, &mapping.AmountColumn, &mapping.DebitColumn, &mapping.CreditColumn,
+		&mapping.CurrencyColumn, &mapping.ReferenceColumn, &mapping.DebitsPositive, &mapping.DecimalComma, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	profile.CreatedAt = createdAt.Format(time.RFC3339)
+	return &profile, nil
+}
+
+func (s *importServer) SaveImportProfile(ctx context.Context, req *pb.SaveImportProfileRequest) (*pb.SaveImportProfileResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(req.GetName())
+	if name == "" || len(name) > 100 {
+		return nil, status.Error(codes.InvalidArgument, "name must be between 1 and 100 characters")
+	}
+	mapping := req.GetMapping()
+	if err := validateMapping(mapping); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	query := `
+		INSERT INTO import_profile_data (uuid, name, delimiter, skip_rows, no_header, date_column, date_format, description_column,
+			amount_column, debit_column, credit_column, currency_column, reference_column, debits_positive, decimal_comma)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (uuid, name) DO UPDATE SET delimiter = EXCLUDED.delimiter, skip_rows = EXCLUDED.skip_rows,
+			no_header = EXCLUDED.no_header, date_column = EXCLUDED.date_column, date_format = EXCLUDED.date_format,
+			description_column = EXCLUDED.description_column, amount_column = EXCLUDED.amount_column,
+			debit_column = EXCLUDED.debit_column, credit_column = EXCLUDED.credit_column,
+			currency_column = EXCLUDED.currency_column, reference_column = EXCLUDED.reference_column,
+			debits_positive = EXCLUDED.debits_positive, decimal_comma = EXCLUDED.decimal_comma
+		RETURNING ` + importProfileColumns
+	profile, err := scanImportProfile(s.db.QueryRowContext(ctx, query, userId, name, mapping.GetDelimiter(), mapping.GetSkipRows(),
+		mapping.GetNoHeader(), strings.TrimSpace(mapping.GetDateColumn()), nullIfEmpty(mapping.GetDateFormat()),
+		strings.TrimSpace(mapping.GetDescriptionColumn()), nullIfEmpty(mapping.GetAmountColumn()), nullIfEmpty(mapping.GetDebitColumn()),
+		nullIfEmpty(mapping.GetCreditColumn()), nullIfEmpty(mapping.GetCurrencyColumn()), nullIfEmpty(mapping.GetReferenceColumn()),
+		mapping.GetDebitsPositive(), mapping.GetDecimalComma()))
+	if err != nil {
+		log.Printf("Failed to save import profile: %v", err)
+		return nil, err
+	}
+	return &pb.SaveImportProfileResponse{Profile: profile}, nil
+}
+
+func (s *importServer) ListImportProfiles(ctx context.Context, req *pb.ListImportProfilesRequest) (*pb.ListImportProfilesResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	page, err := newPage(req, importProfileOrders, "name asc", "", defaultPageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	var total int32
+	if req.GetIncludeTotalCount() {
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM import_profile_data WHERE uuid = $1`, userId).Scan(&total); err != nil {
+			log.Printf("Failed to count import profiles: %v", err)
+			return nil, err
+		}
+	}
+
+	after, args := page.condition([]any{userId})
+	query := `SELECT ` + importProfileColumns + `, ` + page.keyColumns() + ` FROM import_profile_data WHERE uuid = $1 AND ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Failed to list import profiles: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []*pb.ImportProfile
+	for rows.Next() {
+		profile, err := scanImportProfile(withKey{rows, page})
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	next, n := page.nextToken()
+	return &pb.ListImportProfilesResponse{Profiles: profiles[:n], NextPageToken: next, TotalCount: total}, nil
+}
+
+func (s *importServer) DeleteImportProfile(ctx context.Context, req *pb.DeleteImportProfileRequest) (*pb.DeleteImportProfileResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid profile id")
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM import_profile_data WHERE id = $1 AND uuid = $2`, req.GetId(), userId)
+	if err != nil {
+		log.Printf("Failed to delete import profile: %v", err)
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, status.Error(codes.NotFound, "profile not found")
+	}
+	return &pb.DeleteImportProfileResponse{}, nil
+}
+
+// importKeys identifies each line for de-duplication: by account and FITID
+// when the bank gave one, otherwise by a hash of the line. Identical lines
+// in one statement, such as two coffees on the same day, are told apart by
+// their position among each other, so importing the statement again still
+// matches them.
+func importKeys(lines []statementLine) []string {
+	keys := make([]string, len(lines))
+	occurrences := map[string]int{}
+	for i, line := range lines {
+		if line.fitid != "" {
+			keys[i] = "fitid:" + line.account + ":" + line.fitid
+			if len(keys[i]) <= 255 {
+				continue
+			}
+			keys[i] = "fitid:" + messageKey(line.account, line.fitid)
+			continue
+		}
+		hash := messageKey(line.date.Format("2006-01-02"), fmt.Sprintf("%.2f", line.amount), line.currency,
+			strings.ToLower(strings.Join(strings.Fields(line.description), " ")), line.number)
+		occurrences[hash]++
+		keys[i] = fmt.Sprintf("hash:%s:%d", hash, occurrences[hash])
+	}
+	return keys
+}
+
+// statementOptions are the options sent with the first chunk of a statement.
+type statementOptions struct {
+	format                    string
+	filename                  string
+	profileId                 string
+	mapping                   *pb.CsvMapping
+	currency                  string
+	groupId                   string
+	dryRun                    bool
+	dateOrder                 string
+	includePossibleDuplicates bool
+	skipCredits               bool
+}
+
+// ImportStatement is a client-streaming RPC that receives a bank statement,
+// reads it and imports its transactions in one database transaction. Rows
+// that cannot be read are reported and left out.
+func (s *importServer) ImportStatement(stream pb.ImportService_ImportStatementServer) error {
+	ctx := stream.Context()
+	userId, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	var options statementOptions
+	first := true
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Printf("Error receiving statement chunk: %v", err)
+			return err
+		}
+		if first {
+			options = statementOptions{
+				format:                    strings.ToLower(strings.TrimSpace(chunk.GetFormat())),
+				filename:                  chunk.GetFilename(),
+				profileId:                 chunk.GetProfileId(),
+				mapping:                   chunk.GetMapping(),
+				currency:                  strings.ToUpper(strings.TrimSpace(chunk.GetCurrency())),
+				groupId:                   chunk.GetGroupId(),
+				dryRun:                    chunk.GetDryRun(),
+				dateOrder:                 chunk.GetDateOrder(),
+				includePossibleDuplicates: chunk.GetIncludePossibleDuplicates(),
+				skipCredits:               chunk.GetSkipCredits(),
+			}
+			first = false
+		}
+		if len(content)+len(chunk.GetChunks()) > maxStatementSize {
+			return status.Error(codes.InvalidArgument, "statements must be at most 10 MB")
+		}
+		content = append(content, chunk.GetChunks()...)
+	}
+	if len(content) == 0 {
+		return status.Error(codes.InvalidArgument, "the statement is empty")
+	}
+
+	res, err := s.importStatement(ctx, userId, content, options)
+	if err != nil {
+		return err
+	}
+	log.Printf("Imported %s statement for user %s: %d rows, %d imported, %d duplicates, %d errors (dry run %v)",
+		res.Format, userId, len(res.Rows), res.Imported, res.Duplicates, res.Errors, res.DryRun)
+	return stream.SendAndClose(res)
+}
+
+func (s *importServer) importStatement(ctx context.Context, userId string, content []byte, options statementOptions) (*pb.ImportStatementResponse, error) {
+	format := options.format
+	if format == "" {
+		format = detectStatementFormat(options.filename, content)
+	}
+	if options.groupId != "" {
+		if _, err := groupRole(ctx, s.db, options.groupId, userId); err != nil {
+			return nil, err
+		}
+	}
+	currency := options.currency
+	if currency == "" {
+		if err := s.db.QueryRowContext(ctx, `SELECT default_currency FROM user_data WHERE uuid = $1`, userId).Scan(&currency); err != nil {
+			return nil, err
+		}
+	}
+	if len(currency) != 3 {
+		return nil, status.Error(codes.InvalidArgument, "currency must be a three-letter code")
+	}
+
+	var lines []statementLine
+	var err error
+	switch format {
+	case formatOfx, formatQfx:
+		lines, err = parseOfx(content)
+	case formatQif:
+		if options.dateOrder != "" && options.dateOrder != "mdy" && options.dateOrder != "dmy" {
+			return nil, status.Error(codes.InvalidArgument, `date_order must be "mdy" or "dmy"`)
+		}
+		lines, err = parseQif(content, options.dateOrder)
+	case formatCsv:
+		mapping := options.mapping
+		if options.profileId != "" {
+			if _, err := uuid.Parse(options.profileId); err != nil {
+				return nil, status.Error(codes.InvalidArgument, "invalid profile id")
+			}
+			profile, err := scanImportProfile(s.db.QueryRowContext(ctx,
+				`SELECT `+importProfileColumns+` FROM import_profile_data WHERE id = $1 AND uuid = $2`, options.profileId, userId))
+			if err == sql.ErrNoRows {
+				return nil, status.Error(codes.NotFound, "profile not found")
+			}
+			if err != nil {
+				return nil, err
+			}
+			mapping = profile.Mapping
+		}
+		if err := validateMapping(mapping); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		lines, err = parseCsv(content, mapping)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "format must be csv, ofx, qfx or qif")
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if len(lines) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the statement has no transactions")
+	}
+	if len(lines) > maxStatementLines {
+		return nil, status.Errorf(codes.InvalidArgument, "statements must have at most %d transactions", maxStatementLines)
+	}
+
+	rules, err := loadRules(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Error loading categorization rules: %v", err)
+		return nil, err
+	}
+	model, err := loadCategoryModel(ctx, s.db, nil, userId)
+	if err != nil {
+		log.Printf("Error loading category model: %v", err)
+		return nil, err
+	}
+
+	// Everything, dry runs included, runs in one transaction; a dry run is
+	// rolled back so its preview shows exactly what would be stored.
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	keys := importKeys(lines)
+	seen := map[string]bool{}
+	existing, err := tx.QueryContext(ctx, `SELECT import_key FROM expense_data WHERE uuid = $1 AND import_key = ANY($2)`, userId, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+	for existing.Next() {
+		var key string
+		if err := existing.Scan(&key); err != nil {
+			existing.Close()
+			return nil, err
+		}
+		seen[key] = true
+	}
+	existing.Close()
+	if err := existing.Err(); err != nil {
+		return nil, err
+	}
+
+	res := &pb.ImportStatementResponse{Format: format, DryRun: options.dryRun}
+	for i, line := range lines {
+		row := &pb.StatementRow{
+			Line:        int32(line.line),
+			Description: line.description,
+			Amount:      -line.amount,
+			Currency:    line.currency,
+		}
+		if row.Currency == "" {
+			row.Currency = currency
+		}
+		if !line.date.IsZero() {
+			row.Date = line.date.Format(time.RFC3339)
+		}
+		res.Rows = append(res.Rows, row)
+
+		description := strings.Join(strings.Fields(line.description), " ")
+		if runes := []rune(description); len(runes) > 100 {
+			description = string(runes[:100])
+		}
+		switch {
+		case line.err != "":
+			row.Status, row.Reason = rowError, line.err
+		case description == "":
+			row.Status, row.Reason = rowError, "the transaction has no description"
+		case len(row.Currency) != 3:
+			row.Status, row.Reason = rowError, "the currency is not a three-letter code"
+		case line.amount == 0 || math.Abs(line.amount) >= 1e8:
+			row.Status, row.Reason = rowSkipped, "the amount is zero or too large"
+		case seen[keys[i]]:
+			row.Status, row.Reason = rowDuplicate, "already imported"
+		case line.amount > 0 && options.skipCredits:
+			row.Status, row.Reason = rowSkipped, "money received"
+		}
+		seen[keys[i]] = true
+		if row.Status != "" {
+			continue
+		}
+
+		if !options.includePossibleDuplicates {
+			var possible bool
+			query := `
+				SELECT EXISTS (SELECT 1 FROM expense_data WHERE uuid = $1 AND round(amount::numeric, 2) = round($2::numeric, 2) AND currency = $3
+					AND date_and_time >= $4::timestamptz - interval '1 day' AND date_and_time < $4::timestamptz + interval '2 days'
+					AND coalesce(source, '') <> $5)`
+			if err := tx.QueryRowContext(ctx, query, userId, row.Amount, row.Currency, line.date.UTC().Truncate(24*time.Hour), expenseSourceImport).Scan(&possible); err != nil {
+				return nil, err
+			}
+			if possible {
+				row.Status, row.Reason = rowPossibleDuplicate, "an expense with this amount was already recorded around this date"
+				continue
+			}
+		}
+
+		var expense models.Transaction
+		category, tags := categorize(rules, description, row.Amount, "")
+		expense.CategorySource = categorySourceRule
+		if category == "" {
+			if prediction, ok := model.classify(description, row.Amount, "", line.date); ok {
+				category = prediction.category
+				expense.CategorySource = categorySourceClassifier
+				expense.CategoryConfidence = prediction.confidence
+			}
+		}
+		if category == "" {
+			category = uncategorized
+			expense.CategorySource = ""
+		}
+		expense.UUID = userId
+		expense.GroupID = options.groupId
+		expense.Source = expenseSourceImport
+		expense.ImportKey = keys[i]
+		expense.MerchantDetails.Name = description
+		expense.TransactionDetails.DateTime = line.date
+		expense.TransactionDetails.TotalAmount = row.Amount
+		expense.TransactionDetails.Currency = row.Currency
+		expense.SpendingCategory = category
+		expense.Tags = normalizeTags(tags)
+
+		expenseId, err := writeExpense(ctx, tx, expense)
+		if err != nil {
+			log.Printf("Error writing imported expense: %v", err)
+			return nil, err
+		}
+		if err := tx.QueryRowContext(ctx, `SELECT category FROM expense_data WHERE id = $1`, expenseId).Scan(&row.Category); err != nil {
+			return nil, err
+		}
+		row.Status = rowNew
+		if !options.dryRun {
+			row.Status = rowCreated
+			row.ExpenseId = expenseId
+		}
+	}
+
+	for _, row := range res.Rows {
+		switch row.Status {
+		case rowNew, rowCreated:
+			res.Imported++
+		case rowDuplicate, rowPossibleDuplicate:
+			res.Duplicates++
+		case rowSkipped:
+			res.Skipped++
+		case rowError:
+			res.Errors++
+		}
+	}
+	if options.dryRun {
+		return res, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
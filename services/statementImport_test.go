@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportKeys(t *testing.T) {
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	coffee := statementLine{date: day, description: "BLUE TOKAI", amount: -180, currency: "INR"}
+	lines := []statementLine{
+		coffee,
+		{date: day, description: "  blue   tokai ", amount: -180, currency: "INR"},
+		{date: day.Add(9 * time.Hour), description: "Blue Tokai", amount: -180, currency: "INR"},
+		{date: day, description: "BLUE TOKAI", amount: -180, currency: "INR", number: "42"},
+		{date: day, description: "BLUE TOKAI", amount: -180.004, currency: "INR"},
+		{date: day, description: "BLUE TOKAI", amount: -180, currency: "USD"},
+		{date: day.AddDate(0, 0, 1), description: "BLUE TOKAI", amount: -180, currency: "INR"},
+		{account: "001234", fitid: "T1", date: day, description: "SWIGGY", amount: -250},
+		{account: "009999", fitid: "T1", date: day, description: "SWIGGY", amount: -250},
+		{account: "001234", fitid: strings.Repeat("x", 300)},
+	}
+	keys := importKeys(lines)
+
+	// Identical lines, after folding case and spaces and rounding to the
+	// cent, are numbered in the order they appear.
+	hash := strings.TrimSuffix(keys[0], ":1")
+	if !strings.HasPrefix(keys[0], "hash:") || !strings.HasSuffix(keys[0], ":1") {
+		t.Fatalf("first key = %q, want hash:...:1", keys[0])
+	}
+	for i, want := range map[int]string{1: hash + ":2", 2: hash + ":3", 4: hash + ":4"} {
+		if keys[i] != want {
+			t.Errorf("key %d = %q, want %q", i, keys[i], want)
+		}
+	}
+	// A check number, another currency or another day is another line.
+	for _, i := range []int{3, 5, 6} {
+		if strings.HasPrefix(keys[i], hash+":") || !strings.HasSuffix(keys[i], ":1") {
+			t.Errorf("key %d = %q, want a first occurrence of another hash", i, keys[i])
+		}
+	}
+	// FITIDs are unique per account and kept when they fit.
+	if keys[7] != "fitid:001234:T1" || keys[8] != "fitid:009999:T1" {
+		t.Errorf("FITID keys = %q, %q", keys[7], keys[8])
+	}
+	if len(keys[9]) > 255 || !strings.HasPrefix(keys[9], "fitid:") {
+		t.Errorf("long FITID key = %q", keys[9])
+	}
+
+	// Importing an overlapping statement again finds the same keys.
+	again := importKeys([]statementLine{lines[6], coffee, coffee, lines[7]})
+	if again[1] != keys[0] || again[2] != keys[1] || again[0] != keys[6] || again[3] != keys[7] {
+		t.Errorf("keys of the second import = %q, want %q, %q, %q, %q", again, keys[6], keys[0], keys[1], keys[7])
+	}
+}
@@ -0,0 +1,458 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// Statement formats.
+const (
+	formatCsv = "csv"
+	formatOfx = "ofx"
+	formatQfx = "qfx"
+	formatQif = "qif"
+)
+
+const maxStatementLines = 10000
+
+// statementLine is one transaction read from a statement. amount follows the
+// bank's sign, negative for money spent. fitid is an id the bank guarantees
+// to be unique, while number, such as a check number, is not. err is set
+// when the line could not be read; the other fields are then best effort.
+type statementLine struct {
+	line        int
+	date        time.Time
+	description string
+	amount      float64
+	currency    string
+	account     string
+	fitid       string
+	number      string
+	err         string
+}
+
+var (
+	ofxTransactionPattern = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+	ofxAccountPattern     = regexp.MustCompile(`(?is)<(?:BANKACCTFROM|CCACCTFROM)>.*?<ACCTID>\s*([^<\r\n]+)`)
+	ofxCurrencyPattern    = regexp.MustCompile(`(?is)<CURDEF>\s*([A-Za-z]{3})`)
+	ofxTimezonePattern    = regexp.MustCompile(`\[([+-]?\d+(?:\.\d+)?)`)
+	amountNoisePattern    = regexp.MustCompile(`[^\d.,()+-]`)
+)
+
+// detectStatementFormat guesses the format from the file name, then from
+// the content.
+func detectStatementFormat(filename string, content []byte) string {
+	switch strings.ToLower(strings.TrimPrefix(path.Ext(filename), ".")) {
+	case formatCsv, "txt":
+		return formatCsv
+	case formatOfx:
+		return formatOfx
+	case formatQfx:
+		return formatQfx
+	case formatQif:
+		return formatQif
+	}
+	head := strings.ToUpper(string(content[:min(len(content), 1024)]))
+	switch {
+	case strings.Contains(head, "OFXHEADER") || strings.Contains(head, "<OFX>"):
+		return formatOfx
+	case strings.HasPrefix(strings.TrimSpace(head), "!TYPE") || strings.HasPrefix(strings.TrimSpace(head), "!ACCOUNT"):
+		return formatQif
+	}
+	return formatCsv
+}
+
+// lineAt returns the 1-based line of content that offset falls on.
+func lineAt(content []byte, offset int) int {
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
+
+// ofxField reads a field of an OFX aggregate. SGML OFX leaves elements
+// unclosed, so the value runs to the next tag or line break.
+func ofxField(block, name string) string {
+	start := strings.Index(strings.ToUpper(block), "<"+name+">")
+	if start < 0 {
+		return ""
+	}
+	value := block[start+len(name)+2:]
+	if end := strings.IndexAny(value, "<\r\n"); end >= 0 {
+		value = value[:end]
+	}
+	return strings.TrimSpace(value)
+}
+
+// parseOfxDate reads an OFX date, YYYYMMDD[HHMMSS[.XXX]][[offset:TZ]].
+func parseOfxDate(value string) (time.Time, error) {
+	digits := value
+	if i := strings.IndexAny(digits, ".[ "); i >= 0 {
+		digits = digits[:i]
+	}
+	location := time.UTC
+	if match := ofxTimezonePattern.FindStringSubmatch(value); match != nil {
+		if hours, err := strconv.ParseFloat(match[1], 64); err == nil {
+			location = time.FixedZone("", int(hours*3600))
+		}
+	}
+	switch len(digits) {
+	case 8:
+		return time.ParseInLocation("20060102", digits, location)
+	case 12:
+		return time.ParseInLocation("200601021504", digits, location)
+	case 14:
+		return time.ParseInLocation("20060102150405", digits, location)
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// parseOfx reads the transactions of an OFX or QFX statement, SGML or XML.
+func parseOfx(content []byte) ([]statementLine, error) {
+	text := string(content)
+	if !strings.Contains(strings.ToUpper(text), "<OFX>") {
+		return nil, fmt.Errorf("the file is not an OFX statement")
+	}
+	account := ""
+	if match := ofxAccountPattern.FindStringSubmatch(text); match != nil {
+		account = strings.TrimSpace(match[1])
+	}
+	currency := ""
+	if match := ofxCurrencyPattern.FindStringSubmatch(text); match != nil {
+		currency = strings.ToUpper(match[1])
+	}
+
+	var lines []statementLine
+	for _, match := range ofxTransactionPattern.FindAllStringSubmatchIndex(text, -1) {
+		block := text[match[2]:match[3]]
+		line := statementLine{line: lineAt(content, match[0]), account: account, currency: currency}
+		line.fitid = ofxField(block, "FITID")
+		line.description = ofxField(block, "NAME")
+		if line.description == "" {
+			line.description = ofxField(block, "PAYEE")
+		}
+		if line.description == "" {
+			line.description = ofxField(block, "MEMO")
+		}
+		line.number = ofxField(block, "CHECKNUM")
+		if symbol := ofxField(block, "CURSYM"); len(symbol) == 3 {
+			line.currency = strings.ToUpper(symbol)
+		}
+
+		var err error
+		if line.date, err = parseOfxDate(ofxField(block, "DTPOSTED")); err != nil {
+			line.err = "DTPOSTED is not a valid date"
+		} else if line.amount, err = strconv.ParseFloat(ofxField(block, "TRNAMT"), 64); err != nil {
+			line.err = "TRNAMT is not a valid amount"
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// parseQifDate reads the date formats Quicken writes, such as "1/31/2024",
+// "01/31'24" and "31.01.2024", in the given order of day and month.
+func parseQifDate(value, order string) (time.Time, error) {
+	value = strings.NewReplacer("'", "/", " ", "", ".", "/", "-", "/").Replace(strings.TrimSpace(value))
+	parts := strings.Split(value, "/")
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", value)
+		}
+		numbers[i] = n
+	}
+	month, day, year := numbers[0], numbers[1], numbers[2]
+	if order == "dmy" {
+		month, day = day, month
+	}
+	if len(parts[0]) == 4 {
+		year, month, day = numbers[0], numbers[1], numbers[2]
+	}
+	if year < 100 {
+		// Quicken writes years from 2000 on as 00 to 99 after an apostrophe.
+		year += 2000
+		if year > time.Now().Year()+1 {
+			year -= 100
+		}
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+// parseQif reads the transactions of a QIF file. Records end with "^";
+// records outside a bank, cash or card section, such as account and
+// category lists, are skipped.
+func parseQif(content []byte, order string) ([]statementLine, error) {
+	var lines []statementLine
+	current := statementLine{}
+	var date, amount string
+	started := false
+	transactions := true
+	for i, raw := range strings.Split(string(content), "\n") {
+		text := strings.TrimRight(raw, "\r")
+		if text == "" {
+			continue
+		}
+		if !started {
+			current = statementLine{line: i + 1}
+			date, amount = "", ""
+		}
+		switch text[0] {
+		case '!':
+			header := strings.ToLower(strings.TrimSpace(text))
+			if strings.HasPrefix(header, "!type:") {
+				section := strings.TrimPrefix(header, "!type:")
+				transactions = section != "cat" && section != "class" && section != "memorized" && section != "invitem" && section != "security" && section != "prices"
+			} else if header == "!account" {
+				transactions = false
+			}
+			continue
+		case '^':
+			if !started || !transactions {
+				started = false
+				continue
+			}
+			var err error
+			if current.date, err = parseQifDate(date, order); err != nil {
+				current.err = "D is not a valid date"
+			} else if current.amount, err = parseAmount(amount, false); err != nil {
+				current.err = "T is not a valid amount"
+			}
+			lines = append(lines, current)
+			started = false
+			continue
+		case 'D':
+			date = text[1:]
+		case 'T', 'U':
+			if amount == "" || text[0] == 'T' {
+				amount = text[1:]
+			}
+		case 'P':
+			current.description = strings.TrimSpace(text[1:])
+		case 'M':
+			if current.description == "" {
+				current.description = strings.TrimSpace(text[1:])
+			}
+		case 'N':
+			current.number = strings.TrimSpace(text[1:])
+		}
+		started = true
+	}
+	if started {
+		return nil, fmt.Errorf("the last QIF record is not terminated with ^")
+	}
+	return lines, nil
+}
+
+// parseAmount reads an amount such as "-1,234.50", "(12.00)", "₹ 99" or
+// "12,50" with decimalComma.
+func parseAmount(value string, decimalComma bool) (float64, error) {
+	value = strings.TrimSpace(value)
+	negative := false
+	upper := strings.ToUpper(value)
+	if strings.HasSuffix(upper, "DR") {
+		negative = true
+		value = value[:len(value)-2]
+	} else if strings.HasSuffix(upper, "CR") {
+		value = value[:len(value)-2]
+	}
+	value = amountNoisePattern.ReplaceAllString(value, "")
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = strings.Trim(value, "()")
+	}
+	if decimalComma {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		amount = -math.Abs(amount)
+	}
+	return amount, nil
+}
+
+// dateLayout turns a date_format such as "DD/MM/YYYY" into a Go layout.
+var dateLayout = strings.NewReplacer(
+	"YYYY", "2006", "YY", "06",
+	"MMM", "Jan", "MM", "01", "M", "1",
+	"DD", "02", "D", "2",
+	"HH", "15", "mm", "04", "ss", "05",
+)
+
+// isoDateLayouts are tried when a mapping has no date_format.
+var isoDateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02", "2006/01/02", "02 Jan 2006", "02-Jan-2006", "Jan 2, 2006"}
+
+func parseCsvDate(value, format string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if format != "" {
+		return time.Parse(dateLayout.Replace(format), value)
+	}
+	for _, layout := range isoDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// validateMapping checks a mapping and fills in its defaults.
+func validateMapping(mapping *pb.CsvMapping) error {
+	if mapping == nil {
+		return fmt.Errorf("a CSV statement needs a profile or a mapping")
+	}
+	switch strings.ToLower(mapping.Delimiter) {
+	case "":
+		mapping.Delimiter = ","
+	case "tab", `\t`:
+		mapping.Delimiter = "\t"
+	}
+	if len([]rune(mapping.Delimiter)) != 1 || mapping.Delimiter == `"` || mapping.Delimiter == "\n" {
+		return fmt.Errorf("delimiter must be a single character")
+	}
+	if mapping.SkipRows < 0 || mapping.SkipRows > 100 {
+		return fmt.Errorf("skip_rows must be between 0 and 100")
+	}
+	if strings.TrimSpace(mapping.DateColumn) == "" || strings.TrimSpace(mapping.DescriptionColumn) == "" {
+		return fmt.Errorf("date_column and description_column are required")
+	}
+	if mapping.AmountColumn == "" && (mapping.DebitColumn == "" || mapping.CreditColumn == "") {
+		return fmt.Errorf("amount_column, or debit_column and credit_column, are required")
+	}
+	if mapping.DateFormat != "" && (len(mapping.DateFormat) > 50 || !strings.Contains(mapping.DateFormat, "YY") ||
+		!strings.Contains(mapping.DateFormat, "M") || !strings.Contains(mapping.DateFormat, "D")) {
+		return fmt.Errorf("date_format must have a year, a month and a day, e.g. DD/MM/YYYY")
+	}
+	for _, column := range []string{mapping.DateColumn, mapping.DescriptionColumn, mapping.AmountColumn, mapping.DebitColumn, mapping.CreditColumn, mapping.CurrencyColumn, mapping.ReferenceColumn} {
+		if len(column) > 100 {
+			return fmt.Errorf("column names must be at most 100 characters")
+		}
+		if mapping.NoHeader && column != "" {
+			if n, err := strconv.Atoi(column); err != nil || n < 1 {
+				return fmt.Errorf("columns must be 1-based positions when no_header is set")
+			}
+		}
+	}
+	return nil
+}
+
+// parseCsv reads a CSV statement with a validated mapping.
+func parseCsv(content []byte, mapping *pb.CsvMapping) ([]statementLine, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = []rune(mapping.Delimiter)[0]
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = false
+
+	for i := 0; i < int(mapping.SkipRows); i++ {
+		if _, err := reader.Read(); err != nil {
+			return nil, fmt.Errorf("the file has fewer than %d rows", mapping.SkipRows)
+		}
+	}
+
+	columns := map[string]int{}
+	if mapping.NoHeader {
+		for _, column := range []string{mapping.DateColumn, mapping.DescriptionColumn, mapping.AmountColumn, mapping.DebitColumn, mapping.CreditColumn, mapping.CurrencyColumn, mapping.ReferenceColumn} {
+			if n, err := strconv.Atoi(column); err == nil {
+				columns[column] = n - 1
+			}
+		}
+	} else {
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("the file has no header row")
+		}
+		for i, name := range header {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		for _, column := range []string{mapping.DateColumn, mapping.DescriptionColumn, mapping.AmountColumn, mapping.DebitColumn, mapping.CreditColumn, mapping.CurrencyColumn, mapping.ReferenceColumn} {
+			if column == "" {
+				continue
+			}
+			index, ok := columns[strings.ToLower(strings.TrimSpace(column))]
+			if !ok {
+				return nil, fmt.Errorf("the header has no %q column", column)
+			}
+			columns[column] = index
+		}
+	}
+	field := func(record []string, column string) string {
+		if column == "" {
+			return ""
+		}
+		if index, ok := columns[column]; ok && index < len(record) {
+			return strings.TrimSpace(record[index])
+		}
+		return ""
+	}
+
+	var lines []statementLine
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			line := statementLine{err: "the row is not valid CSV"}
+			if parseErr, ok := err.(*csv.ParseError); ok {
+				line.line = parseErr.StartLine
+			}
+			lines = append(lines, line)
+			continue
+		}
+		line := statementLine{}
+		line.line, _ = reader.FieldPos(0)
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		line.description = field(record, mapping.DescriptionColumn)
+		line.currency = strings.ToUpper(field(record, mapping.CurrencyColumn))
+		line.fitid = field(record, mapping.ReferenceColumn)
+		if line.date, err = parseCsvDate(field(record, mapping.DateColumn), mapping.DateFormat); err != nil {
+			line.err = "the date does not match the date format"
+		} else if mapping.AmountColumn != "" {
+			if line.amount, err = parseAmount(field(record, mapping.AmountColumn), mapping.DecimalComma); err != nil {
+				line.err = "the amount is not a number"
+			} else if mapping.DebitsPositive {
+				line.amount = -line.amount
+			}
+		} else {
+			debit, credit := field(record, mapping.DebitColumn), field(record, mapping.CreditColumn)
+			var debitAmount, creditAmount float64
+			if debit != "" {
+				debitAmount, err = parseAmount(debit, mapping.DecimalComma)
+			}
+			if err == nil && credit != "" {
+				creditAmount, err = parseAmount(credit, mapping.DecimalComma)
+			}
+			if err != nil {
+				line.err = "the debit or credit is not a number"
+			}
+			line.amount = math.Abs(creditAmount) - math.Abs(debitAmount)
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
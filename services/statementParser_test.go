@@ -0,0 +1,379 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+// readLine is the part of a statementLine the parsers are expected to fill
+// in, with the date as text so that lines compare with ==.
+type readLine struct {
+	line        int
+	date        string
+	description string
+	amount      float64
+	currency    string
+	fitid       string
+	err         string
+}
+
+func readLines(lines []statementLine) []readLine {
+	read := make([]readLine, len(lines))
+	for i, l := range lines {
+		read[i] = readLine{l.line, "", l.description, l.amount, l.currency, l.fitid, l.err}
+		if !l.date.IsZero() {
+			read[i].date = l.date.Format(time.RFC3339)
+		}
+	}
+	return read
+}
+
+func TestParseCsv(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *pb.CsvMapping
+		content string
+		want    []readLine
+	}{
+		{
+			"day first with signed amounts",
+			&pb.CsvMapping{DateColumn: "Date", DateFormat: "DD/MM/YYYY", DescriptionColumn: "Narration", AmountColumn: "Amount", ReferenceColumn: "Ref"},
+			"date,narration,amount,ref\n03/04/2025,SWIGGY,-250.00,R1\n04/04/2025,SALARY,\"50,000.00\",R2\n",
+			[]readLine{
+				{2, "2025-04-03T00:00:00Z", "SWIGGY", -250, "", "R1", ""},
+				{3, "2025-04-04T00:00:00Z", "SALARY", 50000, "", "R2", ""},
+			},
+		},
+		{
+			"month first",
+			&pb.CsvMapping{DateColumn: "Date", DateFormat: "MM/DD/YYYY", DescriptionColumn: "Description", AmountColumn: "Amount"},
+			"Date,Description,Amount\n03/04/2025,UBER,-12.5\n",
+			[]readLine{{2, "2025-03-04T00:00:00Z", "UBER", -12.5, "", "", ""}},
+		},
+		{
+			"ISO dates without a format",
+			&pb.CsvMapping{DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount", CurrencyColumn: "Currency"},
+			"Date,Description,Amount,Currency\n2025-03-04,PRET,-8.40,gbp\n2025-03-05 09:30:00,TESCO,-20,GBP\n",
+			[]readLine{
+				{2, "2025-03-04T00:00:00Z", "PRET", -8.4, "GBP", "", ""},
+				{3, "2025-03-05T09:30:00Z", "TESCO", -20, "GBP", "", ""},
+			},
+		},
+		{
+			"debits positive",
+			&pb.CsvMapping{DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount", DebitsPositive: true},
+			"Date,Description,Amount\n2025-03-04,NETFLIX,649\n2025-03-05,REFUND,-100\n",
+			[]readLine{
+				{2, "2025-03-04T00:00:00Z", "NETFLIX", -649, "", "", ""},
+				{3, "2025-03-05T00:00:00Z", "REFUND", 100, "", "", ""},
+			},
+		},
+		{
+			"debit and credit columns",
+			&pb.CsvMapping{DateColumn: "Date", DescriptionColumn: "Description", DebitColumn: "Withdrawal", CreditColumn: "Deposit"},
+			"Date,Description,Withdrawal,Deposit\n2025-03-04,RENT,\"15,000.00\",\n2025-03-05,INTEREST,,120.50\n2025-03-06,ODD,-10,\n",
+			[]readLine{
+				{2, "2025-03-04T00:00:00Z", "RENT", -15000, "", "", ""},
+				{3, "2025-03-05T00:00:00Z", "INTEREST", 120.5, "", "", ""},
+				{4, "2025-03-06T00:00:00Z", "ODD", -10, "", "", ""},
+			},
+		},
+		{
+			"decimal comma, semicolons and Dr/Cr suffixes",
+			&pb.CsvMapping{Delimiter: ";", DateColumn: "Datum", DateFormat: "DD.MM.YYYY", DescriptionColumn: "Text", AmountColumn: "Betrag", DecimalComma: true},
+			"Datum;Text;Betrag\n04.03.2025;REWE;1.234,50 Dr\n05.03.2025;GEHALT;2.000,00 Cr\n06.03.2025;DM;(12,99)\n",
+			[]readLine{
+				{2, "2025-03-04T00:00:00Z", "REWE", -1234.5, "", "", ""},
+				{3, "2025-03-05T00:00:00Z", "GEHALT", 2000, "", "", ""},
+				{4, "2025-03-06T00:00:00Z", "DM", -12.99, "", "", ""},
+			},
+		},
+		{
+			"no header, preamble rows and a byte order mark",
+			&pb.CsvMapping{NoHeader: true, SkipRows: 2, DateColumn: "1", DescriptionColumn: "3", AmountColumn: "2"},
+			"\xef\xbb\xbfStatement for XX1234\nPeriod: March\n2025-03-04,-99,CHAI POINT\n",
+			[]readLine{{3, "2025-03-04T00:00:00Z", "CHAI POINT", -99, "", "", ""}},
+		},
+		{
+			"malformed rows",
+			&pb.CsvMapping{DateColumn: "Date", DateFormat: "DD/MM/YYYY", DescriptionColumn: "Description", AmountColumn: "Amount"},
+			"Date,Description,Amount\n31/02/2025,BAD DATE,-1\n\n01/03/2025,BAD AMOUNT,abc\n02/03/2025,SHORT\n03/03/2025,OK,-5\n",
+			[]readLine{
+				{2, "", "BAD DATE", 0, "", "", "the date does not match the date format"},
+				{4, "2025-03-01T00:00:00Z", "BAD AMOUNT", 0, "", "", "the amount is not a number"},
+				{5, "2025-03-02T00:00:00Z", "SHORT", 0, "", "", "the amount is not a number"},
+				{6, "2025-03-03T00:00:00Z", "OK", -5, "", "", ""},
+			},
+		},
+		{
+			"bad debit",
+			&pb.CsvMapping{DateColumn: "Date", DescriptionColumn: "Description", DebitColumn: "Debit", CreditColumn: "Credit"},
+			"Date,Description,Debit,Credit\n2025-03-04,X,ten,\n",
+			[]readLine{{2, "2025-03-04T00:00:00Z", "X", 0, "", "", "the debit or credit is not a number"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateMapping(tt.mapping); err != nil {
+				t.Fatalf("validateMapping: %v", err)
+			}
+			lines, err := parseCsv([]byte(tt.content), tt.mapping)
+			if err != nil {
+				t.Fatalf("parseCsv: %v", err)
+			}
+			if got := readLines(lines); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("lines =\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCsvRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *pb.CsvMapping
+		content string
+	}{
+		{"missing column", &pb.CsvMapping{DateColumn: "Date", DescriptionColumn: "Payee", AmountColumn: "Amount"}, "Date,Description,Amount\n"},
+		{"no header row", &pb.CsvMapping{DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount"}, ""},
+		{"fewer rows than skipped", &pb.CsvMapping{SkipRows: 3, DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount"}, "a\nb\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateMapping(tt.mapping); err != nil {
+				t.Fatalf("validateMapping: %v", err)
+			}
+			if _, err := parseCsv([]byte(tt.content), tt.mapping); err == nil {
+				t.Error("parseCsv accepted the file")
+			}
+		})
+	}
+}
+
+func TestValidateMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *pb.CsvMapping
+	}{
+		{"no mapping", nil},
+		{"no date column", &pb.CsvMapping{DescriptionColumn: "Description", AmountColumn: "Amount"}},
+		{"debit without credit", &pb.CsvMapping{DateColumn: "Date", DescriptionColumn: "Description", DebitColumn: "Debit"}},
+		{"date format without a day", &pb.CsvMapping{DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount", DateFormat: "MM/YYYY"}},
+		{"long delimiter", &pb.CsvMapping{Delimiter: ";;", DateColumn: "Date", DescriptionColumn: "Description", AmountColumn: "Amount"}},
+		{"named column without a header", &pb.CsvMapping{NoHeader: true, DateColumn: "Date", DescriptionColumn: "2", AmountColumn: "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateMapping(tt.mapping); err == nil {
+				t.Error("validateMapping accepted the mapping")
+			}
+		})
+	}
+}
+
+func TestParseOfx(t *testing.T) {
+	sgml := strings.Join([]string{
+		"OFXHEADER:100",
+		"DATA:OFXSGML",
+		"",
+		"<OFX>",
+		"<BANKMSGSRSV1><STMTTRNRS><STMTRS>",
+		"<CURDEF>INR",
+		"<BANKACCTFROM><BANKID>HDFC<ACCTID>001234<ACCTTYPE>SAVINGS</BANKACCTFROM>",
+		"<BANKTRANLIST>",
+		"<STMTTRN>",
+		"<TRNTYPE>DEBIT",
+		"<DTPOSTED>20250304101500.000[+5.5:IST]",
+		"<TRNAMT>-250.00",
+		"<FITID>T1",
+		"<NAME>SWIGGY",
+		"</STMTTRN>",
+		"<STMTTRN>",
+		"<TRNTYPE>CREDIT",
+		"<DTPOSTED>20250305",
+		"<TRNAMT>1000",
+		"<FITID>T2",
+		"<MEMO>REFUND FROM AMAZON",
+		"<CURRENCY><CURSYM>usd</CURRENCY>",
+		"</STMTTRN>",
+		"<STMTTRN>",
+		"<DTPOSTED>2025-03-06",
+		"<TRNAMT>-1",
+		"<FITID>T3",
+		"<NAME>BAD DATE",
+		"</STMTTRN>",
+		"<STMTTRN>",
+		"<DTPOSTED>20250307",
+		"<TRNAMT>ten",
+		"<FITID>T4",
+		"<PAYEE>BAD AMOUNT",
+		"</STMTTRN>",
+		"</BANKTRANLIST>",
+		"</STMTRS></STMTTRNRS></BANKMSGSRSV1>",
+		"</OFX>",
+	}, "\r\n")
+	xml := `<?xml version="1.0"?><OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><CURDEF>EUR</CURDEF>` +
+		`<CCACCTFROM><ACCTID>9876</ACCTID></CCACCTFROM><BANKTRANLIST>` +
+		`<STMTTRN><DTPOSTED>20250304120000[-5:EST]</DTPOSTED><TRNAMT>-30.00</TRNAMT><FITID>X1</FITID><NAME>NETFLIX.COM</NAME></STMTTRN>` +
+		`</BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`
+
+	tests := []struct {
+		name    string
+		content string
+		account string
+		want    []readLine
+	}{
+		{"sgml", sgml, "001234", []readLine{
+			{9, "2025-03-04T10:15:00+05:30", "SWIGGY", -250, "INR", "T1", ""},
+			{16, "2025-03-05T00:00:00Z", "REFUND FROM AMAZON", 1000, "USD", "T2", ""},
+			{24, "", "BAD DATE", 0, "INR", "T3", "DTPOSTED is not a valid date"},
+			{30, "2025-03-07T00:00:00Z", "BAD AMOUNT", 0, "INR", "T4", "TRNAMT is not a valid amount"},
+		}},
+		{"xml", xml, "9876", []readLine{
+			{1, "2025-03-04T12:00:00-05:00", "NETFLIX.COM", -30, "EUR", "X1", ""},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := parseOfx([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("parseOfx: %v", err)
+			}
+			if got := readLines(lines); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("lines =\n%v\nwant\n%v", got, tt.want)
+			}
+			for _, line := range lines {
+				if line.account != tt.account {
+					t.Errorf("line %d account = %q, want %q", line.line, line.account, tt.account)
+				}
+			}
+		})
+	}
+
+	if _, err := parseOfx([]byte("Date,Description,Amount\n")); err == nil {
+		t.Error("parseOfx accepted a CSV file")
+	}
+}
+
+func TestParseQif(t *testing.T) {
+	content := strings.Join([]string{
+		"!Type:Cat",
+		"NGroceries",
+		"^",
+		"!Type:Bank",
+		"D03/04/2025",
+		"T-1,250.00",
+		"PBIG BAZAAR",
+		"N101",
+		"^",
+		"D3/5'25",
+		"U500",
+		"MSALARY ADVANCE",
+		"^",
+		"D2025-03-06",
+		"T-10",
+		"PISO DATE",
+		"^",
+		"D02/30/2025",
+		"T-1",
+		"PBAD DATE",
+		"^",
+		"D03/07/2025",
+		"Tabc",
+		"PBAD AMOUNT",
+		"^",
+	}, "\r\n")
+
+	tests := []struct {
+		order string
+		want  []readLine
+	}{
+		{"mdy", []readLine{
+			{5, "2025-03-04T00:00:00Z", "BIG BAZAAR", -1250, "", "", ""},
+			{10, "2025-03-05T00:00:00Z", "SALARY ADVANCE", 500, "", "", ""},
+			{14, "2025-03-06T00:00:00Z", "ISO DATE", -10, "", "", ""},
+			{18, "", "BAD DATE", 0, "", "", "D is not a valid date"},
+			{22, "2025-03-07T00:00:00Z", "BAD AMOUNT", 0, "", "", "T is not a valid amount"},
+		}},
+		{"dmy", []readLine{
+			{5, "2025-04-03T00:00:00Z", "BIG BAZAAR", -1250, "", "", ""},
+			{10, "2025-05-03T00:00:00Z", "SALARY ADVANCE", 500, "", "", ""},
+			{14, "2025-03-06T00:00:00Z", "ISO DATE", -10, "", "", ""},
+			{18, "", "BAD DATE", 0, "", "", "D is not a valid date"},
+			{22, "2025-07-03T00:00:00Z", "BAD AMOUNT", 0, "", "", "T is not a valid amount"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			lines, err := parseQif([]byte(content), tt.order)
+			if err != nil {
+				t.Fatalf("parseQif: %v", err)
+			}
+			if got := readLines(lines); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("lines =\n%v\nwant\n%v", got, tt.want)
+			}
+			if lines[0].number != "101" {
+				t.Errorf("number = %q, want 101", lines[0].number)
+			}
+		})
+	}
+
+	if _, err := parseQif([]byte("!Type:Bank\nD03/04/2025\nT-1\n"), "mdy"); err == nil {
+		t.Error("parseQif accepted an unterminated record")
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value        string
+		decimalComma bool
+		want         float64
+	}{
+		{"-1,234.50", false, -1234.5},
+		{"+99", false, 99},
+		{"(12.00)", false, -12},
+		{"₹ 1,00,000.00", false, 100000},
+		{"$45.20", false, 45.2},
+		{"250.00 DR", false, -250},
+		{"250.00 Cr", false, 250},
+		{"-250.00 Cr", false, -250},
+		{"1.234,50", true, 1234.5},
+		{"12,50", true, 12.5},
+	}
+	for _, tt := range tests {
+		got, err := parseAmount(tt.value, tt.decimalComma)
+		if err != nil || got != tt.want {
+			t.Errorf("parseAmount(%q, %v) = %v, %v; want %v", tt.value, tt.decimalComma, got, err, tt.want)
+		}
+	}
+	for _, value := range []string{"", "abc", "NaN", "1.2.3", "Inf"} {
+		if got, err := parseAmount(value, false); err == nil {
+			t.Errorf("parseAmount(%q) = %v, want an error", value, got)
+		}
+	}
+}
+
+func TestDetectStatementFormat(t *testing.T) {
+	tests := []struct {
+		filename string
+		content  string
+		want     string
+	}{
+		{"march.QFX", "", formatQfx},
+		{"march.qif", "", formatQif},
+		{"march.txt", "<OFX>", formatCsv},
+		{"download", "OFXHEADER:100\r\nDATA:OFXSGML", formatOfx},
+		{"download", "<?xml version=\"1.0\"?><OFX>", formatOfx},
+		{"download", "!Type:Bank\nD1/1/2025", formatQif},
+		{"download", "Date,Description,Amount", formatCsv},
+	}
+	for _, tt := range tests {
+		if got := detectStatementFormat(tt.filename, []byte(tt.content)); got != tt.want {
+			t.Errorf("detectStatementFormat(%q, %q) = %s, want %s", tt.filename, tt.content, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 key of the RFC 4226 and RFC 6238 test vectors,
+// "12345678901234567890", in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestHotpRfc4226(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfcSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	for counter, code := range want {
+		if got := hotp(key, uint64(counter)); got != code {
+			t.Errorf("hotp(%d) = %s, want %s", counter, got, code)
+		}
+	}
+}
+
+// The RFC 6238 vectors have eight digits; a six-digit code is their last six.
+func TestVerifyTotpRfc6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		code := tt.code[len(tt.code)-totpDigits:]
+		step, ok := verifyTOTP(rfcSecret, code, time.Unix(tt.unix, 0))
+		if !ok {
+			t.Errorf("code %s rejected at %d", code, tt.unix)
+			continue
+		}
+		if step != tt.unix/totpPeriod {
+			t.Errorf("code %s matched step %d, want %d", code, step, tt.unix/totpPeriod)
+		}
+	}
+}
+
+func TestVerifyTotpWindow(t *testing.T) {
+	// At 59 seconds the current step is 1; the codes of steps 0 to 3 are
+	// those of the RFC 4226 counters.
+	at := time.Unix(59, 0)
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		ok     bool
+		step   int64
+	}{
+		{"current step", rfcSecret, "287082", true, 1},
+		{"one step behind", rfcSecret, "755224", true, 0},
+		{"one step ahead", rfcSecret, "359152", true, 2},
+		{"two steps ahead", rfcSecret, "969429", false, 0},
+		{"spaces and lower-case secret", strings.ToLower(rfcSecret), " 287 082 ", true, 1},
+		{"too short", rfcSecret, "28708", false, 0},
+		{"eight digits", rfcSecret, "94287082", false, 0},
+		{"invalid secret", "not base32!", "287082", false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := verifyTOTP(tt.secret, tt.code, at)
+			if ok != tt.ok || step != tt.step {
+				t.Errorf("verifyTOTP = %d, %v; want %d, %v", step, ok, tt.step, tt.ok)
+			}
+		})
+	}
+}
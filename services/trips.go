@@ -0,0 +1,602 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/barathsurya2004/expenses/proto"
+)
+
+const (
+	maxTripNameLength   = 100
+	maxTripDays         = 366
+	maxTripBudget       = 1e10
+	maxTripExchangeRate = 1e6
+)
+
+type tripsServer struct {
+	pb.UnimplementedTripsServiceServer
+	db *sql.DB
+}
+
+var tripOrders = map[string]pageOrder{
+	"starts_on desc": {column: "t.starts_on", cast: "date", desc: true, id: "t.id", idCast: "uuid", idDesc: true},
+	"starts_on asc":  {column: "t.starts_on", cast: "date", id: "t.id", idCast: "uuid"},
+	"name asc":       {column: "lower(t.name)", cast: "text", id: "t.id", idCast: "uuid"},
+}
+
+const tripColumns = `
+	t.id, t.name, coalesce(t.group_id::text, ''), t.uuid, t.starts_on, t.ends_on, coalesce(t.budget, 0),
+	t.home_currency, coalesce(t.foreign_currency, ''), coalesce(t.exchange_rate, 0),
+	(SELECT count(*) FROM expense_data WHERE trip_id = t.id), t.created_at`
+
+func scanTrip(row interface{ Scan(...any) error }) (*pb.Trip, error) {
+	var trip pb.Trip
+	var startsOn, endsOn, createdAt time.Time
+	if err := row.Scan(&trip.Id, &trip.Name, &trip.GroupId, &trip.CreatedBy, &startsOn, &endsOn, &trip.Budget,
+		&trip.HomeCurrency, &trip.ForeignCurrency, &trip.ExchangeRate, &trip.ExpenseCount, &createdAt); err != nil {
+		return nil, err
+	}
+	trip.StartsOn = startsOn.Format("2006-01-02")
+	trip.EndsOn = endsOn.Format("2006-01-02")
+	trip.CreatedAt = createdAt.Format(time.RFC3339)
+	return &trip, nil
+}
+
+// assignTrips files the expenses matching condition, on expense_data e,
+// under the trip their date falls in, or none. Expenses whose trip was set
+// by hand are left alone.
+func assignTrips(ctx context.Context, tx *sql.Tx, condition string, args ...any) error {
+	query := `
+		UPDATE expense_data e SET trip_id = (
+			SELECT t.id FROM trip_data t, user_data u
+			WHERE u.uuid = e.uuid
+				AND (t.group_id = e.group_id OR (e.group_id IS NULL AND t.group_id IS NULL AND t.uuid = e.uuid))
+				AND (e.date_and_time AT TIME ZONE u.time_zone)::date BETWEEN t.starts_on AND t.ends_on
+			ORDER BY t.starts_on DESC, t.created_at DESC, t.id
+			LIMIT 1)
+		WHERE NOT e.trip_manual AND ` + condition
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// reassignTrip runs assignTrips over the expenses a change to a trip can
+// affect: those on it, and those of its owner or group dated between from
+// and to. The range is widened by a day either side, as dates are compared
+// in the time zone of whoever recorded the expense.
+func reassignTrip(ctx context.Context, tx *sql.Tx, trip *pb.Trip, from, to time.Time) error {
+	condition, owner := "e.uuid = $1 AND e.group_id IS NULL", trip.CreatedBy
+	if trip.GroupId != "" {
+		condition, owner = "e.group_id = $1", trip.GroupId
+	}
+	condition += " AND (e.trip_id = $2 OR (e.date_and_time >= $3 AND e.date_and_time < $4))"
+	return assignTrips(ctx, tx, condition, owner, trip.Id, from.AddDate(0, 0, -1), to.AddDate(0, 0, 2))
+}
+
+// loadTrip returns the trip if it is the caller's own or belongs to a group
+// they are in. Anyone else gets NotFound so trip ids cannot be probed. edit
+// also requires the caller to have created it or to own or run its group.
+func (s *tripsServer) loadTrip(ctx context.Context, tripId, userId string, edit bool) (*pb.Trip, error) {
+	if _, err := uuid.Parse(tripId); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid trip id")
+	}
+	trip, err := scanTrip(s.db.QueryRowContext(ctx, `SELECT `+tripColumns+` FROM trip_data t WHERE t.id = $1`, tripId))
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "trip not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if trip.GroupId == "" {
+		if trip.CreatedBy != userId {
+			return nil, status.Error(codes.NotFound, "trip not found")
+		}
+		return trip, nil
+	}
+	role, err := groupRole(ctx, s.db, trip.GroupId, userId)
+	if status.Code(err) == codes.NotFound {
+		return nil, status.Error(codes.NotFound, "trip not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if edit && trip.CreatedBy != userId && role != groupRoleOwner && role != groupRoleAdmin {
+		return nil, status.Error(codes.PermissionDenied, "only the trip's creator and the group's owners and admins can change it")
+	}
+	return trip, nil
+}
+
+// tripFields are the editable fields of a trip.
+type tripFields struct {
+	name            string
+	startsOn        time.Time
+	endsOn          time.Time
+	budget          float64
+	homeCurrency    string
+	foreignCurrency string
+	exchangeRate    float64
+}
+
+func parseTripDate(value, field string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s must be a YYYY-MM-DD date", field)
+	}
+	return date, nil
+}
+
+// validate checks the fields and normalizes the name and currencies.
+func (f *tripFields) validate() error {
+	f.name = strings.TrimSpace(f.name)
+	if f.name == "" || len(f.name) > maxTripNameLength {
+		return status.Errorf(codes.InvalidArgument, "name must be between 1 and %d characters", maxTripNameLength)
+	}
+	if f.endsOn.Before(f.startsOn) {
+		return status.Error(codes.InvalidArgument, "ends_on cannot be before starts_on")
+	}
+	if f.endsOn.Sub(f.startsOn) >= maxTripDays*24*time.Hour {
+		return status.Errorf(codes.InvalidArgument, "a trip can span at most %d days", maxTripDays)
+	}
+	if f.budget < 0 || f.budget >= maxTripBudget {
+		return status.Error(codes.InvalidArgument, "budget must be between 0 and 10000000000")
+	}
+	f.homeCurrency = strings.ToUpper(strings.TrimSpace(f.homeCurrency))
+	if len(f.homeCurrency) != 3 {
+		return status.Error(codes.InvalidArgument, "home_currency must be a three-letter code")
+	}
+	f.foreignCurrency = strings.ToUpper(strings.TrimSpace(f.foreignCurrency))
+	if f.foreignCurrency != "" && (len(f.foreignCurrency) != 3 || f.foreignCurrency == f.homeCurrency) {
+		return status.Error(codes.InvalidArgument, "foreign_currency must be a three-letter code other than home_currency")
+	}
+	if f.exchangeRate < 0 || f.exchangeRate >= maxTripExchangeRate {
+		return status.Error(codes.InvalidArgument, "exchange_rate must be between 0 and 1000000")
+	}
+	if f.exchangeRate > 0 && f.foreignCurrency == "" {
+		return status.Error(codes.InvalidArgument, "exchange_rate needs a foreign_currency")
+	}
+	return nil
+}
+
+func (s *tripsServer) CreateTrip(ctx context.Context, req *pb.CreateTripRequest) (*pb.TripResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetGroupId() != "" {
+		if _, err := groupRole(ctx, s.db, req.GetGroupId(), userId); err != nil {
+			return nil, err
+		}
+	}
+	fields := tripFields{
+		name:            req.GetName(),
+		budget:          req.GetBudget(),
+		homeCurrency:    req.GetHomeCurrency(),
+		foreignCurrency: req.GetForeignCurrency(),
+		exchangeRate:    req.GetExchangeRate(),
+	}
+	if fields.startsOn, err = parseTripDate(req.GetStartsOn(), "starts_on"); err != nil {
+		return nil, err
+	}
+	if fields.endsOn, err = parseTripDate(req.GetEndsOn(), "ends_on"); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(fields.homeCurrency) == "" {
+		if err := s.db.QueryRowContext(ctx, `SELECT default_currency FROM user_data WHERE uuid = $1`, userId).Scan(&fields.homeCurrency); err != nil {
+			return nil, err
+		}
+	}
+	if err := fields.validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO trip_data (uuid, group_id, name, starts_on, ends_on, budget, home_currency, foreign_currency, exchange_rate)
+		VALUES ($1, nullif($2, '')::uuid, $3, $4, $5, nullif($6, 0), $7, nullif($8, ''), nullif($9, 0))
+		RETURNING id`
+	var tripId string
+	err = tx.QueryRowContext(ctx, query, userId, req.GetGroupId(), fields.name, fields.startsOn, fields.endsOn,
+		fields.budget, fields.homeCurrency, fields.foreignCurrency, fields.exchangeRate).Scan(&tripId)
+	if err != nil {
+		log.Printf("Failed to create trip: %v", err)
+		return nil, err
+	}
+	trip := &pb.Trip{Id: tripId, GroupId: req.GetGroupId(), CreatedBy: userId}
+	if err := reassignTrip(ctx, tx, trip, fields.startsOn, fields.endsOn); err != nil {
+		log.Printf("Failed to assign expenses to trip: %v", err)
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	trip, err = s.loadTrip(ctx, tripId, userId, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TripResponse{Trip: trip}, nil
+}
+
+func (s *tripsServer) UpdateTrip(ctx context.Context, req *pb.UpdateTripRequest) (*pb.TripResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trip, err := s.loadTrip(ctx, req.GetId(), userId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := tripFields{
+		name:            trip.Name,
+		budget:          trip.Budget,
+		homeCurrency:    trip.HomeCurrency,
+		foreignCurrency: trip.ForeignCurrency,
+		exchangeRate:    trip.ExchangeRate,
+	}
+	oldStart, _ := time.Parse("2006-01-02", trip.StartsOn)
+	oldEnd, _ := time.Parse("2006-01-02", trip.EndsOn)
+	fields.startsOn, fields.endsOn = oldStart, oldEnd
+	if req.Name != nil {
+		fields.name = req.GetName()
+	}
+	if req.StartsOn != nil {
+		if fields.startsOn, err = parseTripDate(req.GetStartsOn(), "starts_on"); err != nil {
+			return nil, err
+		}
+	}
+	if req.EndsOn != nil {
+		if fields.endsOn, err = parseTripDate(req.GetEndsOn(), "ends_on"); err != nil {
+			return nil, err
+		}
+	}
+	if req.Budget != nil {
+		fields.budget = req.GetBudget()
+	}
+	if req.HomeCurrency != nil {
+		fields.homeCurrency = req.GetHomeCurrency()
+	}
+	if req.ForeignCurrency != nil {
+		fields.foreignCurrency = req.GetForeignCurrency()
+		if strings.TrimSpace(fields.foreignCurrency) == "" {
+			fields.exchangeRate = 0
+		}
+	}
+	if req.ExchangeRate != nil {
+		fields.exchangeRate = req.GetExchangeRate()
+	}
+	if err := fields.validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE trip_data SET name = $1, starts_on = $2, ends_on = $3, budget = nullif($4, 0), home_currency = $5,
+			foreign_currency = nullif($6, ''), exchange_rate = nullif($7, 0)
+		WHERE id = $8`
+	if _, err := tx.ExecContext(ctx, query, fields.name, fields.startsOn, fields.endsOn, fields.budget,
+		fields.homeCurrency, fields.foreignCurrency, fields.exchangeRate, trip.Id); err != nil {
+		log.Printf("Failed to update trip: %v", err)
+		return nil, err
+	}
+	if !fields.startsOn.Equal(oldStart) || !fields.endsOn.Equal(oldEnd) {
+		from, to := oldStart, oldEnd
+		if fields.startsOn.Before(from) {
+			from = fields.startsOn
+		}
+		if fields.endsOn.After(to) {
+			to = fields.endsOn
+		}
+		if err := reassignTrip(ctx, tx, trip, from, to); err != nil {
+			log.Printf("Failed to assign expenses to trip: %v", err)
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	trip, err = s.loadTrip(ctx, trip.Id, userId, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TripResponse{Trip: trip}, nil
+}
+
+func (s *tripsServer) DeleteTrip(ctx context.Context, req *pb.DeleteTripRequest) (*pb.DeleteTripResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trip, err := s.loadTrip(ctx, req.GetId(), userId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Expenses put on the trip by hand go back to automatic assignment, and
+	// with the trip gone may fall into an overlapping one.
+	if _, err := tx.ExecContext(ctx, `UPDATE expense_data SET trip_manual = false WHERE trip_id = $1`, trip.Id); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM trip_data WHERE id = $1`, trip.Id); err != nil {
+		log.Printf("Failed to delete trip: %v", err)
+		return nil, err
+	}
+	startsOn, _ := time.Parse("2006-01-02", trip.StartsOn)
+	endsOn, _ := time.Parse("2006-01-02", trip.EndsOn)
+	if err := reassignTrip(ctx, tx, trip, startsOn, endsOn); err != nil {
+		log.Printf("Failed to reassign expenses of deleted trip: %v", err)
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteTripResponse{Message: "Trip deleted"}, nil
+}
+
+func (s *tripsServer) ListTrips(ctx context.Context, req *pb.ListTripsRequest) (*pb.ListTripsResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scope, owner := "t.uuid = $1 AND t.group_id IS NULL", userId
+	if req.GetGroupId() != "" {
+		if _, err := groupRole(ctx, s.db, req.GetGroupId(), userId); err != nil {
+			return nil, err
+		}
+		scope, owner = "t.group_id = $1", req.GetGroupId()
+	}
+
+	page, err := newPage(req, tripOrders, "starts_on desc", pageFilter(req.GetGroupId()), defaultPageSize, maxPageSize)
+	if err != nil {
+		return nil, err
+	}
+	var total int32
+	if req.GetIncludeTotalCount() {
+		if err := s.db.QueryRowContext(ctx, `SELECT count(*) FROM trip_data t WHERE `+scope, owner).Scan(&total); err != nil {
+			log.Printf("Failed to count trips: %v", err)
+			return nil, err
+		}
+	}
+
+	after, args := page.condition([]any{owner})
+	query := `SELECT ` + tripColumns + `, ` + page.keyColumns() + ` FROM trip_data t WHERE ` + scope + ` AND ` + after + page.orderLimit()
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Printf("Failed to list trips: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+	var trips []*pb.Trip
+	for rows.Next() {
+		trip, err := scanTrip(withKey{rows, page})
+		if err != nil {
+			return nil, err
+		}
+		trips = append(trips, trip)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	next, n := page.nextToken()
+	return &pb.ListTripsResponse{Trips: trips[:n], NextPageToken: next, TotalCount: total}, nil
+}
+
+func (s *tripsServer) SetExpenseTrip(ctx context.Context, req *pb.SetExpenseTripRequest) (*pb.SetExpenseTripResponse, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(req.GetExpenseId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid expense id")
+	}
+	if !req.GetAutomatic() && req.GetTripId() != "" {
+		if _, err := uuid.Parse(req.GetTripId()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid trip id")
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Only the member who recorded an expense can choose its trip.
+	var groupId sql.NullString
+	err = tx.QueryRowContext(ctx, `SELECT group_id FROM expense_data WHERE id = $1 AND uuid = $2 FOR UPDATE`, req.GetExpenseId(), userId).Scan(&groupId)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "expense not found")
+	}
+	if err != nil {
+		log.Printf("Error loading expense: %v", err)
+		return nil, err
+	}
+
+	var message string
+	switch {
+	case req.GetAutomatic():
+		if _, err := tx.ExecContext(ctx, `UPDATE expense_data SET trip_manual = false WHERE id = $1`, req.GetExpenseId()); err != nil {
+			return nil, err
+		}
+		if err := assignTrips(ctx, tx, "e.id = $1", req.GetExpenseId()); err != nil {
+			log.Printf("Failed to assign expense to trip: %v", err)
+			return nil, err
+		}
+		message = "Expense assigned to trips automatically"
+	case req.GetTripId() == "":
+		if _, err := tx.ExecContext(ctx, `UPDATE expense_data SET trip_id = NULL, trip_manual = true WHERE id = $1`, req.GetExpenseId()); err != nil {
+			return nil, err
+		}
+		message = "Expense removed from trips"
+	default:
+		// A personal expense can go on the caller's own trips, and a group
+		// expense on the group's.
+		var tripGroup, tripOwner string
+		query := `SELECT coalesce(group_id::text, ''), uuid FROM trip_data WHERE id = $1`
+		err := tx.QueryRowContext(ctx, query, req.GetTripId()).Scan(&tripGroup, &tripOwner)
+		if err == sql.ErrNoRows || (err == nil && (tripGroup != groupId.String || (tripGroup == "" && tripOwner != userId))) {
+			return nil, status.Error(codes.NotFound, "trip not found")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE expense_data SET trip_id = $1, trip_manual = true WHERE id = $2`, req.GetTripId(), req.GetExpenseId()); err != nil {
+			log.Printf("Failed to assign expense to trip: %v", err)
+			return nil, err
+		}
+		message = "Expense assigned to trip"
+	}
+
+	var tripId string
+	if err := tx.QueryRowContext(ctx, `SELECT coalesce(trip_id::text, '') FROM expense_data WHERE id = $1`, req.GetExpenseId()).Scan(&tripId); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &pb.SetExpenseTripResponse{ExpenseId: req.GetExpenseId(), TripId: tripId, Message: message}, nil
+}
+
+// tripConvert returns an amount in the trip's home and foreign currencies,
+// and false when it cannot be converted to the home currency: when it was
+// spent in neither, or in the foreign currency of a trip without an exchange
+// rate. Without a rate home spending only counts in the home currency.
+func tripConvert(trip *pb.Trip, amount float64, currency string) (float64, float64, bool) {
+	switch {
+	case currency == trip.HomeCurrency:
+		if trip.ExchangeRate > 0 {
+			return amount, amount / trip.ExchangeRate, true
+		}
+		return amount, 0, true
+	case currency == trip.ForeignCurrency && currency != "" && trip.ExchangeRate > 0:
+		return amount * trip.ExchangeRate, amount, true
+	}
+	return 0, 0, false
+}
+
+func roundCents(amount float64) float64 {
+	return fromCents(toCents(amount))
+}
+
+func (s *tripsServer) GetTripSummary(ctx context.Context, req *pb.GetTripSummaryRequest) (*pb.TripSummary, error) {
+	userId, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trip, err := s.loadTrip(ctx, req.GetId(), userId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Days are those of whoever recorded each expense, as for assignment.
+	query := `
+		SELECT to_char((e.date_and_time AT TIME ZONE coalesce(u.time_zone, 'UTC'))::date, 'YYYY-MM-DD'),
+			e.category, e.currency, sum(e.amount), count(*)
+		FROM expense_data e LEFT JOIN user_data u ON u.uuid = e.uuid
+		WHERE e.trip_id = $1
+		GROUP BY 1, 2, 3`
+	rows, err := s.db.QueryContext(ctx, query, trip.Id)
+	if err != nil {
+		log.Printf("Failed to summarize trip: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := &pb.TripSummary{Trip: trip}
+	days := map[string]*pb.TripDay{}
+	categories := map[string]*pb.TripCategory{}
+	unconverted := map[string]float64{}
+	startsOn, _ := time.Parse("2006-01-02", trip.StartsOn)
+	endsOn, _ := time.Parse("2006-01-02", trip.EndsOn)
+	for day := startsOn; !day.After(endsOn); day = day.AddDate(0, 0, 1) {
+		days[day.Format("2006-01-02")] = &pb.TripDay{Date: day.Format("2006-01-02")}
+	}
+	for rows.Next() {
+		var date, category, currency string
+		var amount float64
+		var count int32
+		if err := rows.Scan(&date, &category, &currency, &amount, &count); err != nil {
+			return nil, err
+		}
+		if days[date] == nil {
+			days[date] = &pb.TripDay{Date: date}
+		}
+		if categories[category] == nil {
+			categories[category] = &pb.TripCategory{Category: category}
+		}
+		summary.ExpenseCount += count
+		days[date].ExpenseCount += count
+		categories[category].ExpenseCount += count
+
+		home, foreign, ok := tripConvert(trip, amount, currency)
+		if !ok {
+			unconverted[currency] += amount
+			continue
+		}
+		summary.HomeTotal += home
+		summary.ForeignTotal += foreign
+		days[date].Home += home
+		days[date].Foreign += foreign
+		categories[category].Home += home
+		categories[category].Foreign += foreign
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, day := range days {
+		day.Home, day.Foreign = roundCents(day.Home), roundCents(day.Foreign)
+		summary.Days = append(summary.Days, day)
+	}
+	sort.Slice(summary.Days, func(i, j int) bool { return summary.Days[i].Date < summary.Days[j].Date })
+	for _, category := range categories {
+		category.Home, category.Foreign = roundCents(category.Home), roundCents(category.Foreign)
+		summary.Categories = append(summary.Categories, category)
+	}
+	sort.Slice(summary.Categories, func(i, j int) bool {
+		a, b := summary.Categories[i], summary.Categories[j]
+		if a.Home != b.Home {
+			return a.Home > b.Home
+		}
+		if a.Foreign != b.Foreign {
+			return a.Foreign > b.Foreign
+		}
+		return a.Category < b.Category
+	})
+	for currency, amount := range unconverted {
+		summary.Unconverted = append(summary.Unconverted, &pb.TripAmount{Currency: currency, Amount: roundCents(amount)})
+	}
+	sort.Slice(summary.Unconverted, func(i, j int) bool { return summary.Unconverted[i].Currency < summary.Unconverted[j].Currency })
+
+	summary.HomeTotal, summary.ForeignTotal = roundCents(summary.HomeTotal), roundCents(summary.ForeignTotal)
+	if trip.Budget > 0 {
+		remaining := roundCents(trip.Budget - summary.HomeTotal)
+		summary.BudgetRemaining = &remaining
+	}
+	return summary, nil
+}
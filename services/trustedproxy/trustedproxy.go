@@ -0,0 +1,95 @@
+// Package trustedproxy decides which forwarded client addresses to believe.
+// Both the HTTP gateway, which sits behind load balancers, and the services,
+// which sit behind the gateway, only honour forwarded addresses from the
+// proxies listed in TRUSTED_PROXIES.
+package trustedproxy
+
+import (
+	"log"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+)
+
+// List is a set of proxy address ranges.
+type List []netip.Prefix
+
+// Load reads TRUSTED_PROXIES, a comma-separated list of CIDRs or single
+// addresses such as "10.0.0.0/8,127.0.0.1". Entries that do not parse are
+// logged and skipped. An empty list trusts no one.
+func Load() List {
+	var list List
+	for _, entry := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				log.Printf("Ignoring invalid TRUSTED_PROXIES entry %q", entry)
+				continue
+			}
+			list = append(list, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			log.Printf("Ignoring invalid TRUSTED_PROXIES entry %q", entry)
+			continue
+		}
+		list = append(list, prefix.Masked())
+	}
+	return list
+}
+
+// Contains reports whether ip, with or without a port, is a trusted proxy.
+func (l List) Contains(ip string) bool {
+	addr, err := netip.ParseAddr(Host(ip))
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range l {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Host strips the port from an address, if it has one.
+func Host(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return strings.Trim(addr, "[]")
+}
+
+// ClientIP returns the address of the end user behind remote, the address
+// the connection came from. Only when remote is a trusted proxy is the
+// X-Forwarded-For chain read, from the right, skipping the trusted proxies
+// in it; the first address left is the client. Entries further left were
+// written by the client itself and are never believed.
+func (l List) ClientIP(remote string, forwarded []string) string {
+	client := Host(remote)
+	if !l.Contains(client) {
+		return client
+	}
+	var chain []string
+	for _, header := range forwarded {
+		chain = append(chain, strings.Split(header, ",")...)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		hop := Host(strings.TrimSpace(chain[i]))
+		if _, err := netip.ParseAddr(hop); err != nil {
+			break
+		}
+		client = hop
+		if !l.Contains(hop) {
+			break
+		}
+	}
+	return client
+}
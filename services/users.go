@@ -11,13 +11,18 @@ import (
 
 	"github.com/google/uuid"
 	_ "github.com/lib/pq" // PostgreSQL driver
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/barathsurya2004/expenses/proto"
 )
 
 type usersServer struct {
 	pb.UnimplementedUsersServiceServer
-	db *sql.DB
+	db     *sql.DB
+	mailer Mailer
+	blobs  BlobStore
+	oidc   *oidcVerifiers
 }
 
 type User struct {
@@ -71,6 +76,10 @@ func (s *usersServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest)
 		return nil, err
 	}
 
+	if err := s.sendEmailVerification(ctx, userId, user.Email); err != nil {
+		log.Printf("Failed to send email verification: %v", err)
+	}
+
 	return &pb.CreateUserResponse{
 		Message:   "User created successfully",
 		UserId:    userId,
@@ -83,34 +92,108 @@ func (s *usersServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.
 
 	var user User
 
-	query := `SELECT uuid,password_hash FROM user_data WHERE username = $1`
 	if req.GetUsername() == "" {
 		log.Printf("Username is required")
-		return nil, fmt.Errorf("username is required")
+		return nil, status.Error(codes.InvalidArgument, "username is required")
 	}
-	err := s.db.QueryRowContext(ctx, query, req.GetUsername()).Scan(&user.ID, &user.Password)
-	if err != nil {
+	if req.GetPassword() == "" {
+		log.Printf("Password is required")
+		return nil, status.Error(codes.InvalidArgument, "password is required")
+	}
+	user.Username = req.GetUsername()
+
+	ip := clientIP(ctx)
+	userKey := usernameThrottleKey(user.Username)
+	ipKey := ipThrottleKey(ip)
+	if err := checkLoginThrottle(ctx, s.db, userKey, ipKey); err != nil {
+		if status.Code(err) == codes.ResourceExhausted {
+			log.Printf("Login for user %s from %s rejected: locked out", user.Username, ip)
+			recordAuthEvent(ctx, s.db, "", user.Username, ip, authEventLoginLocked, false, err.Error())
+		}
+		return nil, err
+	}
+
+	query := `SELECT uuid,coalesce(password_hash, '') FROM user_data WHERE username = $1`
+	err := s.db.QueryRowContext(ctx, query, user.Username).Scan(&user.ID, &user.Password)
+	if err != nil && err != sql.ErrNoRows {
 		log.Printf("Failed to get user: %v", err)
 		return nil, err
 	}
 
-	if req.GetPassword() != "" {
-		if !passwordCheck(user.Password, req.GetPassword()) {
-			log.Printf("Password check failed for user %s", user.Username)
-			return nil, fmt.Errorf("invalid password for user %s", user.Username)
+	if err == sql.ErrNoRows || !passwordCheck(user.Password, req.GetPassword()) {
+		if err == sql.ErrNoRows {
+			// Spend the same time as a real check so unknown usernames
+			// cannot be told apart by latency.
+			passwordCheck(string(dummyPasswordHash), req.GetPassword())
+		}
+		log.Printf("Password check failed for user %s", user.Username)
+		if err := recordLoginFailure(ctx, s.db, userKey, usernameFailureThreshold); err != nil {
+			log.Printf("Failed to record login failure: %v", err)
+		}
+		if err := recordLoginFailure(ctx, s.db, ipKey, ipFailureThreshold); err != nil {
+			log.Printf("Failed to record login failure: %v", err)
 		}
-		log.Printf("Password check successful for user %s", user.Username)
+		recordAuthEvent(ctx, s.db, user.ID, user.Username, ip, authEventLogin, false, "invalid credentials")
+		return nil, status.Error(codes.Unauthenticated, "invalid username or password")
 	}
+	log.Printf("Password check successful for user %s", user.Username)
+
+	if err := resetLoginThrottle(ctx, s.db, userKey); err != nil {
+		log.Printf("Failed to reset login throttle: %v", err)
+	}
+	recordAuthEvent(ctx, s.db, user.ID, user.Username, ip, authEventLogin, true, "")
+
+	return s.completeLogin(ctx, user.ID, user.Username, ip)
+}
 
-	authToken, err := GenToken(user.ID, s.db)
+// completeLogin finishes a successful first-factor login: users with
+// two-factor authentication get an MFA challenge, everyone else a session
+// token and their profile.
+func (s *usersServer) completeLogin(ctx context.Context, userId, username, ip string) (*pb.GetUserResponse, error) {
+	var disabled bool
+	if err := s.db.QueryRowContext(ctx, `SELECT disabled_at IS NOT NULL FROM user_data WHERE uuid = $1`, userId).Scan(&disabled); err != nil {
+		log.Printf("Failed to check account status: %v", err)
+		return nil, err
+	}
+	if disabled {
+		recordAuthEvent(ctx, s.db, userId, username, ip, authEventLogin, false, "account disabled")
+		return nil, status.Error(codes.PermissionDenied, "account is disabled")
+	}
+
+	enabled, err := mfaEnabled(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Failed to check MFA status: %v", err)
+		return nil, err
+	}
+	if enabled {
+		mfaToken, err := newMfaChallenge(ctx, s.db, userId)
+		if err != nil {
+			log.Printf("Failed to create MFA challenge: %v", err)
+			return nil, err
+		}
+		recordAuthEvent(ctx, s.db, userId, username, ip, authEventMfaChallenge, true, "")
+		return &pb.GetUserResponse{
+			MfaRequired: true,
+			MfaToken:    mfaToken,
+		}, nil
+	}
+
+	authToken, err := GenToken(userId, s.db)
 	if err != nil {
 		log.Printf("Failed to generate auth token: %v", err)
 		return nil, err
 	}
 
+	profile, err := loadProfile(ctx, s.db, userId)
+	if err != nil {
+		log.Printf("Failed to load profile: %v", err)
+		return nil, err
+	}
+
 	return &pb.GetUserResponse{
-		UserId:    user.ID,
+		UserId:    userId,
 		AuthToken: authToken, // Replace with actual token generation logic
+		Profile:   profile,
 	}, nil
 
 }
@@ -120,31 +203,100 @@ func (s *usersServer) CheckAuthToken(ctx context.Context, req *pb.CheckAuthToken
 		return nil, fmt.Errorf("auth token is required")
 	}
 
-	var userId string
-	var message string
-
-	query := `SELECT uuid FROM token_data WHERE token = $1`
-	err := s.db.QueryRowContext(ctx, query, req.GetAuthToken()).Scan(&userId)
+	id, err := authenticate(ctx, s.db, req.GetAuthToken())
 	if err != nil {
-		if err == sql.ErrNoRows {
-			message = "Invalid auth token"
-			return &pb.CheckAuthTokenResponse{
-				IsValid: false,
-				Message: message,
-			}, nil
-		}
 		log.Printf("Failed to check auth token: %v", err)
 		return nil, err
 	}
+	if id == nil {
+		return &pb.CheckAuthTokenResponse{
+			IsValid: false,
+			Message: "Invalid auth token",
+		}, nil
+	}
 
-	message = "Auth token is valid"
 	return &pb.CheckAuthTokenResponse{
-		IsValid: true,
-		UserId:  userId,
-		Message: message,
+		IsValid:        true,
+		UserId:         id.UserID,
+		Message:        "Auth token is valid",
+		Scope:          id.Scope,
+		CredentialType: id.CredentialType,
 	}, nil
 }
 
+// dummyPasswordHash is compared against when the username does not exist.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+// verifyUserPassword confirms the password of an already authenticated user
+// before a sensitive change.
+func (s *usersServer) verifyUserPassword(ctx context.Context, userId, password string) error {
+	if password == "" {
+		return status.Error(codes.InvalidArgument, "password is required")
+	}
+	var hash string
+	err := s.db.QueryRowContext(ctx, `SELECT coalesce(password_hash, '') FROM user_data WHERE uuid = $1`, userId).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, fmt.Sprintf("user %s not found", userId))
+	}
+	if err != nil {
+		log.Printf("Failed to get user: %v", err)
+		return err
+	}
+	if !passwordCheck(hash, password) {
+		return status.Error(codes.Unauthenticated, "invalid password")
+	}
+	return nil
+}
+
+// reauthRequest is a sensitive request that carries either the account
+// password or an ID token from a fresh sign-in at a linked provider.
+type reauthRequest interface {
+	GetPassword() string
+	GetProvider() string
+	GetIdToken() string
+	GetNonce() string
+}
+
+// reauthenticate confirms an already authenticated user before a sensitive
+// change. Accounts with a password confirm with it; accounts created through
+// an identity provider have none and confirm with an ID token from one of
+// their linked providers, issued for a sign-in in the last few minutes.
+func (s *usersServer) reauthenticate(ctx context.Context, userId string, req reauthRequest) error {
+	var hasPassword bool
+	err := s.db.QueryRowContext(ctx, `SELECT password_hash IS NOT NULL FROM user_data WHERE uuid = $1`, userId).Scan(&hasPassword)
+	if err == sql.ErrNoRows {
+		return status.Error(codes.NotFound, fmt.Sprintf("user %s not found", userId))
+	}
+	if err != nil {
+		log.Printf("Failed to get user: %v", err)
+		return err
+	}
+	if hasPassword {
+		return s.verifyUserPassword(ctx, userId, req.GetPassword())
+	}
+
+	if req.GetIdToken() == "" {
+		return status.Error(codes.InvalidArgument, "this account has no password, sign in again with a linked identity provider to confirm")
+	}
+	claims, err := s.oidc.verify(ctx, req.GetProvider(), req.GetIdToken(), req.GetNonce())
+	if err != nil {
+		return err
+	}
+	var linked bool
+	query := `SELECT EXISTS (SELECT 1 FROM user_identities WHERE uuid = $1 AND provider = $2 AND subject = $3)`
+	if err := s.db.QueryRowContext(ctx, query, userId, req.GetProvider(), claims.Subject).Scan(&linked); err != nil {
+		log.Printf("Failed to look up identity: %v", err)
+		return err
+	}
+	if !linked {
+		return status.Error(codes.PermissionDenied, "this identity is not linked to your account")
+	}
+	if time.Since(claims.authenticatedAt()) > maxReauthAge {
+		return status.Error(codes.Unauthenticated, "sign-in is too old, sign in with the identity provider again")
+	}
+	return nil
+}
+
 func passwordHash(password string) ([]byte, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -164,7 +316,7 @@ func passwordCheck(hashedPassword, password string) bool {
 }
 
 func GenToken(userId string, db *sql.DB) (string, error) {
-	query := `delete from token_data where uuid = $1`
+	query := `delete from token_data where uuid = $1 and impersonator is null`
 
 	_, err := db.Exec(query, userId)
 	if err != nil {
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The parts of a workbook that do not depend on the data. Style 1 is a
+// date and time, style 2 an amount and style 3 a bold header.
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/worksheets/sheet2.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+<sheet name="Expenses" sheetId="1" r:id="rId1"/>
+<sheet name="Categories" sheetId="2" r:id="rId2"/>
+</sheets>
+</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm"/></numFmts>
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="4">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+</cellXfs>
+</styleSheet>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+const (
+	xlsxStyleDate   = 1
+	xlsxStyleAmount = 2
+	xlsxStyleHeader = 3
+)
+
+// excelEpoch is day zero of the 1900 date system, as Excel counts it.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxSheet writes the rows of a worksheet.
+type xlsxSheet struct {
+	w   io.Writer
+	row int
+	err error
+}
+
+// xlsxCell is a cell value: a string, a float64 or a time.Time.
+type xlsxCell struct {
+	value any
+	style int
+}
+
+// xlsxColumn returns the letters of a zero-based column index.
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func (s *xlsxSheet) writeRow(cells ...xlsxCell) {
+	if s.err != nil {
+		return
+	}
+	s.row++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, s.row)
+	for i, cell := range cells {
+		ref := xlsxColumn(i) + strconv.Itoa(s.row)
+		style := ""
+		if cell.style != 0 {
+			style = fmt.Sprintf(` s="%d"`, cell.style)
+		}
+		switch v := cell.value.(type) {
+		case float64:
+			fmt.Fprintf(&b, `<c r="%s"%s><v>%s</v></c>`, ref, style, strconv.FormatFloat(v, 'f', -1, 64))
+		case time.Time:
+			// Excel has no time zones, so times are written as recorded.
+			local := time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), 0, time.UTC)
+			serial := local.Sub(excelEpoch).Hours() / 24
+			fmt.Fprintf(&b, `<c r="%s"%s><v>%s</v></c>`, ref, style, strconv.FormatFloat(serial, 'f', -1, 64))
+		case string:
+			if v == "" {
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">`, ref, style)
+			xml.EscapeText(&b, []byte(v))
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+	_, s.err = io.WriteString(s.w, b.String())
+}
+
+func xlsxHeader(names ...string) []xlsxCell {
+	cells := make([]xlsxCell, len(names))
+	for i, name := range names {
+		cells[i] = xlsxCell{name, xlsxStyleHeader}
+	}
+	return cells
+}
+
+// writeExportXlsx writes a workbook with the expenses on the first sheet and
+// their totals per category on the second. The expenses are streamed into
+// the archive as they are read.
+func writeExportXlsx(w io.Writer, next func() (*exportRow, error)) error {
+	zw := zip.NewWriter(w)
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	} {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(f, xlsxSheetStart); err != nil {
+		return err
+	}
+	sheet := &xlsxSheet{w: f}
+	sheet.writeRow(xlsxHeader(exportHeader...)...)
+	summary := newExportSummary()
+	for sheet.err == nil {
+		row, err := next()
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			break
+		}
+		summary.add(row)
+		sheet.writeRow(
+			xlsxCell{row.ID, 0},
+			xlsxCell{row.DateTime, xlsxStyleDate},
+			xlsxCell{row.Place, 0},
+			xlsxCell{row.Merchant, 0},
+			xlsxCell{row.Category, 0},
+			xlsxCell{row.ParentCategory, 0},
+			xlsxCell{row.Amount, xlsxStyleAmount},
+			xlsxCell{row.Currency, 0},
+			xlsxCell{row.PaymentMethod, 0},
+			xlsxCell{strings.Join(row.Tags, ";"), 0},
+			xlsxCell{row.Source, 0},
+			xlsxCell{row.GroupID, 0},
+		)
+	}
+	if sheet.err != nil {
+		return sheet.err
+	}
+	if _, err := io.WriteString(f, xlsxSheetEnd); err != nil {
+		return err
+	}
+
+	f, err = zw.Create("xl/worksheets/sheet2.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(f, xlsxSheetStart); err != nil {
+		return err
+	}
+	sheet = &xlsxSheet{w: f}
+	sheet.writeRow(xlsxHeader("category", "currency", "count", "amount")...)
+	for _, total := range sortedTotals(summary.categories, "", false) {
+		sheet.writeRow(
+			xlsxCell{total.Key, 0},
+			xlsxCell{total.Currency, 0},
+			xlsxCell{float64(total.Count), 0},
+			xlsxCell{total.Amount, xlsxStyleAmount},
+		)
+	}
+	if sheet.err != nil {
+		return sheet.err
+	}
+	if _, err := io.WriteString(f, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return zw.Close()
+}